@@ -14,15 +14,20 @@ import (
 	"github.com/gohornet/hornet/core/tangle"
 	"github.com/gohornet/hornet/pkg/toolset"
 
+	"github.com/gohornet/hornet/plugins/alerting"
 	"github.com/gohornet/hornet/plugins/autopeering"
+	"github.com/gohornet/hornet/plugins/coordinator"
 	"github.com/gohornet/hornet/plugins/dashboard"
 	"github.com/gohornet/hornet/plugins/debug"
+	"github.com/gohornet/hornet/plugins/export"
+	"github.com/gohornet/hornet/plugins/hotreload"
 	"github.com/gohornet/hornet/plugins/inx"
 	"github.com/gohornet/hornet/plugins/prometheus"
 	"github.com/gohornet/hornet/plugins/receipt"
 	"github.com/gohornet/hornet/plugins/restapi"
 	restapiv2 "github.com/gohornet/hornet/plugins/restapi/v2"
 	"github.com/gohornet/hornet/plugins/spammer"
+	"github.com/gohornet/hornet/plugins/tracing"
 	"github.com/gohornet/hornet/plugins/urts"
 	"github.com/gohornet/hornet/plugins/warpsync"
 	"github.com/iotaledger/hive.go/app"
@@ -72,6 +77,11 @@ Command line flags:
 			prometheus.Plugin,
 			inx.Plugin,
 			debug.Plugin,
+			coordinator.Plugin,
+			hotreload.Plugin,
+			tracing.Plugin,
+			alerting.Plugin,
+			export.Plugin,
 		}...),
 	)
 }
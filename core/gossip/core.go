@@ -117,11 +117,12 @@ func provide(c *dig.Container) error {
 
 	type serviceDeps struct {
 		dig.In
-		Host               host.Host
-		PeeringManager     *p2p.Manager
-		Storage            *storage.Storage
-		ServerMetrics      *metrics.ServerMetrics
-		ProtocolParameters *iotago.ProtocolParameters
+		Host                 host.Host
+		PeeringManager       *p2p.Manager
+		PeeringPolicyManager *p2p.PolicyManager
+		Storage              *storage.Storage
+		ServerMetrics        *metrics.ServerMetrics
+		ProtocolParameters   *iotago.ProtocolParameters
 	}
 
 	if err := c.Provide(func(deps serviceDeps) *gossip.Service {
@@ -132,6 +133,7 @@ func provide(c *dig.Container) error {
 			deps.ServerMetrics,
 			gossip.WithLogger(logger.NewLogger("GossipService")),
 			gossip.WithUnknownPeersLimit(ParamsGossip.UnknownPeersLimit),
+			gossip.WithPolicyManager(deps.PeeringPolicyManager),
 			gossip.WithStreamReadTimeout(ParamsGossip.StreamReadTimeout),
 			gossip.WithStreamWriteTimeout(ParamsGossip.StreamWriteTimeout),
 		)
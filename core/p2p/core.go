@@ -18,9 +18,16 @@ import (
 	"github.com/gohornet/hornet/pkg/p2p"
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/ioutils"
 	"github.com/iotaledger/hive.go/logger"
 )
 
+const (
+	// PeeringPoliciesFileName is the name of the file the peering policies are stored in.
+	// The file is located next to the peering config file.
+	PeeringPoliciesFileName = "peering_policies.json"
+)
+
 func init() {
 	CoreComponent = &app.CoreComponent{
 		Component: &app.Component{
@@ -223,6 +230,43 @@ func provide(c *dig.Container) error {
 		CoreComponent.LogPanic(err)
 	}
 
+	type policyManagerDeps struct {
+		dig.In
+		PeeringConfigFilePath *string `name:"peeringConfigFilePath"`
+	}
+
+	if err := c.Provide(func(deps policyManagerDeps) *p2p.PolicyManager {
+
+		policiesFilePath := filepath.Join(filepath.Dir(*deps.PeeringConfigFilePath), PeeringPoliciesFileName)
+
+		policyManager := p2p.NewPolicyManager(func(policies *p2p.PeeringPolicies) error {
+			return ioutils.WriteJSONToFile(policiesFilePath, policies, 0660)
+		})
+
+		policiesFileExists, err := ioutils.PathExists(policiesFilePath)
+		if err != nil {
+			CoreComponent.LogPanicf("unable to check peering policies file: %s", err)
+		}
+
+		if policiesFileExists {
+			policies := p2p.NewPeeringPolicies()
+			if err := ioutils.ReadJSONFromFile(policiesFilePath, policies); err != nil {
+				CoreComponent.LogPanicf("unable to read peering policies file: %s", err)
+			}
+
+			if err := policyManager.SetPolicies(policies); err != nil {
+				CoreComponent.LogPanicf("invalid peering policies: %s", err)
+			}
+			CoreComponent.LogInfof(`loaded peering policies from "%s"`, policiesFilePath)
+		}
+
+		policyManager.StoreOnChange(true)
+
+		return policyManager
+	}); err != nil {
+		CoreComponent.LogPanic(err)
+	}
+
 	return nil
 }
 
@@ -1,8 +1,10 @@
 package protocfg
 
 import (
+	"encoding/hex"
 	"encoding/json"
 
+	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/dig"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/crypto"
+	"github.com/iotaledger/hive.go/ioutils"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
@@ -35,30 +38,20 @@ func initConfigPars(c *dig.Container) error {
 
 	type cfgResult struct {
 		dig.Out
-		KeyManager              *keymanager.KeyManager
-		MilestonePublicKeyCount int `name:"milestonePublicKeyCount"`
-		ProtocolParameters      *iotago.ProtocolParameters
-		BaseToken               *BaseToken
+		KeyManager                      *keymanager.KeyManager
+		MilestonePublicKeyCount         int             `name:"milestonePublicKeyCount"`
+		KeyRangesExpiryWarningThreshold milestone.Index `name:"keyRangesExpiryWarningThreshold"`
+		ProtocolParameters              *iotago.ProtocolParameters
+		BaseToken                       *BaseToken
 	}
 
 	if err := c.Provide(func() cfgResult {
 
 		res := cfgResult{
-			MilestonePublicKeyCount: ParamsProtocol.MilestonePublicKeyCount,
-
-			ProtocolParameters: &iotago.ProtocolParameters{
-				Version:       ParamsProtocol.Parameters.Version,
-				NetworkName:   ParamsProtocol.Parameters.NetworkName,
-				Bech32HRP:     iotago.NetworkPrefix(ParamsProtocol.Parameters.Bech32HRP),
-				MinPoWScore:   ParamsProtocol.Parameters.MinPoWScore,
-				BelowMaxDepth: ParamsProtocol.Parameters.BelowMaxDepth,
-				RentStructure: iotago.RentStructure{
-					VByteCost:    ParamsProtocol.Parameters.RentStructureVByteCost,
-					VBFactorData: iotago.VByteCostFactor(ParamsProtocol.Parameters.RentStructureVByteFactorData),
-					VBFactorKey:  iotago.VByteCostFactor(ParamsProtocol.Parameters.RentStructureVByteFactorKey),
-				},
-				TokenSupply: ParamsProtocol.Parameters.TokenSupply,
-			},
+			MilestonePublicKeyCount:         ParamsProtocol.MilestonePublicKeyCount,
+			KeyRangesExpiryWarningThreshold: milestone.Index(ParamsProtocol.KeyRotation.ExpiryWarningThreshold),
+
+			ProtocolParameters: ProtocolParametersFromConfig(ParamsProtocol),
 			BaseToken: &BaseToken{
 				Name:            ParamsProtocol.BaseToken.Name,
 				TickerSymbol:    ParamsProtocol.BaseToken.TickerSymbol,
@@ -82,6 +75,10 @@ func initConfigPars(c *dig.Container) error {
 		if err != nil {
 			CoreComponent.LogPanicf("can't load public key ranges: %s", err)
 		}
+		if err := loadAuthorizedKeyRanges(keyManager, ParamsProtocol.KeyRotation.FilePath); err != nil {
+			CoreComponent.LogPanicf("can't load public key ranges: %s", err)
+		}
+
 		res.KeyManager = keyManager
 		return res
 	}); err != nil {
@@ -91,6 +88,23 @@ func initConfigPars(c *dig.Container) error {
 	return nil
 }
 
+// ProtocolParametersFromConfig returns the protocol parameters of the given protocol config.
+func ProtocolParametersFromConfig(paramsProtocol *ParametersProtocol) *iotago.ProtocolParameters {
+	return &iotago.ProtocolParameters{
+		Version:       paramsProtocol.Parameters.Version,
+		NetworkName:   paramsProtocol.Parameters.NetworkName,
+		Bech32HRP:     iotago.NetworkPrefix(paramsProtocol.Parameters.Bech32HRP),
+		MinPoWScore:   paramsProtocol.Parameters.MinPoWScore,
+		BelowMaxDepth: paramsProtocol.Parameters.BelowMaxDepth,
+		RentStructure: iotago.RentStructure{
+			VByteCost:    paramsProtocol.Parameters.RentStructureVByteCost,
+			VBFactorData: iotago.VByteCostFactor(paramsProtocol.Parameters.RentStructureVByteFactorData),
+			VBFactorKey:  iotago.VByteCostFactor(paramsProtocol.Parameters.RentStructureVByteFactorKey),
+		},
+		TokenSupply: paramsProtocol.Parameters.TokenSupply,
+	}
+}
+
 func KeyManagerWithConfigPublicKeyRanges(coordinatorPublicKeyRanges ConfigPublicKeyRanges) (*keymanager.KeyManager, error) {
 	keyManager := keymanager.New()
 	for _, keyRange := range coordinatorPublicKeyRanges {
@@ -104,3 +118,66 @@ func KeyManagerWithConfigPublicKeyRanges(coordinatorPublicKeyRanges ConfigPublic
 
 	return keyManager, nil
 }
+
+// LoadAuthorizedKeyRanges loads the public key ranges that were added at runtime from the given file into the key manager.
+// It returns the amount of loaded key ranges, which is zero if the file doesn't exist yet.
+func LoadAuthorizedKeyRanges(keyManager *keymanager.KeyManager, filePath string) (int, error) {
+
+	keyRangesFileExists, err := ioutils.PathExists(filePath)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to check public key ranges file")
+	}
+
+	if !keyRangesFileExists {
+		return 0, nil
+	}
+
+	configKeyRanges := ConfigPublicKeyRanges{}
+	if err := ioutils.ReadJSONFromFile(filePath, &configKeyRanges); err != nil {
+		return 0, errors.Wrap(err, "unable to read public key ranges file")
+	}
+
+	keyRanges := make([]*keymanager.KeyRange, 0, len(configKeyRanges))
+	for _, configKeyRange := range configKeyRanges {
+		pubKey, err := crypto.ParseEd25519PublicKeyFromString(configKeyRange.Key)
+		if err != nil {
+			return 0, err
+		}
+
+		keyRange := &keymanager.KeyRange{StartIndex: milestone.Index(configKeyRange.StartIndex), EndIndex: milestone.Index(configKeyRange.EndIndex)}
+		copy(keyRange.PublicKey[:], pubKey)
+		keyRanges = append(keyRanges, keyRange)
+	}
+
+	keyManager.LoadAuthorizedKeyRanges(keyRanges)
+
+	return len(keyRanges), nil
+}
+
+// loadAuthorizedKeyRanges loads the public key ranges that were added at runtime
+// and persists all future additions to the same file.
+func loadAuthorizedKeyRanges(keyManager *keymanager.KeyManager, filePath string) error {
+
+	keyRangesCount, err := LoadAuthorizedKeyRanges(keyManager, filePath)
+	if err != nil {
+		return err
+	}
+
+	if keyRangesCount > 0 {
+		CoreComponent.LogInfof(`loaded %d public key ranges from "%s"`, keyRangesCount, filePath)
+	}
+
+	keyManager.SetStoreCallback(func(keyRanges []*keymanager.KeyRange) error {
+		configKeyRanges := make(ConfigPublicKeyRanges, 0, len(keyRanges))
+		for _, keyRange := range keyRanges {
+			configKeyRanges = append(configKeyRanges, &ConfigPublicKeyRange{
+				Key:        hex.EncodeToString(keyRange.PublicKey[:]),
+				StartIndex: uint32(keyRange.StartIndex),
+				EndIndex:   uint32(keyRange.EndIndex),
+			})
+		}
+		return ioutils.WriteJSONToFile(filePath, configKeyRanges, 0660)
+	})
+
+	return nil
+}
@@ -61,6 +61,13 @@ type ParametersProtocol struct {
 	// the ed25519 public key of the coordinator in hex representation.
 	PublicKeyRanges ConfigPublicKeyRanges `noflag:"true"`
 
+	KeyRotation struct {
+		// the path to the file the public key ranges that were added at runtime are stored in.
+		FilePath string `default:"keyranges.json" usage:"the path to the file the public key ranges that were added at runtime are stored in"`
+		// the amount of milestones before the end of a public key range to warn if there is no successor.
+		ExpiryWarningThreshold uint32 `default:"8640" usage:"the amount of milestones before the end of a public key range to warn if there is no successor"`
+	}
+
 	BaseToken BaseToken `usage:"the network base token properties"`
 }
 
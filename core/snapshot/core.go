@@ -11,11 +11,13 @@ import (
 
 	"github.com/gohornet/hornet/pkg/daemon"
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/hotreload"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/proofbundle"
 	"github.com/gohornet/hornet/pkg/snapshot"
 	"github.com/gohornet/hornet/pkg/tangle"
 	"github.com/iotaledger/hive.go/app"
@@ -61,6 +63,9 @@ var (
 	deps          dependencies
 
 	forceLoadingSnapshot = flag.Bool(CfgSnapshotsForceLoadingSnapshot, false, "force loading of a snapshot, even if a database already exists")
+
+	// the minimum amount of milestones to keep if pruning by milestones is enabled.
+	pruningMilestonesMaxMilestonesToKeepMin milestone.Index
 )
 
 type dependencies struct {
@@ -74,6 +79,8 @@ type dependencies struct {
 	SnapshotsFullPath    string `name:"snapshotsFullPath"`
 	SnapshotsDeltaPath   string `name:"snapshotsDeltaPath"`
 	StorageMetrics       *metrics.StorageMetrics
+	ProtocolParameters   *iotago.ProtocolParameters
+	ConfigReloader       *hotreload.ConfigReloader `optional:"true"`
 }
 
 func initConfigPars(c *dig.Container) error {
@@ -121,26 +128,11 @@ func provide(c *dig.Container) error {
 			snapshotDepth = solidEntryPointCheckThresholdFuture
 		}
 
-		pruningMilestonesEnabled := ParamsPruning.Milestones.Enabled
-		pruningMilestonesMaxMilestonesToKeep := milestone.Index(ParamsPruning.Milestones.MaxMilestonesToKeep)
-		pruningMilestonesMaxMilestonesToKeepMin := snapshotDepth + solidEntryPointCheckThresholdPast + pruningThreshold + 1
-		if pruningMilestonesMaxMilestonesToKeep != 0 && pruningMilestonesMaxMilestonesToKeep < pruningMilestonesMaxMilestonesToKeepMin {
-			CoreComponent.LogWarnf("parameter '%s' is too small (%d). value was changed to %d", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.MaxMilestonesToKeep)), pruningMilestonesMaxMilestonesToKeep, pruningMilestonesMaxMilestonesToKeepMin)
-			pruningMilestonesMaxMilestonesToKeep = pruningMilestonesMaxMilestonesToKeepMin
-		}
-
-		if pruningMilestonesEnabled && pruningMilestonesMaxMilestonesToKeep == 0 {
-			CoreComponent.LogPanicf("%s has to be specified if %s is enabled", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.MaxMilestonesToKeep)), CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.Enabled)))
-		}
+		pruningMilestonesMaxMilestonesToKeepMin = snapshotDepth + solidEntryPointCheckThresholdPast + pruningThreshold + 1
 
-		pruningSizeEnabled := ParamsPruning.Size.Enabled
-		pruningTargetDatabaseSizeBytes, err := bytes.Parse(ParamsPruning.Size.TargetSize)
+		pruningMilestonesEnabled, pruningMilestonesMaxMilestonesToKeep, pruningSizeEnabled, pruningTargetDatabaseSizeBytes, err := pruningParameters()
 		if err != nil {
-			CoreComponent.LogPanicf("parameter %s invalid", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.TargetSize)))
-		}
-
-		if pruningSizeEnabled && pruningTargetDatabaseSizeBytes == 0 {
-			CoreComponent.LogPanicf("%s has to be specified if %s is enabled", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.TargetSize)), CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.Enabled)))
+			CoreComponent.LogPanic(err)
 		}
 
 		return snapshot.NewSnapshotManager(
@@ -171,8 +163,59 @@ func provide(c *dig.Container) error {
 	})
 }
 
+// pruningParameters validates the pruning parameters from the config and returns the values used by the snapshot manager.
+func pruningParameters() (bool, milestone.Index, bool, int64, error) {
+
+	pruningMilestonesEnabled := ParamsPruning.Milestones.Enabled
+	pruningMilestonesMaxMilestonesToKeep := milestone.Index(ParamsPruning.Milestones.MaxMilestonesToKeep)
+	if pruningMilestonesMaxMilestonesToKeep != 0 && pruningMilestonesMaxMilestonesToKeep < pruningMilestonesMaxMilestonesToKeepMin {
+		CoreComponent.LogWarnf("parameter '%s' is too small (%d). value was changed to %d", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.MaxMilestonesToKeep)), pruningMilestonesMaxMilestonesToKeep, pruningMilestonesMaxMilestonesToKeepMin)
+		pruningMilestonesMaxMilestonesToKeep = pruningMilestonesMaxMilestonesToKeepMin
+	}
+
+	if pruningMilestonesEnabled && pruningMilestonesMaxMilestonesToKeep == 0 {
+		return false, 0, false, 0, fmt.Errorf("%s has to be specified if %s is enabled", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.MaxMilestonesToKeep)), CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Milestones.Enabled)))
+	}
+
+	pruningSizeEnabled := ParamsPruning.Size.Enabled
+	pruningTargetDatabaseSizeBytes, err := bytes.Parse(ParamsPruning.Size.TargetSize)
+	if err != nil {
+		return false, 0, false, 0, fmt.Errorf("parameter %s invalid", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.TargetSize)))
+	}
+
+	if pruningSizeEnabled && pruningTargetDatabaseSizeBytes == 0 {
+		return false, 0, false, 0, fmt.Errorf("%s has to be specified if %s is enabled", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.TargetSize)), CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.Size.Enabled)))
+	}
+
+	return pruningMilestonesEnabled, pruningMilestonesMaxMilestonesToKeep, pruningSizeEnabled, pruningTargetDatabaseSizeBytes, nil
+}
+
+// applyPruningParameters applies the pruning parameters from the config to the snapshot manager.
+func applyPruningParameters() error {
+
+	pruningMilestonesEnabled, pruningMilestonesMaxMilestonesToKeep, pruningSizeEnabled, pruningTargetDatabaseSizeBytes, err := pruningParameters()
+	if err != nil {
+		return err
+	}
+
+	deps.SnapshotManager.SetPruningParameters(
+		pruningMilestonesEnabled,
+		pruningMilestonesMaxMilestonesToKeep,
+		pruningSizeEnabled,
+		pruningTargetDatabaseSizeBytes,
+		ParamsPruning.Size.ThresholdPercentage,
+		ParamsPruning.Size.CooldownTime,
+	)
+
+	return nil
+}
+
 func configure() error {
 
+	if deps.ConfigReloader != nil {
+		deps.ConfigReloader.RegisterParameters(CoreComponent.Name, applyPruningParameters, "pruning.milestones", "pruning.size")
+	}
+
 	if deps.DeleteAllFlag {
 		// delete old snapshot files
 		if err := os.Remove(deps.SnapshotsFullPath); err != nil && !os.IsNotExist(err) {
@@ -184,6 +227,10 @@ func configure() error {
 		}
 	}
 
+	if err := configureProofBundles(); err != nil {
+		return err
+	}
+
 	snapshotInfo := deps.Storage.SnapshotInfo()
 
 	switch {
@@ -200,6 +247,41 @@ func configure() error {
 	return nil
 }
 
+// configureProofBundles creates proof bundles for the transactions of the configured addresses
+// before the milestone cones get pruned.
+func configureProofBundles() error {
+
+	if len(ParamsPruning.ProofBundles.Addresses) == 0 {
+		return nil
+	}
+
+	addresses := make([]iotago.Address, 0, len(ParamsPruning.ProofBundles.Addresses))
+	for _, bech32Address := range ParamsPruning.ProofBundles.Addresses {
+		hrp, address, err := iotago.ParseBech32(bech32Address)
+		if err != nil {
+			return fmt.Errorf("parameter %s contains an invalid address (%s): %w", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.ProofBundles.Addresses)), bech32Address, err)
+		}
+		if hrp != deps.ProtocolParameters.Bech32HRP {
+			return fmt.Errorf("parameter %s contains an address of another network (%s)", CoreComponent.App.Config().GetParameterPath(&(ParamsPruning.ProofBundles.Addresses)), bech32Address)
+		}
+		addresses = append(addresses, address)
+	}
+
+	exporter := proofbundle.NewExporter(deps.Storage, ParamsPruning.ProofBundles.Path, addresses)
+
+	deps.SnapshotManager.Events.PruningMilestone.Attach(events.NewClosure(func(msIndex milestone.Index) {
+		exported, err := exporter.ExportMilestone(msIndex)
+		if err != nil {
+			CoreComponent.LogWarnf("creating proof bundles for milestone %d failed: %s", msIndex, err)
+		}
+		if exported > 0 {
+			CoreComponent.LogInfof("created %d proof bundles for milestone %d", exported, msIndex)
+		}
+	}))
+
+	return nil
+}
+
 func run() error {
 
 	newConfirmedMilestoneSignal := make(chan milestone.Index)
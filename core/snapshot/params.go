@@ -45,6 +45,13 @@ type ParametersPruning struct {
 
 	// whether to delete old receipts data from the database
 	PruneReceipts bool `default:"false" usage:"whether to delete old receipts data from the database"`
+
+	ProofBundles struct {
+		// the bech32 addresses for which proof bundles of their transactions are created before the milestone cones are pruned
+		Addresses []string `usage:"the bech32 addresses for which proof bundles of their transactions are created before the milestone cones are pruned"`
+		// the directory the proof bundles are written to
+		Path string `default:"proofbundles" usage:"the directory the proof bundles are written to"`
+	}
 }
 
 var ParamsSnapshots = &ParametersSnapshots{
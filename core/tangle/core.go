@@ -62,18 +62,22 @@ var (
 	onConfirmedMilestoneIndexChanged *events.Closure
 	onPruningMilestoneIndexChanged   *events.Closure
 	onLatestMilestoneIndexChanged    *events.Closure
+	onKeyRangeExpiryCheck            *events.Closure
 )
 
 type dependencies struct {
 	dig.In
-	Storage                  *storage.Storage
-	Tangle                   *tangle.Tangle
-	Requester                *gossip.Requester
-	Broadcaster              *gossip.Broadcaster
-	SnapshotManager          *snapshot.SnapshotManager
-	DatabaseDebug            bool `name:"databaseDebug"`
-	DatabaseAutoRevalidation bool `name:"databaseAutoRevalidation"`
-	PruneReceipts            bool `name:"pruneReceipts"`
+	Storage                         *storage.Storage
+	Tangle                          *tangle.Tangle
+	Requester                       *gossip.Requester
+	Broadcaster                     *gossip.Broadcaster
+	SnapshotManager                 *snapshot.SnapshotManager
+	DatabaseDebug                   bool `name:"databaseDebug"`
+	DatabaseAutoRevalidation        bool `name:"databaseAutoRevalidation"`
+	PruneReceipts                   bool `name:"pruneReceipts"`
+	KeyManager                      *keymanager.KeyManager
+	MilestonePublicKeyCount         int             `name:"milestonePublicKeyCount"`
+	KeyRangesExpiryWarningThreshold milestone.Index `name:"keyRangesExpiryWarningThreshold"`
 }
 
 func provide(c *dig.Container) error {
@@ -243,6 +247,14 @@ func run() error {
 		CoreComponent.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if err := CoreComponent.Daemon().BackgroundWorker("Tangle[KeyRangeExpiryEvents]", func(ctx context.Context) {
+		deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Attach(onKeyRangeExpiryCheck)
+		<-ctx.Done()
+		deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Detach(onKeyRangeExpiryCheck)
+	}, daemon.PriorityStatusReport); err != nil {
+		CoreComponent.LogPanicf("failed to start worker: %s", err)
+	}
+
 	deps.Tangle.RunTangleProcessor()
 
 	// create a background worker that prints a status message every second
@@ -272,6 +284,19 @@ func configureEvents() {
 		// notify peers about our new latest milestone index
 		deps.Broadcaster.BroadcastHeartbeat(nil)
 	})
+
+	// every key range is only reported once
+	reportedKeyRanges := make(map[keymanager.KeyRange]struct{})
+	onKeyRangeExpiryCheck = events.NewClosure(func(confirmedMilestoneIndex milestone.Index) {
+		for _, keyRange := range deps.KeyManager.KeyRangesWithoutSuccessor(confirmedMilestoneIndex, deps.KeyRangesExpiryWarningThreshold, deps.MilestonePublicKeyCount) {
+			if _, reported := reportedKeyRanges[*keyRange]; reported {
+				continue
+			}
+			reportedKeyRanges[*keyRange] = struct{}{}
+
+			CoreComponent.LogWarnf("public key %s expires at milestone %d (confirmed milestone %d), but there are not enough public keys valid afterwards. Add a successor key range in time!", iotago.EncodeHex(keyRange.PublicKey[:]), keyRange.EndIndex, confirmedMilestoneIndex)
+		}
+	})
 }
 
 func attachHeartbeatEvents() {
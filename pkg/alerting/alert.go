@@ -0,0 +1,104 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the state of an alert.
+type State string
+
+const (
+	// StateFiring denotes an alert whose condition holds.
+	StateFiring State = "firing"
+	// StateResolved denotes an alert whose condition does not hold anymore.
+	StateResolved State = "resolved"
+)
+
+// Alert is a notification about a rule whose condition started or stopped to hold.
+type Alert struct {
+	// the name of the rule that fired the alert.
+	Rule string `json:"rule"`
+	// the condition of the rule.
+	Condition string `json:"condition"`
+	// the severity of the alert.
+	Severity Severity `json:"severity"`
+	// the state of the alert.
+	State State `json:"state"`
+	// the value of the metric at the last evaluation.
+	Value float64 `json:"value"`
+	// the time the alert started to fire.
+	StartedAt time.Time `json:"startedAt"`
+	// the time the alert was resolved.
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	// whether the notification of the alert was suppressed by a silence.
+	Silenced bool `json:"silenced"`
+}
+
+// clone returns a copy of the alert.
+func (a *Alert) clone() *Alert {
+	alert := *a
+	if a.ResolvedAt != nil {
+		resolvedAt := *a.ResolvedAt
+		alert.ResolvedAt = &resolvedAt
+	}
+	return &alert
+}
+
+// Silence suppresses the notifications of alerts in a time window.
+type Silence struct {
+	// the unique identifier of the silence.
+	ID uint64 `json:"id"`
+	// the name of the rule whose alerts are silenced (empty for all rules).
+	Rule string `json:"rule,omitempty"`
+	// the start of the time window.
+	StartsAt time.Time `json:"startsAt"`
+	// the end of the time window.
+	EndsAt time.Time `json:"endsAt"`
+	// the reason of the silence.
+	Comment string `json:"comment,omitempty"`
+}
+
+// matches returns whether the silence suppresses the alerts of the given rule at the given time.
+func (s *Silence) matches(rule string, now time.Time) bool {
+	if s.Rule != "" && s.Rule != rule {
+		return false
+	}
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// EventCounter counts the events that happened within a sliding time window.
+// It is used to turn events like disconnects or failures into metrics.
+type EventCounter struct {
+	sync.Mutex
+	window time.Duration
+	events []time.Time
+}
+
+// NewEventCounter creates a new EventCounter with the given window.
+func NewEventCounter(window time.Duration) *EventCounter {
+	return &EventCounter{window: window}
+}
+
+// Add records an event at the given time.
+func (c *EventCounter) Add(now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.events = append(c.events, now)
+}
+
+// Count returns the amount of events within the window before the given time.
+func (c *EventCounter) Count(now time.Time) int {
+	c.Lock()
+	defer c.Unlock()
+
+	// drop the events that left the window
+	i := 0
+	for i < len(c.events) && now.Sub(c.events[i]) > c.window {
+		i++
+	}
+	c.events = c.events[i:]
+
+	return len(c.events)
+}
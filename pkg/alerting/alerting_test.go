@@ -0,0 +1,173 @@
+package alerting_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/alerting"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := alerting.ParseRule("node-unsynced: syncLag > 2 for 1m severity critical")
+	require.NoError(t, err)
+	require.Equal(t, &alerting.Rule{
+		Name:      "node-unsynced",
+		Metric:    "syncLag",
+		Operator:  alerting.OperatorGreater,
+		Threshold: 2,
+		For:       time.Minute,
+		Severity:  alerting.SeverityCritical,
+	}, rule)
+	require.Equal(t, "node-unsynced: syncLag > 2 for 1m0s severity critical", rule.String())
+
+	rule, err = alerting.ParseRule("no-peers: peerCount < 1")
+	require.NoError(t, err)
+	require.Equal(t, alerting.SeverityWarning, rule.Severity)
+	require.Zero(t, rule.For)
+
+	for _, invalid := range []string{
+		"syncLag > 2",
+		": syncLag > 2",
+		"unsynced: syncLag >",
+		"unsynced: syncLag => 2",
+		"unsynced: syncLag > two",
+		"unsynced: syncLag > 2 for",
+		"unsynced: syncLag > 2 for -1m",
+		"unsynced: syncLag > 2 severity fatal",
+		"unsynced: syncLag > 2 during 1m",
+	} {
+		_, err := alerting.ParseRule(invalid)
+		require.ErrorIs(t, err, alerting.ErrInvalidRule, invalid)
+	}
+}
+
+func TestEngine(t *testing.T) {
+	syncLag := 0.0
+	metrics := map[string]alerting.MetricFunc{
+		"syncLag": func() (float64, error) { return syncLag, nil },
+	}
+
+	rule, err := alerting.ParseRule("node-unsynced: syncLag > 2 for 1m")
+	require.NoError(t, err)
+
+	_, err = alerting.New([]*alerting.Rule{rule, rule}, metrics)
+	require.ErrorIs(t, err, alerting.ErrDuplicateRule)
+
+	unknown, err := alerting.ParseRule("unknown: unknownMetric > 0")
+	require.NoError(t, err)
+	_, err = alerting.New([]*alerting.Rule{unknown}, metrics)
+	require.ErrorIs(t, err, alerting.ErrUnknownMetric)
+
+	var delivered []*alerting.Alert
+	notifier := alerting.NotifierFunc(func(_ context.Context, alert *alerting.Alert) error {
+		delivered = append(delivered, alert)
+		return nil
+	})
+
+	engine, err := alerting.New([]*alerting.Rule{rule}, metrics, alerting.WithNotifiers(notifier), alerting.WithRepeatInterval(time.Hour))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Unix(1650000000, 0)
+
+	// the condition needs to hold for a minute before the alert fires
+	syncLag = 5
+	engine.Evaluate(ctx, now)
+	engine.Evaluate(ctx, now.Add(30*time.Second))
+	require.Empty(t, delivered)
+	require.Empty(t, engine.Alerts())
+
+	engine.Evaluate(ctx, now.Add(time.Minute))
+	require.Len(t, delivered, 1)
+	require.Equal(t, alerting.StateFiring, delivered[0].State)
+	require.Equal(t, "syncLag > 2", delivered[0].Condition)
+	require.Equal(t, 5.0, delivered[0].Value)
+	require.Len(t, engine.Alerts(), 1)
+
+	// firing alerts are deduplicated until the repeat interval passed
+	engine.Evaluate(ctx, now.Add(2*time.Minute))
+	require.Len(t, delivered, 1)
+	engine.Evaluate(ctx, now.Add(time.Hour+time.Minute))
+	require.Len(t, delivered, 2)
+
+	syncLag = 0
+	engine.Evaluate(ctx, now.Add(2*time.Hour))
+	require.Len(t, delivered, 3)
+	require.Equal(t, alerting.StateResolved, delivered[2].State)
+	require.NotNil(t, delivered[2].ResolvedAt)
+	require.Empty(t, engine.Alerts())
+
+	history := engine.History()
+	require.Len(t, history, 2)
+	require.Equal(t, alerting.StateResolved, history[0].State)
+	require.Equal(t, alerting.StateFiring, history[1].State)
+
+	// silenced alerts are recorded in the history, but not delivered
+	silence, err := engine.AddSilence("node-unsynced", now.Add(2*time.Hour), now.Add(3*time.Hour), "maintenance")
+	require.NoError(t, err)
+	_, err = engine.AddSilence("unknown", now, now.Add(time.Hour), "")
+	require.Error(t, err)
+
+	syncLag = 5
+	engine.Evaluate(ctx, now.Add(2*time.Hour+time.Minute))
+	engine.Evaluate(ctx, now.Add(2*time.Hour+2*time.Minute))
+	require.Len(t, delivered, 3)
+	require.Len(t, engine.Alerts(), 1)
+	require.True(t, engine.History()[0].Silenced)
+
+	require.Len(t, engine.Silences(now.Add(2*time.Hour)), 1)
+	require.NoError(t, engine.RemoveSilence(silence.ID))
+	require.ErrorIs(t, engine.RemoveSilence(silence.ID), alerting.ErrSilenceNotFound)
+	require.Empty(t, engine.Silences(now.Add(2*time.Hour)))
+
+	// the alert that fired while it was silenced is delivered as soon as the silence is removed
+	engine.Evaluate(ctx, now.Add(2*time.Hour+3*time.Minute))
+	require.Len(t, delivered, 4)
+	require.Equal(t, alerting.StateFiring, delivered[3].State)
+	require.False(t, delivered[3].Silenced)
+
+	engine.Evaluate(ctx, now.Add(2*time.Hour+4*time.Minute))
+	require.Len(t, delivered, 4)
+}
+
+func TestEventCounter(t *testing.T) {
+	counter := alerting.NewEventCounter(time.Hour)
+	now := time.Unix(1650000000, 0)
+
+	counter.Add(now)
+	counter.Add(now.Add(30 * time.Minute))
+	require.Equal(t, 2, counter.Count(now.Add(time.Hour)))
+	require.Equal(t, 1, counter.Count(now.Add(time.Hour+time.Minute)))
+	require.Equal(t, 0, counter.Count(now.Add(2*time.Hour)))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	alerts := make(chan *alerting.Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		alert := &alerting.Alert{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(alert))
+		alerts <- alert
+	}))
+	defer server.Close()
+
+	notifier := alerting.NewWebhookNotifier(server.URL, map[string]string{"Authorization": "secret"}, time.Second)
+	require.NoError(t, notifier.Notify(context.Background(), &alerting.Alert{Rule: "no-peers", State: alerting.StateFiring}))
+
+	alert := <-alerts
+	require.Equal(t, "no-peers", alert.Rule)
+	require.Equal(t, alerting.StateFiring, alert.State)
+
+	failingServer := httptest.NewServer(http.NotFoundHandler())
+	defer failingServer.Close()
+
+	failing := alerting.NewWebhookNotifier(failingServer.URL, nil, time.Second)
+	require.Error(t, failing.Notify(context.Background(), &alerting.Alert{}))
+}
@@ -0,0 +1,394 @@
+package alerting
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+var (
+	// ErrUnknownMetric is returned if a rule refers to a metric that is not known to the engine.
+	ErrUnknownMetric = errors.New("unknown metric")
+	// ErrDuplicateRule is returned if two rules have the same name.
+	ErrDuplicateRule = errors.New("duplicate rule name")
+	// ErrNoValue is returned by a MetricFunc if the metric currently has no value.
+	// Rules on the metric keep their state until the metric has a value again.
+	ErrNoValue = errors.New("metric has no value")
+	// ErrSilenceNotFound is returned if a silence does not exist.
+	ErrSilenceNotFound = errors.New("silence not found")
+)
+
+// MetricFunc returns the current value of a metric.
+type MetricFunc func() (float64, error)
+
+// Options define options for the Engine.
+type Options struct {
+	logger *logger.Logger
+	// the notifiers the alerts are delivered to.
+	notifiers []Notifier
+	// the interval in which the notifications of still firing alerts are repeated (0 = never).
+	repeatInterval time.Duration
+	// the maximum amount of alerts kept in the history.
+	historySize int
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// the default options used for the Engine.
+var defaultOptions = []Option{
+	WithRepeatInterval(0),
+	WithHistorySize(100),
+}
+
+// Option is a function setting an engine option.
+type Option func(opts *Options)
+
+// WithLogger enables logging within the engine.
+func WithLogger(logger *logger.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// WithNotifiers adds notifiers the alerts are delivered to.
+func WithNotifiers(notifiers ...Notifier) Option {
+	return func(opts *Options) {
+		opts.notifiers = append(opts.notifiers, notifiers...)
+	}
+}
+
+// WithRepeatInterval sets the interval in which the notifications of still firing alerts are repeated (0 = never).
+func WithRepeatInterval(repeatInterval time.Duration) Option {
+	return func(opts *Options) {
+		opts.repeatInterval = repeatInterval
+	}
+}
+
+// WithHistorySize sets the maximum amount of alerts kept in the history.
+func WithHistorySize(historySize int) Option {
+	return func(opts *Options) {
+		opts.historySize = historySize
+	}
+}
+
+// ruleState is the evaluation state of a rule.
+type ruleState struct {
+	rule *Rule
+	// the time since the condition of the rule holds.
+	pendingSince time.Time
+	// the currently firing alert of the rule.
+	alert *Alert
+	// the time of the last notification about the firing alert.
+	lastNotified time.Time
+}
+
+// Engine periodically evaluates rules over metrics and delivers the resulting alerts.
+// Alerts are deduplicated: a firing alert is only delivered again after the repeat interval.
+type Engine struct {
+	*logger.WrappedLogger
+
+	metrics map[string]MetricFunc
+
+	stateLock sync.RWMutex
+	states    []*ruleState
+	history   []*Alert
+	silences  []*Silence
+	silenceID uint64
+
+	opts *Options
+}
+
+// New creates a new Engine for the given rules and metrics.
+func New(rules []*Rule, metrics map[string]MetricFunc, opts ...Option) (*Engine, error) {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	names := make(map[string]struct{}, len(rules))
+	states := make([]*ruleState, 0, len(rules))
+	for _, rule := range rules {
+		if _, exists := names[rule.Name]; exists {
+			return nil, errors.WithMessagef(ErrDuplicateRule, "rule: %s", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		if _, exists := metrics[rule.Metric]; !exists {
+			return nil, errors.WithMessagef(ErrUnknownMetric, "rule: %s, metric: %s", rule.Name, rule.Metric)
+		}
+
+		states = append(states, &ruleState{rule: rule})
+	}
+
+	return &Engine{
+		WrappedLogger: logger.NewWrappedLogger(options.logger),
+		metrics:       metrics,
+		states:        states,
+		opts:          options,
+	}, nil
+}
+
+// Rules returns the rules of the engine.
+func (e *Engine) Rules() []*Rule {
+	rules := make([]*Rule, len(e.states))
+	for i, state := range e.states {
+		rules[i] = state.rule
+	}
+	return rules
+}
+
+// Run evaluates the rules in the given interval until the context is canceled.
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Evaluate(ctx, time.Now())
+		}
+	}
+}
+
+// Evaluate evaluates all rules at the given time and delivers the resulting alerts.
+func (e *Engine) Evaluate(ctx context.Context, now time.Time) {
+
+	// the metrics are collected without holding the lock, since some of them are expensive to compute
+	values := make([]*float64, len(e.states))
+	for i, state := range e.states {
+		value, err := e.metrics[state.rule.Metric]()
+		if err != nil {
+			if !errors.Is(err, ErrNoValue) {
+				e.LogWarnf("evaluating rule \"%s\" failed: %s", state.rule.Name, err)
+			}
+			continue
+		}
+		values[i] = &value
+	}
+
+	var notifications []*Alert
+
+	e.stateLock.Lock()
+	for i, state := range e.states {
+		if values[i] == nil {
+			continue
+		}
+
+		if alert := e.evaluateRuleWithoutLocking(state, *values[i], now); alert != nil {
+			notifications = append(notifications, alert)
+		}
+	}
+	e.stateLock.Unlock()
+
+	// the alerts are delivered without holding the lock, so that slow notifiers do not block the API
+	for _, alert := range notifications {
+		for _, notifier := range e.opts.notifiers {
+			if err := notifier.Notify(ctx, alert); err != nil {
+				e.LogWarnf("delivering alert \"%s\" failed: %s", alert.Rule, err)
+			}
+		}
+	}
+}
+
+// evaluateRuleWithoutLocking updates the state of the rule and returns the alert that needs to be delivered, if any.
+func (e *Engine) evaluateRuleWithoutLocking(state *ruleState, value float64, now time.Time) *Alert {
+
+	if !state.rule.Operator.Compare(value, state.rule.Threshold) {
+		state.pendingSince = time.Time{}
+
+		if state.alert == nil {
+			return nil
+		}
+
+		resolvedAt := now
+		state.alert.State = StateResolved
+		state.alert.Value = value
+		state.alert.ResolvedAt = &resolvedAt
+		state.alert.Silenced = e.isSilencedWithoutLocking(state.rule.Name, now)
+
+		alert := state.alert.clone()
+		state.alert = nil
+		e.addToHistoryWithoutLocking(alert)
+
+		if alert.Silenced {
+			return nil
+		}
+		return alert
+	}
+
+	if state.pendingSince.IsZero() {
+		state.pendingSince = now
+	}
+
+	if now.Sub(state.pendingSince) < state.rule.For {
+		// the condition does not hold long enough yet
+		return nil
+	}
+
+	if state.alert != nil {
+		state.alert.Value = value
+
+		// alerts that were silenced so far are delivered as soon as the silence ends
+		if !state.lastNotified.IsZero() && (e.opts.repeatInterval == 0 || now.Sub(state.lastNotified) < e.opts.repeatInterval) {
+			// deduplicate the notifications of the firing alert
+			return nil
+		}
+
+		if e.isSilencedWithoutLocking(state.rule.Name, now) {
+			return nil
+		}
+
+		state.alert.Silenced = false
+		state.lastNotified = now
+		return state.alert.clone()
+	}
+
+	state.alert = &Alert{
+		Rule:      state.rule.Name,
+		Condition: state.rule.Condition(),
+		Severity:  state.rule.Severity,
+		State:     StateFiring,
+		Value:     value,
+		StartedAt: now,
+		Silenced:  e.isSilencedWithoutLocking(state.rule.Name, now),
+	}
+
+	alert := state.alert.clone()
+	e.addToHistoryWithoutLocking(alert)
+
+	if alert.Silenced {
+		return nil
+	}
+
+	state.lastNotified = now
+	return alert
+}
+
+func (e *Engine) addToHistoryWithoutLocking(alert *Alert) {
+	e.history = append(e.history, alert)
+	if len(e.history) > e.opts.historySize {
+		e.history = e.history[len(e.history)-e.opts.historySize:]
+	}
+}
+
+func (e *Engine) isSilencedWithoutLocking(rule string, now time.Time) bool {
+	for _, silence := range e.silences {
+		if silence.matches(rule, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Alerts returns the currently firing alerts.
+func (e *Engine) Alerts() []*Alert {
+	e.stateLock.RLock()
+	defer e.stateLock.RUnlock()
+
+	alerts := make([]*Alert, 0)
+	for _, state := range e.states {
+		if state.alert != nil {
+			alerts = append(alerts, state.alert.clone())
+		}
+	}
+	return alerts
+}
+
+// History returns the recently fired and resolved alerts, newest first.
+func (e *Engine) History() []*Alert {
+	e.stateLock.RLock()
+	defer e.stateLock.RUnlock()
+
+	history := make([]*Alert, len(e.history))
+	for i, alert := range e.history {
+		history[len(e.history)-1-i] = alert.clone()
+	}
+	return history
+}
+
+// AddSilence suppresses the notifications of the alerts of the given rule (empty for all rules) in the given time window.
+func (e *Engine) AddSilence(rule string, startsAt time.Time, endsAt time.Time, comment string) (*Silence, error) {
+	e.stateLock.Lock()
+	defer e.stateLock.Unlock()
+
+	if rule != "" {
+		found := false
+		for _, state := range e.states {
+			if state.rule.Name == rule {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown rule: %s", rule)
+		}
+	}
+
+	if !endsAt.After(startsAt) {
+		return nil, errors.New("the end of the silence needs to be after its start")
+	}
+
+	e.silenceID++
+	silence := &Silence{
+		ID:       e.silenceID,
+		Rule:     rule,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Comment:  comment,
+	}
+	e.silences = append(e.silences, silence)
+
+	copied := *silence
+	return &copied, nil
+}
+
+// RemoveSilence removes the silence with the given ID.
+func (e *Engine) RemoveSilence(id uint64) error {
+	e.stateLock.Lock()
+	defer e.stateLock.Unlock()
+
+	for i, silence := range e.silences {
+		if silence.ID == id {
+			e.silences = append(e.silences[:i], e.silences[i+1:]...)
+			return nil
+		}
+	}
+	return errors.WithMessagef(ErrSilenceNotFound, "id: %d", id)
+}
+
+// Silences returns the silences that did not expire yet at the given time, ordered by their start.
+// Expired silences are removed.
+func (e *Engine) Silences(now time.Time) []*Silence {
+	e.stateLock.Lock()
+	defer e.stateLock.Unlock()
+
+	active := e.silences[:0]
+	for _, silence := range e.silences {
+		if now.Before(silence.EndsAt) {
+			active = append(active, silence)
+		}
+	}
+	e.silences = active
+
+	silences := make([]*Silence, len(e.silences))
+	for i, silence := range e.silences {
+		copied := *silence
+		silences[i] = &copied
+	}
+	sort.SliceStable(silences, func(i, j int) bool {
+		return silences[i].StartsAt.Before(silences[j].StartsAt)
+	})
+	return silences
+}
@@ -0,0 +1,92 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+// Notifier delivers alerts.
+type Notifier interface {
+	// Notify delivers the given alert.
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// NotifierFunc is a function that is used as a Notifier.
+type NotifierFunc func(ctx context.Context, alert *Alert) error
+
+// Notify calls the function.
+func (f NotifierFunc) Notify(ctx context.Context, alert *Alert) error {
+	return f(ctx, alert)
+}
+
+// NewLogNotifier returns a Notifier that writes the alerts to the given logger.
+func NewLogNotifier(log *logger.Logger) Notifier {
+	return NotifierFunc(func(_ context.Context, alert *Alert) error {
+		if alert.State == StateResolved {
+			log.Infof("Alert \"%s\" resolved: %s (current value: %v)", alert.Rule, alert.Condition, alert.Value)
+			return nil
+		}
+
+		switch alert.Severity {
+		case SeverityCritical:
+			log.Errorf("Alert \"%s\" (%s) firing: %s (current value: %v)", alert.Rule, alert.Severity, alert.Condition, alert.Value)
+		case SeverityWarning:
+			log.Warnf("Alert \"%s\" (%s) firing: %s (current value: %v)", alert.Rule, alert.Severity, alert.Condition, alert.Value)
+		default:
+			log.Infof("Alert \"%s\" (%s) firing: %s (current value: %v)", alert.Rule, alert.Severity, alert.Condition, alert.Value)
+		}
+		return nil
+	})
+}
+
+// WebhookNotifier posts the alerts as JSON to a HTTP endpoint.
+type WebhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier.
+func NewWebhookNotifier(url string, headers map[string]string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts the alert to the endpoint of the webhook.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned status code %d", res.StatusCode)
+	}
+
+	return nil
+}
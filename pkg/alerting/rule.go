@@ -0,0 +1,168 @@
+package alerting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidRule is returned if a rule can't be parsed.
+	ErrInvalidRule = errors.New("invalid alerting rule")
+)
+
+// Severity is the severity of an alert.
+type Severity string
+
+const (
+	// SeverityInfo denotes an informational alert.
+	SeverityInfo Severity = "info"
+	// SeverityWarning denotes an alert that needs attention.
+	SeverityWarning Severity = "warning"
+	// SeverityCritical denotes an alert that needs immediate attention.
+	SeverityCritical Severity = "critical"
+)
+
+// Operator compares the value of a metric with the threshold of a rule.
+type Operator string
+
+const (
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+	OperatorEqual          Operator = "=="
+	OperatorNotEqual       Operator = "!="
+)
+
+// Compare returns whether the comparison of the value with the threshold holds.
+func (o Operator) Compare(value float64, threshold float64) bool {
+	switch o {
+	case OperatorGreater:
+		return value > threshold
+	case OperatorGreaterOrEqual:
+		return value >= threshold
+	case OperatorLess:
+		return value < threshold
+	case OperatorLessOrEqual:
+		return value <= threshold
+	case OperatorEqual:
+		return value == threshold
+	case OperatorNotEqual:
+		return value != threshold
+	default:
+		return false
+	}
+}
+
+func (o Operator) valid() bool {
+	switch o {
+	case OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual, OperatorEqual, OperatorNotEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rule defines a condition over a metric that fires an alert.
+type Rule struct {
+	// the unique name of the rule.
+	Name string
+	// the name of the metric the rule is evaluated on.
+	Metric string
+	// the operator that compares the value of the metric with the threshold.
+	Operator Operator
+	// the threshold the value of the metric is compared with.
+	Threshold float64
+	// the time the condition needs to hold before the alert fires.
+	For time.Duration
+	// the severity of the alert.
+	Severity Severity
+}
+
+// Condition returns the condition of the rule in a human readable form.
+func (r *Rule) Condition() string {
+	return fmt.Sprintf("%s %s %s", r.Metric, r.Operator, strconv.FormatFloat(r.Threshold, 'f', -1, 64))
+}
+
+// String returns the rule in the format accepted by ParseRule.
+func (r *Rule) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Name)
+	sb.WriteString(": ")
+	sb.WriteString(r.Condition())
+	if r.For > 0 {
+		sb.WriteString(" for ")
+		sb.WriteString(r.For.String())
+	}
+	sb.WriteString(" severity ")
+	sb.WriteString(string(r.Severity))
+	return sb.String()
+}
+
+// ParseRule parses a rule in the format "<name>: <metric> <operator> <threshold> [for <duration>] [severity <severity>]".
+// The default severity is "warning".
+func ParseRule(s string) (*Rule, error) {
+
+	name, expression, found := strings.Cut(s, ":")
+	if !found {
+		return nil, errors.WithMessagef(ErrInvalidRule, "missing rule name: %s", s)
+	}
+
+	rule := &Rule{
+		Name:     strings.TrimSpace(name),
+		Severity: SeverityWarning,
+	}
+	if rule.Name == "" || strings.ContainsAny(rule.Name, " \t") {
+		return nil, errors.WithMessagef(ErrInvalidRule, "invalid rule name: %s", s)
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) < 3 {
+		return nil, errors.WithMessagef(ErrInvalidRule, "missing condition: %s", s)
+	}
+
+	rule.Metric = fields[0]
+	rule.Operator = Operator(fields[1])
+	if !rule.Operator.valid() {
+		return nil, errors.WithMessagef(ErrInvalidRule, "unknown operator \"%s\": %s", fields[1], s)
+	}
+
+	threshold, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidRule, "invalid threshold \"%s\": %s", fields[2], s)
+	}
+	rule.Threshold = threshold
+
+	for i := 3; i < len(fields); i += 2 {
+		if i+1 >= len(fields) {
+			return nil, errors.WithMessagef(ErrInvalidRule, "missing value for \"%s\": %s", fields[i], s)
+		}
+
+		switch fields[i] {
+		case "for":
+			duration, err := time.ParseDuration(fields[i+1])
+			if err != nil || duration < 0 {
+				return nil, errors.WithMessagef(ErrInvalidRule, "invalid duration \"%s\": %s", fields[i+1], s)
+			}
+			rule.For = duration
+
+		case "severity":
+			severity := Severity(fields[i+1])
+			switch severity {
+			case SeverityInfo, SeverityWarning, SeverityCritical:
+			default:
+				return nil, errors.WithMessagef(ErrInvalidRule, "unknown severity \"%s\": %s", fields[i+1], s)
+			}
+			rule.Severity = severity
+
+		default:
+			return nil, errors.WithMessagef(ErrInvalidRule, "unknown keyword \"%s\": %s", fields[i], s)
+		}
+	}
+
+	return rule, nil
+}
@@ -8,5 +8,8 @@ const (
 	StorePrefixMilestones           byte = 5
 	StorePrefixChildren             byte = 6
 	StorePrefixUnreferencedMessages byte = 7
+	StorePrefixMilestoneStats       byte = 8
+	StorePrefixWhiteFlagMessages    byte = 9
+	StorePrefixTransactionMessages  byte = 10
 	StorePrefixHealth               byte = 255
 )
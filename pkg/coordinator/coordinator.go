@@ -0,0 +1,424 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/syncutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/builder"
+)
+
+var (
+	// ErrCritical is returned if the coordinator hit a critical error and the node should be stopped.
+	ErrCritical = errors.New("critical coordinator error")
+	// ErrNotBootstrapped is returned if the coordinator was not bootstrapped yet.
+	ErrNotBootstrapped = errors.New("coordinator not bootstrapped")
+	// ErrAlreadyBootstrapped is returned if the coordinator was bootstrapped already.
+	ErrAlreadyBootstrapped = errors.New("coordinator already bootstrapped")
+	// ErrStateMismatch is returned if the state of the coordinator does not match the milestones known by the node.
+	ErrStateMismatch = errors.New("coordinator state does not match the milestones of the node")
+	// ErrQuorumNotReached is returned if not enough signers are available to sign a milestone.
+	ErrQuorumNotReached = errors.New("not enough milestone signers available")
+)
+
+// ComputeWhiteFlagMutationsFunc computes the white-flag mutations of a milestone with the given parents.
+type ComputeWhiteFlagMutationsFunc = func(ctx context.Context, index milestone.Index, timestamp uint32, parents hornet.MessageIDs, previousMilestoneID iotago.MilestoneID) (*whiteflag.WhiteFlagMutations, error)
+
+// AttachMessageFunc attaches a message to the tangle and does the proof of work if needed.
+type AttachMessageFunc = func(ctx context.Context, msg *iotago.Message) (hornet.MessageID, error)
+
+// LatestMilestone contains the information about the latest milestone known by the node.
+type LatestMilestone struct {
+	Index       milestone.Index
+	MessageID   hornet.MessageID
+	MilestoneID iotago.MilestoneID
+	Timestamp   time.Time
+}
+
+// Events are the events issued by the coordinator.
+type Events struct {
+	// Fired when a checkpoint message is issued.
+	IssuedCheckpointMessage *events.Event
+	// Fired when a milestone is issued.
+	IssuedMilestone *events.Event
+}
+
+// Options define options for the Coordinator.
+type Options struct {
+	// the logger used to log events.
+	logger *logger.Logger
+	// the path to the state file of the coordinator.
+	stateFilePath string
+	// the interval milestones are issued.
+	milestoneInterval time.Duration
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// the default options used for the Coordinator.
+var defaultOptions = []Option{
+	WithStateFilePath("coordinator.state"),
+	WithMilestoneInterval(10 * time.Second),
+}
+
+// Option is a function setting a coordinator option.
+type Option func(opts *Options)
+
+// WithLogger enables logging within the coordinator.
+func WithLogger(logger *logger.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// WithStateFilePath sets the path to the state file of the coordinator.
+func WithStateFilePath(stateFilePath string) Option {
+	return func(opts *Options) {
+		opts.stateFilePath = stateFilePath
+	}
+}
+
+// WithMilestoneInterval sets the interval milestones are issued.
+func WithMilestoneInterval(milestoneInterval time.Duration) Option {
+	return func(opts *Options) {
+		opts.milestoneInterval = milestoneInterval
+	}
+}
+
+// Coordinator is used to issue signed milestones and checkpoints to the network.
+type Coordinator struct {
+	// the logger used to log events.
+	*logger.WrappedLogger
+
+	milestoneLock syncutils.Mutex
+
+	// used to compute the white-flag mutations of new milestones.
+	computeWhiteFlagMutationsFunc ComputeWhiteFlagMutationsFunc
+	// used to attach the issued messages to the tangle.
+	attachMessageFunc AttachMessageFunc
+	// used to sign the milestones.
+	signerProvider MilestoneSignerProvider
+	// the protocol parameters of the network.
+	protoParas *iotago.ProtocolParameters
+
+	// the options of the coordinator.
+	opts *Options
+
+	// the current state of the coordinator.
+	state *State
+	// the last issued checkpoint message, checkpoints are chained until the next milestone.
+	lastCheckpointMessageID hornet.MessageID
+	// whether the first milestone of the network was issued.
+	bootstrapped bool
+
+	// events of the coordinator.
+	Events *Events
+}
+
+// New creates a new coordinator instance.
+func New(
+	computeWhiteFlagMutationsFunc ComputeWhiteFlagMutationsFunc,
+	attachMessageFunc AttachMessageFunc,
+	signerProvider MilestoneSignerProvider,
+	protoParas *iotago.ProtocolParameters,
+	opts ...Option) *Coordinator {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	result := &Coordinator{
+		WrappedLogger:                 logger.NewWrappedLogger(options.logger),
+		computeWhiteFlagMutationsFunc: computeWhiteFlagMutationsFunc,
+		attachMessageFunc:             attachMessageFunc,
+		signerProvider:                signerProvider,
+		protoParas:                    protoParas,
+		opts:                          options,
+		Events: &Events{
+			IssuedCheckpointMessage: events.NewEvent(CheckpointCaller),
+			IssuedMilestone:         events.NewEvent(MilestoneCaller),
+		},
+	}
+
+	return result
+}
+
+// InitState loads the coordinator state or creates a new one if the network is bootstrapped.
+// The state is checked against the latest milestone known by the node (nil if there is none).
+func (coo *Coordinator) InitState(bootstrap bool, startIndex milestone.Index, latestMilestone *LatestMilestone) error {
+
+	_, err := os.Stat(coo.opts.stateFilePath)
+	stateFileExists := !os.IsNotExist(err)
+
+	if bootstrap {
+		if stateFileExists {
+			return fmt.Errorf("%w: state file already exists: %s", ErrAlreadyBootstrapped, coo.opts.stateFilePath)
+		}
+
+		if startIndex == 0 {
+			// start with milestone 1 at least
+			startIndex = 1
+		}
+
+		state := &State{
+			LatestMilestoneIndex:     startIndex - 1,
+			LatestMilestoneMessageID: hornet.NullMessageID(),
+			LatestMilestoneTime:      time.Time{},
+		}
+
+		if latestMilestone != nil {
+			if latestMilestone.Index != startIndex-1 {
+				return fmt.Errorf("%w: previous milestone does not match the start index: %d != %d", ErrStateMismatch, latestMilestone.Index, startIndex-1)
+			}
+
+			// the network is bootstrapped on top of an existing milestone (e.g. from a snapshot)
+			state.LatestMilestoneMessageID = latestMilestone.MessageID
+			state.LatestMilestoneID = latestMilestone.MilestoneID
+			state.LatestMilestoneTime = latestMilestone.Timestamp
+		}
+
+		coo.state = state
+		coo.lastCheckpointMessageID = state.LatestMilestoneMessageID
+		coo.bootstrapped = false
+
+		return nil
+	}
+
+	if !stateFileExists {
+		return fmt.Errorf("%w: state file not found: %s", ErrNotBootstrapped, coo.opts.stateFilePath)
+	}
+
+	state, err := LoadState(coo.opts.stateFilePath)
+	if err != nil {
+		return err
+	}
+
+	latestMilestoneIndex := milestone.Index(0)
+	if latestMilestone != nil {
+		latestMilestoneIndex = latestMilestone.Index
+	}
+
+	switch {
+	case latestMilestoneIndex == state.LatestMilestoneIndex:
+		// the state matches the node
+
+	case latestMilestone != nil && latestMilestoneIndex == state.LatestMilestoneIndex+1:
+		// the node crashed after the milestone was issued, but before the state was stored
+		coo.LogWarnf("coordinator state was not stored after issuing milestone %d, recovering from the node", latestMilestoneIndex)
+
+		state.LatestMilestoneIndex = latestMilestone.Index
+		state.LatestMilestoneMessageID = latestMilestone.MessageID
+		state.LatestMilestoneID = latestMilestone.MilestoneID
+		state.LatestMilestoneTime = latestMilestone.Timestamp
+
+		if err := StoreState(coo.opts.stateFilePath, state); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("%w: latest milestone in state: %d, latest milestone of the node: %d", ErrStateMismatch, state.LatestMilestoneIndex, latestMilestoneIndex)
+	}
+
+	coo.state = state
+	coo.lastCheckpointMessageID = state.LatestMilestoneMessageID
+	coo.bootstrapped = true
+
+	return nil
+}
+
+// Bootstrap issues the first milestone of the network, if the coordinator was not bootstrapped yet.
+func (coo *Coordinator) Bootstrap(ctx context.Context) (hornet.MessageID, error) {
+	coo.milestoneLock.Lock()
+	defer coo.milestoneLock.Unlock()
+
+	if coo.state == nil {
+		return nil, ErrNotBootstrapped
+	}
+
+	if coo.bootstrapped {
+		return nil, ErrAlreadyBootstrapped
+	}
+
+	milestoneMessageID, err := coo.issueMilestone(ctx, hornet.MessageIDs{coo.state.LatestMilestoneMessageID})
+	if err != nil {
+		return nil, err
+	}
+	coo.bootstrapped = true
+
+	return milestoneMessageID, nil
+}
+
+// IssueCheckpoint issues a chain of checkpoint messages that reference the given tips.
+// Every checkpoint references the previous checkpoint and up to "MaxParentsInAMessage-1" tips.
+// The chain starts at the last checkpoint or the last milestone, and is referenced by the next milestone.
+func (coo *Coordinator) IssueCheckpoint(ctx context.Context, checkpointIndex int, tips hornet.MessageIDs) (hornet.MessageID, error) {
+	coo.milestoneLock.Lock()
+	defer coo.milestoneLock.Unlock()
+
+	if !coo.bootstrapped {
+		return nil, ErrNotBootstrapped
+	}
+
+	if len(tips) == 0 {
+		return nil, ErrNoTipsAvailable
+	}
+
+	lastCheckpointMessageID := coo.lastCheckpointMessageID
+	for tipStart := 0; tipStart < len(tips); tipStart += iotago.MaxParentsInAMessage - 1 {
+		tipEnd := tipStart + iotago.MaxParentsInAMessage - 1
+		if tipEnd > len(tips) {
+			tipEnd = len(tips)
+		}
+
+		parents := append(hornet.MessageIDs{lastCheckpointMessageID}, tips[tipStart:tipEnd]...).RemoveDupsAndSortByLexicalOrder()
+
+		msg, err := builder.NewMessageBuilder(coo.protoParas.Version).
+			ParentsMessageIDs(parents.ToSliceOfArrays()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		messageID, err := coo.attachMessageFunc(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		lastCheckpointMessageID = messageID
+		coo.lastCheckpointMessageID = messageID
+		coo.Events.IssuedCheckpointMessage.Trigger(checkpointIndex, tipStart, len(tips), messageID)
+	}
+
+	return lastCheckpointMessageID, nil
+}
+
+// IssueMilestone issues a new milestone on top of the last checkpoint and the last milestone.
+// Errors wrapping ErrCritical mean that the coordinator state could not be updated and the coordinator must be stopped.
+func (coo *Coordinator) IssueMilestone(ctx context.Context) (hornet.MessageID, error) {
+	coo.milestoneLock.Lock()
+	defer coo.milestoneLock.Unlock()
+
+	if !coo.bootstrapped {
+		return nil, ErrNotBootstrapped
+	}
+
+	return coo.issueMilestone(ctx, hornet.MessageIDs{coo.lastCheckpointMessageID, coo.state.LatestMilestoneMessageID})
+}
+
+// createMilestone creates a signed milestone payload on top of the given parents.
+func (coo *Coordinator) createMilestone(ctx context.Context, index milestone.Index, timestamp uint32, parents hornet.MessageIDs) (*iotago.Milestone, error) {
+
+	signer := coo.signerProvider.MilestoneIndexSigner(index)
+	if len(signer.PublicKeys()) < coo.signerProvider.PublicKeysCount() {
+		return nil, fmt.Errorf("%w: %d of %d signers available for milestone %d", ErrQuorumNotReached, len(signer.PublicKeys()), coo.signerProvider.PublicKeysCount(), index)
+	}
+
+	mutations, err := coo.computeWhiteFlagMutationsFunc(ctx, index, timestamp, parents, coo.state.LatestMilestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute white flag mutations: %w", err)
+	}
+
+	milestonePayload := iotago.NewMilestone(uint32(index), timestamp, coo.protoParas.Version, coo.state.LatestMilestoneID, parents.ToSliceOfArrays(), mutations.ConfirmedMerkleRoot, mutations.AppliedMerkleRoot)
+
+	if err := milestonePayload.Sign(signer.PublicKeys(), signer.SigningFunc()); err != nil {
+		return nil, err
+	}
+
+	if err := milestonePayload.VerifySignatures(coo.signerProvider.PublicKeysCount(), signer.PublicKeysSet()); err != nil {
+		return nil, err
+	}
+
+	return milestonePayload, nil
+}
+
+// issueMilestone creates, signs and attaches the next milestone and stores the new state afterwards.
+func (coo *Coordinator) issueMilestone(ctx context.Context, parents hornet.MessageIDs) (hornet.MessageID, error) {
+
+	index := coo.state.LatestMilestoneIndex + 1
+	parents = parents.RemoveDupsAndSortByLexicalOrder()
+
+	// the timestamp of the milestones must be strictly increasing
+	milestoneTime := time.Now()
+	if !milestoneTime.Truncate(time.Second).After(coo.state.LatestMilestoneTime) {
+		milestoneTime = coo.state.LatestMilestoneTime.Add(time.Second)
+	}
+
+	milestonePayload, err := coo.createMilestone(ctx, index, uint32(milestoneTime.Unix()), parents)
+	if err != nil {
+		return nil, err
+	}
+
+	milestoneID, err := milestonePayload.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := builder.NewMessageBuilder(coo.protoParas.Version).
+		ParentsMessageIDs(parents.ToSliceOfArrays()).
+		Payload(milestonePayload).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	milestoneMessageID, err := coo.attachMessageFunc(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	coo.state.LatestMilestoneIndex = index
+	coo.state.LatestMilestoneMessageID = milestoneMessageID
+	coo.state.LatestMilestoneID = *milestoneID
+	coo.state.LatestMilestoneTime = time.Unix(int64(milestonePayload.Timestamp), 0)
+	coo.lastCheckpointMessageID = milestoneMessageID
+
+	if err := StoreState(coo.opts.stateFilePath, coo.state); err != nil {
+		// the milestone was issued already, the coordinator must not issue another milestone with the same index
+		return nil, fmt.Errorf("%w: %s", ErrCritical, err)
+	}
+
+	coo.Events.IssuedMilestone.Trigger(index, *milestoneID, milestoneMessageID)
+
+	return milestoneMessageID, nil
+}
+
+// State returns a copy of the current state of the coordinator.
+func (coo *Coordinator) State() *State {
+	coo.milestoneLock.Lock()
+	defer coo.milestoneLock.Unlock()
+
+	if coo.state == nil {
+		return nil
+	}
+
+	state := *coo.state
+	return &state
+}
+
+// Bootstrapped returns whether the first milestone of the network was issued.
+func (coo *Coordinator) Bootstrapped() bool {
+	coo.milestoneLock.Lock()
+	defer coo.milestoneLock.Unlock()
+
+	return coo.bootstrapped
+}
+
+// MilestoneInterval returns the interval milestones are issued.
+func (coo *Coordinator) MilestoneInterval() time.Duration {
+	return coo.opts.milestoneInterval
+}
@@ -0,0 +1,219 @@
+package coordinator_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/coordinator"
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func messageID(b byte) hornet.MessageID {
+	id := hornet.NullMessageID()
+	id[0] = b
+	return id
+}
+
+func TestHeaviestSelector(t *testing.T) {
+
+	selector := coordinator.NewHeaviestSelector(2, 10, time.Second)
+
+	_, err := selector.SelectTips(1)
+	require.ErrorIs(t, err, coordinator.ErrNoTipsAvailable)
+
+	// 1 <- 2 <- 3 <- 4
+	//        \
+	//         5
+	// 6
+	require.Equal(t, 1, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(1), hornet.MessageIDs{hornet.NullMessageID()})))
+	require.Equal(t, 2, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(2), hornet.MessageIDs{messageID(1)})))
+	require.Equal(t, 3, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(3), hornet.MessageIDs{messageID(2)})))
+	require.Equal(t, 4, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(4), hornet.MessageIDs{messageID(3)})))
+	require.Equal(t, 5, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(5), hornet.MessageIDs{messageID(2)})))
+	require.Equal(t, 6, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(6), hornet.MessageIDs{hornet.NullMessageID()})))
+
+	// known messages are not tracked twice
+	require.Equal(t, 6, selector.OnNewSolidMessage(storage.NewMessageMetadata(messageID(6), hornet.MessageIDs{hornet.NullMessageID()})))
+
+	// referenced messages are not tracked
+	referencedMsgMeta := storage.NewMessageMetadata(messageID(7), hornet.MessageIDs{messageID(6)})
+	referencedMsgMeta.SetReferenced(true, 1)
+	require.Equal(t, 6, selector.OnNewSolidMessage(referencedMsgMeta))
+
+	// tip 4 references 4 messages, tip 5 adds only one more message and tip 6 is a single message,
+	// both are below the threshold if another tip was selected already
+	tips, err := selector.SelectTips(1)
+	require.NoError(t, err)
+	require.Equal(t, hornet.MessageIDs{messageID(4)}, tips)
+
+	// the tracked messages are removed after the selection
+	require.Equal(t, 0, selector.TrackedMessagesCount())
+}
+
+// testEnvironment contains a coordinator that attaches its messages to an in-memory tangle.
+type testEnvironment struct {
+	coo            *coordinator.Coordinator
+	keyManager     *keymanager.KeyManager
+	stateFilePath  string
+	messages       map[string]*iotago.Message
+	lastMilestones []*iotago.Milestone
+}
+
+func newTestEnvironment(t *testing.T, signers int) *testEnvironment {
+
+	te := &testEnvironment{
+		keyManager:    keymanager.New(),
+		stateFilePath: filepath.Join(t.TempDir(), "coordinator.state"),
+		messages:      make(map[string]*iotago.Message),
+	}
+
+	var privateKeys []ed25519.PrivateKey
+	for i := 0; i < 2; i++ {
+		pubKey, privKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		te.keyManager.AddKeyRange(pubKey, 0, 0)
+		if i < signers {
+			privateKeys = append(privateKeys, privKey)
+		}
+	}
+
+	computeWhiteFlagMutations := func(_ context.Context, _ milestone.Index, _ uint32, _ hornet.MessageIDs, _ iotago.MilestoneID) (*whiteflag.WhiteFlagMutations, error) {
+		return &whiteflag.WhiteFlagMutations{}, nil
+	}
+
+	attachMessage := func(_ context.Context, msg *iotago.Message) (hornet.MessageID, error) {
+		msgID, err := msg.ID()
+		if err != nil {
+			return nil, err
+		}
+
+		if milestonePayload, ok := msg.Payload.(*iotago.Milestone); ok {
+			te.lastMilestones = append(te.lastMilestones, milestonePayload)
+		}
+
+		messageID := hornet.MessageIDFromArray(*msgID)
+		te.messages[messageID.ToMapKey()] = msg
+		return messageID, nil
+	}
+
+	te.coo = coordinator.New(
+		computeWhiteFlagMutations,
+		attachMessage,
+		coordinator.NewInMemoryEd25519MilestoneSignerProvider(privateKeys, te.keyManager, 2),
+		&iotago.ProtocolParameters{Version: 2},
+		coordinator.WithStateFilePath(te.stateFilePath),
+	)
+
+	return te
+}
+
+func TestCoordinator(t *testing.T) {
+
+	te := newTestEnvironment(t, 2)
+
+	// the coordinator needs to be bootstrapped first
+	require.ErrorIs(t, te.coo.InitState(false, 0, nil), coordinator.ErrNotBootstrapped)
+	require.NoError(t, te.coo.InitState(true, 1, nil))
+
+	_, err := te.coo.IssueMilestone(context.Background())
+	require.ErrorIs(t, err, coordinator.ErrNotBootstrapped)
+
+	firstMilestoneMessageID, err := te.coo.Bootstrap(context.Background())
+	require.NoError(t, err)
+	require.True(t, te.coo.Bootstrapped())
+	require.Len(t, te.lastMilestones, 1)
+	require.Equal(t, uint32(1), te.lastMilestones[0].Index)
+	require.NoError(t, te.lastMilestones[0].VerifySignatures(2, te.keyManager.PublicKeysSetForMilestoneIndex(1)))
+
+	// issue a chain of checkpoints that is referenced by the next milestone
+	var tips hornet.MessageIDs
+	for i := byte(1); i <= 10; i++ {
+		tips = append(tips, messageID(i))
+	}
+
+	lastCheckpointMessageID, err := te.coo.IssueCheckpoint(context.Background(), 0, tips)
+	require.NoError(t, err)
+
+	lastCheckpoint := te.messages[lastCheckpointMessageID.ToMapKey()]
+	require.NotNil(t, lastCheckpoint)
+	require.Len(t, lastCheckpoint.Parents, 4)
+
+	secondMilestoneMessageID, err := te.coo.IssueMilestone(context.Background())
+	require.NoError(t, err)
+	require.Len(t, te.lastMilestones, 2)
+
+	secondMilestone := te.lastMilestones[1]
+	require.Equal(t, uint32(2), secondMilestone.Index)
+	require.Greater(t, secondMilestone.Timestamp, te.lastMilestones[0].Timestamp)
+	require.ElementsMatch(t, iotago.MilestoneParentMessageIDs{firstMilestoneMessageID.ToArray(), lastCheckpointMessageID.ToArray()}, secondMilestone.Parents)
+
+	firstMilestoneID, err := te.lastMilestones[0].ID()
+	require.NoError(t, err)
+	require.Equal(t, *firstMilestoneID, secondMilestone.PreviousMilestoneID)
+
+	// the state was persisted
+	state, err := coordinator.LoadState(te.stateFilePath)
+	require.NoError(t, err)
+	require.Equal(t, te.coo.State(), state)
+	require.Equal(t, milestone.Index(2), state.LatestMilestoneIndex)
+	require.Equal(t, secondMilestoneMessageID, state.LatestMilestoneMessageID)
+}
+
+func TestCoordinatorInitState(t *testing.T) {
+
+	te := newTestEnvironment(t, 2)
+	require.NoError(t, te.coo.InitState(true, 1, nil))
+
+	_, err := te.coo.Bootstrap(context.Background())
+	require.NoError(t, err)
+
+	// the state can't be bootstrapped twice
+	restarted := newTestEnvironment(t, 2)
+	restarted.stateFilePath = te.stateFilePath
+	restarted.coo = coordinator.New(nil, nil, nil, nil, coordinator.WithStateFilePath(te.stateFilePath))
+	require.ErrorIs(t, restarted.coo.InitState(true, 1, nil), coordinator.ErrAlreadyBootstrapped)
+
+	state := te.coo.State()
+	latestMilestone := &coordinator.LatestMilestone{
+		Index:       state.LatestMilestoneIndex,
+		MessageID:   state.LatestMilestoneMessageID,
+		MilestoneID: state.LatestMilestoneID,
+		Timestamp:   state.LatestMilestoneTime,
+	}
+
+	// the state matches the node
+	require.NoError(t, restarted.coo.InitState(false, 0, latestMilestone))
+	require.True(t, restarted.coo.Bootstrapped())
+
+	// the node crashed after issuing the next milestone, before the state was stored
+	latestMilestone.Index++
+	latestMilestone.MessageID = messageID(1)
+	require.NoError(t, restarted.coo.InitState(false, 0, latestMilestone))
+	require.Equal(t, milestone.Index(2), restarted.coo.State().LatestMilestoneIndex)
+	require.Equal(t, messageID(1), restarted.coo.State().LatestMilestoneMessageID)
+
+	// the node is ahead of the coordinator
+	latestMilestone.Index += 2
+	require.ErrorIs(t, restarted.coo.InitState(false, 0, latestMilestone), coordinator.ErrStateMismatch)
+}
+
+func TestCoordinatorQuorum(t *testing.T) {
+
+	te := newTestEnvironment(t, 1)
+	require.NoError(t, te.coo.InitState(true, 1, nil))
+
+	_, err := te.coo.Bootstrap(context.Background())
+	require.ErrorIs(t, err, coordinator.ErrQuorumNotReached)
+	require.False(t, te.coo.Bootstrapped())
+}
@@ -0,0 +1,17 @@
+package coordinator
+
+import (
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// CheckpointCaller is used to signal issued checkpoints.
+func CheckpointCaller(handler interface{}, params ...interface{}) {
+	handler.(func(checkpointIndex int, tipIndex int, tipsTotal int, messageID hornet.MessageID))(params[0].(int), params[1].(int), params[2].(int), params[3].(hornet.MessageID))
+}
+
+// MilestoneCaller is used to signal issued milestones.
+func MilestoneCaller(handler interface{}, params ...interface{}) {
+	handler.(func(index milestone.Index, milestoneID iotago.MilestoneID, messageID hornet.MessageID))(params[0].(milestone.Index), params[1].(iotago.MilestoneID), params[2].(hornet.MessageID))
+}
@@ -0,0 +1,239 @@
+package coordinator
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/utils"
+	"github.com/iotaledger/hive.go/crypto"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrNoPrivateKeys is returned if no private keys for the milestone signers were found.
+	ErrNoPrivateKeys = errors.New("no private keys given")
+)
+
+// MilestoneSignerProvider provides milestone signers.
+type MilestoneSignerProvider interface {
+	// MilestoneIndexSigner returns a new signer for the milestone index.
+	MilestoneIndexSigner(index milestone.Index) MilestoneIndexSigner
+	// PublicKeysCount returns the amount of public keys in a milestone.
+	PublicKeysCount() int
+}
+
+// MilestoneIndexSigner is a signer for a particular milestone.
+type MilestoneIndexSigner interface {
+	// PublicKeys returns a slice of the used public keys.
+	PublicKeys() []iotago.MilestonePublicKey
+	// PublicKeysSet returns a map of all valid public keys for the milestone index.
+	PublicKeysSet() iotago.MilestonePublicKeySet
+	// SigningFunc returns a function to sign the particular milestone.
+	SigningFunc() iotago.MilestoneSigningFunc
+}
+
+// sortedPublicKeys returns the public keys of the given mapping sorted by their bytes,
+// so that the signers are always chosen in the same order.
+func sortedPublicKeys(keyMapping iotago.MilestonePublicKeyMapping) []iotago.MilestonePublicKey {
+	pubKeys := make([]iotago.MilestonePublicKey, 0, len(keyMapping))
+	for pubKey := range keyMapping {
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	sort.Slice(pubKeys, func(i int, j int) bool {
+		return string(pubKeys[i][:]) < string(pubKeys[j][:])
+	})
+
+	return pubKeys
+}
+
+// InMemoryEd25519MilestoneSignerProvider provides InMemoryEd25519MilestoneIndexSigner.
+// Every private key represents a signer of the quorum.
+type InMemoryEd25519MilestoneSignerProvider struct {
+	privateKeys     []ed25519.PrivateKey
+	keyManager      *keymanager.KeyManager
+	publicKeysCount int
+}
+
+// NewInMemoryEd25519MilestoneSignerProvider creates a new InMemoryEd25519MilestoneSignerProvider.
+func NewInMemoryEd25519MilestoneSignerProvider(privateKeys []ed25519.PrivateKey, keyManager *keymanager.KeyManager, publicKeysCount int) *InMemoryEd25519MilestoneSignerProvider {
+	return &InMemoryEd25519MilestoneSignerProvider{
+		privateKeys:     privateKeys,
+		keyManager:      keyManager,
+		publicKeysCount: publicKeysCount,
+	}
+}
+
+// MilestoneIndexSigner returns a new signer for the milestone index.
+func (p *InMemoryEd25519MilestoneSignerProvider) MilestoneIndexSigner(index milestone.Index) MilestoneIndexSigner {
+
+	pubKeySet := p.keyManager.PublicKeysSetForMilestoneIndex(index)
+	keyMapping := p.keyManager.MilestonePublicKeyMappingForMilestoneIndex(index, p.privateKeys, p.publicKeysCount)
+
+	return &InMemoryEd25519MilestoneIndexSigner{
+		pubKeys:    sortedPublicKeys(keyMapping),
+		pubKeySet:  pubKeySet,
+		keyMapping: keyMapping,
+	}
+}
+
+// PublicKeysCount returns the amount of public keys in a milestone.
+func (p *InMemoryEd25519MilestoneSignerProvider) PublicKeysCount() int {
+	return p.publicKeysCount
+}
+
+// InMemoryEd25519MilestoneIndexSigner is an in memory signer for a particular milestone.
+type InMemoryEd25519MilestoneIndexSigner struct {
+	pubKeys    []iotago.MilestonePublicKey
+	pubKeySet  iotago.MilestonePublicKeySet
+	keyMapping iotago.MilestonePublicKeyMapping
+}
+
+// PublicKeys returns a slice of the used public keys.
+func (s *InMemoryEd25519MilestoneIndexSigner) PublicKeys() []iotago.MilestonePublicKey {
+	return s.pubKeys
+}
+
+// PublicKeysSet returns a map of all valid public keys for the milestone index.
+func (s *InMemoryEd25519MilestoneIndexSigner) PublicKeysSet() iotago.MilestonePublicKeySet {
+	return s.pubKeySet
+}
+
+// SigningFunc returns a function to sign the particular milestone.
+func (s *InMemoryEd25519MilestoneIndexSigner) SigningFunc() iotago.MilestoneSigningFunc {
+	return iotago.InMemoryEd25519MilestoneSigner(s.keyMapping)
+}
+
+// InsecureRemoteEd25519MilestoneSignerProvider provides InsecureRemoteEd25519MilestoneIndexSigner.
+// The remote endpoint dispatches the signing requests to the signers of the quorum.
+type InsecureRemoteEd25519MilestoneSignerProvider struct {
+	remoteEndpoint  string
+	keyManager      *keymanager.KeyManager
+	publicKeysCount int
+}
+
+// NewInsecureRemoteEd25519MilestoneSignerProvider creates a new InsecureRemoteEd25519MilestoneSignerProvider.
+// The remote endpoint must only be reachable on the same host.
+func NewInsecureRemoteEd25519MilestoneSignerProvider(remoteEndpoint string, keyManager *keymanager.KeyManager, publicKeysCount int) *InsecureRemoteEd25519MilestoneSignerProvider {
+	return &InsecureRemoteEd25519MilestoneSignerProvider{
+		remoteEndpoint:  remoteEndpoint,
+		keyManager:      keyManager,
+		publicKeysCount: publicKeysCount,
+	}
+}
+
+// MilestoneIndexSigner returns a new signer for the milestone index.
+func (p *InsecureRemoteEd25519MilestoneSignerProvider) MilestoneIndexSigner(index milestone.Index) MilestoneIndexSigner {
+
+	pubKeySet := p.keyManager.PublicKeysSetForMilestoneIndex(index)
+
+	pubKeys := p.keyManager.PublicKeysForMilestoneIndex(index)
+	sort.Slice(pubKeys, func(i int, j int) bool {
+		return string(pubKeys[i][:]) < string(pubKeys[j][:])
+	})
+	if len(pubKeys) > p.publicKeysCount {
+		pubKeys = pubKeys[:p.publicKeysCount]
+	}
+
+	return &InsecureRemoteEd25519MilestoneIndexSigner{
+		remoteEndpoint: p.remoteEndpoint,
+		pubKeys:        pubKeys,
+		pubKeySet:      pubKeySet,
+	}
+}
+
+// PublicKeysCount returns the amount of public keys in a milestone.
+func (p *InsecureRemoteEd25519MilestoneSignerProvider) PublicKeysCount() int {
+	return p.publicKeysCount
+}
+
+// InsecureRemoteEd25519MilestoneIndexSigner is a signer for a particular milestone that uses a remote endpoint.
+type InsecureRemoteEd25519MilestoneIndexSigner struct {
+	remoteEndpoint string
+	pubKeys        []iotago.MilestonePublicKey
+	pubKeySet      iotago.MilestonePublicKeySet
+}
+
+// PublicKeys returns a slice of the used public keys.
+func (s *InsecureRemoteEd25519MilestoneIndexSigner) PublicKeys() []iotago.MilestonePublicKey {
+	return s.pubKeys
+}
+
+// PublicKeysSet returns a map of all valid public keys for the milestone index.
+func (s *InsecureRemoteEd25519MilestoneIndexSigner) PublicKeysSet() iotago.MilestonePublicKeySet {
+	return s.pubKeySet
+}
+
+// SigningFunc returns a function to sign the particular milestone.
+func (s *InsecureRemoteEd25519MilestoneIndexSigner) SigningFunc() iotago.MilestoneSigningFunc {
+	return iotago.InsecureRemoteEd25519MilestoneSigner(s.remoteEndpoint)
+}
+
+// ParsePrivateKeys parses the given hex encoded ed25519 private keys.
+func ParsePrivateKeys(privateKeysHex []string) ([]ed25519.PrivateKey, error) {
+
+	var privateKeys []ed25519.PrivateKey
+	for i, privateKeyHex := range privateKeysHex {
+		privateKeyHex = strings.TrimSpace(privateKeyHex)
+		if privateKeyHex == "" {
+			continue
+		}
+
+		privateKey, err := crypto.ParseEd25519PrivateKeyFromString(privateKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key at pos %d: %w", i, err)
+		}
+		privateKeys = append(privateKeys, privateKey)
+	}
+
+	if len(privateKeys) == 0 {
+		return nil, ErrNoPrivateKeys
+	}
+
+	return privateKeys, nil
+}
+
+// LoadPrivateKeysFromEnvironment loads the comma separated hex encoded ed25519 private keys from the given environment variable.
+func LoadPrivateKeysFromEnvironment(name string) ([]ed25519.PrivateKey, error) {
+
+	keys, err := utils.LoadStringFromEnvironment(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePrivateKeys(strings.Split(keys, ","))
+}
+
+// LoadPrivateKeysFromFile loads the hex encoded ed25519 private keys from the given file.
+// The file contains one key per line, empty lines and lines starting with "#" are ignored.
+func LoadPrivateKeysFromFile(filePath string) ([]ed25519.PrivateKey, error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open private keys file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var keys []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read private keys file: %w", err)
+	}
+
+	return ParsePrivateKeys(keys)
+}
@@ -0,0 +1,103 @@
+package coordinator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/ioutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// State stores the latest state of the coordinator.
+type State struct {
+	LatestMilestoneIndex     milestone.Index
+	LatestMilestoneMessageID hornet.MessageID
+	LatestMilestoneID        iotago.MilestoneID
+	LatestMilestoneTime      time.Time
+}
+
+// jsonState is the JSON representation of a coordinator state.
+type jsonState struct {
+	LatestMilestoneIndex     uint32 `json:"latestMilestoneIndex"`
+	LatestMilestoneMessageID string `json:"latestMilestoneMessageId"`
+	LatestMilestoneID        string `json:"latestMilestoneId"`
+	LatestMilestoneTime      int64  `json:"latestMilestoneTime"`
+}
+
+func (cs *State) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(&jsonState{
+		LatestMilestoneIndex:     uint32(cs.LatestMilestoneIndex),
+		LatestMilestoneMessageID: cs.LatestMilestoneMessageID.ToHex(),
+		LatestMilestoneID:        hex.EncodeToString(cs.LatestMilestoneID[:]),
+		LatestMilestoneTime:      cs.LatestMilestoneTime.UnixNano(),
+	})
+}
+
+func (cs *State) UnmarshalJSON(data []byte) error {
+
+	jsonCooState := &jsonState{}
+	if err := json.Unmarshal(data, jsonCooState); err != nil {
+		return err
+	}
+
+	latestMilestoneMessageID, err := hornet.MessageIDFromHex(jsonCooState.LatestMilestoneMessageID)
+	if err != nil {
+		return fmt.Errorf("invalid latest milestone message ID: %w", err)
+	}
+
+	latestMilestoneIDBytes, err := hex.DecodeString(jsonCooState.LatestMilestoneID)
+	if err != nil {
+		return fmt.Errorf("invalid latest milestone ID: %w", err)
+	}
+	if len(latestMilestoneIDBytes) != iotago.MilestoneIDLength {
+		return fmt.Errorf("invalid latest milestone ID length: %d", len(latestMilestoneIDBytes))
+	}
+
+	cs.LatestMilestoneIndex = milestone.Index(jsonCooState.LatestMilestoneIndex)
+	cs.LatestMilestoneMessageID = latestMilestoneMessageID
+	copy(cs.LatestMilestoneID[:], latestMilestoneIDBytes)
+	cs.LatestMilestoneTime = time.Unix(0, jsonCooState.LatestMilestoneTime)
+
+	return nil
+}
+
+// LoadState loads the coordinator state from the given file.
+func LoadState(filePath string) (*State, error) {
+
+	state := &State{}
+	if err := ioutils.ReadJSONFromFile(filePath, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// StoreState stores the coordinator state in the given file.
+// The state is written to a temporary file first and renamed afterwards,
+// so that the previous state is not lost if the node crashes while writing.
+func StoreState(filePath string, state *State) error {
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return errors.Wrap(err, "unable to create coordinator state directory")
+	}
+
+	tempFilePath := fmt.Sprintf("%s_tmp", filePath)
+	if err := ioutils.WriteJSONToFile(tempFilePath, state, 0600); err != nil {
+		return errors.Wrap(err, "unable to write coordinator state")
+	}
+
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return errors.Wrap(err, "unable to replace coordinator state")
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package coordinator
+
+import (
+	"bytes"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+var (
+	// ErrNoTipsAvailable is returned when no tips are available in the node.
+	ErrNoTipsAvailable = errors.New("no tips available")
+)
+
+// refSet is a bitset of the tracked messages, the bit position is the position of the message in the tracking order.
+type refSet []uint64
+
+// with returns a copy of the set with enough capacity for the given position that includes the given position.
+func (r refSet) with(pos int) refSet {
+	result := make(refSet, pos/64+1)
+	copy(result, r)
+	result[pos/64] |= 1 << (uint(pos) % 64)
+	return result
+}
+
+// union adds all positions of other to the set.
+func (r refSet) union(other refSet) refSet {
+	if len(other) > len(r) {
+		result := make(refSet, len(other))
+		copy(result, r)
+		r = result
+	}
+	for i, word := range other {
+		r[i] |= word
+	}
+	return r
+}
+
+// countWithout returns the amount of positions in the set that are not part of other.
+func (r refSet) countWithout(other refSet) int {
+	count := 0
+	for i, word := range r {
+		if i < len(other) {
+			word &^= other[i]
+		}
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// trackedMessage is a message that is tracked by the HeaviestSelector.
+type trackedMessage struct {
+	messageID hornet.MessageID
+	// the tracked messages in the past cone of this message, including the message itself.
+	refs refSet
+}
+
+// HeaviestSelector implements the heaviest branch selection strategy.
+// It tracks all solid messages that were not referenced by the coordinator yet
+// and selects the tips that reference the most of these messages.
+type HeaviestSelector struct {
+	syncutils.Mutex
+
+	minHeaviestBranchUnreferencedMessagesThreshold int
+	maxHeaviestBranchTipsPerCheckpoint             int
+	heaviestBranchSelectionTimeout                 time.Duration
+
+	trackedMessages map[string]*trackedMessage
+	tips            map[string]*trackedMessage
+}
+
+// NewHeaviestSelector creates a new HeaviestSelector instance.
+func NewHeaviestSelector(minHeaviestBranchUnreferencedMessagesThreshold int, maxHeaviestBranchTipsPerCheckpoint int, heaviestBranchSelectionTimeout time.Duration) *HeaviestSelector {
+	s := &HeaviestSelector{
+		minHeaviestBranchUnreferencedMessagesThreshold: minHeaviestBranchUnreferencedMessagesThreshold,
+		maxHeaviestBranchTipsPerCheckpoint:             maxHeaviestBranchTipsPerCheckpoint,
+		heaviestBranchSelectionTimeout:                 heaviestBranchSelectionTimeout,
+	}
+	s.reset()
+	return s
+}
+
+// reset removes all tracked messages.
+func (s *HeaviestSelector) reset() {
+	s.trackedMessages = make(map[string]*trackedMessage)
+	s.tips = make(map[string]*trackedMessage)
+}
+
+// Reset removes all tracked messages.
+func (s *HeaviestSelector) Reset() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.reset()
+}
+
+// TrackedMessagesCount returns the amount of tracked messages.
+func (s *HeaviestSelector) TrackedMessagesCount() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.trackedMessages)
+}
+
+// OnNewSolidMessage adds a new solid message to the tracked messages
+// and returns the amount of tracked messages afterwards.
+func (s *HeaviestSelector) OnNewSolidMessage(msgMeta *storage.MessageMetadata) (trackedMessagesCount int) {
+	s.Lock()
+	defer s.Unlock()
+
+	if msgMeta.IsReferenced() {
+		// message was already referenced by a milestone
+		return len(s.trackedMessages)
+	}
+
+	messageIDMapKey := msgMeta.MessageID().ToMapKey()
+	if _, exists := s.trackedMessages[messageIDMapKey]; exists {
+		return len(s.trackedMessages)
+	}
+
+	var refs refSet
+	for _, parent := range msgMeta.Parents() {
+		parentMapKey := parent.ToMapKey()
+
+		trackedParent, exists := s.trackedMessages[parentMapKey]
+		if !exists {
+			// the parent was already referenced or is not tracked anymore
+			continue
+		}
+
+		refs = refs.union(trackedParent.refs)
+
+		// the parent is no tip anymore
+		delete(s.tips, parentMapKey)
+	}
+
+	msg := &trackedMessage{
+		messageID: msgMeta.MessageID(),
+		refs:      refs.with(len(s.trackedMessages)),
+	}
+
+	s.trackedMessages[messageIDMapKey] = msg
+	s.tips[messageIDMapKey] = msg
+
+	return len(s.trackedMessages)
+}
+
+// SelectTips selects the tips of the heaviest branches, which reference the most unreferenced messages.
+// A tip is only selected if it references at least "minHeaviestBranchUnreferencedMessagesThreshold" messages
+// that are not referenced by the previously selected tips, unless less than minRequiredTips were selected.
+// All tracked messages are removed afterwards, because the selected tips will be referenced by the coordinator.
+func (s *HeaviestSelector) SelectTips(minRequiredTips int) (hornet.MessageIDs, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// the tips are sorted to get a deterministic selection for branches with the same weight
+	tips := make([]*trackedMessage, 0, len(s.tips))
+	for _, tip := range s.tips {
+		tips = append(tips, tip)
+	}
+	sort.Slice(tips, func(i int, j int) bool {
+		return bytes.Compare(tips[i].messageID, tips[j].messageID) < 0
+	})
+
+	deadline := time.Now().Add(s.heaviestBranchSelectionTimeout)
+
+	var selected hornet.MessageIDs
+	var referenced refSet
+	for len(tips) > 0 && len(selected) < s.maxHeaviestBranchTipsPerCheckpoint {
+		if len(selected) >= minRequiredTips && time.Now().After(deadline) {
+			break
+		}
+
+		bestTipIndex := -1
+		bestTipCount := 0
+		for i, tip := range tips {
+			if count := tip.refs.countWithout(referenced); count > bestTipCount {
+				bestTipIndex = i
+				bestTipCount = count
+			}
+		}
+
+		if bestTipIndex == -1 {
+			// all remaining tips are referenced by the selected tips already
+			break
+		}
+
+		if bestTipCount < s.minHeaviestBranchUnreferencedMessagesThreshold && len(selected) >= minRequiredTips {
+			// the remaining branches are too light
+			break
+		}
+
+		bestTip := tips[bestTipIndex]
+		selected = append(selected, bestTip.messageID)
+		referenced = referenced.union(bestTip.refs)
+		tips = append(tips[:bestTipIndex], tips[bestTipIndex+1:]...)
+	}
+
+	if len(selected) < minRequiredTips {
+		return nil, ErrNoTipsAvailable
+	}
+
+	s.reset()
+
+	return selected, nil
+}
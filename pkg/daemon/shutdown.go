@@ -5,6 +5,7 @@ package daemon
 
 const (
 	PriorityCloseDatabase   = iota // no dependencies
+	PriorityTracing                // no dependencies, stopped late to export the spans of the other workers
 	PriorityFlushToDatabase        // depends on PriorityCloseDatabase
 	PriorityDatabaseHealth
 	PriorityTipselection        // depends on PriorityFlushToDatabase, triggered by PriorityReceiveTxWorker, PriorityMilestoneSolidifier
@@ -26,9 +27,13 @@ const (
 	PriorityMetricsUpdater
 	PriorityDashboard
 	PriorityPoWHandler
-	PriorityRestAPI // depends on PriorityPoWHandler
-	PrioritySpammer // depends on PriorityPoWHandler
+	PriorityRestAPI     // depends on PriorityPoWHandler
+	PrioritySpammer     // depends on PriorityPoWHandler
+	PriorityCoordinator // depends on PriorityPoWHandler, triggers PriorityMilestoneSolidifier
 	PriorityIndexer
 	PriorityStatusReport
 	PriorityPrometheus
+	PriorityAlerting
+	PriorityExport
+	PriorityHotReload // triggers PriorityP2PManager, PrioritySnapshots, PrioritySpammer
 )
@@ -11,6 +11,15 @@ import (
 
 // NewPebbleDB creates a new pebble DB instance.
 func NewPebbleDB(directory string, reportCompactionRunning func(running bool), enableFilter bool) (*pebbleDB.DB, error) {
+	return newPebbleDB(directory, reportCompactionRunning, enableFilter, false)
+}
+
+// NewPebbleDBReadOnly opens an existing pebble DB instance in read-only mode.
+func NewPebbleDBReadOnly(directory string) (*pebbleDB.DB, error) {
+	return newPebbleDB(directory, nil, false, true)
+}
+
+func newPebbleDB(directory string, reportCompactionRunning func(running bool), enableFilter bool, readOnly bool) (*pebbleDB.DB, error) {
 	cache := pebbleDB.NewCache(128 << 20) // 128 MB
 	defer cache.Unref()
 
@@ -238,5 +247,13 @@ func NewPebbleDB(directory string, reportCompactionRunning func(running bool), e
 	// The default value is 1.
 	opts.MaxConcurrentCompactions = 1
 
+	// ReadOnly indicates that the DB should be opened in read-only mode. Writes
+	// to the DB will return an error, background compactions are disabled,
+	// and the flush that normally occurs after replaying the WAL at startup is
+	// disabled.
+	//
+	// The default value is false.
+	opts.ReadOnly = readOnly
+
 	return pebble.CreateDB(directory, opts)
 }
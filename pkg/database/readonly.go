@@ -0,0 +1,106 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/pebble"
+	"github.com/iotaledger/hive.go/kvstore/rocksdb"
+)
+
+var (
+	// ErrReadOnly is returned when a write operation is executed on a read-only store.
+	ErrReadOnly = errors.New("store is read-only")
+)
+
+// readOnlyStore wraps a kvstore and rejects all write operations.
+type readOnlyStore struct {
+	kvstore.KVStore
+}
+
+// NewReadOnlyStore wraps the given store and rejects all write operations.
+func NewReadOnlyStore(store kvstore.KVStore) kvstore.KVStore {
+	return &readOnlyStore{KVStore: store}
+}
+
+func (s *readOnlyStore) WithRealm(realm kvstore.Realm) (kvstore.KVStore, error) {
+	store, err := s.KVStore.WithRealm(realm)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyStore{KVStore: store}, nil
+}
+
+func (s *readOnlyStore) Clear() error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyStore) Set(_ kvstore.Key, _ kvstore.Value) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyStore) Delete(_ kvstore.Key) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyStore) DeletePrefix(_ kvstore.KeyPrefix) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyStore) Flush() error {
+	// nothing to flush
+	return nil
+}
+
+func (s *readOnlyStore) Batched() (kvstore.BatchedMutations, error) {
+	return &readOnlyBatchedMutations{}, nil
+}
+
+// readOnlyBatchedMutations rejects all mutations of a batch.
+type readOnlyBatchedMutations struct{}
+
+func (b *readOnlyBatchedMutations) Set(_ kvstore.Key, _ kvstore.Value) error {
+	return ErrReadOnly
+}
+
+func (b *readOnlyBatchedMutations) Delete(_ kvstore.Key) error {
+	return ErrReadOnly
+}
+
+func (b *readOnlyBatchedMutations) Cancel() {}
+
+func (b *readOnlyBatchedMutations) Commit() error {
+	return nil
+}
+
+// StoreReadOnly opens an existing database in read-only mode.
+// Pebble databases are opened in read-only mode by the engine itself,
+// for all engines the returned store rejects write operations.
+func StoreReadOnly(path string, dbEngine ...Engine) (kvstore.KVStore, error) {
+
+	targetEngine, err := CheckDatabaseEngine(path, false, dbEngine...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch targetEngine {
+	case EnginePebble:
+		db, err := NewPebbleDBReadOnly(path)
+		if err != nil {
+			return nil, err
+		}
+		return NewReadOnlyStore(pebble.New(db)), nil
+
+	case EngineRocksDB:
+		db, err := NewRocksDB(path)
+		if err != nil {
+			return nil, err
+		}
+		return NewReadOnlyStore(rocksdb.New(db)), nil
+
+	default:
+		return nil, fmt.Errorf("unknown database engine: %s, supported engines: pebble/rocksdb", targetEngine)
+	}
+}
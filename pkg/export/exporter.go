@@ -0,0 +1,237 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// StateFileName is the name of the file that contains the progress of the export.
+	StateFileName = "export_state.json"
+	// SchemaFileName is the name of the file that contains the schema of the exported tables.
+	SchemaFileName = "schema.sql"
+)
+
+var (
+	// ErrExportGap is returned if the milestones following the last exported milestone are not available in the source.
+	ErrExportGap = errors.New("milestones following the last exported milestone are not available")
+	// ErrInvalidPartitionSize is returned if the partition size is zero.
+	ErrInvalidPartitionSize = errors.New("invalid partition size")
+)
+
+// Source provides the data that is exported.
+type Source interface {
+	// MilestoneRange returns the first and the last milestone that can be exported.
+	MilestoneRange() (milestone.Index, milestone.Index, error)
+	// ExportMilestone writes the milestone, the messages it referenced and the ledger changes it applied to the writer.
+	ExportMilestone(ctx context.Context, writer Writer, msIndex milestone.Index) error
+}
+
+// LedgerSource is a Source that also contains a ledger state, for example the unspent outputs of a full snapshot.
+type LedgerSource interface {
+	Source
+	// LedgerIndex returns the index of the milestone of the ledger state.
+	LedgerIndex() milestone.Index
+	// ExportLedger writes the unspent outputs of the ledger state to the writer.
+	ExportLedger(ctx context.Context, writer Writer) error
+}
+
+// State is the progress of an export.
+type State struct {
+	// The index of the ledger state that was exported (0 if none).
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The index of the last exported milestone.
+	LastExportedIndex milestone.Index `json:"lastExportedIndex"`
+}
+
+// Exporter exports milestones, messages and the ledger changes of a source
+// as SQL dumps, partitioned by milestone ranges.
+// The progress is stored in the export directory, so an export can be resumed.
+type Exporter struct {
+	directory     string
+	partitionSize milestone.Index
+	hrp           iotago.NetworkPrefix
+}
+
+// NewExporter creates a new Exporter that writes partitions of partitionSize milestones to the given directory.
+// The HRP is used to encode the addresses of the outputs.
+func NewExporter(directory string, partitionSize uint32, hrp iotago.NetworkPrefix) (*Exporter, error) {
+	if partitionSize == 0 {
+		return nil, ErrInvalidPartitionSize
+	}
+
+	return &Exporter{
+		directory:     directory,
+		partitionSize: milestone.Index(partitionSize),
+		hrp:           hrp,
+	}, nil
+}
+
+// PartitionFilePath returns the path of the partition that contains the milestones from start to end.
+func (e *Exporter) PartitionFilePath(start milestone.Index, end milestone.Index) string {
+	return filepath.Join(e.directory, fmt.Sprintf("%010d-%010d.sql", start, end))
+}
+
+// LedgerFilePath returns the path of the exported ledger state at the given milestone.
+func (e *Exporter) LedgerFilePath(ledgerIndex milestone.Index) string {
+	return filepath.Join(e.directory, fmt.Sprintf("ledger-%010d.sql", ledgerIndex))
+}
+
+// State returns the progress of the export.
+func (e *Exporter) State() (*State, error) {
+	state := &State{}
+
+	data, err := os.ReadFile(filepath.Join(e.directory, StateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, errors.Wrap(err, "unable to read export state")
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrap(err, "unable to parse export state")
+	}
+
+	return state, nil
+}
+
+// writeFile writes the file via a temporary file, so that it is either written completely or not at all.
+func writeFile(filePath string, writeFunc func(file *os.File) error) error {
+	filePathTmp := filePath + "_tmp"
+
+	file, err := os.OpenFile(filePathTmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFunc(file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(filePathTmp)
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(filePathTmp, filePath)
+}
+
+func (e *Exporter) storeState(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(e.directory, StateFileName), func(file *os.File) error {
+		_, err := file.Write(data)
+		return err
+	})
+}
+
+// exportPartition writes a partition via the given export function.
+func (e *Exporter) exportPartition(filePath string, exportFunc func(writer Writer) error) error {
+	return writeFile(filePath, func(file *os.File) error {
+		writer := NewSQLWriter(file, e.hrp)
+		if err := exportFunc(writer); err != nil {
+			return err
+		}
+		return writer.Close()
+	})
+}
+
+// Export exports the milestones of the source up to the target index (0 = the last milestone of the source),
+// continuing after the last exported milestone. The ledger state of a LedgerSource is exported with the first export.
+// The partitions are aligned to multiples of the partition size, the last partition may be incomplete.
+// It returns the index of the last exported milestone.
+func (e *Exporter) Export(ctx context.Context, source Source, targetIndex milestone.Index) (milestone.Index, error) {
+
+	if err := os.MkdirAll(e.directory, 0700); err != nil {
+		return 0, errors.Wrap(err, "unable to create export directory")
+	}
+
+	if err := os.WriteFile(filepath.Join(e.directory, SchemaFileName), []byte(Schema), 0666); err != nil {
+		return 0, errors.Wrap(err, "unable to write schema")
+	}
+
+	state, err := e.State()
+	if err != nil {
+		return 0, err
+	}
+
+	if ledgerSource, ok := source.(LedgerSource); ok && state.LedgerIndex == 0 && state.LastExportedIndex == 0 {
+		ledgerIndex := ledgerSource.LedgerIndex()
+		if err := e.exportPartition(e.LedgerFilePath(ledgerIndex), func(writer Writer) error {
+			return ledgerSource.ExportLedger(ctx, writer)
+		}); err != nil {
+			return 0, errors.Wrapf(err, "unable to export ledger state at milestone %d", ledgerIndex)
+		}
+
+		state.LedgerIndex = ledgerIndex
+		if err := e.storeState(state); err != nil {
+			return 0, err
+		}
+	}
+
+	startIndex, endIndex, err := source.MilestoneRange()
+	if err != nil {
+		return 0, err
+	}
+
+	if targetIndex == 0 || targetIndex > endIndex {
+		targetIndex = endIndex
+	}
+
+	nextIndex := startIndex
+	if state.LastExportedIndex != 0 {
+		if state.LastExportedIndex+1 < startIndex {
+			return state.LastExportedIndex, errors.WithMessagef(ErrExportGap, "last exported milestone: %d, first available milestone: %d", state.LastExportedIndex, startIndex)
+		}
+		nextIndex = state.LastExportedIndex + 1
+	}
+	if nextIndex == 0 {
+		// there is no milestone with index 0
+		nextIndex = 1
+	}
+
+	for nextIndex <= targetIndex {
+		partitionEnd := ((nextIndex-1)/e.partitionSize + 1) * e.partitionSize
+		if partitionEnd > targetIndex {
+			partitionEnd = targetIndex
+		}
+
+		if err := e.exportPartition(e.PartitionFilePath(nextIndex, partitionEnd), func(writer Writer) error {
+			for msIndex := nextIndex; msIndex <= partitionEnd; msIndex++ {
+				if err := ctx.Err(); err != nil {
+					return common.ErrOperationAborted
+				}
+
+				if err := source.ExportMilestone(ctx, writer, msIndex); err != nil {
+					return errors.Wrapf(err, "unable to export milestone %d", msIndex)
+				}
+			}
+			return nil
+		}); err != nil {
+			return state.LastExportedIndex, err
+		}
+
+		state.LastExportedIndex = partitionEnd
+		if err := e.storeState(state); err != nil {
+			return state.LastExportedIndex, err
+		}
+
+		nextIndex = partitionEnd + 1
+	}
+
+	return state.LastExportedIndex, nil
+}
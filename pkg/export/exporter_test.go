@@ -0,0 +1,112 @@
+package export_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/export"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/model/utxo/utils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// testSource exports a milestone that creates a single output for every index in its range.
+type testSource struct {
+	startIndex milestone.Index
+	endIndex   milestone.Index
+	exported   []milestone.Index
+}
+
+func (s *testSource) MilestoneRange() (milestone.Index, milestone.Index, error) {
+	return s.startIndex, s.endIndex, nil
+}
+
+func (s *testSource) ExportMilestone(_ context.Context, writer export.Writer, msIndex milestone.Index) error {
+	s.exported = append(s.exported, msIndex)
+
+	if err := writer.WriteMilestone(&iotago.Milestone{Index: uint32(msIndex), Timestamp: uint32(msIndex), Parents: iotago.MilestoneParentMessageIDs{utils.RandMessageID().ToArray()}}); err != nil {
+		return err
+	}
+
+	output := utxo.CreateOutput(utils.RandOutputID(), utils.RandMessageID(), msIndex, uint32(msIndex), utils.RandOutputOnAddressWithAmount(iotago.OutputBasic, utils.RandAddress(iotago.AddressEd25519), 100))
+	if err := writer.WriteOutput(output); err != nil {
+		return err
+	}
+
+	return writer.WriteMilestoneDiff(msIndex, utxo.Outputs{output}, nil)
+}
+
+func TestExporterPartitions(t *testing.T) {
+
+	directory := t.TempDir()
+
+	exporter, err := export.NewExporter(directory, 10, iotago.PrefixTestnet)
+	require.NoError(t, err)
+
+	source := &testSource{startIndex: 5, endIndex: 23}
+
+	lastExportedIndex, err := exporter.Export(context.Background(), source, 18)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(18), lastExportedIndex)
+
+	// partitions are aligned to multiples of the partition size
+	require.FileExists(t, exporter.PartitionFilePath(5, 10))
+	require.FileExists(t, exporter.PartitionFilePath(11, 18))
+	require.FileExists(t, filepath.Join(directory, export.SchemaFileName))
+
+	state, err := exporter.State()
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(18), state.LastExportedIndex)
+
+	// the export is resumed after the last exported milestone
+	source.exported = nil
+	lastExportedIndex, err = exporter.Export(context.Background(), source, 0)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(23), lastExportedIndex)
+	require.Equal(t, []milestone.Index{19, 20, 21, 22, 23}, source.exported)
+	require.FileExists(t, exporter.PartitionFilePath(19, 20))
+	require.FileExists(t, exporter.PartitionFilePath(21, 23))
+
+	data, err := os.ReadFile(exporter.PartitionFilePath(19, 20))
+	require.NoError(t, err)
+
+	content := string(data)
+	require.True(t, strings.HasPrefix(content, "BEGIN;\n"))
+	require.True(t, strings.HasSuffix(content, "COMMIT;\n"))
+	require.Equal(t, 2, strings.Count(content, "INSERT INTO milestones "))
+	require.Equal(t, 2, strings.Count(content, "INSERT INTO outputs "))
+	require.Equal(t, 2, strings.Count(content, "'created'"))
+	require.Contains(t, content, "'"+string(iotago.PrefixTestnet)+"1")
+
+	// nothing left to export
+	source.exported = nil
+	lastExportedIndex, err = exporter.Export(context.Background(), source, 0)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(23), lastExportedIndex)
+	require.Empty(t, source.exported)
+}
+
+func TestExporterGap(t *testing.T) {
+
+	exporter, err := export.NewExporter(t.TempDir(), 10, iotago.PrefixTestnet)
+	require.NoError(t, err)
+
+	_, err = exporter.Export(context.Background(), &testSource{startIndex: 1, endIndex: 10}, 0)
+	require.NoError(t, err)
+
+	// the milestones 11-14 were pruned in the meantime
+	lastExportedIndex, err := exporter.Export(context.Background(), &testSource{startIndex: 15, endIndex: 20}, 0)
+	require.ErrorIs(t, err, export.ErrExportGap)
+	require.Equal(t, milestone.Index(10), lastExportedIndex)
+}
+
+func TestExporterInvalidPartitionSize(t *testing.T) {
+	_, err := export.NewExporter(t.TempDir(), 0, iotago.PrefixTestnet)
+	require.ErrorIs(t, err, export.ErrInvalidPartitionSize)
+}
@@ -0,0 +1,57 @@
+package export
+
+// Schema is the PostgreSQL schema of the tables the SQL dumps are imported into.
+//
+//   - milestones:      the milestone payloads.
+//   - messages:        the messages, with the index of the milestone that referenced them.
+//   - outputs:         the outputs, with the index of the milestone that booked them.
+//   - spents:          the spent outputs, with the index of the milestone that spent them.
+//   - milestone_diffs: the outputs created ("created") and consumed ("consumed") by a milestone.
+//
+// All IDs are stored as raw bytes, addresses are bech32 encoded.
+// The statements of the dumps ignore rows that already exist,
+// so partitions can be imported more than once.
+const Schema = `CREATE TABLE IF NOT EXISTS milestones (
+    milestone_index       BIGINT PRIMARY KEY,
+    milestone_id          BYTEA NOT NULL,
+    timestamp             BIGINT NOT NULL,
+    previous_milestone_id BYTEA NOT NULL,
+    confirmed_merkle_root BYTEA NOT NULL,
+    applied_merkle_root   BYTEA NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+    message_id                 BYTEA PRIMARY KEY,
+    milestone_index_referenced BIGINT NOT NULL,
+    payload_type               BIGINT,
+    data                       BYTEA NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS outputs (
+    output_id                  BYTEA PRIMARY KEY,
+    output_type                TEXT NOT NULL,
+    address                    TEXT,
+    amount                     BIGINT NOT NULL,
+    message_id                 BYTEA NOT NULL,
+    milestone_index_booked     BIGINT NOT NULL,
+    milestone_timestamp_booked BIGINT NOT NULL,
+    data                       BYTEA NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS spents (
+    output_id                 BYTEA PRIMARY KEY,
+    transaction_id_spent      BYTEA NOT NULL,
+    milestone_index_spent     BIGINT NOT NULL,
+    milestone_timestamp_spent BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS milestone_diffs (
+    milestone_index BIGINT NOT NULL,
+    output_id       BYTEA NOT NULL,
+    change          TEXT NOT NULL,
+    PRIMARY KEY (milestone_index, output_id, change)
+);
+
+CREATE INDEX IF NOT EXISTS outputs_address_idx ON outputs (address);
+CREATE INDEX IF NOT EXISTS messages_milestone_index_referenced_idx ON messages (milestone_index_referenced);
+`
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/snapshot"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// SnapshotSource exports the ledger state and the milestone diffs of a full and an optional delta snapshot file.
+// Snapshot files don't contain messages, only the milestones and their ledger changes are exported.
+type SnapshotSource struct {
+	fullSnapshotPath string
+	protoParas       *iotago.ProtocolParameters
+	ledgerIndex      milestone.Index
+	milestoneDiffs   map[milestone.Index]*snapshot.MilestoneDiff
+	startIndex       milestone.Index
+	endIndex         milestone.Index
+}
+
+// NewSnapshotSource reads the milestone diffs of the full and the delta snapshot file (optional).
+func NewSnapshotSource(fullSnapshotPath string, deltaSnapshotPath string, protoParas *iotago.ProtocolParameters) (*SnapshotSource, error) {
+
+	s := &SnapshotSource{
+		fullSnapshotPath: fullSnapshotPath,
+		protoParas:       protoParas,
+		milestoneDiffs:   make(map[milestone.Index]*snapshot.MilestoneDiff),
+	}
+
+	fullHeader, err := s.readMilestoneDiffs(fullSnapshotPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read full snapshot file")
+	}
+	if fullHeader.Type != snapshot.Full {
+		return nil, errors.WithMessage(snapshot.ErrNoFullSnapshot, fullSnapshotPath)
+	}
+	s.ledgerIndex = fullHeader.LedgerMilestoneIndex
+
+	if deltaSnapshotPath != "" {
+		deltaHeader, err := s.readMilestoneDiffs(deltaSnapshotPath, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read delta snapshot file")
+		}
+		if deltaHeader.Type != snapshot.Delta {
+			return nil, errors.Errorf("not a delta snapshot: %s", deltaSnapshotPath)
+		}
+	}
+
+	s.startIndex = s.ledgerIndex + 1
+	for msIndex := range s.milestoneDiffs {
+		if msIndex < s.startIndex {
+			s.startIndex = msIndex
+		}
+		if msIndex > s.endIndex {
+			s.endIndex = msIndex
+		}
+	}
+
+	return s, nil
+}
+
+// readMilestoneDiffs reads the milestone diffs of the snapshot file and passes the unspent outputs to the output consumer.
+func (s *SnapshotSource) readMilestoneDiffs(filePath string, outputConsumer snapshot.OutputConsumerFunc) (*snapshot.ReadFileHeader, error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	if outputConsumer == nil {
+		outputConsumer = func(_ *utxo.Output) error { return nil }
+	}
+
+	var header *snapshot.ReadFileHeader
+	if err := snapshot.StreamSnapshotDataFrom(file,
+		s.protoParas,
+		func(readHeader *snapshot.ReadFileHeader) error {
+			header = readHeader
+			return nil
+		},
+		func(_ hornet.MessageID) error { return nil },
+		outputConsumer,
+		func(_ *utxo.TreasuryOutput) error { return nil },
+		func(msDiff *snapshot.MilestoneDiff) error {
+			s.milestoneDiffs[milestone.Index(msDiff.Milestone.Index)] = msDiff
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// MilestoneRange returns the range of the milestone diffs of the snapshot files.
+func (s *SnapshotSource) MilestoneRange() (milestone.Index, milestone.Index, error) {
+	return s.startIndex, s.endIndex, nil
+}
+
+// LedgerIndex returns the ledger index of the full snapshot.
+func (s *SnapshotSource) LedgerIndex() milestone.Index {
+	return s.ledgerIndex
+}
+
+// ExportLedger writes the unspent outputs of the full snapshot to the writer.
+func (s *SnapshotSource) ExportLedger(ctx context.Context, writer Writer) error {
+	file, err := os.Open(s.fullSnapshotPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	return snapshot.StreamSnapshotDataFrom(file,
+		s.protoParas,
+		func(_ *snapshot.ReadFileHeader) error { return nil },
+		func(_ hornet.MessageID) error { return nil },
+		func(output *utxo.Output) error {
+			if err := ctx.Err(); err != nil {
+				return common.ErrOperationAborted
+			}
+			return writer.WriteOutput(output)
+		},
+		func(_ *utxo.TreasuryOutput) error { return nil },
+		func(_ *snapshot.MilestoneDiff) error { return nil },
+	)
+}
+
+// ExportMilestone writes the milestone and its ledger changes to the writer.
+// Milestones without a milestone diff in the snapshot files are skipped.
+func (s *SnapshotSource) ExportMilestone(_ context.Context, writer Writer, msIndex milestone.Index) error {
+
+	msDiff, exists := s.milestoneDiffs[msIndex]
+	if !exists {
+		return nil
+	}
+
+	if err := writer.WriteMilestone(msDiff.Milestone); err != nil {
+		return err
+	}
+
+	for _, output := range msDiff.Created {
+		if err := writer.WriteOutput(output); err != nil {
+			return err
+		}
+	}
+
+	for _, spent := range msDiff.Consumed {
+		if err := writer.WriteSpent(spent); err != nil {
+			return err
+		}
+	}
+
+	return writer.WriteMilestoneDiff(msIndex, msDiff.Created, msDiff.Consumed)
+}
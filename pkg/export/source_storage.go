@@ -0,0 +1,141 @@
+package export
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// StorageSource exports the milestones, messages and ledger changes of a node's storage.
+// It only reads from the storage.
+type StorageSource struct {
+	storage *storage.Storage
+}
+
+// NewStorageSource creates a new StorageSource.
+func NewStorageSource(dbStorage *storage.Storage) *StorageSource {
+	return &StorageSource{storage: dbStorage}
+}
+
+// MilestoneRange returns the milestones after the pruning index up to the ledger index.
+func (s *StorageSource) MilestoneRange() (milestone.Index, milestone.Index, error) {
+	snapshotInfo := s.storage.SnapshotInfo()
+	if snapshotInfo == nil {
+		return 0, 0, errors.New("snapshot info not found")
+	}
+
+	ledgerIndex, err := s.storage.UTXOManager().ReadLedgerIndex()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return snapshotInfo.PruningIndex + 1, ledgerIndex, nil
+}
+
+// referencedMessageIDs returns the messages referenced by the milestone.
+// The white-flag messages are used if they were stored, otherwise the past cone of the milestone is traversed.
+func (s *StorageSource) referencedMessageIDs(ctx context.Context, cachedMilestone *storage.CachedMilestone) (hornet.MessageIDs, error) {
+	msIndex := cachedMilestone.Milestone().Index()
+
+	whiteFlagMessages, err := s.storage.WhiteFlagMessages(msIndex)
+	if err != nil {
+		return nil, err
+	}
+	if whiteFlagMessages != nil {
+		return whiteFlagMessages.Referenced, nil
+	}
+
+	var messageIDs hornet.MessageIDs
+	if err := dag.TraverseParents(
+		ctx,
+		s.storage,
+		cachedMilestone.Milestone().Parents(),
+		// traversal stops if no more messages pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedMsgMeta *storage.CachedMetadata) (bool, error) { // meta +1
+			defer cachedMsgMeta.Release(true) // meta -1
+
+			referenced, at := cachedMsgMeta.Metadata().ReferencedWithIndex()
+			return referenced && at == msIndex, nil
+		},
+		// consumer
+		func(cachedMsgMeta *storage.CachedMetadata) error { // meta +1
+			cachedMsgMeta.ConsumeMetadata(func(metadata *storage.MessageMetadata) { // meta -1
+				messageIDs = append(messageIDs, metadata.MessageID())
+			})
+			return nil
+		},
+		// called on missing parents
+		// return error on missing parents
+		nil,
+		// called on solid entry points
+		// Ignore solid entry points (snapshot milestone included)
+		nil,
+		false); err != nil {
+		return nil, err
+	}
+
+	return messageIDs, nil
+}
+
+// ExportMilestone writes the milestone, the messages it referenced and the ledger changes it applied to the writer.
+// Milestones that are not available in the storage are skipped.
+func (s *StorageSource) ExportMilestone(ctx context.Context, writer Writer, msIndex milestone.Index) error {
+
+	cachedMilestone := s.storage.CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	if err := writer.WriteMilestone(cachedMilestone.Milestone().Milestone()); err != nil {
+		return err
+	}
+
+	messageIDs, err := s.referencedMessageIDs(ctx, cachedMilestone)
+	if err != nil {
+		return err
+	}
+
+	for _, messageID := range messageIDs {
+		cachedMsg := s.storage.CachedMessageOrNil(messageID) // message +1
+		if cachedMsg == nil {
+			continue
+		}
+
+		err := writer.WriteMessage(msIndex, cachedMsg.Message())
+		cachedMsg.Release(true) // message -1
+		if err != nil {
+			return err
+		}
+	}
+
+	msDiff, err := s.storage.UTXOManager().MilestoneDiff(msIndex)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			// no ledger changes stored for this milestone
+			return nil
+		}
+		return err
+	}
+
+	for _, output := range msDiff.Outputs {
+		if err := writer.WriteOutput(output); err != nil {
+			return err
+		}
+	}
+
+	for _, spent := range msDiff.Spents {
+		if err := writer.WriteSpent(spent); err != nil {
+			return err
+		}
+	}
+
+	return writer.WriteMilestoneDiff(msIndex, msDiff.Outputs, msDiff.Spents)
+}
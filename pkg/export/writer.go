@@ -0,0 +1,193 @@
+package export
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/snapshot"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MilestoneDiffChangeCreated marks an output that was created by a milestone.
+	MilestoneDiffChangeCreated = "created"
+	// MilestoneDiffChangeConsumed marks an output that was consumed by a milestone.
+	MilestoneDiffChangeConsumed = "consumed"
+)
+
+// Writer writes the exported data of a partition.
+type Writer interface {
+	// WriteMilestone writes a milestone.
+	WriteMilestone(milestonePayload *iotago.Milestone) error
+	// WriteMessage writes a message that was referenced by the given milestone.
+	WriteMessage(msIndexReferenced milestone.Index, message *storage.Message) error
+	// WriteOutput writes an output.
+	WriteOutput(output *utxo.Output) error
+	// WriteSpent writes a spent output.
+	WriteSpent(spent *utxo.Spent) error
+	// WriteMilestoneDiff writes the outputs created and consumed by a milestone.
+	WriteMilestoneDiff(msIndex milestone.Index, created utxo.Outputs, consumed utxo.Spents) error
+	// Close finishes the partition.
+	Close() error
+}
+
+// SQLWriter writes the exported data as PostgreSQL statements (see Schema).
+// All statements of a partition are executed in a single transaction.
+type SQLWriter struct {
+	writer *bufio.Writer
+	hrp    iotago.NetworkPrefix
+	began  bool
+}
+
+// NewSQLWriter creates a new SQLWriter that writes to the given writer.
+// The HRP is used to encode the addresses of the outputs.
+func NewSQLWriter(writer io.Writer, hrp iotago.NetworkPrefix) *SQLWriter {
+	return &SQLWriter{
+		writer: bufio.NewWriter(writer),
+		hrp:    hrp,
+	}
+}
+
+// sqlBytes returns the bytes as a PostgreSQL bytea literal.
+func sqlBytes(data []byte) string {
+	if data == nil {
+		return "NULL"
+	}
+	return `'\x` + hex.EncodeToString(data) + `'`
+}
+
+// sqlString returns the string as a PostgreSQL string literal.
+// Only bech32 addresses and output type names are written, which don't contain quotes.
+func sqlString(value string) string {
+	if value == "" {
+		return "NULL"
+	}
+	return "'" + value + "'"
+}
+
+func (w *SQLWriter) insert(table string, columns string, values ...string) error {
+	if !w.began {
+		w.began = true
+		if _, err := w.writer.WriteString("BEGIN;\n"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w.writer, "INSERT INTO %s (%s) VALUES (", table, columns); err != nil {
+		return err
+	}
+	for i, value := range values {
+		if i > 0 {
+			if _, err := w.writer.WriteString(", "); err != nil {
+				return err
+			}
+		}
+		if _, err := w.writer.WriteString(value); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString(") ON CONFLICT DO NOTHING;\n")
+	return err
+}
+
+func (w *SQLWriter) WriteMilestone(milestonePayload *iotago.Milestone) error {
+	milestoneID, err := milestonePayload.ID()
+	if err != nil {
+		return err
+	}
+
+	return w.insert("milestones", "milestone_index, milestone_id, timestamp, previous_milestone_id, confirmed_merkle_root, applied_merkle_root",
+		strconv.FormatUint(uint64(milestonePayload.Index), 10),
+		sqlBytes(milestoneID[:]),
+		strconv.FormatUint(uint64(milestonePayload.Timestamp), 10),
+		sqlBytes(milestonePayload.PreviousMilestoneID[:]),
+		sqlBytes(milestonePayload.ConfirmedMerkleRoot[:]),
+		sqlBytes(milestonePayload.AppliedMerkleRoot[:]),
+	)
+}
+
+func (w *SQLWriter) WriteMessage(msIndexReferenced milestone.Index, message *storage.Message) error {
+	payloadType := "NULL"
+	if payload := message.Message().Payload; payload != nil {
+		payloadType = strconv.FormatUint(uint64(payload.PayloadType()), 10)
+	}
+
+	return w.insert("messages", "message_id, milestone_index_referenced, payload_type, data",
+		sqlBytes(message.MessageID()),
+		strconv.FormatUint(uint64(msIndexReferenced), 10),
+		payloadType,
+		sqlBytes(message.Data()),
+	)
+}
+
+func (w *SQLWriter) WriteOutput(output *utxo.Output) error {
+	data, err := output.Output().Serialize(serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return err
+	}
+
+	address := ""
+	if owner := snapshot.OutputOwner(output.Output()); owner != nil {
+		address = owner.Bech32(w.hrp)
+	}
+
+	return w.insert("outputs", "output_id, output_type, address, amount, message_id, milestone_index_booked, milestone_timestamp_booked, data",
+		sqlBytes(output.OutputID()[:]),
+		sqlString(output.OutputType().String()),
+		sqlString(address),
+		strconv.FormatUint(output.Deposit(), 10),
+		sqlBytes(output.MessageID()),
+		strconv.FormatUint(uint64(output.MilestoneIndex()), 10),
+		strconv.FormatUint(uint64(output.MilestoneTimestamp()), 10),
+		sqlBytes(data),
+	)
+}
+
+func (w *SQLWriter) WriteSpent(spent *utxo.Spent) error {
+	return w.insert("spents", "output_id, transaction_id_spent, milestone_index_spent, milestone_timestamp_spent",
+		sqlBytes(spent.OutputID()[:]),
+		sqlBytes(spent.TargetTransactionID()[:]),
+		strconv.FormatUint(uint64(spent.MilestoneIndex()), 10),
+		strconv.FormatUint(uint64(spent.MilestoneTimestamp()), 10),
+	)
+}
+
+func (w *SQLWriter) WriteMilestoneDiff(msIndex milestone.Index, created utxo.Outputs, consumed utxo.Spents) error {
+	for _, output := range created {
+		if err := w.insert("milestone_diffs", "milestone_index, output_id, change",
+			strconv.FormatUint(uint64(msIndex), 10),
+			sqlBytes(output.OutputID()[:]),
+			sqlString(MilestoneDiffChangeCreated),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, spent := range consumed {
+		if err := w.insert("milestone_diffs", "milestone_index, output_id, change",
+			strconv.FormatUint(uint64(msIndex), 10),
+			sqlBytes(spent.OutputID()[:]),
+			sqlString(MilestoneDiffChangeConsumed),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *SQLWriter) Close() error {
+	if w.began {
+		if _, err := w.writer.WriteString("COMMIT;\n"); err != nil {
+			return err
+		}
+	}
+	return w.writer.Flush()
+}
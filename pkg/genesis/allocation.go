@@ -0,0 +1,237 @@
+package genesis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// OutputTypeBasic allocates the tokens in a basic output owned by the address.
+	OutputTypeBasic = "basic"
+	// OutputTypeNFT allocates the tokens in a new NFT output owned by the address.
+	OutputTypeNFT = "nft"
+	// OutputTypeAlias allocates the tokens in a new alias output, the address is state controller and governor.
+	OutputTypeAlias = "alias"
+)
+
+const (
+	csvColumnAddress                = "address"
+	csvColumnAmount                 = "amount"
+	csvColumnOutputType             = "outputType"
+	csvColumnTimelockUnixTime       = "timelockUnixTime"
+	csvColumnTimelockMilestoneIndex = "timelockMilestoneIndex"
+	csvColumnVestingStart           = "vestingStart"
+	csvColumnVestingInterval        = "vestingInterval"
+	csvColumnVestingPeriods         = "vestingPeriods"
+)
+
+var (
+	// ErrInvalidAllocation is returned if an allocation is invalid.
+	ErrInvalidAllocation = errors.New("invalid allocation")
+	// ErrUnknownAllocationsFileFormat is returned if the format of the allocations file is unknown.
+	ErrUnknownAllocationsFileFormat = errors.New("unknown allocations file format")
+)
+
+// Vesting splits an allocation into several outputs that are unlocked one after another.
+type Vesting struct {
+	// The unix time in seconds at which the first part of the allocation is unlocked.
+	Start uint32 `json:"start"`
+	// The time in seconds between the unlocks of two parts of the allocation.
+	Interval uint32 `json:"interval"`
+	// The amount of parts the allocation is split into.
+	Periods uint32 `json:"periods"`
+}
+
+// Allocation defines an amount of tokens that is allocated to an address in the genesis snapshot.
+type Allocation struct {
+	// The bech32 or hex encoded ed25519 address that owns the allocation.
+	Address string `json:"address"`
+	// The amount of base tokens of the allocation.
+	Amount uint64 `json:"amount"`
+	// The type of the output (basic, nft or alias). Defaults to basic.
+	OutputType string `json:"outputType,omitempty"`
+	// The unix time in seconds until which the allocation is locked.
+	TimelockUnixTime uint32 `json:"timelockUnixTime,omitempty"`
+	// The milestone index until which the allocation is locked.
+	TimelockMilestoneIndex uint32 `json:"timelockMilestoneIndex,omitempty"`
+	// The vesting schedule of the allocation. It can't be combined with a timelock.
+	Vesting *Vesting `json:"vesting,omitempty"`
+	// The native tokens of the allocation. They can't be combined with a vesting schedule.
+	NativeTokens iotago.NativeTokens `json:"nativeTokens,omitempty"`
+}
+
+// ReadAllocationsFile reads the allocations from a JSON or CSV file.
+// The format is chosen by the file extension.
+func ReadAllocationsFile(filePath string) ([]*Allocation, error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open allocations file")
+	}
+	defer func() { _ = file.Close() }()
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return ReadAllocationsJSON(file)
+	case ".csv":
+		return ReadAllocationsCSV(file)
+	default:
+		return nil, errors.WithMessagef(ErrUnknownAllocationsFileFormat, "file: %s", filePath)
+	}
+}
+
+// ReadAllocationsJSON reads the allocations from a JSON array.
+func ReadAllocationsJSON(reader io.Reader) ([]*Allocation, error) {
+
+	var allocations []*Allocation
+	if err := json.NewDecoder(reader).Decode(&allocations); err != nil {
+		return nil, errors.Wrap(err, "unable to parse allocations")
+	}
+
+	return allocations, nil
+}
+
+// ReadAllocationsCSV reads the allocations from CSV records.
+// The first record is the header that names the columns, only the "address" and "amount" columns are required.
+// Native tokens are only supported in the JSON format.
+func ReadAllocationsCSV(reader io.Reader) ([]*Allocation, error) {
+
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read allocations header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		switch column {
+		case csvColumnAddress, csvColumnAmount, csvColumnOutputType,
+			csvColumnTimelockUnixTime, csvColumnTimelockMilestoneIndex,
+			csvColumnVestingStart, csvColumnVestingInterval, csvColumnVestingPeriods:
+			columns[column] = i
+		default:
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "unknown column: %s", column)
+		}
+	}
+
+	for _, column := range []string{csvColumnAddress, csvColumnAmount} {
+		if _, exists := columns[column]; !exists {
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "missing column: %s", column)
+		}
+	}
+
+	var allocations []*Allocation
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrapf(err, "unable to read allocation in line %d", line)
+		}
+
+		field := func(column string) string {
+			if i, exists := columns[column]; exists {
+				return record[i]
+			}
+			return ""
+		}
+
+		parseUint := func(column string, bitSize int) (uint64, error) {
+			value := field(column)
+			if value == "" {
+				return 0, nil
+			}
+
+			parsed, err := strconv.ParseUint(value, 10, bitSize)
+			if err != nil {
+				return 0, errors.WithMessagef(ErrInvalidAllocation, "line %d, column %s: %s", line, column, err)
+			}
+			return parsed, nil
+		}
+
+		allocation := &Allocation{
+			Address:    field(csvColumnAddress),
+			OutputType: field(csvColumnOutputType),
+		}
+
+		if allocation.Amount, err = parseUint(csvColumnAmount, 64); err != nil {
+			return nil, err
+		}
+
+		timelockUnixTime, err := parseUint(csvColumnTimelockUnixTime, 32)
+		if err != nil {
+			return nil, err
+		}
+		allocation.TimelockUnixTime = uint32(timelockUnixTime)
+
+		timelockMilestoneIndex, err := parseUint(csvColumnTimelockMilestoneIndex, 32)
+		if err != nil {
+			return nil, err
+		}
+		allocation.TimelockMilestoneIndex = uint32(timelockMilestoneIndex)
+
+		vestingStart, err := parseUint(csvColumnVestingStart, 32)
+		if err != nil {
+			return nil, err
+		}
+		vestingInterval, err := parseUint(csvColumnVestingInterval, 32)
+		if err != nil {
+			return nil, err
+		}
+		vestingPeriods, err := parseUint(csvColumnVestingPeriods, 32)
+		if err != nil {
+			return nil, err
+		}
+		if vestingPeriods > 0 {
+			allocation.Vesting = &Vesting{
+				Start:    uint32(vestingStart),
+				Interval: uint32(vestingInterval),
+				Periods:  uint32(vestingPeriods),
+			}
+		}
+
+		allocations = append(allocations, allocation)
+	}
+
+	return allocations, nil
+}
+
+// parseAddress parses a bech32 address of the network or a hex encoded ed25519 address.
+func parseAddress(address string, protoParas *iotago.ProtocolParameters) (iotago.Address, error) {
+
+	if hrp, bech32Address, err := iotago.ParseBech32(address); err == nil {
+		if hrp != protoParas.Bech32HRP {
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "address %s has the wrong network prefix (expected %s)", address, protoParas.Bech32HRP)
+		}
+		return bech32Address, nil
+	}
+
+	if !strings.HasPrefix(address, "0x") {
+		address = "0x" + address
+	}
+
+	addressBytes, err := iotago.DecodeHex(address)
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidAllocation, "invalid address %s: %s", address, err)
+	}
+	if len(addressBytes) != iotago.Ed25519AddressBytesLength {
+		return nil, errors.WithMessagef(ErrInvalidAllocation, "invalid address length: %d != %d (%s)", len(addressBytes), iotago.Ed25519AddressBytesLength, address)
+	}
+
+	var ed25519Address iotago.Ed25519Address
+	copy(ed25519Address[:], addressBytes)
+
+	return &ed25519Address, nil
+}
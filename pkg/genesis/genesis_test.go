@@ -0,0 +1,170 @@
+package genesis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/genesis"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	testAddress1 = "0x6920b176f613ec7be59e68fc68f597eb3393af80f74c7c3db78198147d5f1f92"
+	testAddress2 = "52fdfc072182654f163f5f0f9a621d729566c74d10037c4d7bbb0407d1e2c649"
+)
+
+var testProtoParas = &iotago.ProtocolParameters{
+	Version:     2,
+	NetworkName: "testnet",
+	Bech32HRP:   iotago.PrefixTestnet,
+	TokenSupply: 1_000_000,
+}
+
+func TestReadAllocationsCSV(t *testing.T) {
+
+	allocations, err := genesis.ReadAllocationsCSV(strings.NewReader(`address,amount,outputType,timelockUnixTime,vestingStart,vestingInterval,vestingPeriods
+` + testAddress1 + `,500000,nft,1700000000,,,
+` + testAddress2 + `,400000,,,1700000000,3600,4
+`))
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+
+	require.Equal(t, testAddress1, allocations[0].Address)
+	require.EqualValues(t, 500_000, allocations[0].Amount)
+	require.Equal(t, genesis.OutputTypeNFT, allocations[0].OutputType)
+	require.EqualValues(t, 1_700_000_000, allocations[0].TimelockUnixTime)
+	require.Nil(t, allocations[0].Vesting)
+
+	require.Equal(t, &genesis.Vesting{Start: 1_700_000_000, Interval: 3600, Periods: 4}, allocations[1].Vesting)
+
+	_, err = genesis.ReadAllocationsCSV(strings.NewReader("address,amount,unknown\n"))
+	require.ErrorIs(t, err, genesis.ErrInvalidAllocation)
+
+	_, err = genesis.ReadAllocationsCSV(strings.NewReader("address\n"))
+	require.ErrorIs(t, err, genesis.ErrInvalidAllocation)
+
+	_, err = genesis.ReadAllocationsCSV(strings.NewReader("address,amount\n" + testAddress1 + ",abc\n"))
+	require.ErrorIs(t, err, genesis.ErrInvalidAllocation)
+}
+
+func TestReadAllocationsJSON(t *testing.T) {
+
+	allocations, err := genesis.ReadAllocationsJSON(strings.NewReader(`[
+		{"address": "` + testAddress1 + `", "amount": 600000, "outputType": "alias"},
+		{"address": "` + testAddress2 + `", "amount": 300000, "vesting": {"start": 1700000000, "interval": 60, "periods": 2}}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	require.Equal(t, genesis.OutputTypeAlias, allocations[0].OutputType)
+	require.Equal(t, &genesis.Vesting{Start: 1_700_000_000, Interval: 60, Periods: 2}, allocations[1].Vesting)
+}
+
+func TestBuild(t *testing.T) {
+
+	allocations := []*genesis.Allocation{
+		{Address: testAddress1, Amount: 500_000, OutputType: genesis.OutputTypeAlias},
+		{Address: testAddress2, Amount: 100_000, TimelockMilestoneIndex: 10},
+		{Address: testAddress2, Amount: 300_001, Vesting: &genesis.Vesting{Start: 1_700_000_000, Interval: 3600, Periods: 3}},
+	}
+
+	outputs, err := genesis.Build(allocations, 99_999, testProtoParas)
+	require.NoError(t, err)
+	require.Len(t, outputs, 5)
+
+	require.IsType(t, &iotago.AliasOutput{}, outputs[0])
+	require.NotNil(t, outputs[0].UnlockConditions().MustSet().StateControllerAddress())
+	require.NotNil(t, outputs[0].UnlockConditions().MustSet().GovernorAddress())
+
+	require.EqualValues(t, 10, outputs[1].UnlockConditions().MustSet().Timelock().MilestoneIndex)
+
+	// the remainder of the vesting split is added to the last period
+	for i, expected := range []struct {
+		amount   uint64
+		unixTime uint32
+	}{
+		{100_000, 1_700_000_000},
+		{100_000, 1_700_003_600},
+		{100_001, 1_700_007_200},
+	} {
+		require.Equal(t, expected.amount, outputs[2+i].Deposit())
+		require.Equal(t, expected.unixTime, outputs[2+i].UnlockConditions().MustSet().Timelock().UnixTime)
+	}
+
+	summary := genesis.NewSummary()
+	for _, output := range outputs {
+		summary.AddOutput(output)
+	}
+	require.Equal(t, 5, summary.Outputs)
+	require.Equal(t, 2, summary.Addresses)
+	require.EqualValues(t, 900_001, summary.Amount)
+	require.Equal(t, 4, summary.TimelockedOutputs)
+	require.EqualValues(t, 400_001, summary.TimelockedAmount)
+	require.EqualValues(t, 500_000, summary.AmountByOutputType[iotago.OutputAlias.String()])
+	require.Equal(t, 4, summary.OutputsByOutputType[iotago.OutputBasic.String()])
+
+	// the allocations and the treasury have to match the token supply
+	_, err = genesis.Build(allocations, 0, testProtoParas)
+	require.ErrorIs(t, err, genesis.ErrSupplyMismatch)
+}
+
+func TestBuildInvalidAllocations(t *testing.T) {
+
+	for name, allocation := range map[string]*genesis.Allocation{
+		"zero amount":          {Address: testAddress1},
+		"invalid address":      {Address: "0x1234", Amount: 1},
+		"wrong network prefix": {Address: (&iotago.Ed25519Address{}).Bech32(iotago.PrefixMainnet), Amount: 1},
+		"unknown output type":  {Address: testAddress1, Amount: 1, OutputType: "foundry"},
+		"timelocked alias":     {Address: testAddress1, Amount: 1, OutputType: genesis.OutputTypeAlias, TimelockUnixTime: 1},
+		"vesting and timelock": {Address: testAddress1, Amount: 10, TimelockUnixTime: 1, Vesting: &genesis.Vesting{Start: 1, Interval: 1, Periods: 2}},
+		"vesting interval":     {Address: testAddress1, Amount: 10, Vesting: &genesis.Vesting{Start: 1, Periods: 2}},
+		"vesting amount":       {Address: testAddress1, Amount: 1, Vesting: &genesis.Vesting{Start: 1, Interval: 1, Periods: 2}},
+	} {
+		_, err := allocation.Outputs(testProtoParas)
+		require.ErrorIs(t, err, genesis.ErrInvalidAllocation, name)
+	}
+}
+
+func TestBuildWithProtocolParameters(t *testing.T) {
+
+	protoParas := &iotago.ProtocolParameters{
+		Version:     2,
+		NetworkName: "testnet",
+		Bech32HRP:   iotago.NetworkPrefix("tst"),
+		RentStructure: iotago.RentStructure{
+			VByteCost:    500,
+			VBFactorData: 1,
+			VBFactorKey:  10,
+		},
+		TokenSupply: 1_000_000,
+	}
+
+	address := (&iotago.Ed25519Address{}).Bech32(protoParas.Bech32HRP)
+
+	outputs, err := genesis.Build([]*genesis.Allocation{
+		{Address: address, Amount: 1_000_000},
+	}, 0, protoParas)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+
+	// addresses of other networks are rejected
+	_, err = (&genesis.Allocation{Address: (&iotago.Ed25519Address{}).Bech32(iotago.PrefixTestnet), Amount: 1_000_000}).Outputs(protoParas)
+	require.ErrorIs(t, err, genesis.ErrInvalidAllocation)
+
+	// the outputs have to cover the storage deposit
+	minDeposit, err := protoParas.RentStructure.CoversStateRent(outputs[0], outputs[0].Deposit())
+	require.NoError(t, err)
+	require.Greater(t, minDeposit, uint64(0))
+
+	_, err = (&genesis.Allocation{Address: address, Amount: minDeposit}).Outputs(protoParas)
+	require.NoError(t, err)
+
+	_, err = (&genesis.Allocation{Address: address, Amount: minDeposit - 1}).Outputs(protoParas)
+	require.ErrorIs(t, err, genesis.ErrInvalidAllocation)
+}
+
+func TestOutputID(t *testing.T) {
+	require.Equal(t, iotago.OutputID{}, genesis.OutputID(0))
+	require.NotEqual(t, genesis.OutputID(1), genesis.OutputID(2))
+}
@@ -0,0 +1,177 @@
+package genesis
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrSupplyMismatch is returned if the allocations and the treasury don't sum up to the token supply.
+	ErrSupplyMismatch = errors.New("allocations don't match the token supply")
+)
+
+// OutputID returns the ID of the genesis output with the given index.
+// Genesis outputs are not created by a transaction, so the index is encoded
+// in the transaction ID to get unique output, alias and NFT IDs.
+func OutputID(index uint32) iotago.OutputID {
+	outputID := iotago.OutputID{}
+	binary.LittleEndian.PutUint32(outputID[:4], index)
+	return outputID
+}
+
+// timelock returns the timelock unlock condition for the given criteria, or nil if both are zero.
+func timelock(unixTime uint32, milestoneIndex uint32) iotago.UnlockCondition {
+	if unixTime == 0 && milestoneIndex == 0 {
+		return nil
+	}
+	return &iotago.TimelockUnlockCondition{UnixTime: unixTime, MilestoneIndex: milestoneIndex}
+}
+
+// output creates an output of the given type.
+func output(outputType string, address iotago.Address, amount uint64, nativeTokens iotago.NativeTokens, timelockCondition iotago.UnlockCondition) (iotago.Output, error) {
+
+	switch outputType {
+	case OutputTypeBasic, "":
+		conditions := iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: address}}
+		if timelockCondition != nil {
+			conditions = append(conditions, timelockCondition)
+		}
+		return &iotago.BasicOutput{
+			Amount:       amount,
+			NativeTokens: nativeTokens,
+			Conditions:   conditions,
+		}, nil
+
+	case OutputTypeNFT:
+		conditions := iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: address}}
+		if timelockCondition != nil {
+			conditions = append(conditions, timelockCondition)
+		}
+		// the NFT ID is derived from the output ID, because it is a new NFT
+		return &iotago.NFTOutput{
+			Amount:       amount,
+			NativeTokens: nativeTokens,
+			Conditions:   conditions,
+		}, nil
+
+	case OutputTypeAlias:
+		if timelockCondition != nil {
+			return nil, errors.WithMessage(ErrInvalidAllocation, "alias outputs can't be timelocked")
+		}
+		// the alias ID is derived from the output ID, because it is a new alias
+		return &iotago.AliasOutput{
+			Amount:       amount,
+			NativeTokens: nativeTokens,
+			Conditions: iotago.UnlockConditions{
+				&iotago.StateControllerAddressUnlockCondition{Address: address},
+				&iotago.GovernorAddressUnlockCondition{Address: address},
+			},
+		}, nil
+
+	default:
+		return nil, errors.WithMessagef(ErrInvalidAllocation, "unknown output type: %s", outputType)
+	}
+}
+
+// Outputs creates the outputs of the allocation.
+// A vested allocation is split into one timelocked output per period,
+// the remainder of the split is added to the last period.
+func (a *Allocation) Outputs(protoParas *iotago.ProtocolParameters) ([]iotago.Output, error) {
+
+	address, err := parseAddress(a.Address, protoParas)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Amount == 0 {
+		return nil, errors.WithMessagef(ErrInvalidAllocation, "amount of %s is zero", a.Address)
+	}
+
+	if len(a.NativeTokens) > 0 {
+		if _, err := a.NativeTokens.Set(); err != nil {
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "native tokens of %s: %s", a.Address, err)
+		}
+		for _, nativeToken := range a.NativeTokens {
+			if nativeToken.Amount == nil || nativeToken.Amount.Sign() <= 0 {
+				return nil, errors.WithMessagef(ErrInvalidAllocation, "native token amount of %s has to be greater than zero", a.Address)
+			}
+		}
+	}
+
+	var outputs []iotago.Output
+
+	if a.Vesting == nil {
+		out, err := output(a.OutputType, address, a.Amount, a.NativeTokens, timelock(a.TimelockUnixTime, a.TimelockMilestoneIndex))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "address %s", a.Address)
+		}
+		outputs = append(outputs, out)
+	} else {
+		switch {
+		case a.TimelockUnixTime != 0 || a.TimelockMilestoneIndex != 0:
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "vesting of %s can't be combined with a timelock", a.Address)
+		case len(a.NativeTokens) > 0:
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "vesting of %s can't be combined with native tokens", a.Address)
+		case a.Vesting.Periods == 0:
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "vesting of %s needs at least one period", a.Address)
+		case a.Vesting.Periods > 1 && a.Vesting.Interval == 0:
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "vesting interval of %s is zero", a.Address)
+		case uint64(a.Vesting.Start)+uint64(a.Vesting.Periods-1)*uint64(a.Vesting.Interval) > uint64(^uint32(0)):
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "vesting of %s ends after the maximum unix time", a.Address)
+		case a.Amount < uint64(a.Vesting.Periods):
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "amount of %s is too small for %d vesting periods", a.Address, a.Vesting.Periods)
+		}
+
+		amountPerPeriod := a.Amount / uint64(a.Vesting.Periods)
+		for period := uint32(0); period < a.Vesting.Periods; period++ {
+			amount := amountPerPeriod
+			if period == a.Vesting.Periods-1 {
+				amount += a.Amount % uint64(a.Vesting.Periods)
+			}
+
+			out, err := output(a.OutputType, address, amount, nil, timelock(a.Vesting.Start+period*a.Vesting.Interval, 0))
+			if err != nil {
+				return nil, errors.WithMessagef(err, "address %s", a.Address)
+			}
+			outputs = append(outputs, out)
+		}
+	}
+
+	for _, out := range outputs {
+		if _, err := protoParas.RentStructure.CoversStateRent(out, out.Deposit()); err != nil {
+			return nil, errors.WithMessagef(ErrInvalidAllocation, "output of %s: %s", a.Address, err)
+		}
+	}
+
+	return outputs, nil
+}
+
+// Build creates the outputs of all allocations
+// and checks that the allocations and the treasury sum up to the token supply of the protocol.
+func Build(allocations []*Allocation, treasury uint64, protoParas *iotago.ProtocolParameters) ([]iotago.Output, error) {
+
+	var outputs []iotago.Output
+
+	sum := treasury
+	for _, allocation := range allocations {
+		if sum+allocation.Amount < sum {
+			return nil, errors.WithMessage(ErrSupplyMismatch, "the sum of the allocations overflows")
+		}
+		sum += allocation.Amount
+
+		allocationOutputs, err := allocation.Outputs(protoParas)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, allocationOutputs...)
+	}
+
+	if sum != protoParas.TokenSupply {
+		return nil, errors.WithMessagef(ErrSupplyMismatch, "allocations and treasury: %d, token supply: %d", sum, protoParas.TokenSupply)
+	}
+
+	return outputs, nil
+}
@@ -0,0 +1,72 @@
+package genesis
+
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// Summary aggregates the outputs of a (genesis) snapshot.
+type Summary struct {
+	// The amount of outputs.
+	Outputs int `json:"outputs"`
+	// The amount of distinct addresses that own outputs.
+	Addresses int `json:"addresses"`
+	// The amount of base tokens in all outputs.
+	Amount uint64 `json:"amount"`
+	// The amount of outputs per output type.
+	OutputsByOutputType map[string]int `json:"outputsByOutputType"`
+	// The amount of base tokens per output type.
+	AmountByOutputType map[string]uint64 `json:"amountByOutputType"`
+	// The amount of outputs with a timelock unlock condition.
+	TimelockedOutputs int `json:"timelockedOutputs"`
+	// The amount of base tokens in outputs with a timelock unlock condition.
+	TimelockedAmount uint64 `json:"timelockedAmount"`
+	// The amount of outputs that hold native tokens.
+	NativeTokenOutputs int `json:"nativeTokenOutputs"`
+
+	addresses map[string]struct{}
+}
+
+// NewSummary creates a new empty Summary.
+func NewSummary() *Summary {
+	return &Summary{
+		OutputsByOutputType: make(map[string]int),
+		AmountByOutputType:  make(map[string]uint64),
+		addresses:           make(map[string]struct{}),
+	}
+}
+
+// owner returns the address that owns the output.
+func owner(output iotago.Output) iotago.Address {
+	conditions := output.UnlockConditions().MustSet()
+	if address := conditions.Address(); address != nil {
+		return address.Address
+	}
+	if stateController := conditions.StateControllerAddress(); stateController != nil {
+		return stateController.Address
+	}
+	return nil
+}
+
+// AddOutput adds the output to the summary.
+func (s *Summary) AddOutput(output iotago.Output) {
+	s.Outputs++
+	s.Amount += output.Deposit()
+
+	outputType := output.Type().String()
+	s.OutputsByOutputType[outputType]++
+	s.AmountByOutputType[outputType] += output.Deposit()
+
+	if address := owner(output); address != nil {
+		s.addresses[address.Key()] = struct{}{}
+		s.Addresses = len(s.addresses)
+	}
+
+	if output.UnlockConditions().MustSet().Timelock() != nil {
+		s.TimelockedOutputs++
+		s.TimelockedAmount += output.Deposit()
+	}
+
+	if len(output.NativeTokenSet()) > 0 {
+		s.NativeTokenOutputs++
+	}
+}
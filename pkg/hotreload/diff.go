@@ -0,0 +1,65 @@
+package hotreload
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ParameterChange describes the change of a single configuration parameter.
+type ParameterChange struct {
+	// Key is the flattened and lowercased key of the parameter.
+	Key string
+	// Old is the previous value of the parameter, nil if the parameter was added.
+	Old interface{}
+	// New is the new value of the parameter, nil if the parameter was removed.
+	New interface{}
+}
+
+// String returns a human readable representation of the change.
+func (c *ParameterChange) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %v", c.Key, c.New)
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed %v", c.Key, c.Old)
+	default:
+		return fmt.Sprintf("%s: %v => %v", c.Key, c.Old, c.New)
+	}
+}
+
+// DiffParameters compares two flattened parameter maps and returns the changes sorted by key.
+func DiffParameters(oldParameters map[string]interface{}, newParameters map[string]interface{}) []*ParameterChange {
+
+	var changes []*ParameterChange
+
+	for key, oldValue := range oldParameters {
+		newValue, exists := newParameters[key]
+		if !exists {
+			changes = append(changes, &ParameterChange{Key: key, Old: oldValue})
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, &ParameterChange{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+
+	for key, newValue := range newParameters {
+		if _, exists := oldParameters[key]; !exists {
+			changes = append(changes, &ParameterChange{Key: key, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// matchesKey checks whether the key equals the given prefix or is a child of it.
+func matchesKey(key string, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
@@ -0,0 +1,183 @@
+package hotreload
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/logger"
+)
+
+// ApplyFunc applies the reloaded parameters to a running component.
+// It is called after the bound parameter structs were updated.
+type ApplyFunc func() error
+
+// parametersHandler is a set of hot-reloadable parameters of a component.
+type parametersHandler struct {
+	name      string
+	keys      []string
+	applyFunc ApplyFunc
+}
+
+// ConfigReloader reloads the node configuration file and applies changes of
+// parameters that were registered as hot-reloadable.
+// Changes of all other parameters are only logged, since they require a restart of the node.
+type ConfigReloader struct {
+	// the logger used to log events.
+	*logger.WrappedLogger
+	// the app configuration that is updated on changes.
+	config *configuration.Configuration
+	// the path of the configuration file.
+	filePath string
+	// lock for the reload and the handlers.
+	reloadLock sync.Mutex
+	// the flattened parameters that were loaded from the file the last time.
+	fileParameters map[string]interface{}
+	// the registered handlers of hot-reloadable parameters.
+	handlers []*parametersHandler
+}
+
+// NewConfigReloader creates a new ConfigReloader for the given app configuration and its file.
+func NewConfigReloader(log *logger.Logger, config *configuration.Configuration, filePath string) (*ConfigReloader, error) {
+
+	// the file may not exist yet, in that case all parameters are reported as added as soon as it gets created
+	fileParameters, err := loadFileParameters(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if fileParameters == nil {
+		fileParameters = make(map[string]interface{})
+	}
+
+	return &ConfigReloader{
+		WrappedLogger:  logger.NewWrappedLogger(log),
+		config:         config,
+		filePath:       filePath,
+		fileParameters: fileParameters,
+	}, nil
+}
+
+// RegisterParameters registers a set of hot-reloadable parameters.
+// The keys are the paths of the parameters or of their parent namespace (case-insensitive).
+// applyFunc is called once per reload if any of the parameters changed.
+func (r *ConfigReloader) RegisterParameters(name string, applyFunc ApplyFunc, keys ...string) {
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	lowerKeys := make([]string, len(keys))
+	for i, key := range keys {
+		lowerKeys[i] = strings.ToLower(key)
+	}
+
+	r.handlers = append(r.handlers, &parametersHandler{
+		name:      name,
+		keys:      lowerKeys,
+		applyFunc: applyFunc,
+	})
+}
+
+// IsHotReloadable checks whether the parameter with the given key is hot-reloadable.
+func (r *ConfigReloader) IsHotReloadable(key string) bool {
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	return r.handlerForKey(strings.ToLower(key)) != nil
+}
+
+// handlerForKey returns the handler that is responsible for the given key.
+// reloadLock needs to be held by the caller.
+func (r *ConfigReloader) handlerForKey(key string) *parametersHandler {
+	for _, handler := range r.handlers {
+		for _, prefix := range handler.keys {
+			if matchesKey(key, prefix) {
+				return handler
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload loads the configuration file, logs all changed parameters and applies
+// the changes of the hot-reloadable parameters.
+// It returns the changes that were found in the file.
+func (r *ConfigReloader) Reload() ([]*ParameterChange, error) {
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	fileParameters, err := loadFileParameters(r.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffParameters(r.fileParameters, fileParameters)
+	r.fileParameters = fileParameters
+
+	if len(changes) == 0 {
+		r.LogDebugf("reloaded %s, no parameters changed", r.filePath)
+		return changes, nil
+	}
+
+	changedHandlers := make(map[*parametersHandler]struct{})
+	for _, change := range changes {
+		handler := r.handlerForKey(change.Key)
+		if handler == nil {
+			r.LogWarnf("parameter changed, restart required to apply it: %s", change)
+			continue
+		}
+
+		value := change.New
+		if value == nil {
+			// the parameter was removed from the file, fall back to the default value
+			boundParameter := r.config.BoundParameter(change.Key)
+			if boundParameter == nil {
+				r.LogWarnf("parameter removed, restart required to apply it: %s", change)
+				continue
+			}
+			value = boundParameter.DefaultVal
+		}
+
+		if err := r.config.Set(change.Key, value); err != nil {
+			r.LogWarnf("applying parameter failed: %s, error: %s", change, err)
+			continue
+		}
+
+		r.LogInfof("parameter changed: %s", change)
+		changedHandlers[handler] = struct{}{}
+	}
+
+	if len(changedHandlers) == 0 {
+		return changes, nil
+	}
+
+	r.config.UpdateBoundParameters()
+
+	var applyErr error
+	for _, handler := range r.handlers {
+		if _, changed := changedHandlers[handler]; !changed {
+			continue
+		}
+
+		if err := handler.applyFunc(); err != nil {
+			r.LogWarnf("applying reloaded parameters of %s failed: %s", handler.name, err)
+			applyErr = errors.Wrapf(err, "applying reloaded parameters of %s failed", handler.name)
+			continue
+		}
+		r.LogInfof("applied reloaded parameters of %s", handler.name)
+	}
+
+	return changes, applyErr
+}
+
+// loadFileParameters loads the flattened parameters of the given configuration file.
+func loadFileParameters(filePath string) (map[string]interface{}, error) {
+	config := configuration.New()
+	if err := config.LoadFile(filePath); err != nil {
+		return nil, errors.Wrapf(err, "loading config file %s failed", filePath)
+	}
+
+	return config.All(), nil
+}
@@ -0,0 +1,108 @@
+package hotreload_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/hotreload"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/logger"
+)
+
+type testParameters struct {
+	Reloadable struct {
+		Limit   int           `default:"10" usage:"a reloadable limit"`
+		Timeout time.Duration `default:"5s" usage:"a reloadable timeout"`
+	}
+	BindAddress string `default:"localhost:1234" usage:"a parameter that requires a restart"`
+}
+
+func TestDiffParameters(t *testing.T) {
+
+	changes := hotreload.DiffParameters(
+		map[string]interface{}{
+			"a.b":  float64(1),
+			"a.c":  "unchanged",
+			"list": []interface{}{"x", "y"},
+			"old":  true,
+		},
+		map[string]interface{}{
+			"a.b":  float64(2),
+			"a.c":  "unchanged",
+			"list": []interface{}{"x"},
+			"new":  "value",
+		},
+	)
+
+	require.Equal(t, []*hotreload.ParameterChange{
+		{Key: "a.b", Old: float64(1), New: float64(2)},
+		{Key: "list", Old: []interface{}{"x", "y"}, New: []interface{}{"x"}},
+		{Key: "new", New: "value"},
+		{Key: "old", Old: true},
+	}, changes)
+}
+
+func TestConfigReloader(t *testing.T) {
+
+	cfg := configuration.New()
+	require.NoError(t, cfg.Set("logger.disableStacktrace", true))
+
+	// no need to check the error, since the global logger could already be initialized
+	_ = logger.InitGlobalLogger(cfg)
+
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"test":{"reloadable":{"limit":20},"bindAddress":"localhost:1234"}}`), 0600))
+
+	params := &testParameters{}
+	flagSet := configuration.NewUnsortedFlagSet("test", flag.ContinueOnError)
+
+	appConfig := configuration.New()
+	appConfig.BindParameters(flagSet, "test", params)
+	require.NoError(t, appConfig.LoadFile(filePath))
+	require.NoError(t, appConfig.LoadFlagSet(flagSet))
+	appConfig.UpdateBoundParameters()
+	require.Equal(t, 20, params.Reloadable.Limit)
+
+	configReloader, err := hotreload.NewConfigReloader(logger.NewLogger("HotReload"), appConfig, filePath)
+	require.NoError(t, err)
+
+	var applied int
+	configReloader.RegisterParameters("Test", func() error {
+		applied++
+		return nil
+	}, "test.reloadable")
+
+	require.True(t, configReloader.IsHotReloadable("test.reloadable.limit"))
+	require.True(t, configReloader.IsHotReloadable("Test.Reloadable.Timeout"))
+	require.False(t, configReloader.IsHotReloadable("test.bindAddress"))
+
+	// nothing changed
+	changes, err := configReloader.Reload()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, 0, applied)
+
+	// change a reloadable and a non-reloadable parameter
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"test":{"reloadable":{"limit":30,"timeout":"1m"},"bindAddress":"0.0.0.0:1234"}}`), 0600))
+
+	changes, err = configReloader.Reload()
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Equal(t, 1, applied)
+	require.Equal(t, 30, params.Reloadable.Limit)
+	require.Equal(t, time.Minute, params.Reloadable.Timeout)
+	require.Equal(t, "localhost:1234", params.BindAddress)
+
+	// removing a reloadable parameter resets it to the default value
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"test":{"reloadable":{"timeout":"1m"},"bindAddress":"0.0.0.0:1234"}}`), 0600))
+
+	_, err = configReloader.Reload()
+	require.NoError(t, err)
+	require.Equal(t, 2, applied)
+	require.Equal(t, 10, params.Reloadable.Limit)
+}
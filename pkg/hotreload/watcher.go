@@ -0,0 +1,150 @@
+package hotreload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/events"
+)
+
+// FilePathCaller is used to signal events that only carry a file path.
+func FilePathCaller(handler interface{}, params ...interface{}) {
+	handler.(func(string))(params[0].(string))
+}
+
+// FileWatcherEvents are the events fired by the FileWatcher.
+type FileWatcherEvents struct {
+	// Changed is fired when the content of the watched file changed.
+	Changed *events.Event
+	// Error is fired when an error occurred while watching the file.
+	Error *events.Event
+}
+
+// FileWatcher watches a single file for changes of its content.
+// The parent directory of the file is watched instead of the file itself,
+// so that files which are replaced atomically (e.g. by renaming or by swapping
+// symlinks like Kubernetes does for mounted ConfigMaps) are detected as well.
+type FileWatcher struct {
+	// the path of the watched file.
+	filePath string
+	// the time to wait for further filesystem events before the file is checked.
+	debounceTime time.Duration
+	// the hash of the file content that was last seen.
+	lastHash []byte
+	// Events are the events fired by the FileWatcher.
+	Events *FileWatcherEvents
+}
+
+// NewFileWatcher creates a new FileWatcher for the given file.
+func NewFileWatcher(filePath string, debounceTime time.Duration) (*FileWatcher, error) {
+
+	absFilePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the file may not exist yet, in that case it is reported as changed as soon as it gets created
+	lastHash, err := hashFile(absFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return &FileWatcher{
+		filePath:     absFilePath,
+		debounceTime: debounceTime,
+		lastHash:     lastHash,
+		Events: &FileWatcherEvents{
+			Changed: events.NewEvent(FilePathCaller),
+			Error:   events.NewEvent(events.ErrorCaller),
+		},
+	}, nil
+}
+
+// FilePath returns the absolute path of the watched file.
+func (w *FileWatcher) FilePath() string {
+	return w.filePath
+}
+
+// Run watches the file until the given context is done.
+func (w *FileWatcher) Run(ctx context.Context) error {
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "creating file watcher failed")
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(filepath.Dir(w.filePath)); err != nil {
+		return errors.Wrapf(err, "watching directory of %s failed", w.filePath)
+	}
+
+	debounceTimer := time.NewTimer(w.debounceTime)
+	if !debounceTimer.Stop() {
+		<-debounceTimer.C
+	}
+	defer debounceTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return errors.New("file watcher event channel closed")
+			}
+
+			// every event in the directory could affect the file (e.g. symlink swaps),
+			// so we wait until the directory settled and compare the content afterwards.
+			debounceTimer.Reset(w.debounceTime)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errors.New("file watcher error channel closed")
+			}
+			w.Events.Error.Trigger(err)
+
+		case <-debounceTimer.C:
+			w.checkFile()
+		}
+	}
+}
+
+// checkFile triggers the Changed event if the content of the file changed.
+func (w *FileWatcher) checkFile() {
+
+	hash, err := hashFile(w.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the file may be missing temporarily while it is replaced
+			return
+		}
+		w.Events.Error.Trigger(err)
+		return
+	}
+
+	if bytes.Equal(hash, w.lastHash) {
+		return
+	}
+	w.lastHash = hash
+
+	w.Events.Changed.Trigger(w.filePath)
+}
+
+// hashFile returns the SHA-256 hash of the content of the given file.
+func hashFile(filePath string) ([]byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(content)
+	return hash[:], nil
+}
@@ -0,0 +1,351 @@
+package inxauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/iotaledger/hive.go/logger"
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// MetadataKeyAuthorization is the gRPC metadata key that contains the bearer token of an extension.
+	MetadataKeyAuthorization = "authorization"
+
+	bearerPrefix = "bearer "
+)
+
+var (
+	// ErrUnknownCapability is returned if an extension is granted a capability that doesn't exist.
+	ErrUnknownCapability = errors.New("unknown capability")
+	// ErrInvalidExtension is returned if the definition of an extension is invalid.
+	ErrInvalidExtension = errors.New("invalid extension")
+)
+
+// Capability is a permission to call a group of INX RPCs.
+type Capability string
+
+const (
+	// CapabilityAll grants access to all INX RPCs.
+	CapabilityAll Capability = "*"
+	// CapabilityReadNode grants access to the node status and configuration.
+	CapabilityReadNode Capability = "read-node"
+	// CapabilityReadMilestones grants access to milestones and milestone streams.
+	CapabilityReadMilestones Capability = "read-milestones"
+	// CapabilityComputeWhiteFlag grants access to the white flag computation.
+	CapabilityComputeWhiteFlag Capability = "compute-whiteflag"
+	// CapabilityReadMessages grants access to messages, their metadata and message streams.
+	CapabilityReadMessages Capability = "read-messages"
+	// CapabilitySubmitMessages grants access to submit new messages to the network.
+	CapabilitySubmitMessages Capability = "submit-messages"
+	// CapabilityReadLedger grants access to the ledger state, ledger updates, the treasury and receipts.
+	CapabilityReadLedger Capability = "read-ledger"
+	// CapabilityRegisterAPIRoutes grants access to register and unregister REST API proxy routes.
+	CapabilityRegisterAPIRoutes Capability = "register-api-routes"
+	// CapabilityPerformAPIRequests grants access to perform requests against the REST API of the node.
+	CapabilityPerformAPIRequests Capability = "perform-api-requests"
+	// CapabilityManageKeyRanges grants access to add public key ranges of the milestone signers.
+	CapabilityManageKeyRanges Capability = "manage-key-ranges"
+)
+
+// methodCapabilities maps the INX RPC names to the capability that is needed to call them.
+var methodCapabilities = map[string]Capability{
+	"ReadNodeStatus":             CapabilityReadNode,
+	"ReadNodeConfiguration":      CapabilityReadNode,
+	"ReadMilestone":              CapabilityReadMilestones,
+	"ListenToLatestMilestone":    CapabilityReadMilestones,
+	"ListenToConfirmedMilestone": CapabilityReadMilestones,
+	"ComputeWhiteFlag":           CapabilityComputeWhiteFlag,
+	"ListenToMessages":           CapabilityReadMessages,
+	"ListenToSolidMessages":      CapabilityReadMessages,
+	"ListenToReferencedMessages": CapabilityReadMessages,
+	"ReadMessage":                CapabilityReadMessages,
+	"ReadMessageMetadata":        CapabilityReadMessages,
+	"SubmitMessage":              CapabilitySubmitMessages,
+	"ReadUnspentOutputs":         CapabilityReadLedger,
+	"ListenToLedgerUpdates":      CapabilityReadLedger,
+	"ListenToTreasuryUpdates":    CapabilityReadLedger,
+	"ReadOutput":                 CapabilityReadLedger,
+	"ListenToMigrationReceipts":  CapabilityReadLedger,
+	"RegisterAPIRoute":           CapabilityRegisterAPIRoutes,
+	"UnregisterAPIRoute":         CapabilityRegisterAPIRoutes,
+	"PerformAPIRequest":          CapabilityPerformAPIRequests,
+	// INX tangle service
+	inxtangle.MethodReadMessageChildren:            CapabilityReadMessages,
+	inxtangle.MethodReadMilestoneCone:              CapabilityReadMessages,
+	inxtangle.MethodListenToMessageMetadataUpdates: CapabilityReadMessages,
+	inxtangle.MethodReadMilestoneStats:             CapabilityReadMilestones,
+	inxtangle.MethodListenToMilestoneStats:         CapabilityReadMilestones,
+	inxtangle.MethodReadMessageInclusionProof:      CapabilityReadMessages,
+	inxtangle.MethodValidateTransaction:            CapabilityReadLedger,
+	inxtangle.MethodComputeStorageDeposit:          CapabilityReadNode,
+	inxtangle.MethodListKeyRanges:                  CapabilityReadMilestones,
+	inxtangle.MethodAddKeyRange:                    CapabilityManageKeyRanges,
+	inxtangle.MethodValidateKeyRange:               CapabilityReadMilestones,
+}
+
+// knownServices are the gRPC services of the INX server.
+var knownServices = []string{
+	inx.INX_ServiceDesc.ServiceName,
+	inxtangle.ServiceName,
+}
+
+// Capabilities returns all known capabilities.
+func Capabilities() []Capability {
+	return []Capability{
+		CapabilityAll,
+		CapabilityReadNode,
+		CapabilityReadMilestones,
+		CapabilityComputeWhiteFlag,
+		CapabilityReadMessages,
+		CapabilitySubmitMessages,
+		CapabilityReadLedger,
+		CapabilityRegisterAPIRoutes,
+		CapabilityPerformAPIRequests,
+		CapabilityManageKeyRanges,
+	}
+}
+
+// CapabilityForMethod returns the capability that is needed to call the given full gRPC method name.
+// RPCs that are unknown to the node (e.g. added in a newer INX version) need CapabilityAll.
+func CapabilityForMethod(fullMethod string) Capability {
+	for _, service := range knownServices {
+		prefix := fmt.Sprintf("/%s/", service)
+		if !strings.HasPrefix(fullMethod, prefix) {
+			continue
+		}
+
+		capability, exists := methodCapabilities[strings.TrimPrefix(fullMethod, prefix)]
+		if !exists {
+			return CapabilityAll
+		}
+
+		return capability
+	}
+
+	return CapabilityAll
+}
+
+// HashToken returns the hex encoded sha256 hash of an extension token.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Extension defines an INX extension and the capabilities it was granted.
+type Extension struct {
+	// the name of the extension.
+	Name string
+	// the hex encoded sha256 hash of the bearer token of the extension.
+	TokenHash string
+	// the common name of the TLS client certificate of the extension.
+	CertificateCommonName string
+	// the capabilities that were granted to the extension.
+	Capabilities []Capability
+
+	tokenHash    []byte
+	capabilities map[Capability]struct{}
+}
+
+// HasCapability checks whether the extension was granted the given capability.
+func (e *Extension) HasCapability(capability Capability) bool {
+	if _, exists := e.capabilities[CapabilityAll]; exists {
+		return true
+	}
+
+	_, exists := e.capabilities[capability]
+	return exists
+}
+
+type extensionContextKey struct{}
+
+// ExtensionFromContext returns the authenticated extension of an INX call.
+func ExtensionFromContext(ctx context.Context) *Extension {
+	extension, ok := ctx.Value(extensionContextKey{}).(*Extension)
+	if !ok {
+		return nil
+	}
+	return extension
+}
+
+// Authorizer authenticates INX extensions by their bearer token or TLS client certificate
+// and authorizes their calls based on the granted capabilities.
+type Authorizer struct {
+	*logger.WrappedLogger
+
+	extensions []*Extension
+}
+
+// NewAuthorizer creates a new Authorizer for the given extensions.
+func NewAuthorizer(log *logger.Logger, extensions []*Extension) (*Authorizer, error) {
+
+	knownCapabilities := make(map[Capability]struct{})
+	for _, capability := range Capabilities() {
+		knownCapabilities[capability] = struct{}{}
+	}
+
+	names := make(map[string]struct{})
+	tokenHashes := make(map[string]struct{})
+	commonNames := make(map[string]struct{})
+
+	for _, extension := range extensions {
+		if extension.Name == "" {
+			return nil, errors.WithMessage(ErrInvalidExtension, "name is missing")
+		}
+		if _, exists := names[extension.Name]; exists {
+			return nil, errors.WithMessagef(ErrInvalidExtension, "duplicate name: %s", extension.Name)
+		}
+		names[extension.Name] = struct{}{}
+
+		if extension.TokenHash == "" && extension.CertificateCommonName == "" {
+			return nil, errors.WithMessagef(ErrInvalidExtension, "extension %s needs either a token hash or a certificate common name", extension.Name)
+		}
+
+		if extension.TokenHash != "" {
+			tokenHash, err := hex.DecodeString(extension.TokenHash)
+			if err != nil || len(tokenHash) != sha256.Size {
+				return nil, errors.WithMessagef(ErrInvalidExtension, "invalid token hash of extension %s", extension.Name)
+			}
+			if _, exists := tokenHashes[extension.TokenHash]; exists {
+				return nil, errors.WithMessagef(ErrInvalidExtension, "duplicate token hash of extension %s", extension.Name)
+			}
+			tokenHashes[extension.TokenHash] = struct{}{}
+			extension.tokenHash = tokenHash
+		}
+
+		if extension.CertificateCommonName != "" {
+			if _, exists := commonNames[extension.CertificateCommonName]; exists {
+				return nil, errors.WithMessagef(ErrInvalidExtension, "duplicate certificate common name of extension %s", extension.Name)
+			}
+			commonNames[extension.CertificateCommonName] = struct{}{}
+		}
+
+		extension.capabilities = make(map[Capability]struct{})
+		for _, capability := range extension.Capabilities {
+			if _, exists := knownCapabilities[capability]; !exists {
+				return nil, errors.WithMessagef(ErrUnknownCapability, "extension %s: %s", extension.Name, capability)
+			}
+			extension.capabilities[capability] = struct{}{}
+		}
+	}
+
+	return &Authorizer{
+		WrappedLogger: logger.NewWrappedLogger(log),
+		extensions:    extensions,
+	}, nil
+}
+
+// Authenticate returns the extension that belongs to the TLS client certificate or the bearer token of the call.
+func (a *Authorizer) Authenticate(ctx context.Context) (*Extension, error) {
+
+	// the verified TLS client certificate takes precedence
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			for _, chain := range tlsInfo.State.VerifiedChains {
+				if len(chain) == 0 {
+					continue
+				}
+
+				commonName := chain[0].Subject.CommonName
+				for _, extension := range a.extensions {
+					if extension.CertificateCommonName != "" && extension.CertificateCommonName == commonName {
+						return extension, nil
+					}
+				}
+			}
+		}
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	values := md.Get(MetadataKeyAuthorization)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	token := values[0]
+	if len(token) > len(bearerPrefix) && strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		token = token[len(bearerPrefix):]
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	for _, extension := range a.extensions {
+		if extension.tokenHash != nil && subtle.ConstantTimeCompare(extension.tokenHash, tokenHash[:]) == 1 {
+			return extension, nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+}
+
+// Authorize authenticates the extension of the call and checks if it was granted the capability that is needed to call the given method.
+func (a *Authorizer) Authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+
+	extension, err := a.Authenticate(ctx)
+	if err != nil {
+		if p, ok := peer.FromContext(ctx); ok {
+			a.LogWarnf("denied %s from %s: %s", fullMethod, p.Addr.String(), status.Convert(err).Message())
+		} else {
+			a.LogWarnf("denied %s: %s", fullMethod, status.Convert(err).Message())
+		}
+		return nil, err
+	}
+
+	capability := CapabilityForMethod(fullMethod)
+	if !extension.HasCapability(capability) {
+		a.LogWarnf("denied %s for extension %s: missing capability %s", fullMethod, extension.Name, capability)
+		return nil, status.Errorf(codes.PermissionDenied, "extension %s is missing capability %s", extension.Name, capability)
+	}
+
+	a.LogDebugf("extension %s called %s", extension.Name, fullMethod)
+
+	return context.WithValue(ctx, extensionContextKey{}, extension), nil
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that authorizes every unary call.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.Authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that authorizes every stream.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.Authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorizedServerStream wraps a grpc.ServerStream to carry the authenticated extension in its context.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,84 @@
+package inxauth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gohornet/hornet/pkg/inxauth"
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	inx "github.com/iotaledger/inx/go"
+)
+
+func fullMethod(name string) string {
+	return fmt.Sprintf("/%s/%s", inx.INX_ServiceDesc.ServiceName, name)
+}
+
+func TestCapabilityForMethod(t *testing.T) {
+
+	// every RPC of the INX service needs a dedicated capability
+	for _, method := range inx.INX_ServiceDesc.Methods {
+		require.NotEqual(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod(fullMethod(method.MethodName)), method.MethodName)
+	}
+	for _, stream := range inx.INX_ServiceDesc.Streams {
+		require.NotEqual(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod(fullMethod(stream.StreamName)), stream.StreamName)
+	}
+	for _, method := range inxtangle.INXTangle_ServiceDesc.Methods {
+		require.NotEqual(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod(fmt.Sprintf("/%s/%s", inxtangle.ServiceName, method.MethodName)), method.MethodName)
+	}
+	for _, stream := range inxtangle.INXTangle_ServiceDesc.Streams {
+		require.NotEqual(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod(fmt.Sprintf("/%s/%s", inxtangle.ServiceName, stream.StreamName)), stream.StreamName)
+	}
+	require.Equal(t, inxauth.CapabilityReadMessages, inxauth.CapabilityForMethod(fmt.Sprintf("/%s/%s", inxtangle.ServiceName, inxtangle.MethodReadMilestoneCone)))
+	require.Equal(t, inxauth.CapabilityReadMilestones, inxauth.CapabilityForMethod(fmt.Sprintf("/%s/%s", inxtangle.ServiceName, inxtangle.MethodListenToMilestoneStats)))
+	require.Equal(t, inxauth.CapabilityManageKeyRanges, inxauth.CapabilityForMethod(fmt.Sprintf("/%s/%s", inxtangle.ServiceName, inxtangle.MethodAddKeyRange)))
+
+	require.Equal(t, inxauth.CapabilitySubmitMessages, inxauth.CapabilityForMethod(fullMethod("SubmitMessage")))
+	require.Equal(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod(fullMethod("Unknown")))
+	require.Equal(t, inxauth.CapabilityAll, inxauth.CapabilityForMethod("/other.Service/SubmitMessage"))
+}
+
+func TestAuthorizer(t *testing.T) {
+
+	_, err := inxauth.NewAuthorizer(nil, []*inxauth.Extension{
+		{Name: "indexer", TokenHash: inxauth.HashToken("token"), Capabilities: []inxauth.Capability{"unknown"}},
+	})
+	require.ErrorIs(t, err, inxauth.ErrUnknownCapability)
+
+	_, err = inxauth.NewAuthorizer(nil, []*inxauth.Extension{
+		{Name: "indexer", Capabilities: []inxauth.Capability{inxauth.CapabilityReadLedger}},
+	})
+	require.ErrorIs(t, err, inxauth.ErrInvalidExtension)
+
+	authorizer, err := inxauth.NewAuthorizer(nil, []*inxauth.Extension{
+		{Name: "indexer", TokenHash: inxauth.HashToken("indexer-token"), Capabilities: []inxauth.Capability{inxauth.CapabilityReadNode, inxauth.CapabilityReadLedger}},
+		{Name: "spammer", TokenHash: inxauth.HashToken("spammer-token"), Capabilities: []inxauth.Capability{inxauth.CapabilityAll}},
+	})
+	require.NoError(t, err)
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(inxauth.MetadataKeyAuthorization, "Bearer "+token))
+	}
+
+	_, err = authorizer.Authorize(context.Background(), fullMethod("ReadOutput"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = authorizer.Authorize(withToken("invalid"), fullMethod("ReadOutput"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx, err := authorizer.Authorize(withToken("indexer-token"), fullMethod("ReadOutput"))
+	require.NoError(t, err)
+	require.Equal(t, "indexer", inxauth.ExtensionFromContext(ctx).Name)
+
+	_, err = authorizer.Authorize(withToken("indexer-token"), fullMethod("SubmitMessage"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx, err = authorizer.Authorize(withToken("spammer-token"), fullMethod("SubmitMessage"))
+	require.NoError(t, err)
+	require.Equal(t, "spammer", inxauth.ExtensionFromContext(ctx).Name)
+}
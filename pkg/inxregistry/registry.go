@@ -0,0 +1,559 @@
+package inxregistry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+
+	"github.com/gohornet/hornet/pkg/inxauth"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+)
+
+const (
+	// MetadataKeyExtensionName is the gRPC metadata key an extension uses to announce its name.
+	MetadataKeyExtensionName = "inx-extension-name"
+	// MetadataKeyExtensionVersion is the gRPC metadata key an extension uses to announce its version.
+	MetadataKeyExtensionVersion = "inx-extension-version"
+	// MetadataKeyExtensionCapabilities is the gRPC metadata key an extension uses to announce its capabilities (comma separated).
+	MetadataKeyExtensionCapabilities = "inx-extension-capabilities"
+)
+
+var (
+	// ErrExtensionNotAlive is the reason for removing the routes of an extension that has no open connections and was not seen within the timeout.
+	ErrExtensionNotAlive = errors.New("extension is not alive")
+)
+
+// ProbeFunc checks if the REST API proxy target of an extension is reachable.
+type ProbeFunc func(ctx context.Context, host string, port uint32) error
+
+// Route is a REST API proxy route that was registered by an extension.
+type Route struct {
+	// the route of the REST API proxy.
+	Route string `json:"route"`
+	// the host the route is proxied to.
+	Host string `json:"host"`
+	// the port the route is proxied to.
+	Port uint32 `json:"port"`
+}
+
+// Extension holds the information about a connected INX extension.
+type Extension struct {
+	// the unique identifier of the extension (the name, or the remote address if no name was announced).
+	ID string `json:"id"`
+	// the announced or authenticated name of the extension.
+	Name string `json:"name,omitempty"`
+	// the announced version of the extension.
+	Version string `json:"version,omitempty"`
+	// the announced capabilities of the extension.
+	Capabilities []string `json:"capabilities,omitempty"`
+	// the remote address of the last call of the extension.
+	RemoteAddress string `json:"remoteAddress"`
+	// the time the extension was seen for the first time.
+	ConnectedAt time.Time `json:"connectedAt"`
+	// the time of the last call of the extension.
+	LastSeen time.Time `json:"lastSeen"`
+	// the amount of open streams of the extension.
+	ActiveStreams int `json:"activeStreams"`
+	// the amount of open connections of the extension.
+	// the connections are kept open as long as the extension answers the keep-alive pings of the server.
+	ActiveConnections int `json:"activeConnections"`
+	// the REST API proxy routes the extension registered.
+	Routes []*Route `json:"routes"`
+}
+
+func (e *Extension) clone() *Extension {
+	extension := *e
+	extension.Capabilities = make([]string, len(e.Capabilities))
+	copy(extension.Capabilities, e.Capabilities)
+	extension.Routes = make([]*Route, 0, len(e.Routes))
+	for _, route := range e.Routes {
+		r := *route
+		extension.Routes = append(extension.Routes, &r)
+	}
+	return &extension
+}
+
+// ExtensionCaller is used to signal updates of extensions.
+func ExtensionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(extension *Extension))(params[0].(*Extension))
+}
+
+// RouteCaller is used to signal removed routes.
+func RouteCaller(handler interface{}, params ...interface{}) {
+	handler.(func(extension *Extension, route *Route))(params[0].(*Extension), params[1].(*Route))
+}
+
+// Events are the events fired by the Registry.
+type Events struct {
+	// Fired when a new extension was seen.
+	ExtensionConnected *events.Event
+	// Fired when an extension was removed because it is not alive anymore.
+	ExtensionRemoved *events.Event
+	// Fired when a REST API proxy route of an extension was removed because its target is not reachable anymore.
+	RouteRemoved *events.Event
+}
+
+// Options define options for the Registry.
+type Options struct {
+	logger *logger.Logger
+	// the time after which an extension without open connections and calls is considered dead.
+	timeout time.Duration
+	// the amount of consecutive failed probes after which a route is removed.
+	maxFailedProbes int
+	// the function that is used to probe the targets of the routes.
+	probeFunc ProbeFunc
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// the default options used for the Registry.
+var defaultOptions = []Option{
+	WithTimeout(1 * time.Minute),
+	WithMaxFailedProbes(3),
+	WithProbeFunc(DialProbe(2 * time.Second)),
+}
+
+// Option is a function setting a registry option.
+type Option func(opts *Options)
+
+// WithLogger enables logging within the registry.
+func WithLogger(logger *logger.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// WithTimeout sets the time after which an extension without open connections and calls is considered dead.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.timeout = timeout
+	}
+}
+
+// WithMaxFailedProbes sets the amount of consecutive failed probes after which a route is removed.
+func WithMaxFailedProbes(maxFailedProbes int) Option {
+	return func(opts *Options) {
+		opts.maxFailedProbes = maxFailedProbes
+	}
+}
+
+// WithProbeFunc sets the function that is used to probe the targets of the routes.
+func WithProbeFunc(probeFunc ProbeFunc) Option {
+	return func(opts *Options) {
+		opts.probeFunc = probeFunc
+	}
+}
+
+// DialProbe returns a ProbeFunc that checks if a TCP connection to the target can be established.
+func DialProbe(timeout time.Duration) ProbeFunc {
+	return func(ctx context.Context, host string, port uint32) error {
+		dialer := &net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, fmt.Sprintf("%d", port)))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// registeredExtension is the internal state of an extension.
+type registeredExtension struct {
+	*Extension
+	// the amount of consecutive failed probes per route.
+	failedProbes map[string]int
+	// the open connections of the extension.
+	connections map[uint64]struct{}
+}
+
+// Registry keeps track of the connected INX extensions and the REST API proxy routes they registered.
+type Registry struct {
+	*logger.WrappedLogger
+
+	// Events are the events fired by the Registry.
+	Events *Events
+
+	extensionsLock sync.RWMutex
+	extensions     map[string]*registeredExtension
+
+	// the ID of the last connection that was opened.
+	lastConnectionID uint64
+
+	opts *Options
+}
+
+// New creates a new Registry.
+func New(opts ...Option) *Registry {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	return &Registry{
+		WrappedLogger: logger.NewWrappedLogger(options.logger),
+		Events: &Events{
+			ExtensionConnected: events.NewEvent(ExtensionCaller),
+			ExtensionRemoved:   events.NewEvent(ExtensionCaller),
+			RouteRemoved:       events.NewEvent(RouteCaller),
+		},
+		extensions: make(map[string]*registeredExtension),
+		opts:       options,
+	}
+}
+
+// connectionIDKey is the context key of the ID of the connection a call was received on.
+type connectionIDKey struct{}
+
+// announcement contains the information an extension announced in the metadata of a call.
+type announcement struct {
+	name          string
+	version       string
+	capabilities  []string
+	remoteAddress string
+	// the ID of the connection the call was received on, zero if unknown.
+	connectionID uint64
+}
+
+func announcementFromContext(ctx context.Context) *announcement {
+	a := &announcement{}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		a.remoteAddress = p.Addr.String()
+	}
+
+	if connectionID, ok := ctx.Value(connectionIDKey{}).(uint64); ok {
+		a.connectionID = connectionID
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKeyExtensionName); len(values) > 0 {
+			a.name = values[0]
+		}
+		if values := md.Get(MetadataKeyExtensionVersion); len(values) > 0 {
+			a.version = values[0]
+		}
+		if values := md.Get(MetadataKeyExtensionCapabilities); len(values) > 0 {
+			for _, capability := range strings.Split(values[0], ",") {
+				if capability = strings.TrimSpace(capability); capability != "" {
+					a.capabilities = append(a.capabilities, capability)
+				}
+			}
+		}
+	}
+
+	// the name of an authenticated extension can't be spoofed
+	if extension := inxauth.ExtensionFromContext(ctx); extension != nil {
+		a.name = extension.Name
+	}
+
+	return a
+}
+
+func (a *announcement) id() string {
+	if a.name != "" {
+		return a.name
+	}
+	return a.remoteAddress
+}
+
+// seenWithoutLocking updates the extension of the given announcement and creates it if it doesn't exist yet.
+func (r *Registry) seenWithoutLocking(a *announcement) (*registeredExtension, bool) {
+	now := time.Now()
+
+	extension, exists := r.extensions[a.id()]
+	if !exists {
+		extension = &registeredExtension{
+			Extension: &Extension{
+				ID:          a.id(),
+				Name:        a.name,
+				ConnectedAt: now,
+				Routes:      []*Route{},
+			},
+			failedProbes: make(map[string]int),
+			connections:  make(map[uint64]struct{}),
+		}
+		r.extensions[a.id()] = extension
+	}
+
+	if a.connectionID != 0 {
+		extension.connections[a.connectionID] = struct{}{}
+		extension.ActiveConnections = len(extension.connections)
+	}
+
+	if a.version != "" {
+		extension.Version = a.version
+	}
+	if len(a.capabilities) > 0 {
+		extension.Capabilities = a.capabilities
+	}
+	extension.RemoteAddress = a.remoteAddress
+	extension.LastSeen = now
+
+	return extension, !exists
+}
+
+func (r *Registry) seen(ctx context.Context, streamDelta int) string {
+	a := announcementFromContext(ctx)
+
+	r.extensionsLock.Lock()
+	extension, isNew := r.seenWithoutLocking(a)
+	extension.ActiveStreams += streamDelta
+	snapshot := extension.clone()
+	r.extensionsLock.Unlock()
+
+	if isNew {
+		r.LogInfof("INX extension connected: %s (version: %s, address: %s)", snapshot.ID, snapshot.Version, snapshot.RemoteAddress)
+		r.Events.ExtensionConnected.Trigger(snapshot)
+	}
+
+	return snapshot.ID
+}
+
+// Seen marks the extension of the given call as alive.
+func (r *Registry) Seen(ctx context.Context) string {
+	return r.seen(ctx, 0)
+}
+
+// StreamStarted marks the extension of the given stream as alive and tracks the open stream.
+func (r *Registry) StreamStarted(ctx context.Context) string {
+	return r.seen(ctx, 1)
+}
+
+// StreamEnded stops tracking an open stream of the given extension.
+func (r *Registry) StreamEnded(id string) {
+	r.extensionsLock.Lock()
+	defer r.extensionsLock.Unlock()
+
+	extension, exists := r.extensions[id]
+	if !exists {
+		return
+	}
+
+	if extension.ActiveStreams > 0 {
+		extension.ActiveStreams--
+	}
+	extension.LastSeen = time.Now()
+}
+
+// StatsHandler returns a gRPC stats handler that tracks the connections of the extensions.
+// The server closes connections that don't answer its keep-alive pings, therefore an extension
+// with an open connection is alive, even if it has no open streams and doesn't call the node.
+func (r *Registry) StatsHandler() stats.Handler {
+	return &connectionTracker{registry: r}
+}
+
+// connectionClosed stops tracking the connection with the given ID.
+func (r *Registry) connectionClosed(connectionID uint64) {
+	r.extensionsLock.Lock()
+	defer r.extensionsLock.Unlock()
+
+	for _, extension := range r.extensions {
+		if _, exists := extension.connections[connectionID]; !exists {
+			continue
+		}
+
+		delete(extension.connections, connectionID)
+		extension.ActiveConnections = len(extension.connections)
+		extension.LastSeen = time.Now()
+	}
+}
+
+// connectionTracker is a gRPC stats handler that tags every connection with an ID
+// and notifies the registry about closed connections.
+type connectionTracker struct {
+	registry *Registry
+}
+
+func (t *connectionTracker) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connectionIDKey{}, atomic.AddUint64(&t.registry.lastConnectionID, 1))
+}
+
+func (t *connectionTracker) HandleConn(ctx context.Context, connStats stats.ConnStats) {
+	if _, ok := connStats.(*stats.ConnEnd); !ok {
+		return
+	}
+
+	if connectionID, ok := ctx.Value(connectionIDKey{}).(uint64); ok {
+		t.registry.connectionClosed(connectionID)
+	}
+}
+
+func (t *connectionTracker) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (t *connectionTracker) HandleRPC(_ context.Context, _ stats.RPCStats) {}
+
+// AddRoute registers a REST API proxy route for the extension of the given call.
+// If the route was registered by another extension before, the ownership is transferred.
+func (r *Registry) AddRoute(ctx context.Context, route string, host string, port uint32) {
+	a := announcementFromContext(ctx)
+
+	r.extensionsLock.Lock()
+	defer r.extensionsLock.Unlock()
+
+	r.removeRouteWithoutLocking(route)
+
+	extension, _ := r.seenWithoutLocking(a)
+	extension.Routes = append(extension.Routes, &Route{Route: route, Host: host, Port: port})
+}
+
+// RemoveRoute removes a REST API proxy route from the extension that registered it.
+func (r *Registry) RemoveRoute(route string) {
+	r.extensionsLock.Lock()
+	defer r.extensionsLock.Unlock()
+
+	r.removeRouteWithoutLocking(route)
+}
+
+func (r *Registry) removeRouteWithoutLocking(route string) {
+	for _, extension := range r.extensions {
+		for i, extensionRoute := range extension.Routes {
+			if extensionRoute.Route != route {
+				continue
+			}
+
+			extension.Routes = append(extension.Routes[:i], extension.Routes[i+1:]...)
+			delete(extension.failedProbes, route)
+			return
+		}
+	}
+}
+
+// Extensions returns a snapshot of all known extensions sorted by their ID.
+func (r *Registry) Extensions() []*Extension {
+	r.extensionsLock.RLock()
+	defer r.extensionsLock.RUnlock()
+
+	result := make([]*Extension, 0, len(r.extensions))
+	for _, extension := range r.extensions {
+		result = append(result, extension.clone())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// isAliveWithoutLocking checks if an extension has open connections or streams, or was seen within the timeout.
+func (r *Registry) isAliveWithoutLocking(extension *registeredExtension, now time.Time) bool {
+	return extension.ActiveConnections > 0 || extension.ActiveStreams > 0 || now.Sub(extension.LastSeen) < r.opts.timeout
+}
+
+// CheckLiveness probes the REST API proxy routes of all alive extensions and removes dead extensions.
+// A route is removed if its target failed "maxFailedProbes" consecutive probes.
+// All routes of an extension that has no open connections and was not seen within the timeout are removed
+// without probing, since the proxied port may have been taken over by another process.
+// Extensions that are not alive are removed from the registry.
+func (r *Registry) CheckLiveness(ctx context.Context) {
+
+	// probe the routes without holding the lock
+	r.extensionsLock.RLock()
+	var routes []*Route
+	for _, extension := range r.extensions {
+		if !r.isAliveWithoutLocking(extension, time.Now()) {
+			// the routes of dead extensions are removed anyway
+			continue
+		}
+		for _, route := range extension.Routes {
+			routeCopy := *route
+			routes = append(routes, &routeCopy)
+		}
+	}
+	r.extensionsLock.RUnlock()
+
+	probeResults := make(map[Route]error, len(routes))
+	for _, route := range routes {
+		if ctx.Err() != nil {
+			return
+		}
+		probeResults[*route] = r.opts.probeFunc(ctx, route.Host, route.Port)
+	}
+
+	type removedRoute struct {
+		extension *Extension
+		route     *Route
+		reason    error
+	}
+
+	var removedRoutes []*removedRoute
+	var removedExtensions []*Extension
+
+	r.extensionsLock.Lock()
+	now := time.Now()
+	for id, extension := range r.extensions {
+		alive := r.isAliveWithoutLocking(extension, now)
+
+		remainingRoutes := make([]*Route, 0, len(extension.Routes))
+		for _, route := range extension.Routes {
+			if !alive {
+				delete(extension.failedProbes, route.Route)
+				removedRoutes = append(removedRoutes, &removedRoute{route: route, reason: ErrExtensionNotAlive})
+				continue
+			}
+
+			err, probed := probeResults[*route]
+			if !probed {
+				// the route was added in the meantime
+				remainingRoutes = append(remainingRoutes, route)
+				continue
+			}
+
+			if err == nil {
+				delete(extension.failedProbes, route.Route)
+				remainingRoutes = append(remainingRoutes, route)
+				continue
+			}
+
+			extension.failedProbes[route.Route]++
+			if extension.failedProbes[route.Route] < r.opts.maxFailedProbes {
+				remainingRoutes = append(remainingRoutes, route)
+				continue
+			}
+
+			delete(extension.failedProbes, route.Route)
+			removedRoutes = append(removedRoutes, &removedRoute{route: route, reason: err})
+		}
+		extension.Routes = remainingRoutes
+
+		snapshot := extension.clone()
+		for _, removed := range removedRoutes {
+			if removed.extension == nil {
+				removed.extension = snapshot
+			}
+		}
+
+		if !alive {
+			delete(r.extensions, id)
+			removedExtensions = append(removedExtensions, snapshot)
+		}
+	}
+	r.extensionsLock.Unlock()
+
+	for _, removed := range removedRoutes {
+		r.LogWarnf("removed REST API proxy %s => %s:%d of INX extension %s: %s", removed.route.Route, removed.route.Host, removed.route.Port, removed.extension.ID, removed.reason)
+		r.Events.RouteRemoved.Trigger(removed.extension, removed.route)
+	}
+
+	for _, extension := range removedExtensions {
+		r.LogInfof("INX extension disconnected: %s", extension.ID)
+		r.Events.ExtensionRemoved.Trigger(extension)
+	}
+}
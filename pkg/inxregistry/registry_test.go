@@ -0,0 +1,162 @@
+package inxregistry_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+
+	"github.com/gohornet/hornet/pkg/inxregistry"
+	"github.com/iotaledger/hive.go/events"
+)
+
+func extensionContext(name string, version string, port int) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}})
+	if name == "" {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(
+		inxregistry.MetadataKeyExtensionName, name,
+		inxregistry.MetadataKeyExtensionVersion, version,
+		inxregistry.MetadataKeyExtensionCapabilities, "read-ledger, register-api-routes",
+	))
+}
+
+func TestRegistry(t *testing.T) {
+
+	reachable := map[uint32]bool{}
+	probeFunc := func(_ context.Context, _ string, port uint32) error {
+		if reachable[port] {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	registry := inxregistry.New(
+		inxregistry.WithTimeout(time.Hour),
+		inxregistry.WithMaxFailedProbes(2),
+		inxregistry.WithProbeFunc(probeFunc),
+	)
+
+	var removedRoutes []string
+	registry.Events.RouteRemoved.Attach(events.NewClosure(func(_ *inxregistry.Extension, route *inxregistry.Route) {
+		removedRoutes = append(removedRoutes, route.Route)
+	}))
+
+	var removedExtensions []string
+	registry.Events.ExtensionRemoved.Attach(events.NewClosure(func(extension *inxregistry.Extension) {
+		removedExtensions = append(removedExtensions, extension.ID)
+	}))
+
+	indexerCtx := extensionContext("indexer", "1.0.0", 5000)
+	streamID := registry.StreamStarted(indexerCtx)
+	require.Equal(t, "indexer", streamID)
+	registry.AddRoute(indexerCtx, "indexer/v1", "localhost", 9091)
+	reachable[9091] = true
+
+	// unnamed extensions are identified by their remote address
+	require.Equal(t, "127.0.0.1:5001", registry.Seen(extensionContext("", "", 5001)))
+
+	extensions := registry.Extensions()
+	require.Len(t, extensions, 2)
+	require.Equal(t, "127.0.0.1:5001", extensions[0].ID)
+	require.Equal(t, "indexer", extensions[1].ID)
+	require.Equal(t, "1.0.0", extensions[1].Version)
+	require.Equal(t, []string{"read-ledger", "register-api-routes"}, extensions[1].Capabilities)
+	require.Equal(t, 1, extensions[1].ActiveStreams)
+	require.Equal(t, []*inxregistry.Route{{Route: "indexer/v1", Host: "localhost", Port: 9091}}, extensions[1].Routes)
+
+	// the extension is alive and the route is reachable
+	registry.CheckLiveness(context.Background())
+	require.Empty(t, removedRoutes)
+
+	// the route of an alive extension is removed after too many failed probes
+	reachable[9091] = false
+	registry.CheckLiveness(context.Background())
+	require.Empty(t, removedRoutes)
+	registry.CheckLiveness(context.Background())
+	require.Equal(t, []string{"indexer/v1"}, removedRoutes)
+	require.Empty(t, removedExtensions)
+
+	// the routes of a dead extension are removed without waiting for failed probes
+	registry.AddRoute(indexerCtx, "indexer/v1", "localhost", 9091)
+	registry.StreamEnded(streamID)
+
+	deadRegistry := inxregistry.New(
+		inxregistry.WithTimeout(0),
+		inxregistry.WithMaxFailedProbes(2),
+		inxregistry.WithProbeFunc(probeFunc),
+	)
+	deadRegistry.Events.RouteRemoved.Attach(events.NewClosure(func(_ *inxregistry.Extension, route *inxregistry.Route) {
+		removedRoutes = append(removedRoutes, route.Route)
+	}))
+	deadRegistry.Events.ExtensionRemoved.Attach(events.NewClosure(func(extension *inxregistry.Extension) {
+		removedExtensions = append(removedExtensions, extension.ID)
+	}))
+
+	deadStreamID := deadRegistry.StreamStarted(indexerCtx)
+	deadRegistry.AddRoute(indexerCtx, "indexer/v1", "localhost", 9091)
+
+	// an open stream keeps the extension alive
+	deadRegistry.CheckLiveness(context.Background())
+	require.Equal(t, []string{"indexer/v1"}, removedRoutes)
+	require.Len(t, deadRegistry.Extensions(), 1)
+
+	deadRegistry.StreamEnded(deadStreamID)
+	deadRegistry.CheckLiveness(context.Background())
+	require.Equal(t, []string{"indexer/v1", "indexer/v1"}, removedRoutes)
+	require.Equal(t, []string{"indexer"}, removedExtensions)
+	require.Empty(t, deadRegistry.Extensions())
+
+	// the routes of a dead extension are removed even if something else still accepts connections on the proxied port
+	reachable[9092] = true
+	deadRegistry.AddRoute(indexerCtx, "indexer/v2", "localhost", 9092)
+	deadRegistry.CheckLiveness(context.Background())
+	require.Equal(t, []string{"indexer/v1", "indexer/v1", "indexer/v2"}, removedRoutes)
+	require.Equal(t, []string{"indexer", "indexer"}, removedExtensions)
+	require.Empty(t, deadRegistry.Extensions())
+}
+
+func TestRegistryConnections(t *testing.T) {
+
+	registry := inxregistry.New(
+		inxregistry.WithTimeout(0),
+		inxregistry.WithMaxFailedProbes(2),
+		inxregistry.WithProbeFunc(func(_ context.Context, _ string, _ uint32) error { return nil }),
+	)
+
+	var removedRoutes []string
+	registry.Events.RouteRemoved.Attach(events.NewClosure(func(_ *inxregistry.Extension, route *inxregistry.Route) {
+		removedRoutes = append(removedRoutes, route.Route)
+	}))
+
+	statsHandler := registry.StatsHandler()
+	connCtx := statsHandler.TagConn(extensionContext("dashboard", "1.0.0", 5000), &stats.ConnTagInfo{})
+
+	// the extension only registers its route and doesn't open any streams
+	registry.AddRoute(connCtx, "dashboard/v1", "localhost", 9091)
+
+	extensions := registry.Extensions()
+	require.Len(t, extensions, 1)
+	require.Equal(t, 1, extensions[0].ActiveConnections)
+	require.Zero(t, extensions[0].ActiveStreams)
+
+	// the open connection keeps the extension alive after the timeout
+	registry.CheckLiveness(context.Background())
+	require.Empty(t, removedRoutes)
+	require.Len(t, registry.Extensions(), 1)
+
+	// the connection is closed if the extension doesn't answer the keep-alive pings anymore
+	statsHandler.HandleConn(connCtx, &stats.ConnEnd{})
+	require.Zero(t, registry.Extensions()[0].ActiveConnections)
+
+	registry.CheckLiveness(context.Background())
+	require.Equal(t, []string{"dashboard/v1"}, removedRoutes)
+	require.Empty(t, registry.Extensions())
+}
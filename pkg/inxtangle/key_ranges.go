@@ -0,0 +1,59 @@
+package inxtangle
+
+import (
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// KeyRange is a public key range of the milestone signers.
+type KeyRange struct {
+	// The hex encoded ed25519 public key.
+	PublicKey string `json:"publicKey"`
+	// The milestone index the public key is valid from.
+	StartIndex milestone.Index `json:"start"`
+	// The milestone index the public key is valid until (0 = forever).
+	EndIndex milestone.Index `json:"end"`
+	// Whether the key range was added at runtime with an authorization of the valid keys.
+	Authorized bool `json:"authorized"`
+	// Whether the key range expires soon without enough valid public keys afterwards.
+	ExpiresWithoutSuccessor bool `json:"expiresWithoutSuccessor"`
+}
+
+// KeyRanges is the result of ListKeyRanges and AddKeyRange.
+type KeyRanges struct {
+	// The confirmed milestone index of the node.
+	ConfirmedMilestoneIndex milestone.Index `json:"confirmedMilestoneIndex"`
+	// The public key ranges of the milestone signers.
+	KeyRanges []*KeyRange `json:"keyRanges"`
+}
+
+// NewKeyRanges returns the key ranges with the given states at the confirmed milestone index.
+func NewKeyRanges(confirmedMilestoneIndex milestone.Index, statuses []*keymanager.KeyRangeStatus) *KeyRanges {
+	result := &KeyRanges{
+		ConfirmedMilestoneIndex: confirmedMilestoneIndex,
+		KeyRanges:               make([]*KeyRange, 0, len(statuses)),
+	}
+
+	for _, status := range statuses {
+		result.KeyRanges = append(result.KeyRanges, &KeyRange{
+			PublicKey:               iotago.EncodeHex(status.PublicKey[:]),
+			StartIndex:              status.StartIndex,
+			EndIndex:                status.EndIndex,
+			Authorized:              status.Authorized,
+			ExpiresWithoutSuccessor: status.ExpiresWithoutSuccessor,
+		})
+	}
+
+	return result
+}
+
+// KeyRangeValidation is the result of ValidateKeyRange.
+type KeyRangeValidation struct {
+	// The hex encoded essence of the key range that needs to be signed by the valid public keys.
+	Essence string `json:"essence"`
+	// Whether the key range and its authorization are valid.
+	Valid bool `json:"valid"`
+	// The reason why the key range or its authorization is invalid.
+	Error string `json:"error,omitempty"`
+}
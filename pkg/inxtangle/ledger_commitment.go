@@ -0,0 +1,97 @@
+package inxtangle
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// The INX ledger updates only contain their milestone index. Therefore the node appends the commitment
+// of the milestone to each ledger update as additional protobuf fields, that are ignored by the INX message types.
+// Extensions can read the commitment with LedgerUpdateCommitment.
+
+const (
+	// LedgerUpdateFieldMilestoneID is the protobuf field number of the milestone ID in a ledger update.
+	LedgerUpdateFieldMilestoneID protowire.Number = 1000
+	// LedgerUpdateFieldAppliedMerkleRoot is the protobuf field number of the applied merkle root in a ledger update.
+	LedgerUpdateFieldAppliedMerkleRoot protowire.Number = 1001
+)
+
+var (
+	// ErrLedgerCommitmentNotFound is returned if a ledger update doesn't contain a ledger commitment.
+	ErrLedgerCommitmentNotFound = errors.New("ledger commitment not found")
+)
+
+// LedgerCommitment is the commitment of the milestone that applied a ledger update.
+type LedgerCommitment struct {
+	// The ID of the milestone.
+	MilestoneID iotago.MilestoneID
+	// The merkle root of the messages with transactions that were applied to the ledger by the milestone.
+	AppliedMerkleRoot iotago.MilestoneMerkleProof
+}
+
+// SetLedgerUpdateCommitment appends the given commitment to the ledger update.
+func SetLedgerUpdateCommitment(update *inx.LedgerUpdate, commitment *LedgerCommitment) {
+	var b []byte
+	b = protowire.AppendTag(b, LedgerUpdateFieldMilestoneID, protowire.BytesType)
+	b = protowire.AppendBytes(b, commitment.MilestoneID[:])
+	b = protowire.AppendTag(b, LedgerUpdateFieldAppliedMerkleRoot, protowire.BytesType)
+	b = protowire.AppendBytes(b, commitment.AppliedMerkleRoot[:])
+
+	update.ProtoReflect().SetUnknown(b)
+}
+
+// LedgerUpdateCommitment returns the commitment that was appended to the ledger update by the node.
+func LedgerUpdateCommitment(update *inx.LedgerUpdate) (*LedgerCommitment, error) {
+	commitment := &LedgerCommitment{}
+	var foundMilestoneID, foundAppliedMerkleRoot bool
+
+	b := update.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		number, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "invalid ledger commitment")
+		}
+		b = b[n:]
+
+		if typ != protowire.BytesType || (number != LedgerUpdateFieldMilestoneID && number != LedgerUpdateFieldAppliedMerkleRoot) {
+			// skip unknown fields
+			n = protowire.ConsumeFieldValue(number, typ, b)
+			if n < 0 {
+				return nil, errors.Wrap(protowire.ParseError(n), "invalid ledger commitment")
+			}
+			b = b[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "invalid ledger commitment")
+		}
+		b = b[n:]
+
+		switch number {
+		case LedgerUpdateFieldMilestoneID:
+			if len(value) != iotago.MilestoneIDLength {
+				return nil, errors.Errorf("invalid milestone ID length: %d", len(value))
+			}
+			copy(commitment.MilestoneID[:], value)
+			foundMilestoneID = true
+
+		case LedgerUpdateFieldAppliedMerkleRoot:
+			if len(value) != iotago.MilestoneMerkleProofLength {
+				return nil, errors.Errorf("invalid applied merkle root length: %d", len(value))
+			}
+			copy(commitment.AppliedMerkleRoot[:], value)
+			foundAppliedMerkleRoot = true
+		}
+	}
+
+	if !foundMilestoneID || !foundAppliedMerkleRoot {
+		return nil, ErrLedgerCommitmentNotFound
+	}
+
+	return commitment, nil
+}
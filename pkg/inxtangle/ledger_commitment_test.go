@@ -0,0 +1,36 @@
+package inxtangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	inx "github.com/iotaledger/inx/go"
+)
+
+func TestLedgerUpdateCommitment(t *testing.T) {
+
+	update := &inx.LedgerUpdate{MilestoneIndex: 5}
+
+	_, err := inxtangle.LedgerUpdateCommitment(update)
+	require.ErrorIs(t, err, inxtangle.ErrLedgerCommitmentNotFound)
+
+	commitment := &inxtangle.LedgerCommitment{}
+	commitment.MilestoneID[0] = 1
+	commitment.AppliedMerkleRoot[0] = 2
+	inxtangle.SetLedgerUpdateCommitment(update, commitment)
+
+	// the commitment is kept on the wire, but ignored by the INX message type
+	data, err := proto.Marshal(update)
+	require.NoError(t, err)
+
+	received := &inx.LedgerUpdate{}
+	require.NoError(t, proto.Unmarshal(data, received))
+	require.Equal(t, uint32(5), received.GetMilestoneIndex())
+
+	receivedCommitment, err := inxtangle.LedgerUpdateCommitment(received)
+	require.NoError(t, err)
+	require.Equal(t, commitment, receivedCommitment)
+}
@@ -0,0 +1,116 @@
+package inxtangle
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	inx "github.com/iotaledger/inx/go"
+)
+
+// MessageMetadataState is the state of a message that is signaled by a metadata update.
+type MessageMetadataState string
+
+const (
+	// MessageMetadataStateSolid signals that a message is solid but not referenced yet.
+	// It is also sent if the message needs to be promoted or doesn't need to be promoted anymore.
+	MessageMetadataStateSolid MessageMetadataState = "solid"
+	// MessageMetadataStateReferenced signals that a message was referenced by a milestone.
+	MessageMetadataStateReferenced MessageMetadataState = "referenced"
+	// MessageMetadataStateConflicting signals that a message was referenced by a milestone, but its transaction was conflicting.
+	MessageMetadataStateConflicting MessageMetadataState = "conflicting"
+	// MessageMetadataStateBelowMaxDepth signals that a message fell below max depth and needs to be reattached.
+	MessageMetadataStateBelowMaxDepth MessageMetadataState = "belowMaxDepth"
+)
+
+// StateOfMessageMetadata returns the state of a message that is signaled by the given metadata update.
+func StateOfMessageMetadata(metadata *inx.MessageMetadata) MessageMetadataState {
+	switch {
+	case metadata.GetReferencedByMilestoneIndex() != 0 && metadata.GetLedgerInclusionState() == inx.MessageMetadata_CONFLICTING:
+		return MessageMetadataStateConflicting
+	case metadata.GetReferencedByMilestoneIndex() != 0:
+		return MessageMetadataStateReferenced
+	case metadata.GetShouldReattach():
+		return MessageMetadataStateBelowMaxDepth
+	default:
+		return MessageMetadataStateSolid
+	}
+}
+
+// MessageMetadataFilter restricts the updates that are sent by ListenToMessageMetadataUpdates.
+type MessageMetadataFilter struct {
+	// The hex encoded IDs of the messages to send the updates of (all messages if empty).
+	MessageIDs []string `json:"messageIds,omitempty"`
+	// The states to send the updates of (all states if empty).
+	// The referenced state also matches conflicting messages.
+	States []MessageMetadataState `json:"states,omitempty"`
+}
+
+// MessageMetadataMatcher is the decoded form of a MessageMetadataFilter.
+type MessageMetadataMatcher struct {
+	messageIDs map[string]struct{}
+	states     map[MessageMetadataState]struct{}
+}
+
+// Decode decodes the message IDs and states of the filter.
+func (f *MessageMetadataFilter) Decode() (*MessageMetadataMatcher, error) {
+	matcher := &MessageMetadataMatcher{}
+
+	if len(f.MessageIDs) > 0 {
+		matcher.messageIDs = make(map[string]struct{}, len(f.MessageIDs))
+		for _, messageIDHex := range f.MessageIDs {
+			messageID, err := hornet.MessageIDFromHex(messageIDHex)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid message ID: %s", messageIDHex)
+			}
+			matcher.messageIDs[messageID.ToMapKey()] = struct{}{}
+		}
+	}
+
+	if len(f.States) > 0 {
+		matcher.states = make(map[MessageMetadataState]struct{}, len(f.States))
+		for _, state := range f.States {
+			switch state {
+			case MessageMetadataStateSolid, MessageMetadataStateReferenced, MessageMetadataStateConflicting, MessageMetadataStateBelowMaxDepth:
+				matcher.states[state] = struct{}{}
+			default:
+				return nil, errors.Errorf("unknown state: %s", state)
+			}
+		}
+	}
+
+	return matcher, nil
+}
+
+// MatchesMessageID returns whether the updates of the given message pass the filter.
+func (m *MessageMetadataMatcher) MatchesMessageID(messageID hornet.MessageID) bool {
+	if m.messageIDs == nil {
+		return true
+	}
+	_, matches := m.messageIDs[messageID.ToMapKey()]
+	return matches
+}
+
+// MatchesState returns whether updates with the given state pass the filter.
+func (m *MessageMetadataMatcher) MatchesState(state MessageMetadataState) bool {
+	if m.states == nil {
+		return true
+	}
+	if _, matches := m.states[state]; matches {
+		return true
+	}
+	if state == MessageMetadataStateConflicting {
+		_, matches := m.states[MessageMetadataStateReferenced]
+		return matches
+	}
+	return false
+}
+
+// Matches returns whether the given metadata update passes the filter.
+func (m *MessageMetadataMatcher) Matches(metadata *inx.MessageMetadata) bool {
+	return m.MatchesMessageID(hornet.MessageIDFromArray(metadata.GetMessageId().Unwrap())) && m.MatchesState(StateOfMessageMetadata(metadata))
+}
+
+// TracksTipStates returns whether the tip states of solid but unreferenced messages need to be tracked for the filter.
+func (m *MessageMetadataMatcher) TracksTipStates() bool {
+	return m.MatchesState(MessageMetadataStateSolid) || m.MatchesState(MessageMetadataStateBelowMaxDepth)
+}
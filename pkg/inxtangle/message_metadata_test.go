@@ -0,0 +1,76 @@
+package inxtangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	inx "github.com/iotaledger/inx/go"
+)
+
+func TestMessageMetadataFilter(t *testing.T) {
+
+	messageID := hornet.MessageID(make([]byte, 32))
+	messageID[0] = 1
+	otherMessageID := hornet.MessageID(make([]byte, 32))
+	otherMessageID[0] = 2
+
+	newMetadata := func(messageID hornet.MessageID, referencedIndex uint32, conflicting bool, shouldReattach bool) *inx.MessageMetadata {
+		metadata := &inx.MessageMetadata{
+			MessageId:                  inx.NewMessageId(messageID.ToArray()),
+			Solid:                      true,
+			ReferencedByMilestoneIndex: referencedIndex,
+			ShouldReattach:             shouldReattach,
+		}
+		if conflicting {
+			metadata.LedgerInclusionState = inx.MessageMetadata_CONFLICTING
+		}
+		return metadata
+	}
+
+	solid := newMetadata(messageID, 0, false, false)
+	belowMaxDepth := newMetadata(messageID, 0, false, true)
+	referenced := newMetadata(messageID, 5, false, false)
+	conflicting := newMetadata(messageID, 5, true, false)
+
+	require.Equal(t, inxtangle.MessageMetadataStateSolid, inxtangle.StateOfMessageMetadata(solid))
+	require.Equal(t, inxtangle.MessageMetadataStateBelowMaxDepth, inxtangle.StateOfMessageMetadata(belowMaxDepth))
+	require.Equal(t, inxtangle.MessageMetadataStateReferenced, inxtangle.StateOfMessageMetadata(referenced))
+	require.Equal(t, inxtangle.MessageMetadataStateConflicting, inxtangle.StateOfMessageMetadata(conflicting))
+
+	// an empty filter matches all updates
+	matcher, err := (&inxtangle.MessageMetadataFilter{}).Decode()
+	require.NoError(t, err)
+	require.True(t, matcher.Matches(solid))
+	require.True(t, matcher.Matches(newMetadata(otherMessageID, 5, true, false)))
+	require.True(t, matcher.TracksTipStates())
+
+	matcher, err = (&inxtangle.MessageMetadataFilter{
+		MessageIDs: []string{messageID.ToHex()},
+		States:     []inxtangle.MessageMetadataState{inxtangle.MessageMetadataStateReferenced},
+	}).Decode()
+	require.NoError(t, err)
+	require.True(t, matcher.Matches(referenced))
+	require.True(t, matcher.Matches(conflicting))
+	require.False(t, matcher.Matches(solid))
+	require.False(t, matcher.Matches(belowMaxDepth))
+	require.False(t, matcher.Matches(newMetadata(otherMessageID, 5, false, false)))
+	require.False(t, matcher.MatchesMessageID(otherMessageID))
+	require.False(t, matcher.TracksTipStates())
+
+	matcher, err = (&inxtangle.MessageMetadataFilter{
+		States: []inxtangle.MessageMetadataState{inxtangle.MessageMetadataStateBelowMaxDepth},
+	}).Decode()
+	require.NoError(t, err)
+	require.True(t, matcher.Matches(belowMaxDepth))
+	require.False(t, matcher.Matches(solid))
+	require.True(t, matcher.TracksTipStates())
+
+	_, err = (&inxtangle.MessageMetadataFilter{MessageIDs: []string{"0x1234"}}).Decode()
+	require.Error(t, err)
+
+	_, err = (&inxtangle.MessageMetadataFilter{States: []inxtangle.MessageMetadataState{"pending"}}).Decode()
+	require.Error(t, err)
+}
@@ -0,0 +1,558 @@
+package inxtangle
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	inx "github.com/iotaledger/inx/go"
+)
+
+// The INX protobuf definitions don't contain calls to walk the tangle structure.
+// Therefore these calls are served by an additional gRPC service on the INX server,
+// that reuses the message types of INX. Results without a matching INX message type
+// are sent as protobuf structs.
+
+const (
+	// ServiceName is the full name of the INX tangle service.
+	ServiceName = "inx.INXTangle"
+
+	MethodReadMessageChildren            = "ReadMessageChildren"
+	MethodReadMilestoneCone              = "ReadMilestoneCone"
+	MethodListenToMessageMetadataUpdates = "ListenToMessageMetadataUpdates"
+	MethodReadMilestoneStats             = "ReadMilestoneStats"
+	MethodListenToMilestoneStats         = "ListenToMilestoneStats"
+	MethodReadMessageInclusionProof      = "ReadMessageInclusionProof"
+	MethodValidateTransaction            = "ValidateTransaction"
+	MethodComputeStorageDeposit          = "ComputeStorageDeposit"
+	MethodListKeyRanges                  = "ListKeyRanges"
+	MethodAddKeyRange                    = "AddKeyRange"
+	MethodValidateKeyRange               = "ValidateKeyRange"
+)
+
+// INXTangleServer is the server API of the INX tangle service.
+type INXTangleServer interface {
+	// ReadMessageChildren streams the message IDs of the children of the given message.
+	ReadMessageChildren(*inx.MessageId, INXTangle_ReadMessageChildrenServer) error
+	// ReadMilestoneCone streams the metadata of all messages that were referenced by the given milestone in white-flag order.
+	ReadMilestoneCone(*inx.MilestoneRequest, INXTangle_ReadMilestoneConeServer) error
+	// ListenToMessageMetadataUpdates streams the metadata of messages that became solid, got referenced or fell below max depth.
+	// The updates are restricted by the given MessageMetadataFilter.
+	ListenToMessageMetadataUpdates(*structpb.Struct, INXTangle_ListenToMessageMetadataUpdatesServer) error
+	// ReadMilestoneStats returns the confirmation statistics of the given milestone.
+	ReadMilestoneStats(context.Context, *inx.MilestoneRequest) (*structpb.Struct, error)
+	// ListenToMilestoneStats streams the confirmation statistics of newly confirmed milestones.
+	ListenToMilestoneStats(*inx.NoParams, INXTangle_ListenToMilestoneStatsServer) error
+	// ReadMessageInclusionProof returns the proof that the given message was referenced (and applied) by a milestone.
+	ReadMessageInclusionProof(context.Context, *inx.MessageId) (*structpb.Struct, error)
+	// ValidateTransaction validates the given serialized transaction payload against the current ledger state without attaching it.
+	ValidateTransaction(context.Context, *wrapperspb.BytesValue) (*structpb.Struct, error)
+	// ComputeStorageDeposit returns the minimum storage deposit of the given serialized output with a breakdown of its virtual bytes.
+	ComputeStorageDeposit(context.Context, *wrapperspb.BytesValue) (*structpb.Struct, error)
+	// ListKeyRanges returns the public key ranges of the milestone signers.
+	ListKeyRanges(context.Context, *inx.NoParams) (*structpb.Struct, error)
+	// AddKeyRange adds an upcoming public key range that was authorized by the currently valid keys.
+	AddKeyRange(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// ValidateKeyRange checks an upcoming public key range and its authorization without adding it.
+	ValidateKeyRange(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// UnimplementedINXTangleServer can be embedded to have forward compatible implementations.
+type UnimplementedINXTangleServer struct {
+}
+
+func (UnimplementedINXTangleServer) ReadMessageChildren(*inx.MessageId, INXTangle_ReadMessageChildrenServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadMessageChildren not implemented")
+}
+func (UnimplementedINXTangleServer) ReadMilestoneCone(*inx.MilestoneRequest, INXTangle_ReadMilestoneConeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadMilestoneCone not implemented")
+}
+func (UnimplementedINXTangleServer) ListenToMessageMetadataUpdates(*structpb.Struct, INXTangle_ListenToMessageMetadataUpdatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListenToMessageMetadataUpdates not implemented")
+}
+
+func (UnimplementedINXTangleServer) ReadMilestoneStats(context.Context, *inx.MilestoneRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadMilestoneStats not implemented")
+}
+func (UnimplementedINXTangleServer) ListenToMilestoneStats(*inx.NoParams, INXTangle_ListenToMilestoneStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListenToMilestoneStats not implemented")
+}
+func (UnimplementedINXTangleServer) ReadMessageInclusionProof(context.Context, *inx.MessageId) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadMessageInclusionProof not implemented")
+}
+func (UnimplementedINXTangleServer) ValidateTransaction(context.Context, *wrapperspb.BytesValue) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTransaction not implemented")
+}
+func (UnimplementedINXTangleServer) ComputeStorageDeposit(context.Context, *wrapperspb.BytesValue) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeStorageDeposit not implemented")
+}
+func (UnimplementedINXTangleServer) ListKeyRanges(context.Context, *inx.NoParams) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeyRanges not implemented")
+}
+func (UnimplementedINXTangleServer) AddKeyRange(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddKeyRange not implemented")
+}
+func (UnimplementedINXTangleServer) ValidateKeyRange(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateKeyRange not implemented")
+}
+
+// RegisterINXTangleServer registers the INX tangle service at the given gRPC server.
+func RegisterINXTangleServer(s grpc.ServiceRegistrar, srv INXTangleServer) {
+	s.RegisterService(&INXTangle_ServiceDesc, srv)
+}
+
+func _INXTangle_ReadMessageChildren_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(inx.MessageId)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(INXTangleServer).ReadMessageChildren(m, &iNXTangleReadMessageChildrenServer{stream})
+}
+
+type INXTangle_ReadMessageChildrenServer interface {
+	Send(*inx.MessageId) error
+	grpc.ServerStream
+}
+
+type iNXTangleReadMessageChildrenServer struct {
+	grpc.ServerStream
+}
+
+func (x *iNXTangleReadMessageChildrenServer) Send(m *inx.MessageId) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _INXTangle_ReadMilestoneCone_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(inx.MilestoneRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(INXTangleServer).ReadMilestoneCone(m, &iNXTangleReadMilestoneConeServer{stream})
+}
+
+type INXTangle_ReadMilestoneConeServer interface {
+	Send(*inx.MessageMetadata) error
+	grpc.ServerStream
+}
+
+type iNXTangleReadMilestoneConeServer struct {
+	grpc.ServerStream
+}
+
+func (x *iNXTangleReadMilestoneConeServer) Send(m *inx.MessageMetadata) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _INXTangle_ListenToMessageMetadataUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(structpb.Struct)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(INXTangleServer).ListenToMessageMetadataUpdates(m, &iNXTangleListenToMessageMetadataUpdatesServer{stream})
+}
+
+type INXTangle_ListenToMessageMetadataUpdatesServer interface {
+	Send(*inx.MessageMetadata) error
+	grpc.ServerStream
+}
+
+type iNXTangleListenToMessageMetadataUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *iNXTangleListenToMessageMetadataUpdatesServer) Send(m *inx.MessageMetadata) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _INXTangle_ReadMilestoneStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(inx.MilestoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ReadMilestoneStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodReadMilestoneStats,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ReadMilestoneStats(ctx, req.(*inx.MilestoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_ListenToMilestoneStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(inx.NoParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(INXTangleServer).ListenToMilestoneStats(m, &iNXTangleListenToMilestoneStatsServer{stream})
+}
+
+type INXTangle_ListenToMilestoneStatsServer interface {
+	Send(*structpb.Struct) error
+	grpc.ServerStream
+}
+
+type iNXTangleListenToMilestoneStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *iNXTangleListenToMilestoneStatsServer) Send(m *structpb.Struct) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _INXTangle_ReadMessageInclusionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(inx.MessageId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ReadMessageInclusionProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodReadMessageInclusionProof,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ReadMessageInclusionProof(ctx, req.(*inx.MessageId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_ValidateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.BytesValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ValidateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodValidateTransaction,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ValidateTransaction(ctx, req.(*wrapperspb.BytesValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_ComputeStorageDeposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.BytesValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ComputeStorageDeposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodComputeStorageDeposit,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ComputeStorageDeposit(ctx, req.(*wrapperspb.BytesValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_ListKeyRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(inx.NoParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ListKeyRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodListKeyRanges,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ListKeyRanges(ctx, req.(*inx.NoParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_AddKeyRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).AddKeyRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodAddKeyRange,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).AddKeyRange(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _INXTangle_ValidateKeyRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(INXTangleServer).ValidateKeyRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/" + MethodValidateKeyRange,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(INXTangleServer).ValidateKeyRange(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// INXTangle_ServiceDesc is the grpc.ServiceDesc of the INX tangle service.
+var INXTangle_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*INXTangleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: MethodReadMilestoneStats,
+			Handler:    _INXTangle_ReadMilestoneStats_Handler,
+		},
+		{
+			MethodName: MethodReadMessageInclusionProof,
+			Handler:    _INXTangle_ReadMessageInclusionProof_Handler,
+		},
+		{
+			MethodName: MethodValidateTransaction,
+			Handler:    _INXTangle_ValidateTransaction_Handler,
+		},
+		{
+			MethodName: MethodComputeStorageDeposit,
+			Handler:    _INXTangle_ComputeStorageDeposit_Handler,
+		},
+		{
+			MethodName: MethodListKeyRanges,
+			Handler:    _INXTangle_ListKeyRanges_Handler,
+		},
+		{
+			MethodName: MethodAddKeyRange,
+			Handler:    _INXTangle_AddKeyRange_Handler,
+		},
+		{
+			MethodName: MethodValidateKeyRange,
+			Handler:    _INXTangle_ValidateKeyRange_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    MethodReadMessageChildren,
+			Handler:       _INXTangle_ReadMessageChildren_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    MethodReadMilestoneCone,
+			Handler:       _INXTangle_ReadMilestoneCone_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    MethodListenToMessageMetadataUpdates,
+			Handler:       _INXTangle_ListenToMessageMetadataUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    MethodListenToMilestoneStats,
+			Handler:       _INXTangle_ListenToMilestoneStats_Handler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// INXTangleClient is the client API of the INX tangle service.
+type INXTangleClient interface {
+	ReadMessageChildren(ctx context.Context, in *inx.MessageId, opts ...grpc.CallOption) (INXTangle_ReadMessageChildrenClient, error)
+	ReadMilestoneCone(ctx context.Context, in *inx.MilestoneRequest, opts ...grpc.CallOption) (INXTangle_ReadMilestoneConeClient, error)
+	ListenToMessageMetadataUpdates(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (INXTangle_ListenToMessageMetadataUpdatesClient, error)
+	ReadMilestoneStats(ctx context.Context, in *inx.MilestoneRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ListenToMilestoneStats(ctx context.Context, in *inx.NoParams, opts ...grpc.CallOption) (INXTangle_ListenToMilestoneStatsClient, error)
+	ReadMessageInclusionProof(ctx context.Context, in *inx.MessageId, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ValidateTransaction(ctx context.Context, in *wrapperspb.BytesValue, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ComputeStorageDeposit(ctx context.Context, in *wrapperspb.BytesValue, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ListKeyRanges(ctx context.Context, in *inx.NoParams, opts ...grpc.CallOption) (*structpb.Struct, error)
+	AddKeyRange(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ValidateKeyRange(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+}
+
+type iNXTangleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewINXTangleClient creates a new client of the INX tangle service.
+func NewINXTangleClient(cc grpc.ClientConnInterface) INXTangleClient {
+	return &iNXTangleClient{cc}
+}
+
+func (c *iNXTangleClient) newServerStream(ctx context.Context, streamIndex int, in interface{}, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	streamDesc := &INXTangle_ServiceDesc.Streams[streamIndex]
+
+	stream, err := c.cc.NewStream(ctx, streamDesc, "/"+ServiceName+"/"+streamDesc.StreamName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (c *iNXTangleClient) ReadMessageChildren(ctx context.Context, in *inx.MessageId, opts ...grpc.CallOption) (INXTangle_ReadMessageChildrenClient, error) {
+	stream, err := c.newServerStream(ctx, 0, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &iNXTangleReadMessageChildrenClient{stream}, nil
+}
+
+type INXTangle_ReadMessageChildrenClient interface {
+	Recv() (*inx.MessageId, error)
+	grpc.ClientStream
+}
+
+type iNXTangleReadMessageChildrenClient struct {
+	grpc.ClientStream
+}
+
+func (x *iNXTangleReadMessageChildrenClient) Recv() (*inx.MessageId, error) {
+	m := new(inx.MessageId)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iNXTangleClient) ReadMilestoneCone(ctx context.Context, in *inx.MilestoneRequest, opts ...grpc.CallOption) (INXTangle_ReadMilestoneConeClient, error) {
+	stream, err := c.newServerStream(ctx, 1, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &iNXTangleMessageMetadataClient{stream}, nil
+}
+
+func (c *iNXTangleClient) ListenToMessageMetadataUpdates(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (INXTangle_ListenToMessageMetadataUpdatesClient, error) {
+	stream, err := c.newServerStream(ctx, 2, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &iNXTangleMessageMetadataClient{stream}, nil
+}
+
+type INXTangle_ReadMilestoneConeClient interface {
+	Recv() (*inx.MessageMetadata, error)
+	grpc.ClientStream
+}
+
+type INXTangle_ListenToMessageMetadataUpdatesClient interface {
+	Recv() (*inx.MessageMetadata, error)
+	grpc.ClientStream
+}
+
+type iNXTangleMessageMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *iNXTangleMessageMetadataClient) Recv() (*inx.MessageMetadata, error) {
+	m := new(inx.MessageMetadata)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iNXTangleClient) ReadMilestoneStats(ctx context.Context, in *inx.MilestoneRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodReadMilestoneStats, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) ListenToMilestoneStats(ctx context.Context, in *inx.NoParams, opts ...grpc.CallOption) (INXTangle_ListenToMilestoneStatsClient, error) {
+	stream, err := c.newServerStream(ctx, 3, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &iNXTangleListenToMilestoneStatsClient{stream}, nil
+}
+
+type INXTangle_ListenToMilestoneStatsClient interface {
+	Recv() (*structpb.Struct, error)
+	grpc.ClientStream
+}
+
+type iNXTangleListenToMilestoneStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *iNXTangleListenToMilestoneStatsClient) Recv() (*structpb.Struct, error) {
+	m := new(structpb.Struct)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iNXTangleClient) ReadMessageInclusionProof(ctx context.Context, in *inx.MessageId, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodReadMessageInclusionProof, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) ValidateTransaction(ctx context.Context, in *wrapperspb.BytesValue, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodValidateTransaction, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) ComputeStorageDeposit(ctx context.Context, in *wrapperspb.BytesValue, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodComputeStorageDeposit, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) ListKeyRanges(ctx context.Context, in *inx.NoParams, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodListKeyRanges, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) AddKeyRange(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodAddKeyRange, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iNXTangleClient) ValidateKeyRange(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/"+MethodValidateKeyRange, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
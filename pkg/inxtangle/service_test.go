@@ -0,0 +1,90 @@
+package inxtangle_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	inx "github.com/iotaledger/inx/go"
+)
+
+type testServer struct {
+	inxtangle.UnimplementedINXTangleServer
+}
+
+func (s *testServer) ReadMessageChildren(messageID *inx.MessageId, srv inxtangle.INXTangle_ReadMessageChildrenServer) error {
+	for i := byte(1); i <= 3; i++ {
+		child := messageID.Unwrap()
+		child[0] = i
+		if err := srv.Send(inx.NewMessageId(child)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testServer) ReadMilestoneStats(_ context.Context, req *inx.MilestoneRequest) (*structpb.Struct, error) {
+	return inxtangle.ToStruct(&storage.MilestoneStats{
+		Index:              milestone.Index(req.GetMilestoneIndex()),
+		MessagesReferenced: 10,
+		ConflictsByReason:  map[storage.Conflict]uint32{storage.ConflictInvalidSignature: 2},
+		Durations:          storage.MilestoneStatsDurations{Total: 1500 * time.Microsecond},
+	})
+}
+
+func TestINXTangleService(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	inxtangle.RegisterINXTangleServer(grpcServer, &testServer{})
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := inxtangle.NewINXTangleClient(conn)
+
+	stream, err := client.ReadMessageChildren(context.Background(), inx.NewMessageId([32]byte{0, 42}))
+	require.NoError(t, err)
+
+	var children [][32]byte
+	for {
+		child, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		children = append(children, child.Unwrap())
+	}
+	require.Equal(t, [][32]byte{{1, 42}, {2, 42}, {3, 42}}, children)
+
+	statsStruct, err := client.ReadMilestoneStats(context.Background(), &inx.MilestoneRequest{MilestoneIndex: 7})
+	require.NoError(t, err)
+	stats := &storage.MilestoneStats{}
+	require.NoError(t, inxtangle.FromStruct(statsStruct, stats))
+	require.Equal(t, milestone.Index(7), stats.Index)
+	require.Equal(t, uint32(10), stats.MessagesReferenced)
+	require.Equal(t, map[storage.Conflict]uint32{storage.ConflictInvalidSignature: 2}, stats.ConflictsByReason)
+	require.Equal(t, 1500*time.Microsecond, stats.Durations.Total)
+
+	// unimplemented calls are answered by the embedded server
+	coneStream, err := client.ReadMilestoneCone(context.Background(), &inx.MilestoneRequest{MilestoneIndex: 1})
+	require.NoError(t, err)
+	_, err = coneStream.Recv()
+	require.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package inxtangle
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToStruct converts the JSON representation of the given value to a protobuf struct.
+// This is used for results without a matching INX message type, so that extensions
+// receive the same fields as the REST API.
+func ToStruct(v interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode %T", v)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %T", v)
+	}
+
+	return structpb.NewStruct(fields)
+}
+
+// FromStruct decodes a protobuf struct that was created by ToStruct into the value pointed to by v.
+func FromStruct(s *structpb.Struct, v interface{}) error {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %T", v)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrapf(err, "failed to decode %T", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+package keymanager
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrKeyRangeInvalid is returned if the end index of a key range is smaller than its start index.
+	ErrKeyRangeInvalid = errors.New("invalid key range")
+	// ErrKeyRangeNotUpcoming is returned if a key range starts at or before the confirmed milestone index.
+	ErrKeyRangeNotUpcoming = errors.New("key range does not start after the confirmed milestone index")
+	// ErrKeyRangeAlreadyExists is returned if the public key of a key range is already known for an overlapping range.
+	ErrKeyRangeAlreadyExists = errors.New("key range already exists")
+	// ErrKeyRangeInvalidSignature is returned if a signature of a key range authorization is invalid.
+	ErrKeyRangeInvalidSignature = errors.New("invalid key range signature")
+	// ErrKeyRangeQuorumNotReached is returned if not enough valid keys signed a key range authorization.
+	ErrKeyRangeQuorumNotReached = errors.New("not enough valid signatures for key range authorization")
+)
+
+// keyRangeEssencePrefix is prepended to the essence of a key range authorization,
+// so that the signatures can't be mistaken for signatures of other objects.
+var keyRangeEssencePrefix = []byte("HORNET_KEY_RANGE")
+
+// StoreKeyRangesFunc is a function that persists the key ranges that were added with an authorization.
+type StoreKeyRangesFunc func(keyRanges []*KeyRange) error
+
+// KeyRangeAuthorizationSignature is a hex encoded signature of a key range authorization.
+type KeyRangeAuthorizationSignature struct {
+	// The hex encoded ed25519 public key of the signer.
+	PublicKey string `json:"publicKey"`
+	// The hex encoded ed25519 signature of the key range essence.
+	Signature string `json:"signature"`
+}
+
+// KeyRangeAuthorization is the hex encoded form of a key range and its authorization,
+// as it is sent by the REST API and INX clients.
+type KeyRangeAuthorization struct {
+	// The hex encoded ed25519 public key.
+	PublicKey string `json:"publicKey"`
+	// The milestone index the public key is valid from.
+	StartIndex milestone.Index `json:"start"`
+	// The milestone index the public key is valid until (0 = forever).
+	EndIndex milestone.Index `json:"end"`
+	// The signatures of the public keys that are valid at the confirmed milestone index.
+	Signatures []*KeyRangeAuthorizationSignature `json:"signatures"`
+}
+
+// Decode decodes the key range and the signatures of its authorization.
+func (a *KeyRangeAuthorization) Decode() (*KeyRange, []*iotago.Ed25519Signature, error) {
+	pubKeyBytes, err := iotago.DecodeHex(a.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid publicKey")
+	}
+	if len(pubKeyBytes) != ed25519.PublicKeySize {
+		return nil, nil, errors.Errorf("invalid publicKey, length: %d", len(pubKeyBytes))
+	}
+
+	keyRange := &KeyRange{StartIndex: a.StartIndex, EndIndex: a.EndIndex}
+	copy(keyRange.PublicKey[:], pubKeyBytes)
+
+	signatures := make([]*iotago.Ed25519Signature, 0, len(a.Signatures))
+	for _, sig := range a.Signatures {
+		sigPubKeyBytes, err := iotago.DecodeHex(sig.PublicKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid signature publicKey")
+		}
+		if len(sigPubKeyBytes) != ed25519.PublicKeySize {
+			return nil, nil, errors.Errorf("invalid signature publicKey, length: %d", len(sigPubKeyBytes))
+		}
+
+		sigBytes, err := iotago.DecodeHex(sig.Signature)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid signature")
+		}
+		if len(sigBytes) != ed25519.SignatureSize {
+			return nil, nil, errors.Errorf("invalid signature, length: %d", len(sigBytes))
+		}
+
+		signature := &iotago.Ed25519Signature{}
+		copy(signature.PublicKey[:], sigPubKeyBytes)
+		copy(signature.Signature[:], sigBytes)
+		signatures = append(signatures, signature)
+	}
+
+	return keyRange, signatures, nil
+}
+
+// NewKeyRangeAuthorization returns the hex encoded form of a key range and the signatures of its authorization.
+func NewKeyRangeAuthorization(keyRange *KeyRange, signatures []*iotago.Ed25519Signature) *KeyRangeAuthorization {
+	authorization := &KeyRangeAuthorization{
+		PublicKey:  iotago.EncodeHex(keyRange.PublicKey[:]),
+		StartIndex: keyRange.StartIndex,
+		EndIndex:   keyRange.EndIndex,
+		Signatures: make([]*KeyRangeAuthorizationSignature, 0, len(signatures)),
+	}
+
+	for _, signature := range signatures {
+		authorization.Signatures = append(authorization.Signatures, &KeyRangeAuthorizationSignature{
+			PublicKey: iotago.EncodeHex(signature.PublicKey[:]),
+			Signature: iotago.EncodeHex(signature.Signature[:]),
+		})
+	}
+
+	return authorization
+}
+
+// KeyRangeEssence returns the essence of a key range that is signed by the valid keys to authorize it.
+// The essence contains the network ID, so that an authorization can't be replayed on other networks.
+func KeyRangeEssence(networkID iotago.NetworkID, keyRange *KeyRange) []byte {
+	var buf bytes.Buffer
+	buf.Write(keyRangeEssencePrefix)
+	_ = binary.Write(&buf, binary.LittleEndian, networkID)
+	buf.Write(keyRange.PublicKey[:])
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(keyRange.StartIndex))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(keyRange.EndIndex))
+	return buf.Bytes()
+}
+
+// SignKeyRange signs the essence of a key range for the given network with the given private keys.
+func SignKeyRange(networkID iotago.NetworkID, keyRange *KeyRange, privateKeys []ed25519.PrivateKey) []*iotago.Ed25519Signature {
+	essence := KeyRangeEssence(networkID, keyRange)
+
+	signatures := make([]*iotago.Ed25519Signature, 0, len(privateKeys))
+	for _, privateKey := range privateKeys {
+		signature := &iotago.Ed25519Signature{}
+		copy(signature.PublicKey[:], privateKey.Public().(ed25519.PublicKey))
+		copy(signature.Signature[:], ed25519.Sign(privateKey, essence))
+		signatures = append(signatures, signature)
+	}
+
+	return signatures
+}
+
+// SetStoreCallback sets the callback that is used to persist the key ranges that were added with an authorization.
+func (k *KeyManager) SetStoreCallback(storeCallback StoreKeyRangesFunc) {
+	k.keyRangesLock.Lock()
+	defer k.keyRangesLock.Unlock()
+
+	k.storeCallback = storeCallback
+}
+
+// AuthorizedKeyRanges returns a copy of the key ranges that were added with an authorization.
+func (k *KeyManager) AuthorizedKeyRanges() []*KeyRange {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	return copyKeyRanges(k.authorizedKeyRanges)
+}
+
+// LoadAuthorizedKeyRanges adds previously persisted authorized key ranges without verifying them again.
+// Key ranges that are already known (e.g. because they were added to the config in the meantime) are skipped.
+func (k *KeyManager) LoadAuthorizedKeyRanges(keyRanges []*KeyRange) {
+	k.keyRangesLock.Lock()
+	defer k.keyRangesLock.Unlock()
+
+	for _, keyRange := range keyRanges {
+		if k.containsKeyRangeWithoutLocking(keyRange) {
+			continue
+		}
+
+		keyRange := &KeyRange{PublicKey: keyRange.PublicKey, StartIndex: keyRange.StartIndex, EndIndex: keyRange.EndIndex}
+		k.addKeyRangeWithoutLocking(keyRange)
+		k.authorizedKeyRanges = append(k.authorizedKeyRanges, keyRange)
+	}
+}
+
+// ValidateKeyRange checks if the given key range is a valid upcoming key range for the given confirmed milestone index.
+func (k *KeyManager) ValidateKeyRange(keyRange *KeyRange, confirmedMilestoneIndex milestone.Index) error {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	return k.validateKeyRangeWithoutLocking(keyRange, confirmedMilestoneIndex)
+}
+
+// VerifyKeyRangeAuthorization checks if the given key range was signed for the given network by at least "minSignatures"
+// distinct public keys that are valid at the given confirmed milestone index.
+func (k *KeyManager) VerifyKeyRangeAuthorization(networkID iotago.NetworkID, keyRange *KeyRange, signatures []*iotago.Ed25519Signature, confirmedMilestoneIndex milestone.Index, minSignatures int) error {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	return k.verifyKeyRangeAuthorizationWithoutLocking(networkID, keyRange, signatures, confirmedMilestoneIndex, minSignatures)
+}
+
+// AddAuthorizedKeyRange validates the given key range and its authorization, adds it to the known key ranges and persists it.
+func (k *KeyManager) AddAuthorizedKeyRange(networkID iotago.NetworkID, keyRange *KeyRange, signatures []*iotago.Ed25519Signature, confirmedMilestoneIndex milestone.Index, minSignatures int) error {
+	k.keyRangesLock.Lock()
+	defer k.keyRangesLock.Unlock()
+
+	if err := k.validateKeyRangeWithoutLocking(keyRange, confirmedMilestoneIndex); err != nil {
+		return err
+	}
+
+	if err := k.verifyKeyRangeAuthorizationWithoutLocking(networkID, keyRange, signatures, confirmedMilestoneIndex, minSignatures); err != nil {
+		return err
+	}
+
+	authorizedKeyRanges := append(copyKeyRanges(k.authorizedKeyRanges), &KeyRange{PublicKey: keyRange.PublicKey, StartIndex: keyRange.StartIndex, EndIndex: keyRange.EndIndex})
+	if k.storeCallback != nil {
+		if err := k.storeCallback(authorizedKeyRanges); err != nil {
+			return errors.Wrap(err, "failed to store key ranges")
+		}
+	}
+
+	newKeyRange := authorizedKeyRanges[len(authorizedKeyRanges)-1]
+	k.authorizedKeyRanges = append(k.authorizedKeyRanges, newKeyRange)
+	k.addKeyRangeWithoutLocking(newKeyRange)
+
+	return nil
+}
+
+// KeyRangesWithoutSuccessor returns all key ranges that expire within the next "lookahead" milestones
+// after the given milestone index, if there are less than "milestonePublicKeyCount" valid keys after they expired.
+func (k *KeyManager) KeyRangesWithoutSuccessor(msIndex milestone.Index, lookahead milestone.Index, milestonePublicKeyCount int) []*KeyRange {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	var result []*KeyRange
+	for _, keyRange := range k.keyRanges {
+		if !k.expiresWithoutSuccessorWithoutLocking(keyRange, msIndex, lookahead, milestonePublicKeyCount) {
+			continue
+		}
+
+		result = append(result, &KeyRange{PublicKey: keyRange.PublicKey, StartIndex: keyRange.StartIndex, EndIndex: keyRange.EndIndex})
+	}
+
+	return result
+}
+
+// KeyRangeStatus is a known key range together with its state at a milestone index.
+type KeyRangeStatus struct {
+	KeyRange
+	// Whether the key range was added at runtime with an authorization of the valid keys.
+	Authorized bool
+	// Whether the key range expires soon without enough valid public keys afterwards.
+	ExpiresWithoutSuccessor bool
+}
+
+// KeyRangeStatuses returns all known key ranges with their state at the given milestone index.
+// The expiry is checked like in KeyRangesWithoutSuccessor.
+func (k *KeyManager) KeyRangeStatuses(msIndex milestone.Index, lookahead milestone.Index, milestonePublicKeyCount int) []*KeyRangeStatus {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	authorized := make(map[KeyRange]struct{})
+	for _, keyRange := range k.authorizedKeyRanges {
+		authorized[*keyRange] = struct{}{}
+	}
+
+	result := make([]*KeyRangeStatus, 0, len(k.keyRanges))
+	for _, keyRange := range k.keyRanges {
+		_, isAuthorized := authorized[*keyRange]
+
+		result = append(result, &KeyRangeStatus{
+			KeyRange:                *keyRange,
+			Authorized:              isAuthorized,
+			ExpiresWithoutSuccessor: k.expiresWithoutSuccessorWithoutLocking(keyRange, msIndex, lookahead, milestonePublicKeyCount),
+		})
+	}
+
+	return result
+}
+
+// ValidateKeyRangeAuthorization checks if the given key range is a valid upcoming key range
+// and if it was authorized for the given network by enough valid keys, without adding it.
+func (k *KeyManager) ValidateKeyRangeAuthorization(networkID iotago.NetworkID, keyRange *KeyRange, signatures []*iotago.Ed25519Signature, confirmedMilestoneIndex milestone.Index, minSignatures int) error {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	if err := k.validateKeyRangeWithoutLocking(keyRange, confirmedMilestoneIndex); err != nil {
+		return err
+	}
+
+	return k.verifyKeyRangeAuthorizationWithoutLocking(networkID, keyRange, signatures, confirmedMilestoneIndex, minSignatures)
+}
+
+// IsKeyRangeRejected returns whether the error was returned because a key range or its authorization was rejected.
+func IsKeyRangeRejected(err error) bool {
+	return errors.Is(err, ErrKeyRangeInvalid) ||
+		errors.Is(err, ErrKeyRangeNotUpcoming) ||
+		errors.Is(err, ErrKeyRangeAlreadyExists) ||
+		errors.Is(err, ErrKeyRangeInvalidSignature) ||
+		errors.Is(err, ErrKeyRangeQuorumNotReached)
+}
+
+func (k *KeyManager) expiresWithoutSuccessorWithoutLocking(keyRange *KeyRange, msIndex milestone.Index, lookahead milestone.Index, milestonePublicKeyCount int) bool {
+	if keyRange.EndIndex == 0 || keyRange.EndIndex < msIndex || keyRange.EndIndex-msIndex > lookahead {
+		// the key is valid forever, already expired or doesn't expire soon
+		return false
+	}
+
+	// check if enough keys are valid after the key range expired
+	return len(k.publicKeysForMilestoneIndexWithoutLocking(keyRange.EndIndex+1)) < milestonePublicKeyCount
+}
+
+func (k *KeyManager) containsKeyRangeWithoutLocking(keyRange *KeyRange) bool {
+	for _, r := range k.keyRanges {
+		if *r == *keyRange {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *KeyManager) validateKeyRangeWithoutLocking(keyRange *KeyRange, confirmedMilestoneIndex milestone.Index) error {
+	if keyRange.EndIndex != 0 && keyRange.EndIndex < keyRange.StartIndex {
+		return errors.WithMessagef(ErrKeyRangeInvalid, "end index %d is smaller than start index %d", keyRange.EndIndex, keyRange.StartIndex)
+	}
+
+	if keyRange.StartIndex <= confirmedMilestoneIndex {
+		return errors.WithMessagef(ErrKeyRangeNotUpcoming, "start index %d, confirmed milestone index %d", keyRange.StartIndex, confirmedMilestoneIndex)
+	}
+
+	// the same key is not allowed to be valid twice for the same milestone index
+	for _, r := range k.keyRanges {
+		if r.PublicKey != keyRange.PublicKey {
+			continue
+		}
+
+		if (r.EndIndex == 0 || r.EndIndex >= keyRange.StartIndex) && (keyRange.EndIndex == 0 || keyRange.EndIndex >= r.StartIndex) {
+			return errors.WithMessagef(ErrKeyRangeAlreadyExists, "public key %s is already valid from %d to %d", iotago.EncodeHex(r.PublicKey[:]), r.StartIndex, r.EndIndex)
+		}
+	}
+
+	return nil
+}
+
+func (k *KeyManager) verifyKeyRangeAuthorizationWithoutLocking(networkID iotago.NetworkID, keyRange *KeyRange, signatures []*iotago.Ed25519Signature, confirmedMilestoneIndex milestone.Index, minSignatures int) error {
+	validPublicKeys := make(map[iotago.MilestonePublicKey]struct{})
+	for _, pubKey := range k.publicKeysForMilestoneIndexWithoutLocking(confirmedMilestoneIndex) {
+		validPublicKeys[pubKey] = struct{}{}
+	}
+
+	essence := KeyRangeEssence(networkID, keyRange)
+
+	signedPublicKeys := make(map[iotago.MilestonePublicKey]struct{})
+	for _, signature := range signatures {
+		pubKey := iotago.MilestonePublicKey(signature.PublicKey)
+
+		if _, valid := validPublicKeys[pubKey]; !valid {
+			return errors.WithMessagef(ErrKeyRangeInvalidSignature, "public key %s is not valid at milestone index %d", iotago.EncodeHex(pubKey[:]), confirmedMilestoneIndex)
+		}
+
+		if !ed25519.Verify(signature.PublicKey[:], essence, signature.Signature[:]) {
+			return errors.WithMessagef(ErrKeyRangeInvalidSignature, "signature of public key %s does not match", iotago.EncodeHex(pubKey[:]))
+		}
+
+		signedPublicKeys[pubKey] = struct{}{}
+	}
+
+	if len(signedPublicKeys) < minSignatures {
+		return errors.WithMessagef(ErrKeyRangeQuorumNotReached, "%d valid signatures, %d required", len(signedPublicKeys), minSignatures)
+	}
+
+	return nil
+}
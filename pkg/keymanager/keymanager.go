@@ -3,6 +3,7 @@ package keymanager
 import (
 	"crypto/ed25519"
 	"sort"
+	"sync"
 
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -17,7 +18,13 @@ type KeyRange struct {
 
 // KeyManager provides public and private keys for ranges of milestone indexes.
 type KeyManager struct {
-	keyRanges []*KeyRange
+	keyRangesLock sync.RWMutex
+	keyRanges     []*KeyRange
+
+	// the key ranges that were added at runtime with an authorization of the valid keys.
+	authorizedKeyRanges []*KeyRange
+	// the callback that is used to persist the authorized key ranges.
+	storeCallback StoreKeyRangesFunc
 }
 
 // New returns a new KeyManager.
@@ -31,28 +38,50 @@ func (k *KeyManager) AddKeyRange(publicKey ed25519.PublicKey, startIndex milesto
 	var msPubKey iotago.MilestonePublicKey
 	copy(msPubKey[:], publicKey)
 
-	k.keyRanges = append(k.keyRanges, &KeyRange{PublicKey: msPubKey, StartIndex: startIndex, EndIndex: endIndex})
+	k.keyRangesLock.Lock()
+	defer k.keyRangesLock.Unlock()
+
+	k.addKeyRangeWithoutLocking(&KeyRange{PublicKey: msPubKey, StartIndex: startIndex, EndIndex: endIndex})
+}
+
+func (k *KeyManager) addKeyRangeWithoutLocking(keyRange *KeyRange) {
+	k.keyRanges = append(k.keyRanges, keyRange)
 
 	// sort by start index
-	sort.Slice(k.keyRanges, func(i int, j int) bool {
+	sort.SliceStable(k.keyRanges, func(i int, j int) bool {
 		return k.keyRanges[i].StartIndex < k.keyRanges[j].StartIndex
 	})
 }
 
+// KeyRanges returns a copy of all known key ranges sorted by their start index.
 func (k *KeyManager) KeyRanges() []*KeyRange {
-	keyRanges := []*KeyRange{}
-	for _, r := range k.keyRanges {
-		keyRanges = append(keyRanges, &KeyRange{
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	return copyKeyRanges(k.keyRanges)
+}
+
+func copyKeyRanges(keyRanges []*KeyRange) []*KeyRange {
+	result := []*KeyRange{}
+	for _, r := range keyRanges {
+		result = append(result, &KeyRange{
 			PublicKey:  r.PublicKey,
 			StartIndex: r.StartIndex,
 			EndIndex:   r.EndIndex,
 		})
 	}
-	return keyRanges
+	return result
 }
 
 // PublicKeysForMilestoneIndex returns the valid public keys for a certain milestone index.
 func (k *KeyManager) PublicKeysForMilestoneIndex(msIndex milestone.Index) []iotago.MilestonePublicKey {
+	k.keyRangesLock.RLock()
+	defer k.keyRangesLock.RUnlock()
+
+	return k.publicKeysForMilestoneIndexWithoutLocking(msIndex)
+}
+
+func (k *KeyManager) publicKeysForMilestoneIndexWithoutLocking(msIndex milestone.Index) []iotago.MilestonePublicKey {
 	var pubKeys []iotago.MilestonePublicKey
 
 	for _, pubKeyRange := range k.keyRanges {
@@ -10,6 +10,8 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
+var testNetworkID = iotago.NetworkIDFromString("testnet")
+
 func TestMilestoneKeyManager(t *testing.T) {
 
 	pubKey1, privKey1, err := ed25519.GenerateKey(nil)
@@ -94,3 +96,144 @@ func TestMilestoneKeyManager(t *testing.T) {
 	keysSet17 := km.PublicKeysSetForMilestoneIndex(17)
 	assert.Len(t, keysSet17, 2)
 }
+
+func TestKeyRangeAuthorization(t *testing.T) {
+
+	pubKey1, privKey1, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	pubKey2, privKey2, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	pubKey3, privKey3, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	km := keymanager.New()
+	km.AddKeyRange(pubKey1, 0, 100)
+	km.AddKeyRange(pubKey2, 0, 100)
+
+	var storedKeyRanges []*keymanager.KeyRange
+	km.SetStoreCallback(func(keyRanges []*keymanager.KeyRange) error {
+		storedKeyRanges = keyRanges
+		return nil
+	})
+
+	// both keys expire at the same index without a successor
+	assert.Len(t, km.KeyRangesWithoutSuccessor(50, 10, 2), 0)
+	assert.Len(t, km.KeyRangesWithoutSuccessor(90, 10, 2), 2)
+	assert.Len(t, km.KeyRangesWithoutSuccessor(101, 10, 2), 0)
+
+	var msPubKey3 iotago.MilestonePublicKey
+	copy(msPubKey3[:], pubKey3)
+
+	keyRange := &keymanager.KeyRange{PublicKey: msPubKey3, StartIndex: 80, EndIndex: 0}
+
+	// not enough signatures
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeQuorumNotReached)
+
+	// the same key signed twice
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey1}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeQuorumNotReached)
+
+	// signed by a key that is not valid yet
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey3}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeInvalidSignature)
+
+	// signed for another network
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(iotago.NetworkIDFromString("other"), keyRange, []ed25519.PrivateKey{privKey1, privKey2}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeInvalidSignature)
+
+	// signed for another range
+	otherKeyRange := &keymanager.KeyRange{PublicKey: msPubKey3, StartIndex: 60, EndIndex: 0}
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, otherKeyRange, []ed25519.PrivateKey{privKey1, privKey2}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeInvalidSignature)
+
+	// the key range is not upcoming
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey2}), 80, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeNotUpcoming)
+
+	// the end index is smaller than the start index
+	invalidKeyRange := &keymanager.KeyRange{PublicKey: msPubKey3, StartIndex: 80, EndIndex: 70}
+	err = km.ValidateKeyRange(invalidKeyRange, 50)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeInvalid)
+
+	assert.Nil(t, storedKeyRanges)
+
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey2}), 50, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*keymanager.KeyRange{keyRange}, storedKeyRanges)
+	assert.Equal(t, []*keymanager.KeyRange{keyRange}, km.AuthorizedKeyRanges())
+	assert.Len(t, km.PublicKeysForMilestoneIndex(80), 3)
+
+	// the key range can't be added twice
+	err = km.AddAuthorizedKeyRange(testNetworkID, keyRange, keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey2}), 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeAlreadyExists)
+
+	// one key has a successor now, but a single key is not enough
+	assert.Len(t, km.KeyRangesWithoutSuccessor(90, 10, 2), 2)
+	assert.Len(t, km.KeyRangesWithoutSuccessor(90, 10, 1), 0)
+
+	statuses := km.KeyRangeStatuses(90, 10, 2)
+	assert.Len(t, statuses, 3)
+	for _, status := range statuses {
+		isAddedKeyRange := status.KeyRange == *keyRange
+		assert.Equal(t, isAddedKeyRange, status.Authorized)
+		assert.Equal(t, !isAddedKeyRange, status.ExpiresWithoutSuccessor)
+	}
+
+	// persisted key ranges are loaded only once
+	restarted := keymanager.New()
+	restarted.AddKeyRange(pubKey1, 0, 100)
+	restarted.LoadAuthorizedKeyRanges(storedKeyRanges)
+	restarted.LoadAuthorizedKeyRanges(storedKeyRanges)
+	assert.Len(t, restarted.KeyRanges(), 2)
+	assert.Len(t, restarted.AuthorizedKeyRanges(), 1)
+}
+
+func TestValidateKeyRangeAuthorization(t *testing.T) {
+
+	pubKey1, privKey1, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	pubKey2, privKey2, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	pubKey3, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	km := keymanager.New()
+	km.AddKeyRange(pubKey1, 0, 100)
+	km.AddKeyRange(pubKey2, 0, 100)
+
+	var msPubKey3 iotago.MilestonePublicKey
+	copy(msPubKey3[:], pubKey3)
+
+	keyRange := &keymanager.KeyRange{PublicKey: msPubKey3, StartIndex: 80, EndIndex: 0}
+	signatures := keymanager.SignKeyRange(testNetworkID, keyRange, []ed25519.PrivateKey{privKey1, privKey2})
+
+	// the authorization survives the hex encoding of the APIs
+	decodedKeyRange, decodedSignatures, err := keymanager.NewKeyRangeAuthorization(keyRange, signatures).Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, keyRange, decodedKeyRange)
+	assert.Equal(t, signatures, decodedSignatures)
+
+	invalidAuthorization := keymanager.NewKeyRangeAuthorization(keyRange, signatures)
+	invalidAuthorization.Signatures[0].Signature = "0x1234"
+	_, _, err = invalidAuthorization.Decode()
+	assert.Error(t, err)
+
+	assert.NoError(t, km.ValidateKeyRangeAuthorization(testNetworkID, keyRange, signatures, 50, 2))
+
+	err = km.ValidateKeyRangeAuthorization(testNetworkID, keyRange, signatures[:1], 50, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeQuorumNotReached)
+	assert.True(t, keymanager.IsKeyRangeRejected(err))
+
+	err = km.ValidateKeyRangeAuthorization(testNetworkID, keyRange, signatures, 80, 2)
+	assert.ErrorIs(t, err, keymanager.ErrKeyRangeNotUpcoming)
+	assert.True(t, keymanager.IsKeyRangeRejected(err))
+
+	// validating doesn't add the key range
+	assert.Len(t, km.KeyRanges(), 2)
+	assert.Empty(t, km.AuthorizedKeyRanges())
+}
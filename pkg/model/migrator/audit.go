@@ -0,0 +1,427 @@
+package migrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// AuditIssueType is the type of an issue found by the receipt audit.
+type AuditIssueType string
+
+const (
+	// AuditIssueTreasuryDelta means that the difference between the spent and the new treasury output
+	// does not equal the migrated funds of the receipt.
+	AuditIssueTreasuryDelta AuditIssueType = "treasuryDelta"
+	// AuditIssueTreasuryChain means that a receipt does not spend the treasury output created by the previous receipt.
+	AuditIssueTreasuryChain AuditIssueType = "treasuryChain"
+	// AuditIssueTreasuryOutput means that a stored treasury output does not match the treasury transaction of its receipt.
+	AuditIssueTreasuryOutput AuditIssueType = "treasuryOutput"
+	// AuditIssueUnspentTreasury means that the unspent treasury output is missing, ambiguous
+	// or does not match the treasury transaction of the last receipt.
+	AuditIssueUnspentTreasury AuditIssueType = "unspentTreasury"
+	// AuditIssueOutputIDCollision means that the output ID of migrated funds is used more than once.
+	AuditIssueOutputIDCollision AuditIssueType = "outputIDCollision"
+	// AuditIssueOutputMismatch means that the stored output of migrated funds does not match the receipt entry.
+	AuditIssueOutputMismatch AuditIssueType = "outputMismatch"
+	// AuditIssueDuplicateMigration means that the same legacy tail transaction was migrated more than once.
+	AuditIssueDuplicateMigration AuditIssueType = "duplicateMigration"
+	// AuditIssueBackupMissing means that no backup exists for a stored receipt.
+	AuditIssueBackupMissing AuditIssueType = "backupMissing"
+	// AuditIssueBackupMismatch means that the backup of a receipt does not match the stored receipt.
+	AuditIssueBackupMismatch AuditIssueType = "backupMismatch"
+)
+
+// AuditIssue is an inconsistency found by the receipt audit.
+type AuditIssue struct {
+	// The type of the issue.
+	Type AuditIssueType `json:"type"`
+	// The index of the milestone which contained the affected receipt (0 if not related to a receipt).
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The migrated at index of the affected receipt (0 if not related to a receipt).
+	MigratedAt uint32 `json:"migratedAt"`
+	// The description of the issue.
+	Message string `json:"message"`
+}
+
+// ReceiptAudit is the audit of a single receipt.
+type ReceiptAudit struct {
+	// The index of the milestone which contained the receipt.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt uint32 `json:"migratedAt"`
+	// Whether the receipt is the final one for the migrated at index.
+	Final bool `json:"final"`
+	// The amount of migrated funds entries.
+	Entries int `json:"entries"`
+	// The sum of the migrated funds.
+	MigratedFunds uint64 `json:"migratedFunds,string"`
+	// The ID of the milestone which contained the receipt (empty if unknown).
+	MilestoneID string `json:"milestoneId,omitempty"`
+	// The amount of the spent treasury output.
+	TreasuryInputAmount uint64 `json:"treasuryInputAmount,string"`
+	// Whether the spent treasury output was already pruned, so the treasury delta could not be checked.
+	TreasuryInputPruned bool `json:"treasuryInputPruned"`
+	// The amount of the new treasury output.
+	TreasuryOutputAmount uint64 `json:"treasuryOutputAmount,string"`
+}
+
+// AuditResult is the result of the receipt audit.
+type AuditResult struct {
+	// The ledger index the audit was computed at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The audits of all stored receipts, ordered by milestone index.
+	Receipts []*ReceiptAudit `json:"receipts"`
+	// The sum of the migrated funds of all stored receipts.
+	TotalMigratedFunds uint64 `json:"totalMigratedFunds,string"`
+	// The amount of the unspent treasury output.
+	UnspentTreasuryAmount uint64 `json:"unspentTreasuryAmount,string"`
+	// Whether the receipt backups were checked.
+	BackupsChecked bool `json:"backupsChecked"`
+	// The receipt backups without a stored receipt, e.g. because the receipts were pruned.
+	BackupsWithoutReceipt []string `json:"backupsWithoutReceipt"`
+	// The inconsistencies found.
+	Issues []*AuditIssue `json:"issues"`
+}
+
+// Passed returns whether the audit found no inconsistencies.
+func (r *AuditResult) Passed() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *AuditResult) addIssue(issueType AuditIssueType, rt *utxo.ReceiptTuple, format string, args ...interface{}) {
+	issue := &AuditIssue{
+		Type:    issueType,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if rt != nil {
+		issue.MilestoneIndex = rt.MilestoneIndex
+		issue.MigratedAt = rt.Receipt.MigratedAt
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+// receiptBackupFileName returns the file name of the backup of the given receipt.
+func receiptBackupFileName(rt *utxo.ReceiptTuple) string {
+	return fmt.Sprintf(receiptFilePattern, rt.Receipt.MigratedAt, rt.MilestoneIndex)
+}
+
+// receiptMilestoneID returns the ID of the milestone which contained the receipt.
+// The ID is taken from the treasury output stored in the milestone diff, or, if the milestone was already pruned,
+// from the treasury input of the following receipt or the unspent treasury output for the last receipt.
+// Returns nil if the ID can't be determined.
+func receiptMilestoneID(utxoManager *utxo.Manager, receipts []*utxo.ReceiptTuple, i int, unspentTreasuryOutput *utxo.TreasuryOutput) (*iotago.MilestoneID, error) {
+
+	msDiff, err := utxoManager.MilestoneDiffWithoutLocking(receipts[i].MilestoneIndex)
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, err
+	}
+	if msDiff != nil && msDiff.TreasuryOutput != nil {
+		milestoneID := msDiff.TreasuryOutput.MilestoneID
+		return &milestoneID, nil
+	}
+
+	if i+1 < len(receipts) {
+		milestoneID := iotago.MilestoneID(*receipts[i+1].Receipt.Transaction.Input)
+		return &milestoneID, nil
+	}
+
+	if unspentTreasuryOutput != nil {
+		milestoneID := unspentTreasuryOutput.MilestoneID
+		return &milestoneID, nil
+	}
+
+	return nil, nil
+}
+
+// auditMigratedOutputs checks that the output IDs of the migrated funds are unique
+// and that the stored outputs match the receipt entries.
+func auditMigratedOutputs(utxoManager *utxo.Manager, result *AuditResult, rt *utxo.ReceiptTuple, milestoneID iotago.MilestoneID, seenOutputIDs map[iotago.OutputID]milestone.Index) error {
+
+	for entryIndex, entry := range rt.Receipt.Funds {
+		outputID := utxo.OutputIDForMigratedFunds(milestoneID, uint16(entryIndex))
+
+		if msIndex, seen := seenOutputIDs[outputID]; seen {
+			result.addIssue(AuditIssueOutputIDCollision, rt, "output ID %s of entry %d was already used by the receipt in milestone %d", outputID.ToHex(), entryIndex, msIndex)
+			continue
+		}
+		seenOutputIDs[outputID] = rt.MilestoneIndex
+
+		output, err := utxoManager.ReadOutputByOutputIDWithoutLocking(&outputID)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the output was spent and pruned
+				continue
+			}
+			return err
+		}
+
+		ownerMatches := false
+		if conditions := output.Output().UnlockConditions().MustSet(); conditions.Address() != nil {
+			ownerMatches = conditions.Address().Address.Equal(entry.Address)
+		}
+
+		if output.OutputType() != iotago.OutputBasic || output.Deposit() != entry.Deposit || !ownerMatches || !bytes.Equal(output.MessageID(), hornet.NullMessageID()) {
+			result.addIssue(AuditIssueOutputMismatch, rt, "output %s does not match entry %d of the receipt", outputID.ToHex(), entryIndex)
+		}
+	}
+
+	return nil
+}
+
+// auditReceiptBackups compares the stored receipts with their backups in the backup folder.
+func auditReceiptBackups(result *AuditResult, receipts []*utxo.ReceiptTuple, backupFolder string) error {
+
+	backupFiles := make(map[string]struct{})
+	dirEntries, err := os.ReadDir(backupFolder)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "unable to read receipt backups")
+	}
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			backupFiles[dirEntry.Name()] = struct{}{}
+		}
+	}
+
+	for _, rt := range receipts {
+		fileName := receiptBackupFileName(rt)
+		if _, exists := backupFiles[fileName]; !exists {
+			result.addIssue(AuditIssueBackupMissing, rt, "no backup found (%s)", fileName)
+			continue
+		}
+		delete(backupFiles, fileName)
+
+		backupJSON, err := os.ReadFile(filepath.Join(backupFolder, fileName))
+		if err != nil {
+			return errors.Wrapf(err, "unable to read receipt backup %s", fileName)
+		}
+
+		backupReceipt := &iotago.ReceiptMilestoneOpt{}
+		if err := json.Unmarshal(backupJSON, backupReceipt); err != nil {
+			result.addIssue(AuditIssueBackupMismatch, rt, "unable to parse backup %s: %s", fileName, err)
+			continue
+		}
+
+		backupBytes, err := backupReceipt.Serialize(serializer.DeSeriModeNoValidation, nil)
+		if err != nil {
+			result.addIssue(AuditIssueBackupMismatch, rt, "unable to serialize backup %s: %s", fileName, err)
+			continue
+		}
+
+		receiptBytes, err := rt.Receipt.Serialize(serializer.DeSeriModeNoValidation, nil)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(backupBytes, receiptBytes) {
+			result.addIssue(AuditIssueBackupMismatch, rt, "backup %s does not match the stored receipt", fileName)
+		}
+	}
+
+	result.BackupsChecked = true
+	for fileName := range backupFiles {
+		result.BackupsWithoutReceipt = append(result.BackupsWithoutReceipt, fileName)
+	}
+	sort.Strings(result.BackupsWithoutReceipt)
+
+	return nil
+}
+
+// AuditReceipts walks all stored receipts and treasury outputs and reconciles them.
+// It verifies that the treasury deltas equal the migrated funds of each receipt, that the treasury transactions
+// form a chain, that the output IDs of the migrated funds don't collide and that no legacy bundle was migrated twice.
+// If a backup folder is given, the stored receipts are compared with their backups.
+func AuditReceipts(utxoManager *utxo.Manager, backupFolder string) (*AuditResult, error) {
+
+	utxoManager.ReadLockLedger()
+	defer utxoManager.ReadUnlockLedger()
+
+	result := &AuditResult{
+		Receipts:              make([]*ReceiptAudit, 0),
+		BackupsWithoutReceipt: make([]string, 0),
+		Issues:                make([]*AuditIssue, 0),
+	}
+
+	ledgerIndex, err := utxoManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read ledger index")
+	}
+	result.LedgerIndex = ledgerIndex
+
+	var receipts []*utxo.ReceiptTuple
+	if err := utxoManager.ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+		receipts = append(receipts, rt)
+		return true
+	}, utxo.ReadLockLedger(false)); err != nil {
+		return nil, errors.Wrap(err, "unable to read receipts")
+	}
+
+	// the receipts are stored by their migrated at index, but the treasury transactions are chained by milestone index
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].MilestoneIndex < receipts[j].MilestoneIndex
+	})
+
+	treasuryOutputs := make(map[iotago.MilestoneID]*utxo.TreasuryOutput)
+	var unspentTreasuryOutput *utxo.TreasuryOutput
+	unspentTreasuryOutputsCount := 0
+	if err := utxoManager.ForEachTreasuryOutput(func(output *utxo.TreasuryOutput) bool {
+		treasuryOutputs[output.MilestoneID] = output
+		if !output.Spent {
+			unspentTreasuryOutput = output
+			unspentTreasuryOutputsCount++
+		}
+		return true
+	}, utxo.ReadLockLedger(false)); err != nil {
+		return nil, errors.Wrap(err, "unable to read treasury outputs")
+	}
+
+	switch unspentTreasuryOutputsCount {
+	case 0:
+		result.addIssue(AuditIssueUnspentTreasury, nil, "no unspent treasury output exists")
+	case 1:
+		result.UnspentTreasuryAmount = unspentTreasuryOutput.Amount
+	default:
+		result.addIssue(AuditIssueUnspentTreasury, nil, "%d unspent treasury outputs exist", unspentTreasuryOutputsCount)
+		unspentTreasuryOutput = nil
+	}
+
+	seenOutputIDs := make(map[iotago.OutputID]milestone.Index)
+	seenTailTransactions := make(map[iotago.LegacyTailTransactionHash]milestone.Index)
+	var previousMilestoneID *iotago.MilestoneID
+
+	for i, rt := range receipts {
+		receiptAudit := &ReceiptAudit{
+			MilestoneIndex:       rt.MilestoneIndex,
+			MigratedAt:           rt.Receipt.MigratedAt,
+			Final:                rt.Receipt.Final,
+			Entries:              len(rt.Receipt.Funds),
+			TreasuryOutputAmount: rt.Receipt.Transaction.Output.Amount,
+		}
+		result.Receipts = append(result.Receipts, receiptAudit)
+
+		for _, entry := range rt.Receipt.Funds {
+			if receiptAudit.MigratedFunds > math.MaxUint64-entry.Deposit {
+				return nil, fmt.Errorf("migrated funds of the receipt in milestone %d overflow", rt.MilestoneIndex)
+			}
+			receiptAudit.MigratedFunds += entry.Deposit
+
+			if msIndex, seen := seenTailTransactions[entry.TailTransactionHash]; seen {
+				result.addIssue(AuditIssueDuplicateMigration, rt, "tail transaction %s was already migrated by the receipt in milestone %d", iotago.EncodeHex(entry.TailTransactionHash[:]), msIndex)
+				continue
+			}
+			seenTailTransactions[entry.TailTransactionHash] = rt.MilestoneIndex
+		}
+		result.TotalMigratedFunds += receiptAudit.MigratedFunds
+
+		inputMilestoneID := iotago.MilestoneID(*rt.Receipt.Transaction.Input)
+		if previousMilestoneID != nil && inputMilestoneID != *previousMilestoneID {
+			result.addIssue(AuditIssueTreasuryChain, rt, "treasury input %s is not the treasury output %s of the previous receipt", iotago.EncodeHex(inputMilestoneID[:]), iotago.EncodeHex(previousMilestoneID[:]))
+		}
+
+		inputOutput, exists := treasuryOutputs[inputMilestoneID]
+		switch {
+		case !exists:
+			receiptAudit.TreasuryInputPruned = true
+		case !inputOutput.Spent:
+			result.addIssue(AuditIssueTreasuryOutput, rt, "treasury input %s is not marked as spent", iotago.EncodeHex(inputMilestoneID[:]))
+			fallthrough
+		default:
+			receiptAudit.TreasuryInputAmount = inputOutput.Amount
+			if inputOutput.Amount < receiptAudit.TreasuryOutputAmount || inputOutput.Amount-receiptAudit.TreasuryOutputAmount != receiptAudit.MigratedFunds {
+				result.addIssue(AuditIssueTreasuryDelta, rt, "treasury delta %d-%d does not equal the migrated funds %d", inputOutput.Amount, receiptAudit.TreasuryOutputAmount, receiptAudit.MigratedFunds)
+			}
+		}
+
+		milestoneID, err := receiptMilestoneID(utxoManager, receipts, i, unspentTreasuryOutput)
+		if err != nil {
+			return nil, err
+		}
+		previousMilestoneID = milestoneID
+		if milestoneID == nil {
+			continue
+		}
+		receiptAudit.MilestoneID = iotago.EncodeHex(milestoneID[:])
+
+		if treasuryOutput, exists := treasuryOutputs[*milestoneID]; exists && treasuryOutput.Amount != receiptAudit.TreasuryOutputAmount {
+			result.addIssue(AuditIssueTreasuryOutput, rt, "stored treasury output %s has amount %d instead of %d", receiptAudit.MilestoneID, treasuryOutput.Amount, receiptAudit.TreasuryOutputAmount)
+		}
+
+		if i == len(receipts)-1 && unspentTreasuryOutput != nil {
+			if unspentTreasuryOutput.MilestoneID != *milestoneID || unspentTreasuryOutput.Amount != receiptAudit.TreasuryOutputAmount {
+				result.addIssue(AuditIssueUnspentTreasury, rt, "unspent treasury output %s (%d) was not created by the last receipt", iotago.EncodeHex(unspentTreasuryOutput.MilestoneID[:]), unspentTreasuryOutput.Amount)
+			}
+		}
+
+		if err := auditMigratedOutputs(utxoManager, result, rt, *milestoneID, seenOutputIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if backupFolder != "" {
+		if err := auditReceiptBackups(result, receipts, backupFolder); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Auditor runs the receipt audit and caches its result until the ledger changes.
+// The audit holds the ledger lock while it walks all receipts, so only one audit runs at a time
+// and repeated requests at the same ledger index are served from the cache.
+type Auditor struct {
+	sync.Mutex
+	utxoManager  *utxo.Manager
+	backupFolder string
+	result       *AuditResult
+}
+
+// NewAuditor creates a new Auditor. If a backup folder is given, the receipt backups are checked as well.
+func NewAuditor(utxoManager *utxo.Manager, backupFolder string) *Auditor {
+	return &Auditor{
+		utxoManager:  utxoManager,
+		backupFolder: backupFolder,
+	}
+}
+
+// Audit returns the result of the receipt audit at the current ledger index.
+func (a *Auditor) Audit() (*AuditResult, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	ledgerIndex, err := a.utxoManager.ReadLedgerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read ledger index")
+	}
+
+	if a.result != nil && a.result.LedgerIndex == ledgerIndex {
+		return a.result, nil
+	}
+
+	result, err := AuditReceipts(a.utxoManager, a.backupFolder)
+	if err != nil {
+		return nil, err
+	}
+	a.result = result
+
+	return result, nil
+}
+
+// Audit reconciles the stored receipts and treasury outputs and, if backups are enabled, the receipt backups.
+func (rs *ReceiptService) Audit() (*AuditResult, error) {
+	return rs.auditor.Audit()
+}
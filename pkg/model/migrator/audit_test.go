@@ -0,0 +1,163 @@
+package migrator_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/model/utxo/utils"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+type auditTest struct {
+	t                     *testing.T
+	utxoManager           *utxo.Manager
+	unspentTreasuryOutput *utxo.TreasuryOutput
+	backupFolder          string
+}
+
+func newAuditTest(t *testing.T, treasuryAmount uint64) *auditTest {
+	utxoManager := utxo.New(mapdb.NewMapDB())
+
+	treasuryOutput := &utxo.TreasuryOutput{MilestoneID: utils.RandMilestoneID(), Amount: treasuryAmount}
+	require.NoError(t, utxoManager.StoreUnspentTreasuryOutput(treasuryOutput))
+
+	return &auditTest{
+		t:                     t,
+		utxoManager:           utxoManager,
+		unspentTreasuryOutput: treasuryOutput,
+		backupFolder:          t.TempDir(),
+	}
+}
+
+// applyReceipt applies a receipt that migrates the given deposits and reduces the treasury by the given amount.
+func (a *auditTest) applyReceipt(msIndex milestone.Index, migratedAt uint32, treasuryReduction uint64, deposits ...uint64) *utxo.ReceiptTuple {
+
+	funds := make(iotago.MigratedFundsEntries, len(deposits))
+	for i, deposit := range deposits {
+		entry := &iotago.MigratedFundsEntry{
+			Address: utils.RandAddress(iotago.AddressEd25519),
+			Deposit: deposit,
+		}
+		copy(entry.TailTransactionHash[:], utils.RandBytes(len(entry.TailTransactionHash)))
+		funds[i] = entry
+	}
+
+	input := iotago.TreasuryInput(a.unspentTreasuryOutput.MilestoneID)
+	receipt := &iotago.ReceiptMilestoneOpt{
+		MigratedAt: migratedAt,
+		Final:      true,
+		Funds:      funds,
+		Transaction: &iotago.TreasuryTransaction{
+			Input:  &input,
+			Output: &iotago.TreasuryOutput{Amount: a.unspentTreasuryOutput.Amount - treasuryReduction},
+		},
+	}
+
+	milestoneID := utils.RandMilestoneID()
+	outputs, err := utxo.ReceiptToOutputs(receipt, milestoneID, msIndex, 0)
+	require.NoError(a.t, err)
+
+	treasuryMutation, err := utxo.ReceiptToTreasuryMutation(receipt, a.unspentTreasuryOutput, milestoneID)
+	require.NoError(a.t, err)
+
+	rt := &utxo.ReceiptTuple{Receipt: receipt, MilestoneIndex: msIndex}
+	require.NoError(a.t, a.utxoManager.ApplyConfirmation(msIndex, outputs, nil, treasuryMutation, rt))
+	a.unspentTreasuryOutput = treasuryMutation.NewOutput
+
+	receiptJSON, err := receipt.MarshalJSON()
+	require.NoError(a.t, err)
+	require.NoError(a.t, os.WriteFile(filepath.Join(a.backupFolder, fmt.Sprintf("%d.%d.json", migratedAt, msIndex)), receiptJSON, 0666))
+
+	return rt
+}
+
+func TestAuditReceipts(t *testing.T) {
+	a := newAuditTest(t, 1_000_000)
+
+	a.applyReceipt(10, 100, 300, 100, 200)
+	a.applyReceipt(11, 101, 50, 50)
+
+	result, err := migrator.AuditReceipts(a.utxoManager, a.backupFolder)
+	require.NoError(t, err)
+	require.True(t, result.Passed(), "%v", result.Issues)
+	require.Len(t, result.Receipts, 2)
+	require.EqualValues(t, 350, result.TotalMigratedFunds)
+	require.EqualValues(t, 999_650, result.UnspentTreasuryAmount)
+	require.True(t, result.BackupsChecked)
+	require.Empty(t, result.BackupsWithoutReceipt)
+
+	require.EqualValues(t, 1_000_000, result.Receipts[0].TreasuryInputAmount)
+	require.EqualValues(t, 999_700, result.Receipts[0].TreasuryOutputAmount)
+	require.EqualValues(t, 300, result.Receipts[0].MigratedFunds)
+	require.False(t, result.Receipts[0].TreasuryInputPruned)
+}
+
+func TestAuditReceiptsTreasuryDelta(t *testing.T) {
+	a := newAuditTest(t, 1_000_000)
+
+	// the treasury is reduced by more than the migrated funds
+	a.applyReceipt(10, 100, 500, 100, 200)
+
+	result, err := migrator.AuditReceipts(a.utxoManager, "")
+	require.NoError(t, err)
+	require.False(t, result.Passed())
+	require.False(t, result.BackupsChecked)
+	require.Len(t, result.Issues, 1)
+	require.Equal(t, migrator.AuditIssueTreasuryDelta, result.Issues[0].Type)
+	require.EqualValues(t, 10, result.Issues[0].MilestoneIndex)
+}
+
+func TestAuditReceiptsBackups(t *testing.T) {
+	a := newAuditTest(t, 1_000_000)
+
+	a.applyReceipt(10, 100, 100, 100)
+	a.applyReceipt(11, 101, 100, 100)
+
+	// the backup of the first receipt is missing, the one of the second receipt was modified
+	require.NoError(t, os.Rename(filepath.Join(a.backupFolder, "100.10.json"), filepath.Join(a.backupFolder, "99.9.json")))
+
+	other := newAuditTest(t, 1_000_000)
+	other.backupFolder = a.backupFolder
+	other.applyReceipt(11, 101, 100, 100)
+
+	result, err := migrator.AuditReceipts(a.utxoManager, a.backupFolder)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 2)
+	require.Equal(t, migrator.AuditIssueBackupMissing, result.Issues[0].Type)
+	require.Equal(t, migrator.AuditIssueBackupMismatch, result.Issues[1].Type)
+	require.Equal(t, []string{"99.9.json"}, result.BackupsWithoutReceipt)
+}
+
+func TestAuditor(t *testing.T) {
+	a := newAuditTest(t, 1_000_000)
+
+	a.applyReceipt(10, 100, 300, 100, 200)
+
+	auditor := migrator.NewAuditor(a.utxoManager, "")
+
+	result, err := auditor.Audit()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, result.LedgerIndex)
+	require.Len(t, result.Receipts, 1)
+
+	// the result is cached as long as the ledger index doesn't change
+	cachedResult, err := auditor.Audit()
+	require.NoError(t, err)
+	require.Same(t, result, cachedResult)
+
+	a.applyReceipt(11, 101, 50, 50)
+
+	result, err = auditor.Audit()
+	require.NoError(t, err)
+	require.NotSame(t, cachedResult, result)
+	require.EqualValues(t, 11, result.LedgerIndex)
+	require.Len(t, result.Receipts, 2)
+}
@@ -0,0 +1,163 @@
+package migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+var (
+	// ErrConfirmationNotFound is returned when the white-flag confirmation of a milestone is not available.
+	ErrConfirmationNotFound = errors.New("white-flag confirmation not found")
+	// ErrInvalidLegacyArchive is returned when a legacy archive is malformed or incomplete.
+	ErrInvalidLegacyArchive = errors.New("invalid legacy archive")
+)
+
+// ArchivedConfirmation is the white-flag confirmation of a legacy milestone within a LegacyArchive.
+type ArchivedConfirmation struct {
+	// The index of the legacy milestone.
+	MilestoneIndex uint32 `json:"milestoneIndex"`
+	// The trytes of the milestone bundle.
+	MilestoneBundle []trinary.Trytes `json:"milestoneBundle"`
+	// The included bundles of the white-flag confirmation in their DFS order.
+	IncludedBundles [][]trinary.Trytes `json:"includedBundles"`
+}
+
+// LegacyArchive contains the white-flag confirmations of a continuous range of legacy milestones.
+// The archive itself does not need to be trusted, since every confirmation contains the milestone bundle
+// signed by the legacy coordinator, which is verified by the Validator.
+type LegacyArchive struct {
+	// The index of the first archived milestone.
+	StartIndex uint32 `json:"startIndex"`
+	// The index of the last archived milestone.
+	EndIndex uint32 `json:"endIndex"`
+	// The white-flag confirmations of all milestones from StartIndex to EndIndex.
+	Confirmations []*ArchivedConfirmation `json:"confirmations"`
+}
+
+// verify checks that the archive contains the confirmations of all milestones in its range.
+func (a *LegacyArchive) verify() error {
+	if a.StartIndex == 0 || a.EndIndex < a.StartIndex {
+		return fmt.Errorf("%w: invalid milestone range %d-%d", ErrInvalidLegacyArchive, a.StartIndex, a.EndIndex)
+	}
+
+	if uint64(len(a.Confirmations)) != uint64(a.EndIndex-a.StartIndex)+1 {
+		return fmt.Errorf("%w: %d confirmations for milestone range %d-%d", ErrInvalidLegacyArchive, len(a.Confirmations), a.StartIndex, a.EndIndex)
+	}
+
+	for i, confirmation := range a.Confirmations {
+		if confirmation == nil || confirmation.MilestoneIndex != a.StartIndex+uint32(i) {
+			return fmt.Errorf("%w: confirmation of milestone %d missing", ErrInvalidLegacyArchive, a.StartIndex+uint32(i))
+		}
+		if len(confirmation.MilestoneBundle) == 0 {
+			return fmt.Errorf("%w: milestone bundle of milestone %d missing", ErrInvalidLegacyArchive, confirmation.MilestoneIndex)
+		}
+	}
+
+	return nil
+}
+
+// ExportLegacyArchive fetches the white-flag confirmations from startIndex to endIndex from the given LegacyAPI.
+func ExportLegacyArchive(legacyAPI LegacyAPI, startIndex uint32, endIndex uint32) (*LegacyArchive, error) {
+	archive := &LegacyArchive{
+		StartIndex:    startIndex,
+		EndIndex:      endIndex,
+		Confirmations: make([]*ArchivedConfirmation, 0),
+	}
+
+	for msIndex := startIndex; msIndex <= endIndex && msIndex >= startIndex; msIndex++ {
+		confirmation, err := legacyAPI.GetWhiteFlagConfirmation(msIndex)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query white-flag confirmation of milestone %d: %w", msIndex, err)
+		}
+
+		archive.Confirmations = append(archive.Confirmations, &ArchivedConfirmation{
+			MilestoneIndex:  msIndex,
+			MilestoneBundle: confirmation.MilestoneBundle,
+			IncludedBundles: confirmation.IncludedBundles,
+		})
+	}
+
+	if err := archive.verify(); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// ReadLegacyArchive reads a legacy archive from the given file and checks that it is complete.
+func ReadLegacyArchive(filePath string) (*LegacyArchive, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read legacy archive: %w", err)
+	}
+
+	archive := &LegacyArchive{}
+	if err := json.Unmarshal(data, archive); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLegacyArchive, err)
+	}
+
+	if err := archive.verify(); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// WriteLegacyArchive writes the legacy archive to the given file.
+func WriteLegacyArchive(filePath string, archive *LegacyArchive) error {
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filePath, data)
+}
+
+// writeFileAtomic writes the data via a temporary file, so that the file is either written completely or not at all.
+func writeFileAtomic(filePath string, data []byte) error {
+	filePathTmp := filePath + "_tmp"
+
+	if err := os.WriteFile(filePathTmp, data, 0666); err != nil {
+		return err
+	}
+
+	return os.Rename(filePathTmp, filePath)
+}
+
+// ArchiveAPI is a LegacyAPI that serves the white-flag confirmations of a LegacyArchive,
+// so receipts can be validated without access to a legacy node.
+type ArchiveAPI struct {
+	archive *LegacyArchive
+}
+
+// NewArchiveAPI creates a new ArchiveAPI.
+func NewArchiveAPI(archive *LegacyArchive) *ArchiveAPI {
+	return &ArchiveAPI{archive: archive}
+}
+
+// GetNodeInfo returns the last archived milestone as the latest milestone.
+func (a *ArchiveAPI) GetNodeInfo() (*api.GetNodeInfoResponse, error) {
+	return &api.GetNodeInfoResponse{
+		LatestMilestoneIndex:               int64(a.archive.EndIndex),
+		LatestSolidSubtangleMilestoneIndex: int64(a.archive.EndIndex),
+	}, nil
+}
+
+// GetWhiteFlagConfirmation returns the archived white-flag confirmation of the given milestone.
+func (a *ArchiveAPI) GetWhiteFlagConfirmation(milestoneIndex uint32) (*api.WhiteFlagConfirmation, error) {
+	if milestoneIndex < a.archive.StartIndex || milestoneIndex > a.archive.EndIndex {
+		return nil, fmt.Errorf("%w: milestone %d is not within the archived range %d-%d", ErrConfirmationNotFound, milestoneIndex, a.archive.StartIndex, a.archive.EndIndex)
+	}
+
+	confirmation := a.archive.Confirmations[milestoneIndex-a.archive.StartIndex]
+	return &api.WhiteFlagConfirmation{
+		MilestoneBundle: confirmation.MilestoneBundle,
+		IncludedBundles: confirmation.IncludedBundles,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package migrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/iotaledger/iota.go/api"
+)
+
+func TestLegacyArchive(t *testing.T) {
+	archive, err := migrator.ExportLegacyArchive(&mockAPI{}, 1, validatorTests.latestMilestoneIndex)
+	require.NoError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "legacy_archive.json")
+	require.NoError(t, migrator.WriteLegacyArchive(filePath, archive))
+
+	archive, err = migrator.ReadLegacyArchive(filePath)
+	require.NoError(t, err)
+
+	v := migrator.NewValidator(migrator.NewArchiveAPI(archive), validatorTests.coordinatorAddress, validatorTests.coordinatorMerkleTreeDepth)
+
+	for msIndex := 1; msIndex < len(validatorTests.confirmations); msIndex++ {
+		entries, err := v.QueryMigratedFunds(uint32(msIndex))
+		require.NoError(t, err)
+		requireEntriesEqual(t, validatorTests.confirmations[msIndex].IncludedBundles, entries)
+	}
+
+	// milestones outside of the archived range are not available
+	_, err = v.QueryMigratedFunds(validatorTests.latestMilestoneIndex + 1)
+	require.True(t, errors.Is(err, migrator.ErrConfirmationNotFound))
+
+	// the last archived milestone is the latest one
+	msIndex, _, err := v.QueryNextMigratedFunds(1000)
+	require.NoError(t, err)
+	require.EqualValues(t, validatorTests.latestMilestoneIndex, msIndex)
+}
+
+func TestLegacyArchiveIncomplete(t *testing.T) {
+	archive, err := migrator.ExportLegacyArchive(&mockAPI{}, 1, validatorTests.latestMilestoneIndex)
+	require.NoError(t, err)
+
+	// remove the confirmation of a milestone within the range
+	archive.Confirmations = append(archive.Confirmations[:1], archive.Confirmations[2:]...)
+
+	filePath := filepath.Join(t.TempDir(), "legacy_archive.json")
+	require.NoError(t, migrator.WriteLegacyArchive(filePath, archive))
+
+	_, err = migrator.ReadLegacyArchive(filePath)
+	require.True(t, errors.Is(err, migrator.ErrInvalidLegacyArchive))
+
+	// the confirmation of milestone 0 contains no milestone bundle
+	_, err = migrator.ExportLegacyArchive(&mockAPI{}, 0, validatorTests.latestMilestoneIndex)
+	require.True(t, errors.Is(err, migrator.ErrInvalidLegacyArchive))
+}
+
+// failingAPI is a LegacyAPI that can't be reached.
+type failingAPI struct{}
+
+func (failingAPI) GetNodeInfo() (*api.GetNodeInfoResponse, error) {
+	return nil, errInvalidIndex
+}
+
+func (failingAPI) GetWhiteFlagConfirmation(_ uint32) (*api.WhiteFlagConfirmation, error) {
+	return nil, errInvalidIndex
+}
+
+// tamperingAPI is a LegacyAPI that returns confirmations without the included bundles.
+type tamperingAPI struct{}
+
+func (tamperingAPI) GetNodeInfo() (*api.GetNodeInfoResponse, error) {
+	return mockAPI{}.GetNodeInfo()
+}
+
+func (tamperingAPI) GetWhiteFlagConfirmation(milestoneIndex uint32) (*api.WhiteFlagConfirmation, error) {
+	confirmation, err := mockAPI{}.GetWhiteFlagConfirmation(milestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &api.WhiteFlagConfirmation{MilestoneBundle: confirmation.MilestoneBundle}, nil
+}
+
+func TestCachingAPI(t *testing.T) {
+	cacheDirectory := t.TempDir()
+
+	cachingAPI, err := migrator.NewCachingAPI(&mockAPI{}, cacheDirectory)
+	require.NoError(t, err)
+
+	v := migrator.NewValidator(cachingAPI, validatorTests.coordinatorAddress, validatorTests.coordinatorMerkleTreeDepth)
+	for msIndex := 1; msIndex < len(validatorTests.confirmations); msIndex++ {
+		_, err := v.QueryMigratedFunds(uint32(msIndex))
+		require.NoError(t, err)
+	}
+
+	files, err := os.ReadDir(cacheDirectory)
+	require.NoError(t, err)
+	require.Len(t, files, len(validatorTests.confirmations)-1)
+
+	// the cached confirmations are served even if the legacy node can't be reached anymore
+	cachingAPI, err = migrator.NewCachingAPI(&failingAPI{}, cacheDirectory)
+	require.NoError(t, err)
+
+	v = migrator.NewValidator(cachingAPI, validatorTests.coordinatorAddress, validatorTests.coordinatorMerkleTreeDepth)
+	for msIndex := 1; msIndex < len(validatorTests.confirmations); msIndex++ {
+		entries, err := v.QueryMigratedFunds(uint32(msIndex))
+		require.NoError(t, err)
+		requireEntriesEqual(t, validatorTests.confirmations[msIndex].IncludedBundles, entries)
+	}
+
+	_, err = v.QueryMigratedFunds(validatorTests.latestMilestoneIndex + 1)
+	require.True(t, errors.Is(err, errInvalidIndex))
+
+	// invalid confirmations are not cached, so they can be validated again once the legacy node answers correctly
+	cacheDirectory = t.TempDir()
+
+	cachingAPI, err = migrator.NewCachingAPI(&tamperingAPI{}, cacheDirectory)
+	require.NoError(t, err)
+
+	// milestone 1 contains a migration bundle
+	msIndex := uint32(1)
+	v = migrator.NewValidator(cachingAPI, validatorTests.coordinatorAddress, validatorTests.coordinatorMerkleTreeDepth)
+	_, err = v.QueryMigratedFunds(msIndex)
+	require.Error(t, err)
+
+	files, err = os.ReadDir(cacheDirectory)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	cachingAPI, err = migrator.NewCachingAPI(&mockAPI{}, cacheDirectory)
+	require.NoError(t, err)
+
+	v = migrator.NewValidator(cachingAPI, validatorTests.coordinatorAddress, validatorTests.coordinatorMerkleTreeDepth)
+	entries, err := v.QueryMigratedFunds(msIndex)
+	require.NoError(t, err)
+	requireEntriesEqual(t, validatorTests.confirmations[msIndex].IncludedBundles, entries)
+}
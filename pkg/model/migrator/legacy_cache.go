@@ -0,0 +1,92 @@
+package migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/iotaledger/iota.go/api"
+)
+
+const (
+	cachedConfirmationFilePattern = "%d.json"
+)
+
+// CachingAPI is a LegacyAPI that persists the white-flag confirmations fetched from another LegacyAPI
+// in a local directory, so they are available even if the legacy node can't be reached anymore.
+// The confirmations of legacy milestones never change, so cached confirmations never expire.
+type CachingAPI struct {
+	api       LegacyAPI
+	directory string
+}
+
+// NewCachingAPI creates a new CachingAPI that caches the confirmations of the given LegacyAPI in the given directory.
+func NewCachingAPI(legacyAPI LegacyAPI, directory string) (*CachingAPI, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory: %w", err)
+	}
+
+	return &CachingAPI{
+		api:       legacyAPI,
+		directory: directory,
+	}, nil
+}
+
+// GetNodeInfo returns the node info of the underlying LegacyAPI.
+func (c *CachingAPI) GetNodeInfo() (*api.GetNodeInfoResponse, error) {
+	return c.api.GetNodeInfo()
+}
+
+func (c *CachingAPI) filePath(milestoneIndex uint32) string {
+	return filepath.Join(c.directory, fmt.Sprintf(cachedConfirmationFilePattern, milestoneIndex))
+}
+
+// GetWhiteFlagConfirmation returns the cached white-flag confirmation of the given milestone,
+// or fetches it from the underlying LegacyAPI.
+// Fetched confirmations are not cached until they passed the validation, see StoreWhiteFlagConfirmation.
+func (c *CachingAPI) GetWhiteFlagConfirmation(milestoneIndex uint32) (*api.WhiteFlagConfirmation, error) {
+
+	data, err := os.ReadFile(c.filePath(milestoneIndex))
+	switch {
+	case err == nil:
+		cached := &ArchivedConfirmation{}
+		if err := json.Unmarshal(data, cached); err != nil {
+			return nil, fmt.Errorf("unable to parse cached confirmation of milestone %d: %w", milestoneIndex, err)
+		}
+		return &api.WhiteFlagConfirmation{
+			MilestoneBundle: cached.MilestoneBundle,
+			IncludedBundles: cached.IncludedBundles,
+		}, nil
+
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("unable to read cached confirmation of milestone %d: %w", milestoneIndex, err)
+	}
+
+	return c.api.GetWhiteFlagConfirmation(milestoneIndex)
+}
+
+// StoreWhiteFlagConfirmation adds the validated white-flag confirmation of the given milestone to the cache.
+// Confirmations that are already cached are not written again.
+func (c *CachingAPI) StoreWhiteFlagConfirmation(milestoneIndex uint32, confirmation *api.WhiteFlagConfirmation) error {
+
+	filePath := c.filePath(milestoneIndex)
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(&ArchivedConfirmation{
+		MilestoneIndex:  milestoneIndex,
+		MilestoneBundle: confirmation.MilestoneBundle,
+		IncludedBundles: confirmation.IncludedBundles,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return fmt.Errorf("unable to cache confirmation of milestone %d: %w", milestoneIndex, err)
+	}
+
+	return nil
+}
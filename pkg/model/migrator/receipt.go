@@ -43,10 +43,17 @@ type ReceiptService struct {
 	backupFolder     string
 	validator        *Validator
 	utxoManager      *utxo.Manager
+	auditor          *Auditor
 }
 
 // NewReceiptService creates a new ReceiptService.
 func NewReceiptService(validator *Validator, utxoManager *utxo.Manager, validationEnabled bool, backupEnabled bool, ignoreSoftErrors bool, backupFolder string) *ReceiptService {
+	auditBackupFolder := ""
+	if backupEnabled {
+		// the receipt backups are checked if they are enabled
+		auditBackupFolder = backupFolder
+	}
+
 	return &ReceiptService{
 		ValidationEnabled: validationEnabled,
 		IgnoreSoftErrors:  ignoreSoftErrors,
@@ -54,6 +61,7 @@ func NewReceiptService(validator *Validator, utxoManager *utxo.Manager, validati
 		utxoManager:       utxoManager,
 		validator:         validator,
 		backupFolder:      backupFolder,
+		auditor:           NewAuditor(utxoManager, auditBackupFolder),
 	}
 }
 
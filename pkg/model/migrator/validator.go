@@ -37,6 +37,13 @@ type LegacyAPI interface {
 	GetWhiteFlagConfirmation(milestoneIndex uint32) (*api.WhiteFlagConfirmation, error)
 }
 
+// ConfirmationCache is implemented by LegacyAPIs that cache the white-flag confirmations.
+// The Validator only hands over confirmations that passed the validation,
+// so that an invalid response of a legacy node is never cached.
+type ConfirmationCache interface {
+	StoreWhiteFlagConfirmation(milestoneIndex uint32, confirmation *api.WhiteFlagConfirmation) error
+}
+
 // Validator takes care of fetching and validating white-flag confirmation data from legacy nodes
 // and wrapping them into receipts.
 type Validator struct {
@@ -68,6 +75,12 @@ func (m *Validator) QueryMigratedFunds(milestoneIndex uint32) ([]*iotago.Migrate
 		return nil, common.CriticalError(fmt.Errorf("invalid confirmation data: %w", err))
 	}
 
+	if cache, ok := m.api.(ConfirmationCache); ok {
+		if err := cache.StoreWhiteFlagConfirmation(milestoneIndex, confirmation); err != nil {
+			return nil, common.SoftError(fmt.Errorf("failed to cache confirmation data: %w", err))
+		}
+	}
+
 	migrated := make([]*iotago.MigratedFundsEntry, 0, len(included))
 	for i := range included {
 		output := included[i][0]
@@ -69,6 +69,42 @@ const (
 	ConflictSemanticValidationFailed = 255
 )
 
+// String returns a human readable description of the conflict.
+func (c Conflict) String() string {
+	switch c {
+	case ConflictNone:
+		return "no conflict"
+	case ConflictInputUTXOAlreadySpent:
+		return "the referenced UTXO was already spent"
+	case ConflictInputUTXOAlreadySpentInThisMilestone:
+		return "the referenced UTXO was already spent while confirming this milestone"
+	case ConflictInputUTXONotFound:
+		return "the referenced UTXO cannot be found"
+	case ConflictInputOutputSumMismatch:
+		return "the sum of the inputs and output values does not match"
+	case ConflictInvalidSignature:
+		return "the unlock block signature is invalid"
+	case ConflictTimelockNotExpired:
+		return "the configured timelock is not yet expired"
+	case ConflictInvalidNativeTokens:
+		return "the given native tokens are invalid"
+	case ConflictReturnAmountNotFulfilled:
+		return "the return amount in the transaction is not fulfilled by the output side"
+	case ConflictInvalidInputUnlock:
+		return "an input unlock is invalid"
+	case ConflictInvalidInputsCommitment:
+		return "the inputs commitment is invalid"
+	case ConflictInvalidSender:
+		return "an output contains a sender with an ident which is not unlocked"
+	case ConflictInvalidChainStateTransition:
+		return "the chain state transition is invalid"
+	case ConflictSemanticValidationFailed:
+		return "the semantic validation failed"
+	default:
+		return fmt.Sprintf("unknown conflict (%d)", uint8(c))
+	}
+}
+
 var errorToConflictMapping = map[error]Conflict{
 	// Input validation
 	iotago.ErrMissingUTXO:             ConflictInputUTXONotFound,
@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+const (
+	// the version of the serialized milestone stats.
+	milestoneStatsVersion byte = 1
+)
+
+var (
+	// ErrMilestoneStatsUnknownVersion is returned if the serialized milestone stats have an unknown version.
+	ErrMilestoneStatsUnknownVersion = errors.New("unknown milestone stats version")
+)
+
+// MilestoneStatsDurations holds the durations of the phases of a milestone confirmation.
+// The durations are encoded as nanoseconds in JSON.
+type MilestoneStatsDurations struct {
+	// the time of the solid queue check that marked the milestone cone as solid.
+	Solidification time.Duration `json:"solidification"`
+	// the time to compute the white-flag mutations.
+	Whiteflag time.Duration `json:"whiteflag"`
+	// the time to validate the receipt and to extract the migrated funds.
+	Receipts time.Duration `json:"receipts"`
+	// the time to apply the mutations to the ledger.
+	Confirmation time.Duration `json:"confirmation"`
+	// the time to mark the messages with transactions as referenced.
+	ApplyIncludedWithTransactions time.Duration `json:"applyIncludedWithTransactions"`
+	// the time to mark the messages without transactions as referenced.
+	ApplyExcludedWithoutTransactions time.Duration `json:"applyExcludedWithoutTransactions"`
+	// the time to mark the messages with conflicting transactions as referenced.
+	ApplyExcludedWithConflictingTransactions time.Duration `json:"applyExcludedWithConflictingTransactions"`
+	// the time of the milestone confirmed callbacks.
+	OnMilestoneConfirmed time.Duration `json:"onMilestoneConfirmed"`
+	// the time to update the confirmed milestone index.
+	SetConfirmedMilestoneIndex time.Duration `json:"setConfirmedMilestoneIndex"`
+	// the time to propagate the cone root indexes to the future cone.
+	UpdateConeRootIndexes time.Duration `json:"updateConeRootIndexes"`
+	// the time of the ConfirmedMilestoneChanged event.
+	ConfirmedMilestoneChanged time.Duration `json:"confirmedMilestoneChanged"`
+	// the time of the ConfirmedMilestoneIndexChanged event.
+	ConfirmedMilestoneIndexChanged time.Duration `json:"confirmedMilestoneIndexChanged"`
+	// the time of the milestone confirmed sync event.
+	MilestoneConfirmedSyncEvent time.Duration `json:"milestoneConfirmedSyncEvent"`
+	// the time of the MilestoneConfirmed event.
+	MilestoneConfirmed time.Duration `json:"milestoneConfirmed"`
+	// the time of the LedgerUpdated event.
+	LedgerUpdated time.Duration `json:"ledgerUpdated"`
+	// the time of the TreasuryMutated event.
+	TreasuryMutated time.Duration `json:"treasuryMutated"`
+	// the total time of the confirmation (without the solidification).
+	Total time.Duration `json:"total"`
+}
+
+// all returns pointers to the durations in the order they are serialized.
+// new durations must only be appended to keep the serialization compatible.
+func (d *MilestoneStatsDurations) all() []*time.Duration {
+	return []*time.Duration{
+		&d.Solidification,
+		&d.Whiteflag,
+		&d.Receipts,
+		&d.Confirmation,
+		&d.ApplyIncludedWithTransactions,
+		&d.ApplyExcludedWithoutTransactions,
+		&d.ApplyExcludedWithConflictingTransactions,
+		&d.OnMilestoneConfirmed,
+		&d.SetConfirmedMilestoneIndex,
+		&d.UpdateConeRootIndexes,
+		&d.ConfirmedMilestoneChanged,
+		&d.ConfirmedMilestoneIndexChanged,
+		&d.MilestoneConfirmedSyncEvent,
+		&d.MilestoneConfirmed,
+		&d.LedgerUpdated,
+		&d.TreasuryMutated,
+		&d.Total,
+	}
+}
+
+// MilestoneStats holds the statistics of the confirmation of a milestone.
+type MilestoneStats struct {
+	// the index of the milestone.
+	Index milestone.Index `json:"index"`
+	// the timestamp of the milestone.
+	MilestoneTimestamp uint32 `json:"milestoneTimestamp"`
+	// the unix time in milliseconds the milestone was confirmed by the node.
+	ConfirmedAt int64 `json:"confirmedAt"`
+
+	// the amount of messages that were referenced by the milestone.
+	MessagesReferenced uint32 `json:"messagesReferenced"`
+	// the amount of referenced messages that mutated the ledger.
+	MessagesIncludedWithTransactions uint32 `json:"messagesIncludedWithTransactions"`
+	// the amount of referenced messages without a transaction.
+	MessagesExcludedWithoutTransactions uint32 `json:"messagesExcludedWithoutTransactions"`
+	// the amount of referenced messages with a conflicting transaction.
+	MessagesExcludedWithConflictingTransactions uint32 `json:"messagesExcludedWithConflictingTransactions"`
+	// the amount of referenced messages with a conflicting transaction by the reason of the conflict.
+	ConflictsByReason map[Conflict]uint32 `json:"conflictsByReason"`
+
+	// the amount of outputs that were created by the milestone (including migrated outputs).
+	OutputsCreated uint32 `json:"outputsCreated"`
+	// the amount of outputs that were consumed by the milestone.
+	OutputsConsumed uint32 `json:"outputsConsumed"`
+
+	// whether the node was synced during the confirmation.
+	// MPS, RMPS and the referenced rate are only meaningful if the node was synced.
+	Synced bool `json:"synced"`
+	// the amount of new messages per second since the last milestone.
+	MPS float64 `json:"mps"`
+	// the amount of referenced messages per second since the last milestone.
+	RMPS float64 `json:"rmps"`
+	// the rate of new messages that were referenced since the last milestone in percent.
+	ReferencedRate float64 `json:"referencedRate"`
+	// the time between the timestamps of the last and this milestone in seconds.
+	TimeSinceLastMilestone float64 `json:"timeSinceLastMilestone"`
+
+	// the durations of the phases of the confirmation.
+	Durations MilestoneStatsDurations `json:"durations"`
+}
+
+// Bytes returns the serialized milestone stats.
+func (s *MilestoneStats) Bytes() []byte {
+
+	/*
+		1 byte   version
+		4 bytes  uint32 milestoneTimestamp
+		8 bytes  int64 confirmedAt
+		4 bytes  uint32 messagesReferenced
+		4 bytes  uint32 messagesIncludedWithTransactions
+		4 bytes  uint32 messagesExcludedWithoutTransactions
+		4 bytes  uint32 messagesExcludedWithConflictingTransactions
+		1 byte   conflicts count
+		conflicts count * (1 byte conflict + 4 bytes uint32 count)
+		4 bytes  uint32 outputsCreated
+		4 bytes  uint32 outputsConsumed
+		1 byte   bool synced
+		8 bytes  float64 mps
+		8 bytes  float64 rmps
+		8 bytes  float64 referencedRate
+		8 bytes  float64 timeSinceLastMilestone
+		1 byte   durations count
+		durations count * 8 bytes int64 duration
+	*/
+
+	conflicts := make([]Conflict, 0, len(s.ConflictsByReason))
+	for conflict := range s.ConflictsByReason {
+		conflicts = append(conflicts, conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i] < conflicts[j] })
+
+	durations := s.Durations.all()
+
+	marshalUtil := marshalutil.New(76 + len(conflicts)*5 + len(durations)*8)
+
+	marshalUtil.WriteByte(milestoneStatsVersion)
+	marshalUtil.WriteUint32(s.MilestoneTimestamp)
+	marshalUtil.WriteInt64(s.ConfirmedAt)
+	marshalUtil.WriteUint32(s.MessagesReferenced)
+	marshalUtil.WriteUint32(s.MessagesIncludedWithTransactions)
+	marshalUtil.WriteUint32(s.MessagesExcludedWithoutTransactions)
+	marshalUtil.WriteUint32(s.MessagesExcludedWithConflictingTransactions)
+	marshalUtil.WriteByte(byte(len(conflicts)))
+	for _, conflict := range conflicts {
+		marshalUtil.WriteByte(byte(conflict))
+		marshalUtil.WriteUint32(s.ConflictsByReason[conflict])
+	}
+	marshalUtil.WriteUint32(s.OutputsCreated)
+	marshalUtil.WriteUint32(s.OutputsConsumed)
+	marshalUtil.WriteBool(s.Synced)
+	marshalUtil.WriteFloat64(s.MPS)
+	marshalUtil.WriteFloat64(s.RMPS)
+	marshalUtil.WriteFloat64(s.ReferencedRate)
+	marshalUtil.WriteFloat64(s.TimeSinceLastMilestone)
+	marshalUtil.WriteByte(byte(len(durations)))
+	for _, duration := range durations {
+		marshalUtil.WriteInt64(int64(*duration))
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// MilestoneStatsFromBytes parses the serialized milestone stats of the given milestone.
+func MilestoneStatsFromBytes(msIndex milestone.Index, data []byte) (*MilestoneStats, error) {
+
+	marshalUtil := marshalutil.New(data)
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != milestoneStatsVersion {
+		return nil, errors.WithMessagef(ErrMilestoneStatsUnknownVersion, "version: %d", version)
+	}
+
+	s := &MilestoneStats{
+		Index:             msIndex,
+		ConflictsByReason: make(map[Conflict]uint32),
+	}
+
+	if s.MilestoneTimestamp, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.ConfirmedAt, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.MessagesReferenced, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.MessagesIncludedWithTransactions, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.MessagesExcludedWithoutTransactions, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.MessagesExcludedWithConflictingTransactions, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+
+	conflictsCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(conflictsCount); i++ {
+		conflict, err := marshalUtil.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		count, err := marshalUtil.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		s.ConflictsByReason[Conflict(conflict)] = count
+	}
+
+	if s.OutputsCreated, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.OutputsConsumed, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, err
+	}
+	if s.Synced, err = marshalUtil.ReadBool(); err != nil {
+		return nil, err
+	}
+	if s.MPS, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if s.RMPS, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if s.ReferencedRate, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if s.TimeSinceLastMilestone, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+
+	durationsCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	// durations that were added in later versions of the node are ignored,
+	// missing durations stay zero.
+	durations := s.Durations.all()
+	for i := 0; i < int(durationsCount); i++ {
+		duration, err := marshalUtil.ReadInt64()
+		if err != nil {
+			return nil, err
+		}
+		if i < len(durations) {
+			*durations[i] = time.Duration(duration)
+		}
+	}
+
+	return s, nil
+}
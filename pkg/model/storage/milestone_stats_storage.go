@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+func (s *Storage) configureMilestoneStatsStore(store kvstore.KVStore) error {
+	milestoneStatsStore, err := store.WithRealm([]byte{common.StorePrefixMilestoneStats})
+	if err != nil {
+		return err
+	}
+
+	s.milestoneStatsStore = milestoneStatsStore
+	return nil
+}
+
+// StoreMilestoneStats stores the confirmation statistics of a milestone.
+func (s *Storage) StoreMilestoneStats(stats *MilestoneStats) error {
+	if err := s.milestoneStatsStore.Set(databaseKeyForMilestoneIndex(stats.Index), stats.Bytes()); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store milestone stats")
+	}
+
+	return nil
+}
+
+// MilestoneStats returns the confirmation statistics of the given milestone.
+// It returns nil if no statistics exist for the milestone.
+func (s *Storage) MilestoneStats(msIndex milestone.Index) (*MilestoneStats, error) {
+	data, err := s.milestoneStatsStore.Get(databaseKeyForMilestoneIndex(msIndex))
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.Wrap(NewDatabaseError(err), "failed to retrieve milestone stats")
+		}
+		return nil, nil
+	}
+
+	stats, err := MilestoneStatsFromBytes(msIndex, data)
+	if err != nil {
+		return nil, errors.Wrap(NewDatabaseError(err), "failed to deserialize milestone stats")
+	}
+
+	return stats, nil
+}
+
+// DeleteMilestoneStats deletes the confirmation statistics of the given milestone.
+func (s *Storage) DeleteMilestoneStats(msIndex milestone.Index) error {
+	if err := s.milestoneStatsStore.Delete(databaseKeyForMilestoneIndex(msIndex)); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete milestone stats")
+	}
+
+	return nil
+}
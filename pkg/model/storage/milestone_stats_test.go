@@ -0,0 +1,53 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/storage"
+)
+
+func TestMilestoneStatsSerialization(t *testing.T) {
+	stats := &storage.MilestoneStats{
+		Index:                               42,
+		MilestoneTimestamp:                  1650000000,
+		ConfirmedAt:                         1650000001234,
+		MessagesReferenced:                  10,
+		MessagesIncludedWithTransactions:    5,
+		MessagesExcludedWithoutTransactions: 2,
+		MessagesExcludedWithConflictingTransactions: 3,
+		ConflictsByReason: map[storage.Conflict]uint32{
+			storage.ConflictInputUTXONotFound:     2,
+			storage.ConflictInvalidSignature:      1,
+			storage.ConflictInputUTXOAlreadySpent: 0,
+		},
+		OutputsCreated:         7,
+		OutputsConsumed:        4,
+		Synced:                 true,
+		MPS:                    12.5,
+		RMPS:                   11.25,
+		ReferencedRate:         90,
+		TimeSinceLastMilestone: 10,
+		Durations: storage.MilestoneStatsDurations{
+			Solidification: 3 * time.Millisecond,
+			Whiteflag:      5 * time.Millisecond,
+			Total:          20 * time.Millisecond,
+		},
+	}
+
+	restored, err := storage.MilestoneStatsFromBytes(42, stats.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, stats, restored)
+
+	// stats of a newer version can't be parsed
+	data := stats.Bytes()
+	data[0]++
+	_, err = storage.MilestoneStatsFromBytes(42, data)
+	require.ErrorIs(t, err, storage.ErrMilestoneStatsUnknownVersion)
+
+	// truncated stats are rejected
+	_, err = storage.MilestoneStatsFromBytes(42, stats.Bytes()[:20])
+	require.Error(t, err)
+}
@@ -533,9 +533,16 @@ func (s *Storage) StoreMilestoneIfAbsent(milestonePayload *iotago.Milestone, mes
 	return cachedMilestone, newlyAdded
 }
 
-// DeleteMilestone deletes the milestone in the cache/persistence layer.
+// DeleteMilestone deletes the milestone, its confirmation statistics, its white-flag messages
+// and its transaction messages in the cache/persistence layer.
 // +-0
 func (s *Storage) DeleteMilestone(milestoneIndex milestone.Index) {
+	// the stats, white-flag messages and transaction messages are deleted even if the milestone doesn't exist anymore,
+	// otherwise they would be kept forever if the node crashed during pruning.
+	_ = s.DeleteMilestoneStats(milestoneIndex)
+	_ = s.DeleteWhiteFlagMessages(milestoneIndex)
+	_ = s.DeleteTransactionMessages(milestoneIndex)
+
 	cachedMilestoneIdx := s.cachedMilestoneIndexOrNil(milestoneIndex) // milestone index +1
 	if cachedMilestoneIdx == nil {
 		return
@@ -88,7 +88,10 @@ type Storage struct {
 	healthTrackers []*StoreHealthTracker
 
 	// kv storages
-	snapshotStore kvstore.KVStore
+	snapshotStore            kvstore.KVStore
+	milestoneStatsStore      kvstore.KVStore
+	whiteFlagMessagesStore   kvstore.KVStore
+	transactionMessagesStore kvstore.KVStore
 
 	// object storages
 	childrenStorage             *objectstorage.ObjectStorage
@@ -380,6 +383,18 @@ func (s *Storage) configureStorages(tangleStore kvstore.KVStore, cachesProfile .
 		return err
 	}
 
+	if err := s.configureMilestoneStatsStore(tangleStore); err != nil {
+		return err
+	}
+
+	if err := s.configureWhiteFlagMessagesStore(tangleStore); err != nil {
+		return err
+	}
+
+	if err := s.configureTransactionMessagesStore(tangleStore); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/marshalutil"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// the version of the serialized transaction messages.
+	transactionMessageVersion byte = 1
+
+	// key prefix of the transaction messages by transaction ID.
+	transactionMessagesKeyPrefixTransaction byte = 0
+	// key prefix of the transaction messages by referencing milestone, used for pruning.
+	transactionMessagesKeyPrefixMilestone byte = 1
+)
+
+var (
+	// ErrTransactionMessageUnknownVersion is returned if a serialized transaction message has an unknown version.
+	ErrTransactionMessageUnknownVersion = errors.New("unknown transaction message version")
+)
+
+// DoubleSpend describes which transaction won a double spend against a conflicting transaction.
+type DoubleSpend struct {
+	// the input that was already spent.
+	InputID iotago.OutputID
+	// the transaction that spent the input.
+	WinningTransactionID iotago.TransactionID
+	// the message that contained the winning transaction.
+	// it is nil if the message couldn't be determined anymore at the time of the confirmation.
+	WinningMessageID hornet.MessageID
+}
+
+// TransactionMessage is a message carrying a transaction that was referenced by a milestone,
+// together with the result of the white-flag confirmation of the transaction.
+type TransactionMessage struct {
+	// the ID of the transaction.
+	TransactionID iotago.TransactionID
+	// the ID of the message that carried the transaction.
+	MessageID hornet.MessageID
+	// the index of the milestone that referenced the message.
+	ReferencedByMilestoneIndex milestone.Index
+	// the reason why the transaction was excluded from the ledger, ConflictNone if it was included.
+	Conflict Conflict
+	// the winner of the double spend, only set if the conflict was a double spend.
+	DoubleSpend *DoubleSpend
+}
+
+// IsIncluded returns whether the transaction of the message was included in the ledger.
+func (t *TransactionMessage) IsIncluded() bool {
+	return t.Conflict == ConflictNone
+}
+
+func (t *TransactionMessage) key() []byte {
+	return databaseKeyForTransactionMessage(&t.TransactionID, t.MessageID)
+}
+
+// milestoneKey returns the key of the entry that links the transaction message to the referencing milestone.
+func (t *TransactionMessage) milestoneKey() []byte {
+	return byteutils.ConcatBytes(databaseKeyPrefixForMilestoneTransactionMessages(t.ReferencedByMilestoneIndex), t.TransactionID[:], t.MessageID)
+}
+
+// valueBytes returns the serialized value of the transaction message.
+func (t *TransactionMessage) valueBytes() []byte {
+
+	/*
+		1 byte   version
+		4 bytes  uint32 referenced by milestone index
+		1 byte   conflict
+		1 byte   has double spend
+			34 bytes input ID
+			32 bytes winning transaction ID
+			1 byte   has winning message ID
+				32 bytes winning message ID
+	*/
+
+	marshalUtil := marshalutil.New(7)
+
+	marshalUtil.WriteByte(transactionMessageVersion)
+	marshalUtil.WriteUint32(uint32(t.ReferencedByMilestoneIndex))
+	marshalUtil.WriteByte(byte(t.Conflict))
+	marshalUtil.WriteBool(t.DoubleSpend != nil)
+
+	if t.DoubleSpend != nil {
+		marshalUtil.WriteBytes(t.DoubleSpend.InputID[:])
+		marshalUtil.WriteBytes(t.DoubleSpend.WinningTransactionID[:])
+		marshalUtil.WriteBool(t.DoubleSpend.WinningMessageID != nil)
+		if t.DoubleSpend.WinningMessageID != nil {
+			marshalUtil.WriteBytes(t.DoubleSpend.WinningMessageID)
+		}
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// transactionMessageFromBytes parses a transaction message from its database key and value.
+func transactionMessageFromBytes(key []byte, value []byte) (*TransactionMessage, error) {
+
+	if len(key) != 1+iotago.TransactionIDLength+iotago.MessageIDLength || key[0] != transactionMessagesKeyPrefixTransaction {
+		return nil, errors.Errorf("invalid transaction message key: %s", iotago.EncodeHex(key))
+	}
+
+	transactionMessage := &TransactionMessage{
+		MessageID: hornet.MessageIDFromSlice(key[1+iotago.TransactionIDLength:]),
+	}
+	copy(transactionMessage.TransactionID[:], key[1:1+iotago.TransactionIDLength])
+
+	marshalUtil := marshalutil.New(value)
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != transactionMessageVersion {
+		return nil, errors.WithMessagef(ErrTransactionMessageUnknownVersion, "version: %d", version)
+	}
+
+	msIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	transactionMessage.ReferencedByMilestoneIndex = milestone.Index(msIndex)
+
+	conflict, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	transactionMessage.Conflict = Conflict(conflict)
+
+	hasDoubleSpend, err := marshalUtil.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+	if !hasDoubleSpend {
+		return transactionMessage, nil
+	}
+
+	doubleSpend := &DoubleSpend{}
+
+	inputIDBytes, err := marshalUtil.ReadBytes(iotago.OutputIDLength)
+	if err != nil {
+		return nil, err
+	}
+	copy(doubleSpend.InputID[:], inputIDBytes)
+
+	winningTransactionIDBytes, err := marshalUtil.ReadBytes(iotago.TransactionIDLength)
+	if err != nil {
+		return nil, err
+	}
+	copy(doubleSpend.WinningTransactionID[:], winningTransactionIDBytes)
+
+	hasWinningMessageID, err := marshalUtil.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+	if hasWinningMessageID {
+		winningMessageIDBytes, err := marshalUtil.ReadBytes(iotago.MessageIDLength)
+		if err != nil {
+			return nil, err
+		}
+		doubleSpend.WinningMessageID = hornet.MessageIDFromSlice(winningMessageIDBytes)
+	}
+
+	transactionMessage.DoubleSpend = doubleSpend
+	return transactionMessage, nil
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func databaseKeyPrefixForTransactionMessages(transactionID *iotago.TransactionID) []byte {
+	return byteutils.ConcatBytes([]byte{transactionMessagesKeyPrefixTransaction}, transactionID[:])
+}
+
+func databaseKeyForTransactionMessage(transactionID *iotago.TransactionID, messageID hornet.MessageID) []byte {
+	return byteutils.ConcatBytes(databaseKeyPrefixForTransactionMessages(transactionID), messageID)
+}
+
+func databaseKeyPrefixForMilestoneTransactionMessages(msIndex milestone.Index) []byte {
+	return byteutils.ConcatBytes([]byte{transactionMessagesKeyPrefixMilestone}, databaseKeyForMilestoneIndex(msIndex))
+}
+
+func (s *Storage) configureTransactionMessagesStore(store kvstore.KVStore) error {
+	transactionMessagesStore, err := store.WithRealm([]byte{common.StorePrefixTransactionMessages})
+	if err != nil {
+		return err
+	}
+
+	s.transactionMessagesStore = transactionMessagesStore
+	return nil
+}
+
+// StoreTransactionMessages stores the messages carrying transactions that were referenced by a milestone.
+func (s *Storage) StoreTransactionMessages(transactionMessages []*TransactionMessage) error {
+
+	mutations, err := s.transactionMessagesStore.Batched()
+	if err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store transaction messages")
+	}
+
+	for _, transactionMessage := range transactionMessages {
+		if err := mutations.Set(transactionMessage.key(), transactionMessage.valueBytes()); err != nil {
+			mutations.Cancel()
+			return errors.Wrap(NewDatabaseError(err), "failed to store transaction messages")
+		}
+		if err := mutations.Set(transactionMessage.milestoneKey(), []byte{}); err != nil {
+			mutations.Cancel()
+			return errors.Wrap(NewDatabaseError(err), "failed to store transaction messages")
+		}
+	}
+
+	if err := mutations.Commit(); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store transaction messages")
+	}
+
+	return nil
+}
+
+// TransactionMessages returns all referenced messages that carried the given transaction.
+func (s *Storage) TransactionMessages(transactionID *iotago.TransactionID) ([]*TransactionMessage, error) {
+
+	var transactionMessages []*TransactionMessage
+	var innerErr error
+	if err := s.transactionMessagesStore.Iterate(databaseKeyPrefixForTransactionMessages(transactionID), func(key kvstore.Key, value kvstore.Value) bool {
+		transactionMessage, err := transactionMessageFromBytes(key, value)
+		if err != nil {
+			innerErr = err
+			return false
+		}
+
+		transactionMessages = append(transactionMessages, transactionMessage)
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(NewDatabaseError(err), "failed to retrieve transaction messages")
+	}
+
+	if innerErr != nil {
+		return nil, errors.Wrap(NewDatabaseError(innerErr), "failed to deserialize transaction messages")
+	}
+
+	return transactionMessages, nil
+}
+
+// DeleteTransactionMessages deletes the transaction messages referenced by the given milestone.
+func (s *Storage) DeleteTransactionMessages(msIndex milestone.Index) error {
+
+	milestonePrefix := databaseKeyPrefixForMilestoneTransactionMessages(msIndex)
+
+	var keysToDelete []kvstore.Key
+	if err := s.transactionMessagesStore.IterateKeys(milestonePrefix, func(key kvstore.Key) bool {
+		keysToDelete = append(keysToDelete, byteutils.ConcatBytes([]byte{transactionMessagesKeyPrefixTransaction}, key[len(milestonePrefix):]))
+		return true
+	}); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete transaction messages")
+	}
+
+	mutations, err := s.transactionMessagesStore.Batched()
+	if err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete transaction messages")
+	}
+
+	for _, key := range keysToDelete {
+		if err := mutations.Delete(key); err != nil {
+			mutations.Cancel()
+			return errors.Wrap(NewDatabaseError(err), "failed to delete transaction messages")
+		}
+	}
+
+	if err := mutations.Commit(); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete transaction messages")
+	}
+
+	if err := s.transactionMessagesStore.DeletePrefix(milestonePrefix); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete transaction messages")
+	}
+
+	return nil
+}
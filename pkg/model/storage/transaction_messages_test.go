@@ -0,0 +1,86 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/utxo/utils"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestTransactionMessages(t *testing.T) {
+	dbStorage, err := storage.New(mapdb.NewMapDB(), mapdb.NewMapDB())
+	require.NoError(t, err)
+
+	winner := &storage.TransactionMessage{
+		TransactionID:              *utils.RandTransactionID(),
+		MessageID:                  utils.RandMessageID(),
+		ReferencedByMilestoneIndex: 10,
+		Conflict:                   storage.ConflictNone,
+	}
+
+	loser := &storage.TransactionMessage{
+		TransactionID:              *utils.RandTransactionID(),
+		MessageID:                  utils.RandMessageID(),
+		ReferencedByMilestoneIndex: 11,
+		Conflict:                   storage.ConflictInputUTXOAlreadySpent,
+		DoubleSpend: &storage.DoubleSpend{
+			InputID:              *utils.RandOutputID(),
+			WinningTransactionID: winner.TransactionID,
+			WinningMessageID:     winner.MessageID,
+		},
+	}
+
+	// the same transaction was reattached in another message, but the winner of the double spend is unknown
+	reattachment := &storage.TransactionMessage{
+		TransactionID:              loser.TransactionID,
+		MessageID:                  utils.RandMessageID(),
+		ReferencedByMilestoneIndex: 12,
+		Conflict:                   storage.ConflictInputUTXOAlreadySpent,
+		DoubleSpend: &storage.DoubleSpend{
+			InputID:              loser.DoubleSpend.InputID,
+			WinningTransactionID: winner.TransactionID,
+		},
+	}
+
+	// a transaction that was excluded for another reason
+	invalid := &storage.TransactionMessage{
+		TransactionID:              loser.TransactionID,
+		MessageID:                  utils.RandMessageID(),
+		ReferencedByMilestoneIndex: 12,
+		Conflict:                   storage.ConflictInvalidSignature,
+	}
+
+	require.NoError(t, dbStorage.StoreTransactionMessages([]*storage.TransactionMessage{winner}))
+	require.NoError(t, dbStorage.StoreTransactionMessages([]*storage.TransactionMessage{loser}))
+	require.NoError(t, dbStorage.StoreTransactionMessages([]*storage.TransactionMessage{reattachment, invalid}))
+
+	transactionMessages, err := dbStorage.TransactionMessages(&winner.TransactionID)
+	require.NoError(t, err)
+	require.Equal(t, []*storage.TransactionMessage{winner}, transactionMessages)
+	require.True(t, transactionMessages[0].IsIncluded())
+
+	transactionMessages, err = dbStorage.TransactionMessages(&loser.TransactionID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*storage.TransactionMessage{loser, reattachment, invalid}, transactionMessages)
+
+	// pruning a milestone deletes the transaction messages it referenced
+	require.NoError(t, dbStorage.DeleteTransactionMessages(12))
+
+	transactionMessages, err = dbStorage.TransactionMessages(&loser.TransactionID)
+	require.NoError(t, err)
+	require.Equal(t, []*storage.TransactionMessage{loser}, transactionMessages)
+	require.False(t, transactionMessages[0].IsIncluded())
+
+	transactionMessages, err = dbStorage.TransactionMessages(&winner.TransactionID)
+	require.NoError(t, err)
+	require.Len(t, transactionMessages, 1)
+
+	// unknown transactions have no messages
+	unknownTransactionID := *utils.RandTransactionID()
+	transactionMessages, err = dbStorage.TransactionMessages(&unknownTransactionID)
+	require.NoError(t, err)
+	require.Empty(t, transactionMessages)
+}
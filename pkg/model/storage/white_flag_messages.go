@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/marshalutil"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// the version of the serialized white-flag messages.
+	whiteFlagMessagesVersion byte = 1
+)
+
+var (
+	// ErrWhiteFlagMessagesUnknownVersion is returned if the serialized white-flag messages have an unknown version.
+	ErrWhiteFlagMessagesUnknownVersion = errors.New("unknown white-flag messages version")
+	// ErrWhiteFlagMessagesInvalid is returned if the applied messages are not part of the referenced messages.
+	ErrWhiteFlagMessagesInvalid = errors.New("applied message is not referenced")
+)
+
+// WhiteFlagMessages holds the ordered lists of the messages a milestone referenced and applied,
+// which are the leaves of the merkle trees of the milestone.
+type WhiteFlagMessages struct {
+	// the index of the milestone.
+	Index milestone.Index
+	// the messages referenced by the milestone in white-flag order.
+	Referenced hornet.MessageIDs
+	// the positions of the messages that mutated the ledger in the referenced messages, in white-flag order.
+	appliedPositions []uint32
+}
+
+// NewWhiteFlagMessages creates the white-flag messages of a milestone.
+// The applied messages need to be part of the referenced messages.
+func NewWhiteFlagMessages(msIndex milestone.Index, referenced hornet.MessageIDs, applied hornet.MessageIDs) (*WhiteFlagMessages, error) {
+
+	positions := make(map[string]uint32, len(referenced))
+	for i, messageID := range referenced {
+		positions[messageID.ToMapKey()] = uint32(i)
+	}
+
+	appliedPositions := make([]uint32, len(applied))
+	for i, messageID := range applied {
+		position, exists := positions[messageID.ToMapKey()]
+		if !exists {
+			return nil, errors.WithMessagef(ErrWhiteFlagMessagesInvalid, "message: %s", messageID.ToHex())
+		}
+		appliedPositions[i] = position
+	}
+
+	return &WhiteFlagMessages{
+		Index:            msIndex,
+		Referenced:       referenced,
+		appliedPositions: appliedPositions,
+	}, nil
+}
+
+// Applied returns the messages that mutated the ledger in white-flag order.
+func (w *WhiteFlagMessages) Applied() hornet.MessageIDs {
+	applied := make(hornet.MessageIDs, len(w.appliedPositions))
+	for i, position := range w.appliedPositions {
+		applied[i] = w.Referenced[position]
+	}
+	return applied
+}
+
+// Bytes returns the serialized white-flag messages.
+func (w *WhiteFlagMessages) Bytes() []byte {
+
+	/*
+		1 byte   version
+		4 bytes  uint32 referenced count
+		referenced count * 32 bytes message ID
+		4 bytes  uint32 applied count
+		applied count * 4 bytes uint32 position in the referenced messages
+	*/
+
+	marshalUtil := marshalutil.New(9 + len(w.Referenced)*iotago.MessageIDLength + len(w.appliedPositions)*4)
+
+	marshalUtil.WriteByte(whiteFlagMessagesVersion)
+	marshalUtil.WriteUint32(uint32(len(w.Referenced)))
+	for _, messageID := range w.Referenced {
+		marshalUtil.WriteBytes(messageID)
+	}
+	marshalUtil.WriteUint32(uint32(len(w.appliedPositions)))
+	for _, position := range w.appliedPositions {
+		marshalUtil.WriteUint32(position)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// WhiteFlagMessagesFromBytes parses the serialized white-flag messages of the given milestone.
+func WhiteFlagMessagesFromBytes(msIndex milestone.Index, data []byte) (*WhiteFlagMessages, error) {
+
+	marshalUtil := marshalutil.New(data)
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != whiteFlagMessagesVersion {
+		return nil, errors.WithMessagef(ErrWhiteFlagMessagesUnknownVersion, "version: %d", version)
+	}
+
+	referencedCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(hornet.MessageIDs, referencedCount)
+	for i := range referenced {
+		messageIDBytes, err := marshalUtil.ReadBytes(iotago.MessageIDLength)
+		if err != nil {
+			return nil, err
+		}
+		referenced[i] = hornet.MessageIDFromSlice(messageIDBytes)
+	}
+
+	appliedCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	appliedPositions := make([]uint32, appliedCount)
+	for i := range appliedPositions {
+		if appliedPositions[i], err = marshalUtil.ReadUint32(); err != nil {
+			return nil, err
+		}
+		if appliedPositions[i] >= referencedCount {
+			return nil, errors.WithMessagef(ErrWhiteFlagMessagesInvalid, "position: %d", appliedPositions[i])
+		}
+	}
+
+	return &WhiteFlagMessages{
+		Index:            msIndex,
+		Referenced:       referenced,
+		appliedPositions: appliedPositions,
+	}, nil
+}
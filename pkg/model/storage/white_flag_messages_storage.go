@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+func (s *Storage) configureWhiteFlagMessagesStore(store kvstore.KVStore) error {
+	whiteFlagMessagesStore, err := store.WithRealm([]byte{common.StorePrefixWhiteFlagMessages})
+	if err != nil {
+		return err
+	}
+
+	s.whiteFlagMessagesStore = whiteFlagMessagesStore
+	return nil
+}
+
+// StoreWhiteFlagMessages stores the ordered lists of the messages a milestone referenced and applied.
+func (s *Storage) StoreWhiteFlagMessages(whiteFlagMessages *WhiteFlagMessages) error {
+	if err := s.whiteFlagMessagesStore.Set(databaseKeyForMilestoneIndex(whiteFlagMessages.Index), whiteFlagMessages.Bytes()); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store white-flag messages")
+	}
+
+	return nil
+}
+
+// WhiteFlagMessages returns the ordered lists of the messages the given milestone referenced and applied.
+// It returns nil if the lists don't exist for the milestone.
+func (s *Storage) WhiteFlagMessages(msIndex milestone.Index) (*WhiteFlagMessages, error) {
+	data, err := s.whiteFlagMessagesStore.Get(databaseKeyForMilestoneIndex(msIndex))
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.Wrap(NewDatabaseError(err), "failed to retrieve white-flag messages")
+		}
+		return nil, nil
+	}
+
+	whiteFlagMessages, err := WhiteFlagMessagesFromBytes(msIndex, data)
+	if err != nil {
+		return nil, errors.Wrap(NewDatabaseError(err), "failed to deserialize white-flag messages")
+	}
+
+	return whiteFlagMessages, nil
+}
+
+// DeleteWhiteFlagMessages deletes the ordered lists of the messages the given milestone referenced and applied.
+func (s *Storage) DeleteWhiteFlagMessages(msIndex milestone.Index) error {
+	if err := s.whiteFlagMessagesStore.Delete(databaseKeyForMilestoneIndex(msIndex)); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete white-flag messages")
+	}
+
+	return nil
+}
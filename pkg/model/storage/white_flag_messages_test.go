@@ -0,0 +1,43 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+)
+
+func TestWhiteFlagMessagesSerialization(t *testing.T) {
+	referenced := hornet.MessageIDs{}
+	for i := byte(1); i <= 5; i++ {
+		messageID := hornet.NullMessageID()
+		messageID[0] = i
+		referenced = append(referenced, messageID)
+	}
+	applied := hornet.MessageIDs{referenced[1], referenced[3], referenced[4]}
+
+	whiteFlagMessages, err := storage.NewWhiteFlagMessages(42, referenced, applied)
+	require.NoError(t, err)
+	require.Equal(t, applied, whiteFlagMessages.Applied())
+
+	restored, err := storage.WhiteFlagMessagesFromBytes(42, whiteFlagMessages.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, whiteFlagMessages, restored)
+	require.Equal(t, applied, restored.Applied())
+
+	// applied messages need to be referenced
+	_, err = storage.NewWhiteFlagMessages(42, referenced[:2], applied)
+	require.ErrorIs(t, err, storage.ErrWhiteFlagMessagesInvalid)
+
+	// messages of a newer version can't be parsed
+	data := whiteFlagMessages.Bytes()
+	data[0]++
+	_, err = storage.WhiteFlagMessagesFromBytes(42, data)
+	require.ErrorIs(t, err, storage.ErrWhiteFlagMessagesUnknownVersion)
+
+	// truncated messages are rejected
+	_, err = storage.WhiteFlagMessagesFromBytes(42, whiteFlagMessages.Bytes()[:20])
+	require.Error(t, err)
+}
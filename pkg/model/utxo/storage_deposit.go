@@ -0,0 +1,127 @@
+package utxo
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrStorageDepositNotApplicable is returned if the storage deposit is requested for an output type that is not subject to it.
+	ErrStorageDepositNotApplicable = errors.New("output type is not subject to the storage deposit")
+)
+
+// StorageDeposit is the breakdown of the minimum storage deposit of an output.
+// The virtual bytes of the single parts sum up to the virtual bytes of the output.
+type StorageDeposit struct {
+	// The rent structure the storage deposit was computed with.
+	RentStructure *iotago.RentStructure `json:"rentStructure"`
+	// The virtual bytes of the data the node stores along with every output (output ID, message ID, milestone index and timestamp).
+	MetadataVBytes uint64 `json:"metadataVBytes,string"`
+	// The virtual bytes of the fields of the output that are not listed separately (type, amount and chain specific fields).
+	OutputVBytes uint64 `json:"outputVBytes,string"`
+	// The virtual bytes of the native tokens of the output.
+	NativeTokensVBytes uint64 `json:"nativeTokensVBytes,string"`
+	// The virtual bytes of the unlock conditions of the output.
+	UnlockConditionsVBytes uint64 `json:"unlockConditionsVBytes,string"`
+	// The virtual bytes of the feature blocks of the output.
+	FeatureBlocksVBytes uint64 `json:"featureBlocksVBytes,string"`
+	// The virtual bytes of the immutable feature blocks of the output (alias, foundry and NFT outputs only).
+	ImmutableFeatureBlocksVBytes uint64 `json:"immutableFeatureBlocksVBytes,string"`
+	// The virtual bytes of the output.
+	VBytes uint64 `json:"vBytes,string"`
+	// The minimum storage deposit of the output.
+	MinDeposit uint64 `json:"minDeposit,string"`
+	// The amount of the output.
+	Amount uint64 `json:"amount,string"`
+	// Whether the amount of the output covers the minimum storage deposit.
+	Covered bool `json:"covered"`
+}
+
+// MinStorageDeposit returns the minimum storage deposit of the output for the given rent structure.
+func MinStorageDeposit(output iotago.Output, rentStructure *iotago.RentStructure) uint64 {
+	return rentStructure.VByteCost * output.VBytes(rentStructure, nil)
+}
+
+// outputMetadataVBytes returns the virtual bytes of the data the node stores along with every output.
+// This mirrors the offset that is part of the virtual bytes of every output in iota.go.
+func outputMetadataVBytes(rentStructure *iotago.RentStructure) uint64 {
+	return rentStructure.VBFactorKey.Multiply(iotago.OutputIDLength) +
+		rentStructure.VBFactorData.Multiply(iotago.MessageIDLength+serializer.UInt32ByteSize+serializer.UInt32ByteSize)
+}
+
+// ComputeStorageDeposit computes the minimum storage deposit of the output for the given rent structure
+// and breaks it down into the parts of the output.
+// The output doesn't need to be valid, so it can be used for drafts of outputs that don't have an amount yet.
+func ComputeStorageDeposit(output iotago.Output, rentStructure *iotago.RentStructure) (*StorageDeposit, error) {
+	if output.Type() == iotago.OutputTreasury {
+		return nil, ErrStorageDepositNotApplicable
+	}
+
+	deposit := &StorageDeposit{
+		RentStructure:          rentStructure,
+		MetadataVBytes:         outputMetadataVBytes(rentStructure),
+		NativeTokensVBytes:     output.NativeTokenSet().VBytes(rentStructure, nil),
+		UnlockConditionsVBytes: output.UnlockConditions().VBytes(rentStructure, nil),
+		FeatureBlocksVBytes:    output.FeatureBlocks().VBytes(rentStructure, nil),
+		VBytes:                 output.VBytes(rentStructure, nil),
+		Amount:                 output.Deposit(),
+	}
+
+	if chainOutput, ok := output.(iotago.ChainConstrainedOutput); ok {
+		deposit.ImmutableFeatureBlocksVBytes = chainOutput.ImmutableFeatureBlocks().VBytes(rentStructure, nil)
+	}
+
+	listedVBytes := deposit.MetadataVBytes + deposit.NativeTokensVBytes + deposit.UnlockConditionsVBytes + deposit.FeatureBlocksVBytes + deposit.ImmutableFeatureBlocksVBytes
+	if listedVBytes > deposit.VBytes {
+		return nil, errors.Errorf("virtual bytes of the parts exceed the virtual bytes of the output: %d > %d", listedVBytes, deposit.VBytes)
+	}
+	deposit.OutputVBytes = deposit.VBytes - listedVBytes
+	deposit.MinDeposit = rentStructure.VByteCost * deposit.VBytes
+	deposit.Covered = deposit.Amount >= deposit.MinDeposit
+
+	return deposit, nil
+}
+
+// OutputFromBytes deserializes an output without validating it, so that drafts of outputs can be parsed.
+func OutputFromBytes(data []byte, protoParas *iotago.ProtocolParameters) (iotago.Output, error) {
+	if len(data) == 0 {
+		return nil, errors.New("invalid output length")
+	}
+
+	output, err := iotago.OutputSelector(uint32(data[0]))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to determine output type")
+	}
+
+	if _, err := output.Deserialize(data, serializer.DeSeriModeNoValidation, protoParas); err != nil {
+		return nil, errors.Wrap(err, "unable to deserialize output")
+	}
+
+	return output, nil
+}
+
+// OutputFromJSON decodes the JSON representation of an output without validating it, so that drafts of outputs can be parsed.
+func OutputFromJSON(data []byte) (iotago.Output, error) {
+	rawOutput := json.RawMessage(data)
+
+	jsonOutput, err := iotago.DeserializeObjectFromJSON(&rawOutput, iotago.JsonOutputSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode output")
+	}
+
+	seri, err := jsonOutput.ToSerializable()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode output")
+	}
+
+	output, ok := seri.(iotago.Output)
+	if !ok {
+		return nil, errors.Errorf("unable to decode output: %T is not an output", seri)
+	}
+
+	return output, nil
+}
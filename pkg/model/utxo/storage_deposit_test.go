@@ -0,0 +1,102 @@
+package utxo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/utxo/utils"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var testRentStructure = &iotago.RentStructure{
+	VByteCost:    500,
+	VBFactorData: iotago.VByteCostFactorData,
+	VBFactorKey:  iotago.VByteCostFactorKey,
+}
+
+func TestComputeStorageDeposit(t *testing.T) {
+
+	address := utils.RandAddress(iotago.AddressEd25519)
+
+	basicOutput := &iotago.BasicOutput{
+		Amount:     1_000_000,
+		Conditions: iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: address}},
+		Blocks:     iotago.FeatureBlocks{&iotago.MetadataFeatureBlock{Data: utils.RandBytes(100)}},
+	}
+
+	deposit, err := ComputeStorageDeposit(basicOutput, testRentStructure)
+	require.NoError(t, err)
+	require.Equal(t, basicOutput.VBytes(testRentStructure, nil), deposit.VBytes)
+	require.Equal(t, MinStorageDeposit(basicOutput, testRentStructure), deposit.MinDeposit)
+	require.Equal(t, deposit.VBytes, deposit.MetadataVBytes+deposit.OutputVBytes+deposit.NativeTokensVBytes+deposit.UnlockConditionsVBytes+deposit.FeatureBlocksVBytes+deposit.ImmutableFeatureBlocksVBytes)
+	require.Zero(t, deposit.ImmutableFeatureBlocksVBytes) // basic outputs have no immutable feature blocks
+	require.True(t, deposit.Covered)
+
+	// the minimum deposit is the same iota.go requires
+	minRent, err := testRentStructure.CoversStateRent(basicOutput, deposit.MinDeposit)
+	require.NoError(t, err)
+	require.Equal(t, minRent, deposit.MinDeposit)
+	_, err = testRentStructure.CoversStateRent(basicOutput, deposit.MinDeposit-1)
+	require.ErrorIs(t, err, iotago.ErrVByteRentNotCovered)
+
+	// drafts without an amount are not covered
+	basicOutput.Amount = 0
+	deposit, err = ComputeStorageDeposit(basicOutput, testRentStructure)
+	require.NoError(t, err)
+	require.False(t, deposit.Covered)
+
+	nftOutput := &iotago.NFTOutput{
+		Amount:          1_000_000,
+		Conditions:      iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: address}},
+		ImmutableBlocks: iotago.FeatureBlocks{&iotago.MetadataFeatureBlock{Data: utils.RandBytes(200)}},
+	}
+
+	deposit, err = ComputeStorageDeposit(nftOutput, testRentStructure)
+	require.NoError(t, err)
+	require.Equal(t, nftOutput.VBytes(testRentStructure, nil), deposit.VBytes)
+	require.NotZero(t, deposit.ImmutableFeatureBlocksVBytes)
+	require.Greater(t, deposit.ImmutableFeatureBlocksVBytes, deposit.FeatureBlocksVBytes)
+	require.Equal(t, deposit.VBytes, deposit.MetadataVBytes+deposit.OutputVBytes+deposit.NativeTokensVBytes+deposit.UnlockConditionsVBytes+deposit.FeatureBlocksVBytes+deposit.ImmutableFeatureBlocksVBytes)
+
+	// token amounts and virtual bytes are sent as strings like in the rest of the API
+	depositJSON, err := json.Marshal(deposit)
+	require.NoError(t, err)
+	require.Contains(t, string(depositJSON), fmt.Sprintf(`"minDeposit":"%d"`, deposit.MinDeposit))
+	require.Contains(t, string(depositJSON), `"amount":"1000000"`)
+
+	decodedDeposit := &StorageDeposit{}
+	require.NoError(t, json.Unmarshal(depositJSON, decodedDeposit))
+	require.Equal(t, deposit, decodedDeposit)
+
+	_, err = ComputeStorageDeposit(&iotago.TreasuryOutput{Amount: 1_000_000}, testRentStructure)
+	require.ErrorIs(t, err, ErrStorageDepositNotApplicable)
+}
+
+func TestOutputFromBytesAndJSON(t *testing.T) {
+
+	output := utils.RandOutput(iotago.OutputBasic)
+
+	outputBytes, err := output.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+
+	fromBytes, err := OutputFromBytes(outputBytes, nil)
+	require.NoError(t, err)
+	require.Equal(t, output, fromBytes)
+
+	outputJSON, err := output.MarshalJSON()
+	require.NoError(t, err)
+
+	fromJSON, err := OutputFromJSON(outputJSON)
+	require.NoError(t, err)
+	require.Equal(t, output.VBytes(testRentStructure, nil), fromJSON.VBytes(testRentStructure, nil))
+
+	_, err = OutputFromBytes(nil, nil)
+	require.Error(t, err)
+
+	_, err = OutputFromJSON([]byte(`{"type": 42}`))
+	require.Error(t, err)
+}
@@ -294,6 +294,8 @@ type AutopeeringManager struct {
 	preferIPv6 bool
 	// p2pServiceKey is the service key used for the p2pService.
 	p2pServiceKey service.Key
+	// privateNetwork restricts autopeering to the members of a private network, nil for the public network.
+	privateNetwork *PrivateNetwork
 	// localPeerContainer is the container for the local autopeering peer and database.
 	localPeerContainer *LocalPeerContainer
 	// discoveryProtocol is the peer discovery protocol.
@@ -302,7 +304,7 @@ type AutopeeringManager struct {
 	selectionProtocol *selection.Protocol
 }
 
-func NewAutopeeringManager(log *logger.Logger, bindAddress string, entryNodes []string, preferIPv6 bool, p2pServiceKey service.Key) *AutopeeringManager {
+func NewAutopeeringManager(log *logger.Logger, bindAddress string, entryNodes []string, preferIPv6 bool, p2pServiceKey service.Key, privateNetwork *PrivateNetwork) *AutopeeringManager {
 
 	return &AutopeeringManager{
 		WrappedLogger:      logger.NewWrappedLogger(log),
@@ -310,6 +312,7 @@ func NewAutopeeringManager(log *logger.Logger, bindAddress string, entryNodes []
 		entryNodes:         entryNodes,
 		preferIPv6:         preferIPv6,
 		p2pServiceKey:      p2pServiceKey,
+		privateNetwork:     privateNetwork,
 		localPeerContainer: nil,
 		discoveryProtocol:  nil,
 		selectionProtocol:  nil,
@@ -322,6 +325,11 @@ func (a *AutopeeringManager) P2PServiceKey() service.Key {
 	return a.p2pServiceKey
 }
 
+// PrivateNetwork returns the private network autopeering is restricted to, nil for the public network.
+func (a *AutopeeringManager) PrivateNetwork() *PrivateNetwork {
+	return a.privateNetwork
+}
+
 // LocalPeerContainer returns the container for the local autopeering peer and database.
 func (a *AutopeeringManager) LocalPeerContainer() *LocalPeerContainer {
 	return a.localPeerContainer
@@ -382,6 +390,10 @@ func (a *AutopeeringManager) Init(localPeerContainer *LocalPeerContainer, initSe
 		if p2pPeering.Network() != "tcp" || !netutil.IsValidPort(p2pPeering.Port()) {
 			return false
 		}
+
+		if a.privateNetwork != nil && !a.privateNetwork.IsAllowed(p.PublicKey()) {
+			return false
+		}
 		return true
 	}
 
@@ -410,8 +422,18 @@ func (a *AutopeeringManager) Run(ctx context.Context) {
 		handlers = append(handlers, a.selectionProtocol)
 	}
 
+	var netConn server.NetConn = conn
+	if a.privateNetwork != nil {
+		a.LogInfo("autopeering is restricted to the members of the private network")
+
+		// packets of peers that can't prove their membership are dropped before they reach the protocols
+		netConn = a.privateNetwork.WrapConn(conn, func(fromAddr *net.UDPAddr, err error) {
+			a.LogDebugf("rejected autopeering packet from %s: %s", fromAddr, err)
+		})
+	}
+
 	// start a server doing discovery and peering
-	srv := server.Serve(lPeer, conn, a.LoggerNamed("srv"), handlers...)
+	srv := server.Serve(lPeer, netConn, a.LoggerNamed("srv"), handlers...)
 
 	// start the discovery on that connection
 	a.discoveryProtocol.Start(srv)
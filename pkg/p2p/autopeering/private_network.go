@@ -0,0 +1,198 @@
+package autopeering
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	peer2 "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/autopeering/server"
+	pb "github.com/iotaledger/hive.go/autopeering/server/proto"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+const (
+	// PresharedKeySize is the size of the preshared key of a private autopeering network.
+	PresharedKeySize = 32
+	// packetTagSize is the size of the authentication tag that is appended to every packet in a private network.
+	packetTagSize = sha256.Size
+)
+
+var (
+	// ErrInvalidPresharedKey is returned if the preshared key of a private autopeering network is invalid.
+	ErrInvalidPresharedKey = errors.New("invalid preshared key")
+	// ErrPacketNotAuthenticated is returned if a packet was not authenticated with the preshared key.
+	ErrPacketNotAuthenticated = errors.New("packet not authenticated with the preshared key")
+	// ErrPacketSenderNotAllowed is returned if the sender of a packet is not part of the allowed identities.
+	ErrPacketSenderNotAllowed = errors.New("packet sender not allowed")
+)
+
+// ParsePresharedKey parses a hex encoded preshared key of a private autopeering network.
+func ParsePresharedKey(presharedKeyHex string) ([]byte, error) {
+	presharedKey, err := hex.DecodeString(presharedKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPresharedKey, err)
+	}
+
+	if len(presharedKey) != PresharedKeySize {
+		return nil, fmt.Errorf("%w: wrong length, is %d (wanted %d)", ErrInvalidPresharedKey, len(presharedKey), PresharedKeySize)
+	}
+
+	return presharedKey, nil
+}
+
+// PrivateNetwork restricts autopeering to the members of a private network.
+// Members prove their membership by authenticating every discovery and peering packet
+// with a preshared key and/or by using an identity that is part of the allowed peer IDs.
+type PrivateNetwork struct {
+	// the preshared key used to authenticate packets, nil if not used.
+	presharedKey []byte
+	// the peer IDs that are allowed to take part in autopeering, empty if not used.
+	allowedPeerIDs map[peer2.ID]struct{}
+}
+
+// NewPrivateNetwork creates a new PrivateNetwork.
+// If presharedKey is nil, packets are not authenticated with a preshared key.
+// If allowedPeerIDs is empty, every identity knowing the preshared key is allowed.
+func NewPrivateNetwork(presharedKey []byte, allowedPeerIDs []peer2.ID) (*PrivateNetwork, error) {
+
+	if presharedKey == nil && len(allowedPeerIDs) == 0 {
+		return nil, errors.New("either a preshared key or allowed peer IDs need to be defined for a private network")
+	}
+
+	if presharedKey != nil && len(presharedKey) != PresharedKeySize {
+		return nil, fmt.Errorf("%w: wrong length, is %d (wanted %d)", ErrInvalidPresharedKey, len(presharedKey), PresharedKeySize)
+	}
+
+	allowed := make(map[peer2.ID]struct{}, len(allowedPeerIDs))
+	for _, peerID := range allowedPeerIDs {
+		allowed[peerID] = struct{}{}
+	}
+
+	return &PrivateNetwork{
+		presharedKey:   presharedKey,
+		allowedPeerIDs: allowed,
+	}, nil
+}
+
+// IsAllowed checks whether the identity with the given public key is allowed to take part in the private network.
+func (n *PrivateNetwork) IsAllowed(publicKey ed25519.PublicKey) bool {
+	if len(n.allowedPeerIDs) == 0 {
+		return true
+	}
+
+	peerID, err := ConvertHivePubKeyToPeerID(publicKey)
+	if err != nil {
+		return false
+	}
+
+	_, allowed := n.allowedPeerIDs[peerID]
+	return allowed
+}
+
+// packetTag computes the authentication tag of the given packet.
+func (n *PrivateNetwork) packetTag(packet []byte) []byte {
+	mac := hmac.New(sha256.New, n.presharedKey)
+	_, _ = mac.Write(packet)
+	return mac.Sum(nil)
+}
+
+// seal appends the authentication tag to the given packet.
+func (n *PrivateNetwork) seal(packet []byte) []byte {
+	if n.presharedKey == nil {
+		return packet
+	}
+
+	sealed := make([]byte, 0, len(packet)+packetTagSize)
+	sealed = append(sealed, packet...)
+	return append(sealed, n.packetTag(packet)...)
+}
+
+// open verifies the given packet and returns it without the authentication tag.
+func (n *PrivateNetwork) open(sealed []byte) ([]byte, error) {
+	packet := sealed
+
+	if n.presharedKey != nil {
+		if len(sealed) < packetTagSize {
+			return nil, ErrPacketNotAuthenticated
+		}
+
+		packet = sealed[:len(sealed)-packetTagSize]
+		if !hmac.Equal(sealed[len(sealed)-packetTagSize:], n.packetTag(packet)) {
+			return nil, ErrPacketNotAuthenticated
+		}
+	}
+
+	if len(n.allowedPeerIDs) > 0 {
+		pkt := new(pb.Packet)
+		if err := proto.Unmarshal(packet, pkt); err != nil {
+			return nil, err
+		}
+
+		publicKey, _, err := ed25519.PublicKeyFromBytes(pkt.GetPublicKey())
+		if err != nil {
+			return nil, err
+		}
+
+		// the signature of the packet is verified by the autopeering server afterwards,
+		// so a sender can't use the identity of another member.
+		if !n.IsAllowed(publicKey) {
+			return nil, ErrPacketSenderNotAllowed
+		}
+	}
+
+	return packet, nil
+}
+
+// WrapConn wraps the given connection, so that all packets are authenticated for the private network.
+// Received packets that do not belong to the private network are dropped and passed to onRejected.
+func (n *PrivateNetwork) WrapConn(conn server.NetConn, onRejected func(fromAddr *net.UDPAddr, err error)) server.NetConn {
+	return &privateNetworkConn{
+		NetConn:        conn,
+		privateNetwork: n,
+		onRejected:     onRejected,
+		readBuffer:     make([]byte, server.MaxPacketSize+packetTagSize),
+	}
+}
+
+// privateNetworkConn is a connection that authenticates all packets for a private network.
+type privateNetworkConn struct {
+	server.NetConn
+	privateNetwork *PrivateNetwork
+	onRejected     func(fromAddr *net.UDPAddr, err error)
+	// the buffer used to read the sealed packets. the connection is only read by a single goroutine.
+	readBuffer []byte
+}
+
+// ReadFromUDP reads the next packet that belongs to the private network.
+func (c *privateNetworkConn) ReadFromUDP(b []byte) (int, *net.UDPAddr, error) {
+	for {
+		n, fromAddr, err := c.NetConn.ReadFromUDP(c.readBuffer)
+		if err != nil {
+			return n, fromAddr, err
+		}
+
+		packet, err := c.privateNetwork.open(c.readBuffer[:n])
+		if err != nil {
+			if c.onRejected != nil {
+				c.onRejected(fromAddr, err)
+			}
+			continue
+		}
+
+		return copy(b, packet), fromAddr, nil
+	}
+}
+
+// WriteToUDP seals the packet for the private network and writes it to the given address.
+func (c *privateNetworkConn) WriteToUDP(b []byte, addr *net.UDPAddr) (int, error) {
+	if _, err := c.NetConn.WriteToUDP(c.privateNetwork.seal(b), addr); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,161 @@
+package autopeering_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gohornet/hornet/pkg/p2p/autopeering"
+	pb "github.com/iotaledger/hive.go/autopeering/server/proto"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// packetConn is an in-memory connection that delivers all written packets to itself.
+type packetConn struct {
+	packets chan []byte
+}
+
+func newPacketConn() *packetConn {
+	return &packetConn{packets: make(chan []byte, 10)}
+}
+
+func (c *packetConn) Close() error {
+	close(c.packets)
+	return nil
+}
+
+func (c *packetConn) LocalAddr() net.Addr {
+	return &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 14626}
+}
+
+func (c *packetConn) ReadFromUDP(b []byte) (int, *net.UDPAddr, error) {
+	packet, ok := <-c.packets
+	if !ok {
+		return 0, nil, net.ErrClosed
+	}
+	return copy(b, packet), c.LocalAddr().(*net.UDPAddr), nil
+}
+
+func (c *packetConn) WriteToUDP(b []byte, _ *net.UDPAddr) (int, error) {
+	c.packets <- append([]byte(nil), b...)
+	return len(b), nil
+}
+
+func signedPacket(t *testing.T, privateKey ed25519.PrivateKey) []byte {
+	data := []byte("autopeering")
+
+	packet, err := proto.Marshal(&pb.Packet{
+		PublicKey: privateKey.Public().Bytes(),
+		Signature: privateKey.Sign(data).Bytes(),
+		Data:      data,
+	})
+	require.NoError(t, err)
+
+	return packet
+}
+
+func newIdentity(t *testing.T) (ed25519.PrivateKey, ed25519.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	return privateKey, publicKey
+}
+
+func TestParsePresharedKey(t *testing.T) {
+	_, err := autopeering.ParsePresharedKey("0102")
+	require.ErrorIs(t, err, autopeering.ErrInvalidPresharedKey)
+
+	_, err = autopeering.ParsePresharedKey("zz")
+	require.ErrorIs(t, err, autopeering.ErrInvalidPresharedKey)
+
+	presharedKey, err := autopeering.ParsePresharedKey("bf27b5c3a6bd4cd0ec4fa6bf1b6aee1f27b9b6e1e0cad23c8a9d5b7bce6c2a11")
+	require.NoError(t, err)
+	require.Len(t, presharedKey, autopeering.PresharedKeySize)
+}
+
+func TestPrivateNetworkPresharedKey(t *testing.T) {
+
+	_, err := autopeering.NewPrivateNetwork(nil, nil)
+	require.Error(t, err)
+
+	presharedKey1 := make([]byte, autopeering.PresharedKeySize)
+	presharedKey2 := make([]byte, autopeering.PresharedKeySize)
+	presharedKey2[0] = 1
+
+	network1, err := autopeering.NewPrivateNetwork(presharedKey1, nil)
+	require.NoError(t, err)
+	network2, err := autopeering.NewPrivateNetwork(presharedKey2, nil)
+	require.NoError(t, err)
+
+	privateKey, _ := newIdentity(t)
+	packet := signedPacket(t, privateKey)
+
+	var rejected []error
+	onRejected := func(_ *net.UDPAddr, err error) {
+		rejected = append(rejected, err)
+	}
+
+	conn := newPacketConn()
+	sender := network1.WrapConn(conn, onRejected)
+	receiver := network1.WrapConn(conn, onRejected)
+	foreignReceiver := network2.WrapConn(conn, onRejected)
+
+	// members of the same network can read the packets
+	n, err := sender.WriteToUDP(packet, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(packet), n)
+
+	buffer := make([]byte, 1280)
+	n, _, err = receiver.ReadFromUDP(buffer)
+	require.NoError(t, err)
+	require.Equal(t, packet, buffer[:n])
+	require.Empty(t, rejected)
+
+	// packets authenticated with another key and unauthenticated packets are dropped
+	_, err = sender.WriteToUDP(packet, nil)
+	require.NoError(t, err)
+	_, err = conn.WriteToUDP(packet, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	_, _, err = foreignReceiver.ReadFromUDP(buffer)
+	require.ErrorIs(t, err, net.ErrClosed)
+	require.Len(t, rejected, 2)
+	require.ErrorIs(t, rejected[0], autopeering.ErrPacketNotAuthenticated)
+	require.ErrorIs(t, rejected[1], autopeering.ErrPacketNotAuthenticated)
+}
+
+func TestPrivateNetworkAllowedPeerIDs(t *testing.T) {
+
+	allowedPrivateKey, allowedPublicKey := newIdentity(t)
+	otherPrivateKey, otherPublicKey := newIdentity(t)
+
+	allowedPeerID, err := autopeering.ConvertHivePubKeyToPeerID(allowedPublicKey)
+	require.NoError(t, err)
+
+	network, err := autopeering.NewPrivateNetwork(nil, []peer.ID{allowedPeerID})
+	require.NoError(t, err)
+
+	require.True(t, network.IsAllowed(allowedPublicKey))
+	require.False(t, network.IsAllowed(otherPublicKey))
+
+	var rejected []error
+	conn := newPacketConn()
+	receiver := network.WrapConn(conn, func(_ *net.UDPAddr, err error) {
+		rejected = append(rejected, err)
+	})
+
+	_, err = conn.WriteToUDP(signedPacket(t, otherPrivateKey), nil)
+	require.NoError(t, err)
+	_, err = conn.WriteToUDP(signedPacket(t, allowedPrivateKey), nil)
+	require.NoError(t, err)
+
+	buffer := make([]byte, 1280)
+	n, _, err := receiver.ReadFromUDP(buffer)
+	require.NoError(t, err)
+	require.Equal(t, signedPacket(t, allowedPrivateKey), buffer[:n])
+	require.Len(t, rejected, 1)
+	require.ErrorIs(t, rejected[0], autopeering.ErrPacketSenderNotAllowed)
+}
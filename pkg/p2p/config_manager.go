@@ -103,6 +103,38 @@ func (pm *ConfigManager) RemovePeer(peerID peer.ID) error {
 	return errors.New("peer not found")
 }
 
+// ReplacePeers replaces all peers of the config manager without storing them
+// and returns the peers that were added and removed compared to the previous list.
+// This is used if the peering config was modified externally.
+func (pm *ConfigManager) ReplacePeers(peers []*PeerConfig) (added []*PeerConfig, removed []*PeerConfig) {
+	pm.peersLock.Lock()
+	defer pm.peersLock.Unlock()
+
+	oldPeers := make(map[string]struct{}, len(pm.peers))
+	for _, p := range pm.peers {
+		oldPeers[p.MultiAddress] = struct{}{}
+	}
+
+	newPeers := make(map[string]struct{}, len(peers))
+	for _, p := range peers {
+		newPeers[p.MultiAddress] = struct{}{}
+		if _, exists := oldPeers[p.MultiAddress]; !exists {
+			added = append(added, p)
+		}
+	}
+
+	for _, p := range pm.peers {
+		if _, exists := newPeers[p.MultiAddress]; !exists {
+			removed = append(removed, p)
+		}
+	}
+
+	pm.peers = make([]*PeerConfig, len(peers))
+	copy(pm.peers, peers)
+
+	return added, removed
+}
+
 // StoreOnChange sets whether storing changes to the config is active or not.
 func (pm *ConfigManager) StoreOnChange(store bool) {
 	pm.storeOnChange = store
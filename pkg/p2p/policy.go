@@ -0,0 +1,411 @@
+package p2p
+
+import (
+	"net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrPolicyEntryAlreadyExists gets returned if an entry is tried to be added to the policies which already exists.
+	ErrPolicyEntryAlreadyExists = errors.New("policy entry already exists")
+	// ErrPolicyEntryNotFound gets returned if an entry is tried to be removed from the policies which doesn't exist.
+	ErrPolicyEntryNotFound = errors.New("policy entry not found")
+	// ErrInvalidPeeringPolicies gets returned if the peering policies contain invalid entries.
+	ErrInvalidPeeringPolicies = errors.New("invalid peering policies")
+)
+
+// PolicyViolation defines the reason why a peer is refused by the peering policies.
+type PolicyViolation string
+
+const (
+	// PolicyViolationNone means that no policy is violated.
+	PolicyViolationNone PolicyViolation = ""
+	// PolicyViolationDeniedPeerID means that the ID of the peer is on the deny list.
+	PolicyViolationDeniedPeerID PolicyViolation = "denied peer ID"
+	// PolicyViolationDeniedCIDR means that the IP of the peer is within a denied IP range.
+	PolicyViolationDeniedCIDR PolicyViolation = "denied CIDR"
+	// PolicyViolationDeniedMultiAddress means that the multi address of the peer is on the deny list.
+	PolicyViolationDeniedMultiAddress PolicyViolation = "denied multi address"
+	// PolicyViolationRelationLimitReached means that the maximum amount of peers with the same relation is reached.
+	PolicyViolationRelationLimitReached PolicyViolation = "relation limit reached"
+	// PolicyViolationMaintenance means that the node is in maintenance mode and refuses new inbound peers.
+	PolicyViolationMaintenance PolicyViolation = "maintenance mode"
+)
+
+// PeeringPolicies holds the policies that are applied to peers.
+type PeeringPolicies struct {
+	// The IDs of the peers that are denied.
+	DeniedPeerIDs []string `json:"deniedPeerIds"`
+	// The IP ranges (CIDR notation) of the peers that are denied.
+	DeniedCIDRs []string `json:"deniedCIDRs"`
+	// The multi addresses of the peers that are denied.
+	DeniedMultiAddresses []string `json:"deniedMultiAddresses"`
+	// The maximum amount of gossip streams per peer relation (0 = unlimited).
+	RelationLimits map[PeerRelation]int `json:"relationLimits"`
+	// Whether the node is in maintenance mode and refuses new inbound peers.
+	Maintenance bool `json:"maintenance"`
+}
+
+// NewPeeringPolicies returns empty peering policies.
+func NewPeeringPolicies() *PeeringPolicies {
+	return &PeeringPolicies{
+		DeniedPeerIDs:        []string{},
+		DeniedCIDRs:          []string{},
+		DeniedMultiAddresses: []string{},
+		RelationLimits:       map[PeerRelation]int{},
+		Maintenance:          false,
+	}
+}
+
+// clone returns a deep copy of the peering policies.
+func (pp *PeeringPolicies) clone() *PeeringPolicies {
+	policies := &PeeringPolicies{
+		DeniedPeerIDs:        make([]string, len(pp.DeniedPeerIDs)),
+		DeniedCIDRs:          make([]string, len(pp.DeniedCIDRs)),
+		DeniedMultiAddresses: make([]string, len(pp.DeniedMultiAddresses)),
+		RelationLimits:       make(map[PeerRelation]int, len(pp.RelationLimits)),
+		Maintenance:          pp.Maintenance,
+	}
+	copy(policies.DeniedPeerIDs, pp.DeniedPeerIDs)
+	copy(policies.DeniedCIDRs, pp.DeniedCIDRs)
+	copy(policies.DeniedMultiAddresses, pp.DeniedMultiAddresses)
+	for relation, limit := range pp.RelationLimits {
+		policies.RelationLimits[relation] = limit
+	}
+	return policies
+}
+
+// parsedPolicies holds the parsed form of PeeringPolicies used for the lookups.
+type parsedPolicies struct {
+	deniedPeerIDs        map[peer.ID]struct{}
+	deniedNets           []*net.IPNet
+	deniedMultiAddresses []multiaddr.Multiaddr
+}
+
+// parsePolicies validates and parses the given peering policies.
+func parsePolicies(policies *PeeringPolicies) (*parsedPolicies, error) {
+	parsed := &parsedPolicies{
+		deniedPeerIDs:        make(map[peer.ID]struct{}, len(policies.DeniedPeerIDs)),
+		deniedNets:           make([]*net.IPNet, 0, len(policies.DeniedCIDRs)),
+		deniedMultiAddresses: make([]multiaddr.Multiaddr, 0, len(policies.DeniedMultiAddresses)),
+	}
+
+	for _, peerIDStr := range policies.DeniedPeerIDs {
+		peerID, err := peer.Decode(peerIDStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid peer ID: %s", peerIDStr)
+		}
+		parsed.deniedPeerIDs[peerID] = struct{}{}
+	}
+
+	for _, cidr := range policies.DeniedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR: %s", cidr)
+		}
+		parsed.deniedNets = append(parsed.deniedNets, ipNet)
+	}
+
+	for _, multiAddrStr := range policies.DeniedMultiAddresses {
+		multiAddr, err := multiaddr.NewMultiaddr(multiAddrStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid multi address: %s", multiAddrStr)
+		}
+		parsed.deniedMultiAddresses = append(parsed.deniedMultiAddresses, multiAddr)
+	}
+
+	for relation, limit := range policies.RelationLimits {
+		switch relation {
+		case PeerRelationKnown, PeerRelationUnknown, PeerRelationAutopeered:
+		default:
+			return nil, errors.Errorf("invalid peer relation: %s", relation)
+		}
+		if limit < 0 {
+			return nil, errors.Errorf("invalid limit for peer relation %s: %d", relation, limit)
+		}
+	}
+
+	return parsed, nil
+}
+
+// PolicyManager handles the peering policies.
+// It calls a function if the policies changed.
+type PolicyManager struct {
+	storeCallback func(*PeeringPolicies) error
+	storeOnChange bool
+	policiesLock  sync.RWMutex
+	policies      *PeeringPolicies
+	parsed        *parsedPolicies
+}
+
+// NewPolicyManager creates a new policy manager.
+func NewPolicyManager(storeCallback func(*PeeringPolicies) error) *PolicyManager {
+	policies := NewPeeringPolicies()
+
+	// empty policies are always valid
+	parsed, _ := parsePolicies(policies)
+
+	return &PolicyManager{
+		storeCallback: storeCallback,
+		storeOnChange: false,
+		policies:      policies,
+		parsed:        parsed,
+	}
+}
+
+// Policies returns a copy of the current peering policies.
+func (pm *PolicyManager) Policies() *PeeringPolicies {
+	pm.policiesLock.RLock()
+	defer pm.policiesLock.RUnlock()
+
+	return pm.policies.clone()
+}
+
+// SetPolicies replaces the current peering policies.
+func (pm *PolicyManager) SetPolicies(policies *PeeringPolicies) error {
+	pm.policiesLock.Lock()
+	defer pm.policiesLock.Unlock()
+
+	return pm.setPolicies(policies.clone())
+}
+
+// DenyPeerID adds the given peer ID to the deny list.
+func (pm *PolicyManager) DenyPeerID(peerID peer.ID) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedPeerIDs, err = addEntry(policies.DeniedPeerIDs, peerID.String())
+		return err
+	})
+}
+
+// RemoveDeniedPeerID removes the given peer ID from the deny list.
+func (pm *PolicyManager) RemoveDeniedPeerID(peerID peer.ID) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedPeerIDs, err = removeEntry(policies.DeniedPeerIDs, peerID.String())
+		return err
+	})
+}
+
+// DenyCIDR adds the given IP range (CIDR notation) to the deny list.
+func (pm *PolicyManager) DenyCIDR(cidr string) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedCIDRs, err = addEntry(policies.DeniedCIDRs, cidr)
+		return err
+	})
+}
+
+// RemoveDeniedCIDR removes the given IP range (CIDR notation) from the deny list.
+func (pm *PolicyManager) RemoveDeniedCIDR(cidr string) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedCIDRs, err = removeEntry(policies.DeniedCIDRs, cidr)
+		return err
+	})
+}
+
+// DenyMultiAddress adds the given multi address to the deny list.
+func (pm *PolicyManager) DenyMultiAddress(multiAddress multiaddr.Multiaddr) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedMultiAddresses, err = addEntry(policies.DeniedMultiAddresses, multiAddress.String())
+		return err
+	})
+}
+
+// RemoveDeniedMultiAddress removes the given multi address from the deny list.
+func (pm *PolicyManager) RemoveDeniedMultiAddress(multiAddress multiaddr.Multiaddr) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		var err error
+		policies.DeniedMultiAddresses, err = removeEntry(policies.DeniedMultiAddresses, multiAddress.String())
+		return err
+	})
+}
+
+// SetRelationLimit sets the maximum amount of gossip streams for the given peer relation (0 = unlimited).
+func (pm *PolicyManager) SetRelationLimit(relation PeerRelation, limit int) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		if limit == 0 {
+			delete(policies.RelationLimits, relation)
+			return nil
+		}
+		policies.RelationLimits[relation] = limit
+		return nil
+	})
+}
+
+// SetMaintenance enables or disables the maintenance mode.
+// In maintenance mode, no new inbound peers are accepted.
+func (pm *PolicyManager) SetMaintenance(maintenance bool) error {
+	return pm.modify(func(policies *PeeringPolicies) error {
+		policies.Maintenance = maintenance
+		return nil
+	})
+}
+
+// Maintenance tells whether the maintenance mode is active.
+func (pm *PolicyManager) Maintenance() bool {
+	pm.policiesLock.RLock()
+	defer pm.policiesLock.RUnlock()
+
+	return pm.policies.Maintenance
+}
+
+// RelationLimit returns the maximum amount of gossip streams for the given peer relation (0 = unlimited).
+func (pm *PolicyManager) RelationLimit(relation PeerRelation) int {
+	pm.policiesLock.RLock()
+	defer pm.policiesLock.RUnlock()
+
+	return pm.policies.RelationLimits[relation]
+}
+
+// IsDenied checks whether the given peer is on one of the deny lists.
+// The multi address is optional.
+func (pm *PolicyManager) IsDenied(peerID peer.ID, multiAddress multiaddr.Multiaddr) PolicyViolation {
+	pm.policiesLock.RLock()
+	defer pm.policiesLock.RUnlock()
+
+	return pm.isDenied(peerID, multiAddress)
+}
+
+// CheckInbound checks whether an inbound peer with the given relation is allowed by the peering policies.
+// relationCount is the amount of ongoing gossip streams with peers of the same relation.
+func (pm *PolicyManager) CheckInbound(peerID peer.ID, multiAddress multiaddr.Multiaddr, relation PeerRelation, relationCount int) PolicyViolation {
+	pm.policiesLock.RLock()
+	defer pm.policiesLock.RUnlock()
+
+	if violation := pm.isDenied(peerID, multiAddress); violation != PolicyViolationNone {
+		return violation
+	}
+
+	// known peers are still accepted in maintenance mode
+	if pm.policies.Maintenance && relation != PeerRelationKnown {
+		return PolicyViolationMaintenance
+	}
+
+	if limit := pm.policies.RelationLimits[relation]; limit > 0 && relationCount >= limit {
+		return PolicyViolationRelationLimitReached
+	}
+
+	return PolicyViolationNone
+}
+
+// StoreOnChange sets whether storing changes to the policies is active or not.
+func (pm *PolicyManager) StoreOnChange(store bool) {
+	pm.storeOnChange = store
+}
+
+// checks whether the given peer is on one of the deny lists.
+func (pm *PolicyManager) isDenied(peerID peer.ID, multiAddress multiaddr.Multiaddr) PolicyViolation {
+	if _, denied := pm.parsed.deniedPeerIDs[peerID]; denied {
+		return PolicyViolationDeniedPeerID
+	}
+
+	if multiAddress == nil {
+		return PolicyViolationNone
+	}
+
+	for _, deniedMultiAddress := range pm.parsed.deniedMultiAddresses {
+		if deniedMultiAddress.Equal(multiAddress) {
+			return PolicyViolationDeniedMultiAddress
+		}
+	}
+
+	if len(pm.parsed.deniedNets) == 0 {
+		return PolicyViolationNone
+	}
+
+	ip, err := manet.ToIP(multiAddress)
+	if err != nil {
+		// the multi address doesn't contain an IP (e.g. DNS)
+		return PolicyViolationNone
+	}
+
+	for _, deniedNet := range pm.parsed.deniedNets {
+		if deniedNet.Contains(ip) {
+			return PolicyViolationDeniedCIDR
+		}
+	}
+
+	return PolicyViolationNone
+}
+
+// modify applies the given function to a copy of the current policies and replaces them if no error occurred.
+func (pm *PolicyManager) modify(f func(policies *PeeringPolicies) error) error {
+	pm.policiesLock.Lock()
+	defer pm.policiesLock.Unlock()
+
+	policies := pm.policies.clone()
+	if err := f(policies); err != nil {
+		return err
+	}
+
+	return pm.setPolicies(policies)
+}
+
+// setPolicies validates and stores the given policies and sets them afterwards,
+// so that the active policies are left untouched if they can't be persisted.
+// the write lock must be acquired outside.
+func (pm *PolicyManager) setPolicies(policies *PeeringPolicies) error {
+	if policies.DeniedPeerIDs == nil {
+		policies.DeniedPeerIDs = []string{}
+	}
+	if policies.DeniedCIDRs == nil {
+		policies.DeniedCIDRs = []string{}
+	}
+	if policies.DeniedMultiAddresses == nil {
+		policies.DeniedMultiAddresses = []string{}
+	}
+	if policies.RelationLimits == nil {
+		policies.RelationLimits = map[PeerRelation]int{}
+	}
+
+	parsed, err := parsePolicies(policies)
+	if err != nil {
+		return errors.WithMessagef(ErrInvalidPeeringPolicies, "%s", err)
+	}
+
+	if err := pm.store(policies); err != nil {
+		return errors.Wrap(err, "storing peering policies failed")
+	}
+
+	pm.policies = policies
+	pm.parsed = parsed
+
+	return nil
+}
+
+// store calls the storeCallback with the given policies if storeOnChange is active.
+func (pm *PolicyManager) store(policies *PeeringPolicies) error {
+	if !pm.storeOnChange {
+		return nil
+	}
+
+	return pm.storeCallback(policies.clone())
+}
+
+// adds the given entry to the list if it doesn't exist yet.
+func addEntry(entries []string, entry string) ([]string, error) {
+	for _, e := range entries {
+		if e == entry {
+			return nil, ErrPolicyEntryAlreadyExists
+		}
+	}
+	return append(entries, entry), nil
+}
+
+// removes the given entry from the list.
+func removeEntry(entries []string, entry string) ([]string, error) {
+	for i, e := range entries {
+		if e == entry {
+			return append(entries[:i], entries[i+1:]...), nil
+		}
+	}
+	return nil, ErrPolicyEntryNotFound
+}
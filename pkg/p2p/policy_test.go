@@ -0,0 +1,129 @@
+package p2p_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/p2p"
+)
+
+func newPeerID(t *testing.T) peer.ID {
+	_, pubKey, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPublicKey(pubKey)
+	require.NoError(t, err)
+
+	return peerID
+}
+
+func TestPolicyManager(t *testing.T) {
+
+	var storedPolicies *p2p.PeeringPolicies
+	policyManager := p2p.NewPolicyManager(func(policies *p2p.PeeringPolicies) error {
+		storedPolicies = policies
+		return nil
+	})
+	policyManager.StoreOnChange(true)
+
+	peer1 := newPeerID(t)
+	peer2 := newPeerID(t)
+	addr1 := multiaddr.StringCast("/ip4/10.0.0.1/tcp/15600")
+	addr2 := multiaddr.StringCast("/ip4/192.168.1.10/tcp/15600")
+	addr3 := multiaddr.StringCast("/ip4/172.16.0.5/tcp/15600")
+
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer1, addr1))
+
+	// deny by peer ID
+	require.NoError(t, policyManager.DenyPeerID(peer1))
+	require.ErrorIs(t, policyManager.DenyPeerID(peer1), p2p.ErrPolicyEntryAlreadyExists)
+	require.Equal(t, p2p.PolicyViolationDeniedPeerID, policyManager.IsDenied(peer1, nil))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer2, addr1))
+	require.Equal(t, []string{peer1.String()}, storedPolicies.DeniedPeerIDs)
+
+	// deny by CIDR
+	require.NoError(t, policyManager.DenyCIDR("192.168.0.0/16"))
+	require.Equal(t, p2p.PolicyViolationDeniedCIDR, policyManager.IsDenied(peer2, addr2))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer2, addr1))
+	require.ErrorIs(t, policyManager.DenyCIDR("invalid"), p2p.ErrInvalidPeeringPolicies)
+
+	// deny by multi address
+	require.NoError(t, policyManager.DenyMultiAddress(addr3))
+	require.Equal(t, p2p.PolicyViolationDeniedMultiAddress, policyManager.IsDenied(peer2, addr3))
+
+	// remove entries again
+	require.NoError(t, policyManager.RemoveDeniedPeerID(peer1))
+	require.ErrorIs(t, policyManager.RemoveDeniedPeerID(peer1), p2p.ErrPolicyEntryNotFound)
+	require.NoError(t, policyManager.RemoveDeniedCIDR("192.168.0.0/16"))
+	require.NoError(t, policyManager.RemoveDeniedMultiAddress(addr3))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer1, addr1))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer2, addr2))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer2, addr3))
+	require.Empty(t, storedPolicies.DeniedPeerIDs)
+	require.Empty(t, storedPolicies.DeniedCIDRs)
+	require.Empty(t, storedPolicies.DeniedMultiAddresses)
+}
+
+func TestPolicyManagerStoreFailure(t *testing.T) {
+
+	errStore := errors.New("disk full")
+	failStore := false
+	policyManager := p2p.NewPolicyManager(func(policies *p2p.PeeringPolicies) error {
+		if failStore {
+			return errStore
+		}
+		return nil
+	})
+	policyManager.StoreOnChange(true)
+
+	peer1 := newPeerID(t)
+	peer2 := newPeerID(t)
+	require.NoError(t, policyManager.DenyPeerID(peer1))
+
+	// the policies are not changed if they can't be persisted
+	failStore = true
+	err := policyManager.DenyPeerID(peer2)
+	require.ErrorIs(t, err, errStore)
+	require.NotErrorIs(t, err, p2p.ErrInvalidPeeringPolicies)
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.IsDenied(peer2, nil))
+	require.ErrorIs(t, policyManager.RemoveDeniedPeerID(peer1), errStore)
+	require.Equal(t, p2p.PolicyViolationDeniedPeerID, policyManager.IsDenied(peer1, nil))
+	require.Equal(t, []string{peer1.String()}, policyManager.Policies().DeniedPeerIDs)
+}
+
+func TestPolicyManagerCheckInbound(t *testing.T) {
+
+	policyManager := p2p.NewPolicyManager(func(policies *p2p.PeeringPolicies) error {
+		return nil
+	})
+
+	peer1 := newPeerID(t)
+	addr1 := multiaddr.StringCast("/ip4/10.0.0.1/tcp/15600")
+
+	// relation limits
+	require.NoError(t, policyManager.SetRelationLimit(p2p.PeerRelationUnknown, 2))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationUnknown, 1))
+	require.Equal(t, p2p.PolicyViolationRelationLimitReached, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationUnknown, 2))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationAutopeered, 5))
+
+	require.NoError(t, policyManager.SetRelationLimit(p2p.PeerRelationUnknown, 0))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationUnknown, 2))
+
+	// maintenance mode
+	require.NoError(t, policyManager.SetMaintenance(true))
+	require.True(t, policyManager.Maintenance())
+	require.Equal(t, p2p.PolicyViolationMaintenance, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationUnknown, 0))
+	require.Equal(t, p2p.PolicyViolationMaintenance, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationAutopeered, 0))
+	require.Equal(t, p2p.PolicyViolationNone, policyManager.CheckInbound(peer1, addr1, p2p.PeerRelationKnown, 0))
+
+	// invalid policies are rejected and the old ones are kept
+	policies := policyManager.Policies()
+	policies.RelationLimits["invalid"] = 1
+	require.Error(t, policyManager.SetPolicies(policies))
+	require.True(t, policyManager.Maintenance())
+}
@@ -0,0 +1,201 @@
+package proofbundle
+
+import (
+	"crypto"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// BundleVersion is the version of the proof bundle format.
+	BundleVersion byte = 1
+)
+
+var (
+	// ErrMessageNotApplied is returned when a bundle is requested for a message that didn't mutate the ledger.
+	ErrMessageNotApplied = errors.New("message was not applied to the ledger")
+	// ErrUnknownBundleVersion is returned when a bundle has an unknown version.
+	ErrUnknownBundleVersion = errors.New("unknown proof bundle version")
+	// ErrInvalidBundle is returned when a bundle does not prove the inclusion of the message.
+	ErrInvalidBundle = errors.New("invalid proof bundle")
+)
+
+// Bundle is a self-contained proof that a message was applied to the ledger by a milestone.
+// It contains everything needed to verify the proof offline,
+// so it is still valid after the message and the milestone were pruned from the database.
+type Bundle struct {
+	// The version of the bundle format.
+	Version byte
+	// The serialized message.
+	Message []byte
+	// The serialized and signed milestone payload that applied the message.
+	Milestone []byte
+	// The audit path of the message in the merkle tree of the applied messages (AppliedMerkleRoot).
+	AppliedProof *whiteflag.MerkleProof
+}
+
+// jsonBundle defines the JSON representation of a Bundle.
+type jsonBundle struct {
+	Version      byte                   `json:"version"`
+	Message      string                 `json:"message"`
+	Milestone    string                 `json:"milestone"`
+	AppliedProof *whiteflag.MerkleProof `json:"appliedProof"`
+}
+
+func (b *Bundle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonBundle{
+		Version:      b.Version,
+		Message:      iotago.EncodeHex(b.Message),
+		Milestone:    iotago.EncodeHex(b.Milestone),
+		AppliedProof: b.AppliedProof,
+	})
+}
+
+func (b *Bundle) UnmarshalJSON(data []byte) error {
+	jBundle := &jsonBundle{}
+	if err := json.Unmarshal(data, jBundle); err != nil {
+		return err
+	}
+
+	messageBytes, err := iotago.DecodeHex(jBundle.Message)
+	if err != nil {
+		return errors.Wrap(err, "invalid message")
+	}
+
+	milestoneBytes, err := iotago.DecodeHex(jBundle.Milestone)
+	if err != nil {
+		return errors.Wrap(err, "invalid milestone")
+	}
+
+	b.Version = jBundle.Version
+	b.Message = messageBytes
+	b.Milestone = milestoneBytes
+	b.AppliedProof = jBundle.AppliedProof
+	return nil
+}
+
+// New creates a proof bundle for the given message.
+// The message needs to be applied to the ledger by a milestone
+// and the white-flag messages of that milestone need to be available in the database.
+func New(dbStorage *storage.Storage, messageID hornet.MessageID) (*Bundle, error) {
+
+	proof, err := whiteflag.InclusionProofForMessage(dbStorage, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if proof.Applied == nil {
+		return nil, errors.WithMessagef(ErrMessageNotApplied, "message %s", messageID.ToHex())
+	}
+
+	cachedMsg := dbStorage.CachedMessageOrNil(messageID) // message +1
+	if cachedMsg == nil {
+		return nil, errors.WithMessagef(whiteflag.ErrInclusionProofUnavailable, "message not found: %s", messageID.ToHex())
+	}
+	defer cachedMsg.Release(true) // message -1
+
+	cachedMilestone := dbStorage.CachedMilestoneByIndexOrNil(milestone.Index(proof.Milestone.Index)) // milestone +1
+	if cachedMilestone == nil {
+		return nil, errors.WithMessagef(whiteflag.ErrInclusionProofUnavailable, "milestone not found: %d", proof.Milestone.Index)
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	return &Bundle{
+		Version:      BundleVersion,
+		Message:      cachedMsg.Message().Data(),
+		Milestone:    cachedMilestone.Milestone().Data(),
+		AppliedProof: proof.Applied,
+	}, nil
+}
+
+// VerifiedBundle contains the content of a proof bundle that passed the verification.
+type VerifiedBundle struct {
+	// The ID of the message.
+	MessageID hornet.MessageID
+	// The message that was applied to the ledger.
+	Message *iotago.Message
+	// The milestone that applied the message.
+	Milestone *iotago.Milestone
+}
+
+// Verify checks that the milestone of the bundle was signed by the coordinator
+// with the public keys of the key manager, and that the message was applied to the ledger by that milestone.
+// It doesn't need access to a database.
+func (b *Bundle) Verify(keyManager *keymanager.KeyManager, milestonePublicKeyCount int) (*VerifiedBundle, error) {
+
+	if b.Version != BundleVersion {
+		return nil, errors.WithMessagef(ErrUnknownBundleVersion, "version: %d", b.Version)
+	}
+
+	if b.AppliedProof == nil {
+		return nil, errors.WithMessage(ErrInvalidBundle, "applied proof missing")
+	}
+
+	// the message ID is the hash of the serialized message, therefore the message doesn't need to be validated again
+	msg, err := storage.MessageFromBytes(b.Message, serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidBundle, "failed to deserialize message: %s", err)
+	}
+
+	milestonePayload := &iotago.Milestone{}
+	if _, err := milestonePayload.Deserialize(b.Milestone, serializer.DeSeriModeNoValidation, nil); err != nil {
+		return nil, errors.WithMessagef(ErrInvalidBundle, "failed to deserialize milestone: %s", err)
+	}
+
+	if err := milestonePayload.VerifySignatures(milestonePublicKeyCount, keyManager.PublicKeysSetForMilestoneIndex(milestone.Index(milestonePayload.Index))); err != nil {
+		return nil, errors.WithMessagef(ErrInvalidBundle, "invalid milestone signatures: %s", err)
+	}
+
+	valid, err := whiteflag.NewHasher(crypto.BLAKE2b_256).VerifyProof(msg.MessageID(), b.AppliedProof, milestonePayload.AppliedMerkleRoot[:])
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidBundle, "invalid applied proof: %s", err)
+	}
+	if !valid {
+		return nil, errors.WithMessage(ErrInvalidBundle, "applied proof does not match the applied merkle root of the milestone")
+	}
+
+	return &VerifiedBundle{
+		MessageID: msg.MessageID(),
+		Message:   msg.Message(),
+		Milestone: milestonePayload,
+	}, nil
+}
+
+// ReadFile reads a proof bundle from a JSON file.
+func ReadFile(filePath string) (*Bundle, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read proof bundle")
+	}
+
+	bundle := &Bundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, errors.Wrap(err, "failed to parse proof bundle")
+	}
+
+	return bundle, nil
+}
+
+// WriteFile writes the proof bundle to a JSON file.
+func (b *Bundle) WriteFile(filePath string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode proof bundle")
+	}
+
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write proof bundle")
+	}
+
+	return nil
+}
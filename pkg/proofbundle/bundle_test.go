@@ -0,0 +1,98 @@
+package proofbundle_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/proofbundle"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestBundleVerify(t *testing.T) {
+
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	keyManager := keymanager.New()
+	keyManager.AddKeyRange(pubKey, 0, 0)
+
+	msg := &iotago.Message{
+		ProtocolVersion: 2,
+		Parents:         iotago.MessageIDs{{1}},
+		Payload:         &iotago.TaggedData{Tag: []byte("proof"), Data: []byte("bundle")},
+	}
+	msgBytes, err := msg.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+	msgID := hornet.MessageIDFromArray(msg.MustID())
+
+	applied := []encoding.BinaryMarshaler{hornet.MessageIDFromArray([32]byte{2}), msgID, hornet.MessageIDFromArray([32]byte{3})}
+	hasher := whiteflag.NewHasher(crypto.BLAKE2b_256)
+
+	appliedRoot, err := hasher.Hash(applied)
+	require.NoError(t, err)
+	appliedProof, err := hasher.ComputeProof(applied, 1)
+	require.NoError(t, err)
+
+	var appliedMerkleRoot iotago.MilestoneMerkleProof
+	copy(appliedMerkleRoot[:], appliedRoot)
+
+	milestonePayload := iotago.NewMilestone(5, 1650000000, 2, iotago.MilestoneID{}, iotago.MilestoneParentMessageIDs{{1}}, iotago.MilestoneMerkleProof{}, appliedMerkleRoot)
+	keyMapping := keyManager.MilestonePublicKeyMappingForMilestoneIndex(5, []ed25519.PrivateKey{privKey}, 1)
+	var pubKeys []iotago.MilestonePublicKey
+	for pubKey := range keyMapping {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	require.NoError(t, milestonePayload.Sign(pubKeys, iotago.InMemoryEd25519MilestoneSigner(keyMapping)))
+
+	milestoneBytes, err := milestonePayload.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+
+	bundle := &proofbundle.Bundle{
+		Version:      proofbundle.BundleVersion,
+		Message:      msgBytes,
+		Milestone:    milestoneBytes,
+		AppliedProof: appliedProof,
+	}
+
+	// the bundle survives a JSON round-trip
+	bundleJSON, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	restored := &proofbundle.Bundle{}
+	require.NoError(t, json.Unmarshal(bundleJSON, restored))
+	require.Equal(t, bundle, restored)
+
+	verified, err := restored.Verify(keyManager, 1)
+	require.NoError(t, err)
+	require.Equal(t, msgID, verified.MessageID)
+	require.Equal(t, uint32(5), verified.Milestone.Index)
+
+	// the milestone was not signed by the coordinator
+	otherPubKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherKeyManager := keymanager.New()
+	otherKeyManager.AddKeyRange(otherPubKey, 0, 0)
+	_, err = bundle.Verify(otherKeyManager, 1)
+	require.ErrorIs(t, err, proofbundle.ErrInvalidBundle)
+
+	// the message was not applied by the milestone
+	tampered := *bundle
+	tampered.Message = append([]byte{}, msgBytes...)
+	tampered.Message[len(tampered.Message)-9] ^= 0xFF
+	_, err = tampered.Verify(keyManager, 1)
+	require.ErrorIs(t, err, proofbundle.ErrInvalidBundle)
+
+	// bundles of an unknown version are rejected
+	tampered = *bundle
+	tampered.Version++
+	_, err = tampered.Verify(keyManager, 1)
+	require.ErrorIs(t, err, proofbundle.ErrUnknownBundleVersion)
+}
@@ -0,0 +1,143 @@
+package proofbundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// Exporter creates proof bundles for the transactions of a set of addresses.
+// It is used to keep long-term receipts of transactions before the milestone cones are pruned.
+type Exporter struct {
+	storage   *storage.Storage
+	directory string
+	addresses map[string]struct{}
+}
+
+// NewExporter creates a new exporter that writes the proof bundles to the given directory.
+func NewExporter(dbStorage *storage.Storage, directory string, addresses []iotago.Address) *Exporter {
+
+	addressesMap := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		addressesMap[address.Key()] = struct{}{}
+	}
+
+	return &Exporter{
+		storage:   dbStorage,
+		directory: directory,
+		addresses: addressesMap,
+	}
+}
+
+// FilePath returns the path of the proof bundle file of the given message.
+func (e *Exporter) FilePath(msIndex milestone.Index, messageIDHex string) string {
+	return filepath.Join(e.directory, fmt.Sprintf("%d_%s.json", msIndex, messageIDHex))
+}
+
+// ExportMilestone creates proof bundles for all transactions of the exporter's addresses that were applied by the given milestone.
+// It returns the amount of created bundles.
+func (e *Exporter) ExportMilestone(msIndex milestone.Index) (int, error) {
+
+	if len(e.addresses) == 0 {
+		return 0, nil
+	}
+
+	whiteFlagMessages, err := e.storage.WhiteFlagMessages(msIndex)
+	if err != nil {
+		return 0, err
+	}
+	if whiteFlagMessages == nil {
+		// milestones that were confirmed before the white-flag messages were persisted can't be proven
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(e.directory, 0700); err != nil {
+		return 0, errors.Wrap(err, "failed to create proof bundle directory")
+	}
+
+	var exported int
+	for _, messageID := range whiteFlagMessages.Applied() {
+		cachedMsg := e.storage.CachedMessageOrNil(messageID) // message +1
+		if cachedMsg == nil {
+			continue
+		}
+		transaction := cachedMsg.Message().Transaction()
+		cachedMsg.Release(true) // message -1
+
+		if transaction == nil || !e.containsAddress(transaction) {
+			continue
+		}
+
+		bundle, err := New(e.storage, messageID)
+		if err != nil {
+			return exported, errors.Wrapf(err, "failed to create proof bundle for message %s", messageID.ToHex())
+		}
+
+		if err := bundle.WriteFile(e.FilePath(msIndex, messageID.ToHex())); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	return exported, nil
+}
+
+// containsAddress checks whether the transaction sends funds to, or was signed by one of the exporter's addresses.
+func (e *Exporter) containsAddress(transaction *iotago.Transaction) bool {
+	for _, address := range transactionAddresses(transaction) {
+		if _, exists := e.addresses[address.Key()]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionAddresses returns the addresses in the unlock conditions of the outputs
+// and the addresses of the signatures of the transaction.
+func transactionAddresses(transaction *iotago.Transaction) []iotago.Address {
+
+	var addresses []iotago.Address
+
+	for _, output := range transaction.Essence.Outputs {
+		conditions := output.UnlockConditions().MustSet()
+
+		if condition := conditions.Address(); condition != nil {
+			addresses = append(addresses, condition.Address)
+		}
+		if condition := conditions.StorageDepositReturn(); condition != nil {
+			addresses = append(addresses, condition.ReturnAddress)
+		}
+		if condition := conditions.Expiration(); condition != nil {
+			addresses = append(addresses, condition.ReturnAddress)
+		}
+		if condition := conditions.StateControllerAddress(); condition != nil {
+			addresses = append(addresses, condition.Address)
+		}
+		if condition := conditions.GovernorAddress(); condition != nil {
+			addresses = append(addresses, condition.Address)
+		}
+		if condition := conditions.ImmutableAlias(); condition != nil {
+			addresses = append(addresses, condition.Address)
+		}
+	}
+
+	for _, unlockBlock := range transaction.UnlockBlocks {
+		signatureUnlockBlock, ok := unlockBlock.(*iotago.SignatureUnlockBlock)
+		if !ok {
+			continue
+		}
+
+		if signature, ok := signatureUnlockBlock.Signature.(*iotago.Ed25519Signature); ok {
+			address := iotago.Ed25519AddressFromPubKey(signature.PublicKey[:])
+			addresses = append(addresses, &address)
+		}
+	}
+
+	return addresses
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/p2p"
 	"github.com/gohornet/hornet/pkg/profile"
+	"github.com/gohornet/hornet/pkg/tracing"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/objectstorage"
 	"github.com/iotaledger/hive.go/protocol/message"
@@ -392,6 +393,18 @@ func (proc *MessageProcessor) processMessage(p *Protocol, data []byte) {
 // it is safe to call this function for the same WorkUnit multiple times.
 func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 
+	_, span := tracing.Start(context.Background(), "MessageProcessor.processWorkUnit")
+	defer span.End()
+
+	if span.IsRecording() {
+		span.SetAttributes(tracing.String("peer.id", p.PeerID.String()))
+	}
+
+	punish := func(reason error) {
+		span.RecordError(reason)
+		wu.punish(reason)
+	}
+
 	processRequests := func(wu *WorkUnit, msg *storage.Message, isMilestonePayload bool) Requests {
 
 		var requests Requests
@@ -455,14 +468,14 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 	msg, err := storage.MessageFromBytes(wu.receivedMsgBytes, serializer.DeSeriModePerformValidation, proc.protoParas)
 	if err != nil {
 		wu.UpdateState(Invalid)
-		wu.punish(errors.WithMessagef(err, "peer sent an invalid message"))
+		punish(errors.WithMessagef(err, "peer sent an invalid message"))
 		return
 	}
 
 	// check the network ID of the message
 	if msg.ProtocolVersion() != proc.protoParas.Version {
 		wu.UpdateState(Invalid)
-		wu.punish(errors.New("peer sent a message with an invalid protocol version"))
+		punish(errors.New("peer sent a message with an invalid protocol version"))
 		return
 	}
 
@@ -471,17 +484,25 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 	// mark the message as received
 	requests := processRequests(wu, msg, isMilestonePayload)
 
+	if span.IsRecording() {
+		span.SetAttributes(
+			tracing.String("message.id", msg.MessageID().ToHex()),
+			tracing.Bool("message.milestone", isMilestonePayload),
+			tracing.Bool("message.requested", wu.requested),
+		)
+	}
+
 	if !isMilestonePayload {
 		// validate PoW score
 		if !wu.requested && pow.Score(wu.receivedMsgBytes) < proc.protoParas.MinPoWScore {
 			wu.UpdateState(Invalid)
-			wu.punish(errors.New("peer sent a msg with insufficient PoW score"))
+			punish(errors.New("peer sent a msg with insufficient PoW score"))
 			return
 		}
 	} else {
 		// enforce milestone msg nonce == 0
 		if msg.Message().Nonce != 0 {
-			wu.punish(errors.New("milestone msg nonce must be zero"))
+			punish(errors.New("milestone msg nonce must be zero"))
 		}
 
 		// TODO: refactor data flow
@@ -64,6 +64,9 @@ const (
 	// StreamCancelReasonHostShutdown defines a stream cancellation
 	// because the host is shutting down.
 	StreamCancelReasonHostShutdown StreamCancelReason = "host shutdown"
+	// StreamCancelReasonPolicyViolation defines a stream cancellation
+	// because the peer violates the peering policies.
+	StreamCancelReasonPolicyViolation StreamCancelReason = "peering policy violation"
 )
 
 var (
@@ -98,6 +101,8 @@ type ServiceOptions struct {
 	streamWriteTimeout time.Duration
 	// The amount of unknown peers to allow to have a gossip stream with.
 	unknownPeersLimit int
+	// The policy manager used to check inbound peers.
+	policyManager *p2p.PolicyManager
 }
 
 // applies the given ServiceOption.
@@ -150,6 +155,14 @@ func WithUnknownPeersLimit(limit int) ServiceOption {
 	}
 }
 
+// WithPolicyManager defines the policy manager which is used to check
+// whether inbound gossip protocol streams are allowed.
+func WithPolicyManager(policyManager *p2p.PolicyManager) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.policyManager = policyManager
+	}
+}
+
 // ServiceOption is a function setting a ServiceOptions option.
 type ServiceOption func(opts *ServiceOptions)
 
@@ -435,17 +448,24 @@ func (s *Service) handleInboundStream(stream network.Stream) {
 		return
 	}
 
-	// close if the relation to the peer is unknown and no slot is available
-	hasUnknownRelation := true
+	relation := p2p.PeerRelationUnknown
 	s.peeringManager.Call(remotePeerID, func(peer *p2p.Peer) {
-		switch peer.Relation {
-		case p2p.PeerRelationAutopeered:
-			hasUnknownRelation = false
-		case p2p.PeerRelationKnown:
-			hasUnknownRelation = false
-		}
+		relation = peer.Relation
 	})
 
+	// close if the peer violates the peering policies
+	if s.opts.policyManager != nil {
+		violation := s.opts.policyManager.CheckInbound(remotePeerID, stream.Conn().RemoteMultiaddr(), relation, s.relationCount(relation))
+		if violation != p2p.PolicyViolationNone {
+			s.Events.InboundStreamCanceled.Trigger(stream, StreamCancelReason(fmt.Sprintf("%s: %s", StreamCancelReasonPolicyViolation, violation)))
+			s.closeUnwantedStream(stream)
+			return
+		}
+	}
+
+	// close if the relation to the peer is unknown and no slot is available
+	hasUnknownRelation := relation != p2p.PeerRelationAutopeered && relation != p2p.PeerRelationKnown
+
 	var cancelReason StreamCancelReason
 	if hasUnknownRelation {
 		switch {
@@ -469,6 +489,18 @@ func (s *Service) handleInboundStream(stream network.Stream) {
 	s.registerProtocol(remotePeerID, stream)
 }
 
+// returns the amount of ongoing gossip protocol streams with peers of the given relation.
+func (s *Service) relationCount(relation p2p.PeerRelation) int {
+	var count int
+	s.peeringManager.ForEach(func(p *p2p.Peer) bool {
+		if _, ongoing := s.streams[p.ID]; ongoing {
+			count++
+		}
+		return true
+	}, relation)
+	return count
+}
+
 // closes the given unwanted stream by closing the underlying
 // connection and the stream itself.
 func (s *Service) closeUnwantedStream(stream network.Stream) {
@@ -497,6 +529,14 @@ func (s *Service) handleConnected(peer *p2p.Peer, conn network.Conn) {
 			return nil
 		}
 
+		if s.opts.policyManager != nil {
+			if violation := s.opts.policyManager.IsDenied(peer.ID, conn.RemoteMultiaddr()); violation != p2p.PolicyViolationNone {
+				// close the connection to the peer
+				_ = conn.Close()
+				return fmt.Errorf("unable to create gossip stream to %s: %s: %s", peer.ID, StreamCancelReasonPolicyViolation, violation)
+			}
+		}
+
 		if peer.Relation == p2p.PeerRelationUnknown {
 			if len(s.unknownPeers) >= s.opts.unknownPeersLimit {
 				// close the connection to the peer
@@ -0,0 +1,52 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/tracing"
+)
+
+// TracingMiddleware returns a middleware that records a span for every request.
+// The span is added to the context of the request, so that handlers can add child spans.
+func TracingMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if tracing.GetTracerProvider() == nil {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := tracing.ContextWithTraceParent(req.Context(), req.Header.Get(tracing.HeaderTraceParent))
+
+			ctx, span := tracing.StartWithKind(ctx, tracing.SpanKindServer, fmt.Sprintf("HTTP %s %s", req.Method, c.Path()),
+				tracing.String("http.method", req.Method),
+				tracing.String("http.route", c.Path()),
+				tracing.String("http.target", req.URL.Path),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			statusCode := c.Response().Status
+			if err != nil {
+				statusCode = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					statusCode = httpErr.Code
+				}
+			}
+			span.SetAttributes(tracing.Int("http.status_code", statusCode))
+			if statusCode >= http.StatusInternalServerError {
+				span.RecordError(err)
+			}
+
+			return err
+		}
+	}
+}
@@ -19,4 +19,9 @@ type Events struct {
 	SnapshotMetricsUpdated        *events.Event
 	PruningMilestoneIndexChanged  *events.Event
 	PruningMetricsUpdated         *events.Event
+	// PruningFailed is fired if a milestone could not be pruned.
+	PruningFailed *events.Event
+	// PruningMilestone is fired before the cone of a milestone gets pruned.
+	// The event is triggered synchronously, so the data of the milestone cone is still available to the handlers.
+	PruningMilestone *events.Event
 }
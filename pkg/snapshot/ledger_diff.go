@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrNoFullSnapshot is returned if a full snapshot is needed but a delta snapshot was given.
+	ErrNoFullSnapshot = errors.New("not a full snapshot")
+)
+
+// BalanceDelta is the change of the balance of an address between two ledger states.
+type BalanceDelta struct {
+	// The address that owns the outputs.
+	Address iotago.Address
+	// The difference of the balance of the address.
+	Delta int64
+}
+
+// LedgerDiff is the difference between the ledger states of two full snapshots.
+type LedgerDiff struct {
+	// The header of the source snapshot.
+	SourceHeader *ReadFileHeader
+	// The header of the target snapshot.
+	TargetHeader *ReadFileHeader
+	// The outputs that exist in the target but not in the source snapshot, in lexical order.
+	Added utxo.Outputs
+	// The outputs that exist in the source but not in the target snapshot, in lexical order.
+	Removed utxo.Outputs
+	// The balance changes of the addresses that own added or removed outputs, sorted by address.
+	// Addresses with a balance change of zero are omitted.
+	BalanceDeltas []*BalanceDelta
+}
+
+// OutputOwner returns the address that owns the output.
+// This is the state controller for alias outputs and the alias for foundry outputs.
+func OutputOwner(output iotago.Output) iotago.Address {
+	conditions := output.UnlockConditions().MustSet()
+
+	switch {
+	case conditions.Address() != nil:
+		return conditions.Address().Address
+	case conditions.StateControllerAddress() != nil:
+		return conditions.StateControllerAddress().Address
+	case conditions.ImmutableAlias() != nil:
+		return conditions.ImmutableAlias().Address
+	default:
+		return nil
+	}
+}
+
+// streamOutputs streams the header and the unspent outputs of a full snapshot.
+func streamOutputs(reader io.ReadSeeker, protoParas *iotago.ProtocolParameters, outputConsumer OutputConsumerFunc) (*ReadFileHeader, error) {
+
+	var header *ReadFileHeader
+	if err := StreamSnapshotDataFrom(reader,
+		protoParas,
+		func(readHeader *ReadFileHeader) error {
+			if readHeader.Type != Full {
+				return ErrNoFullSnapshot
+			}
+			header = readHeader
+			return nil
+		},
+		func(_ hornet.MessageID) error { return nil },
+		outputConsumer,
+		func(_ *utxo.TreasuryOutput) error { return nil },
+		func(_ *MilestoneDiff) error { return nil },
+	); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// ComputeLedgerDiff streams the outputs of the source and the target full snapshot
+// and computes the difference of their ledger states.
+// The outputs of the source snapshot are kept in memory.
+func ComputeLedgerDiff(source io.ReadSeeker, target io.ReadSeeker, protoParas *iotago.ProtocolParameters) (*LedgerDiff, error) {
+
+	sourceOutputs := make(map[iotago.OutputID]*utxo.Output)
+	sourceHeader, err := streamOutputs(source, protoParas, func(output *utxo.Output) error {
+		sourceOutputs[*output.OutputID()] = output
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read source snapshot")
+	}
+
+	diff := &LedgerDiff{SourceHeader: sourceHeader}
+
+	deltas := make(map[string]*BalanceDelta)
+	addDelta := func(output *utxo.Output, sign int64) {
+		address := OutputOwner(output.Output())
+		if address == nil {
+			return
+		}
+
+		delta, exists := deltas[address.Key()]
+		if !exists {
+			delta = &BalanceDelta{Address: address}
+			deltas[address.Key()] = delta
+		}
+		delta.Delta += sign * int64(output.Deposit())
+	}
+
+	diff.TargetHeader, err = streamOutputs(target, protoParas, func(output *utxo.Output) error {
+		if _, exists := sourceOutputs[*output.OutputID()]; exists {
+			// outputs are immutable, so an output with the same ID is unchanged
+			delete(sourceOutputs, *output.OutputID())
+			return nil
+		}
+
+		diff.Added = append(diff.Added, output)
+		addDelta(output, 1)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read target snapshot")
+	}
+
+	for _, output := range sourceOutputs {
+		diff.Removed = append(diff.Removed, output)
+		addDelta(output, -1)
+	}
+
+	sort.Sort(utxo.LexicalOrderedOutputs(diff.Added))
+	sort.Sort(utxo.LexicalOrderedOutputs(diff.Removed))
+
+	for _, delta := range deltas {
+		if delta.Delta != 0 {
+			diff.BalanceDeltas = append(diff.BalanceDeltas, delta)
+		}
+	}
+	sort.Slice(diff.BalanceDeltas, func(i, j int) bool {
+		return diff.BalanceDeltas[i].Address.Key() < diff.BalanceDeltas[j].Address.Key()
+	})
+
+	return diff, nil
+}
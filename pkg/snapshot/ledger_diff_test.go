@@ -0,0 +1,125 @@
+package snapshot_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/model/utxo/utils"
+	"github.com/gohornet/hornet/pkg/snapshot"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func writeFullSnapshot(t *testing.T, fs vfs.Filesystem, filePath string, ledgerIndex milestone.Index, outputs utxo.Outputs) vfs.File {
+
+	file, err := fs.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0666)
+	require.NoError(t, err)
+
+	header := &snapshot.FileHeader{
+		Type:                 snapshot.Full,
+		Version:              snapshot.SupportedFormatVersion,
+		NetworkID:            1337,
+		SEPMilestoneIndex:    ledgerIndex,
+		LedgerMilestoneIndex: ledgerIndex,
+		TreasuryOutput:       &utxo.TreasuryOutput{Amount: 1000},
+	}
+
+	sepAdded := false
+	outputIndex := 0
+	_, err = snapshot.StreamSnapshotDataTo(file, 0, header,
+		func() (hornet.MessageID, error) {
+			if sepAdded {
+				return nil, nil
+			}
+			sepAdded = true
+			return hornet.NullMessageID(), nil
+		},
+		func() (*utxo.Output, error) {
+			if outputIndex >= len(outputs) {
+				return nil, nil
+			}
+			outputIndex++
+			return outputs[outputIndex-1], nil
+		},
+		func() (*snapshot.MilestoneDiff, error) { return nil, nil },
+	)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	file, err = fs.OpenFile(filePath, os.O_RDONLY, 0666)
+	require.NoError(t, err)
+	return file
+}
+
+func TestComputeLedgerDiff(t *testing.T) {
+
+	address1 := utils.RandAddress(iotago.AddressEd25519)
+	address2 := utils.RandAddress(iotago.AddressEd25519)
+	address3 := utils.RandAddress(iotago.AddressEd25519)
+
+	newOutput := func(address iotago.Address, amount uint64) *utxo.Output {
+		return utxo.CreateOutput(utils.RandOutputID(), utils.RandMessageID(), 1, 0, utils.RandOutputOnAddressWithAmount(iotago.OutputBasic, address, amount))
+	}
+
+	unchanged := newOutput(address1, 100)
+	spent1 := newOutput(address1, 50)
+	spent2 := newOutput(address2, 70)
+	created1 := newOutput(address2, 70)
+	created2 := newOutput(address3, 50)
+
+	fs := memfs.Create()
+	source := writeFullSnapshot(t, fs, "source.bin", 10, utxo.Outputs{spent1, unchanged, spent2})
+	target := writeFullSnapshot(t, fs, "target.bin", 20, utxo.Outputs{created1, unchanged, created2})
+
+	diff, err := snapshot.ComputeLedgerDiff(source, target, &iotago.ProtocolParameters{})
+	require.NoError(t, err)
+
+	require.Equal(t, milestone.Index(10), diff.SourceHeader.LedgerMilestoneIndex)
+	require.Equal(t, milestone.Index(20), diff.TargetHeader.LedgerMilestoneIndex)
+
+	outputIDs := func(outputs utxo.Outputs) map[iotago.OutputID]struct{} {
+		result := make(map[iotago.OutputID]struct{})
+		for _, output := range outputs {
+			result[*output.OutputID()] = struct{}{}
+		}
+		return result
+	}
+	require.Equal(t, outputIDs(utxo.Outputs{created1, created2}), outputIDs(diff.Added))
+	require.Equal(t, outputIDs(utxo.Outputs{spent1, spent2}), outputIDs(diff.Removed))
+
+	// the balance of address2 did not change, so it is omitted
+	deltas := make(map[string]int64)
+	for _, delta := range diff.BalanceDeltas {
+		deltas[delta.Address.Key()] = delta.Delta
+	}
+	require.Equal(t, map[string]int64{
+		address1.Key(): -50,
+		address3.Key(): 50,
+	}, deltas)
+
+	// delta snapshots can't be compared
+	deltaFile, err := fs.OpenFile("delta.bin", os.O_CREATE|os.O_RDWR, 0666)
+	require.NoError(t, err)
+	_, err = snapshot.StreamSnapshotDataTo(deltaFile, 0, &snapshot.FileHeader{
+		Type:                 snapshot.Delta,
+		Version:              snapshot.SupportedFormatVersion,
+		SEPMilestoneIndex:    20,
+		LedgerMilestoneIndex: 20,
+	}, func() (hornet.MessageID, error) { return nil, nil }, nil, func() (*snapshot.MilestoneDiff, error) { return nil, nil })
+	require.NoError(t, err)
+	require.NoError(t, deltaFile.Close())
+
+	deltaFile, err = fs.OpenFile("delta.bin", os.O_RDONLY, 0666)
+	require.NoError(t, err)
+	_, err = source.Seek(0, 0)
+	require.NoError(t, err)
+
+	_, err = snapshot.ComputeLedgerDiff(source, deltaFile, &iotago.ProtocolParameters{})
+	require.ErrorIs(t, err, snapshot.ErrNoFullSnapshot)
+}
@@ -23,6 +23,27 @@ func (s *SnapshotManager) setIsPruning(value bool) {
 	s.statusLock.Unlock()
 }
 
+// SetPruningParameters replaces the parameters used for automatic pruning.
+// It waits until a running snapshot creation or pruning is finished.
+func (s *SnapshotManager) SetPruningParameters(
+	pruningMilestonesEnabled bool,
+	pruningMilestonesMaxMilestonesToKeep milestone.Index,
+	pruningSizeEnabled bool,
+	pruningSizeTargetSizeBytes int64,
+	pruningSizeThresholdPercentage float64,
+	pruningSizeCooldownTime time.Duration) {
+
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
+
+	s.pruningMilestonesEnabled = pruningMilestonesEnabled
+	s.pruningMilestonesMaxMilestonesToKeep = pruningMilestonesMaxMilestonesToKeep
+	s.pruningSizeEnabled = pruningSizeEnabled
+	s.pruningSizeTargetSizeBytes = pruningSizeTargetSizeBytes
+	s.pruningSizeThresholdPercentage = pruningSizeThresholdPercentage
+	s.pruningSizeCooldownTime = pruningSizeCooldownTime
+}
+
 func (s *SnapshotManager) calcTargetIndexBySize(targetSizeBytes ...int64) (milestone.Index, error) {
 
 	if !s.pruningSizeEnabled && len(targetSizeBytes) == 0 {
@@ -244,6 +265,8 @@ func (s *SnapshotManager) pruneDatabase(ctx context.Context, targetIndex milesto
 			continue
 		}
 
+		s.Events.PruningMilestone.Trigger(milestoneIndex)
+
 		messageIDsToDeleteMap := make(map[string]struct{})
 
 		if err := dag.TraverseParents(
@@ -272,6 +295,7 @@ func (s *SnapshotManager) pruneDatabase(ctx context.Context, targetIndex milesto
 			true); err != nil {
 			cachedMilestone.Release(true) // milestone -1
 			s.LogWarnf("Pruning milestone (%d) failed! %s", milestoneIndex, err)
+			s.Events.PruningFailed.Trigger(errors.Wrapf(err, "pruning milestone (%d) failed", milestoneIndex))
 			continue
 		}
 		timeTraverseMilestoneCone := time.Now()
@@ -290,6 +314,7 @@ func (s *SnapshotManager) pruneDatabase(ctx context.Context, targetIndex milesto
 
 		if err := s.pruneMilestone(milestoneIndex, migratedAtIndex...); err != nil {
 			s.LogWarnf("Pruning milestone (%d) failed! %s", milestoneIndex, err)
+			s.Events.PruningFailed.Trigger(errors.Wrapf(err, "pruning milestone (%d) failed", milestoneIndex))
 		}
 		timePruneMilestone := time.Now()
 
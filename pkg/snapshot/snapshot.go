@@ -156,6 +156,8 @@ func NewSnapshotManager(
 			SnapshotMetricsUpdated:        events.NewEvent(SnapshotMetricsCaller),
 			PruningMilestoneIndexChanged:  events.NewEvent(milestone.IndexCaller),
 			PruningMetricsUpdated:         events.NewEvent(PruningMetricsCaller),
+			PruningFailed:                 events.NewEvent(events.ErrorCaller),
+			PruningMilestone:              events.NewEvent(milestone.IndexCaller),
 		},
 	}
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/tracing"
 	"github.com/iotaledger/hive.go/contextutils"
 	"github.com/iotaledger/hive.go/ioutils"
 	"github.com/iotaledger/hive.go/kvstore"
@@ -360,7 +361,16 @@ func (s *SnapshotManager) createSnapshotWithoutLocking(
 	targetIndex milestone.Index,
 	filePath string,
 	writeToDatabase bool,
-	snapshotFullPath ...string) error {
+	snapshotFullPath ...string) (err error) {
+
+	ctx, span := tracing.Start(ctx, "SnapshotManager.createSnapshot",
+		tracing.String("snapshot.type", snapshotNames[snapshotType]),
+		tracing.Uint32("snapshot.target_index", uint32(targetIndex)),
+	)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 
 	s.LogInfof("creating %s snapshot for targetIndex %d", snapshotNames[snapshotType], targetIndex)
 	ts := time.Now()
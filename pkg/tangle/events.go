@@ -2,6 +2,7 @@ package tangle
 
 import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/whiteflag"
 	"github.com/iotaledger/hive.go/events"
@@ -27,6 +28,11 @@ func ConfirmedMilestoneCaller(handler interface{}, params ...interface{}) {
 	handler.(func(confirmation *whiteflag.Confirmation))(params[0].(*whiteflag.Confirmation))
 }
 
+// MilestoneStatsCaller is used to signal stored milestone confirmation statistics.
+func MilestoneStatsCaller(handler interface{}, params ...interface{}) {
+	handler.(func(stats *storage.MilestoneStats))(params[0].(*storage.MilestoneStats))
+}
+
 func MPSMetricsCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*MPSMetrics))(params[0].(*MPSMetrics))
 }
@@ -58,6 +64,7 @@ type Events struct {
 	ConfirmedMilestoneIndexChanged *events.Event
 	NewConfirmedMilestoneMetric    *events.Event
 	ConfirmationMetricsUpdated     *events.Event
+	MilestoneStatsStored           *events.Event
 	MilestoneSolidificationFailed  *events.Event
 	MilestoneTimeout               *events.Event
 	LedgerUpdated                  *events.Event
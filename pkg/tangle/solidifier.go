@@ -17,6 +17,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/tracing"
 	"github.com/gohornet/hornet/pkg/whiteflag"
 )
 
@@ -248,6 +249,9 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 
 	t.setSolidifierMilestoneIndex(milestoneIndexToSolidify)
 
+	spanCtx, span := tracing.Start(context.Background(), "Tangle.solidifyMilestone", tracing.Uint32("milestone.index", uint32(milestoneIndexToSolidify)))
+	defer span.End()
+
 	milestoneSolidificationCtx, milestoneSolidificationCancelFunc := t.newMilestoneSolidificationCtx()
 	defer milestoneSolidificationCancelFunc()
 
@@ -267,12 +271,20 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 	}()
 
 	t.LogInfof("Run solidity check for Milestone (%d)...", milestoneIndexToSolidify)
-	if becameSolid, aborted := t.SolidQueueCheck(
+	_, solidQueueCheckSpan := tracing.Start(spanCtx, "Tangle.SolidQueueCheck")
+	timeStartSolidQueueCheck := time.Now()
+	becameSolid, aborted := t.SolidQueueCheck(
 		milestoneSolidificationCtx,
 		memcachedTraverserStorage,
 		milestoneIndexToSolidify,
 		hornet.MessageIDs{milestoneMessageIDToSolidify},
-	); !becameSolid {
+	)
+	durationSolidQueueCheck := time.Since(timeStartSolidQueueCheck)
+	solidQueueCheckSpan.SetAttributes(tracing.Bool("solid", becameSolid), tracing.Bool("aborted", aborted))
+	solidQueueCheckSpan.End()
+
+	if !becameSolid {
+		span.SetAttributes(tracing.Bool("solid", false))
 		if aborted {
 			// check was aborted due to older milestones/other solidifier running
 			t.LogInfof("Aborted solid queue check for milestone %d", milestoneIndexToSolidify)
@@ -297,6 +309,9 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 		if milestoneIndexClosestNext == milestoneIndexToSolidify {
 			t.LogInfof("Milestones missing between (%d) and (%d). Search for missing milestones...", currentConfirmedIndex, milestoneIndexClosestNext)
 
+			_, searchSpan := tracing.Start(spanCtx, "Tangle.searchMissingMilestones")
+			defer searchSpan.End()
+
 			// no Milestones found in between => search an older milestone in the solid cone
 			if found, err := t.searchMissingMilestones(
 				milestoneSolidificationCtx,
@@ -322,6 +337,7 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 
 	timeStart := time.Now()
 	confirmedMilestoneStats, confirmationMetrics, err := whiteflag.ConfirmMilestone(
+		spanCtx,
 		t.storage.UTXOManager(),
 		memcachedTraverserStorage,
 		messagesMemcache.CachedMessage,
@@ -336,6 +352,10 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 		},
 		func(confirmation *whiteflag.Confirmation) {
 			timeStartConfirmation = time.Now()
+			// the white-flag messages are stored before the confirmed milestone index changes,
+			// so that inclusion proofs are available for all confirmed milestones.
+			t.storeWhiteFlagMessages(confirmation)
+			t.storeTransactionMessages(confirmation, messagesMemcache.CachedMessage)
 			if err := t.syncManager.SetConfirmedMilestoneIndex(milestoneIndexToSolidify); err != nil {
 				t.LogPanicf("SetConfirmedMilestoneIndex failed: %s", err)
 			}
@@ -404,10 +424,23 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 	confirmationMetrics.DurationMilestoneConfirmed = timeMilestoneConfirmed.Sub(timeMilestoneConfirmedSyncEvent)
 	confirmationMetrics.DurationTotal = time.Since(timeStart)
 
+	// the events of the confirmation are triggered within the callbacks of the confirmation,
+	// so their durations are added as attributes.
+	span.SetAttributes(
+		tracing.Bool("solid", true),
+		tracing.Int64("duration.set_confirmed_milestone_index_us", confirmationMetrics.DurationSetConfirmedMilestoneIndex.Microseconds()),
+		tracing.Int64("duration.update_cone_root_indexes_us", confirmationMetrics.DurationUpdateConeRootIndexes.Microseconds()),
+		tracing.Int64("duration.confirmed_milestone_changed_us", confirmationMetrics.DurationConfirmedMilestoneChanged.Microseconds()),
+		tracing.Int64("duration.confirmed_milestone_index_changed_us", confirmationMetrics.DurationConfirmedMilestoneIndexChanged.Microseconds()),
+		tracing.Int64("duration.milestone_confirmed_sync_event_us", confirmationMetrics.DurationMilestoneConfirmedSyncEvent.Microseconds()),
+		tracing.Int64("duration.milestone_confirmed_us", confirmationMetrics.DurationMilestoneConfirmed.Microseconds()),
+	)
+
 	t.Events.ConfirmationMetricsUpdated.Trigger(confirmationMetrics)
 
 	var rmpsMessage string
-	if metric, err := t.calcConfirmedMilestoneMetric(milestonePayloadToSolidify); err == nil {
+	metric, err := t.calcConfirmedMilestoneMetric(milestonePayloadToSolidify)
+	if err == nil {
 		if t.syncManager.IsNodeSynced() {
 			// Only trigger the metrics event if the node is sync (otherwise the MPS and conf.rate is wrong)
 			if t.firstSyncedMilestone == 0 {
@@ -433,6 +466,8 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 
 	t.LogInfof("New confirmed milestone: %d%s", confirmedMilestoneStats.Index, rmpsMessage)
 
+	t.storeMilestoneStats(milestonePayloadToSolidify, confirmedMilestoneStats, confirmationMetrics, durationSolidQueueCheck, metric)
+
 	// Run check for next milestone
 	t.setSolidifierMilestoneIndex(0)
 
@@ -444,6 +479,165 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex milestone.Index, force bool
 	t.milestoneSolidifierWorkerPool.TrySubmit(milestone.Index(0), false)
 }
 
+// storeWhiteFlagMessages persists the ordered lists of the messages the milestone referenced and applied,
+// which are needed to create inclusion proofs for the merkle roots of the milestone.
+func (t *Tangle) storeWhiteFlagMessages(confirmation *whiteflag.Confirmation) {
+
+	whiteFlagMessages, err := storage.NewWhiteFlagMessages(confirmation.MilestoneIndex, confirmation.Mutations.MessagesReferenced, confirmation.Mutations.MessagesIncludedWithTransactions)
+	if err != nil {
+		t.LogWarnf("creating white-flag messages of milestone %d failed: %s", confirmation.MilestoneIndex, err)
+		return
+	}
+
+	if err := t.storage.StoreWhiteFlagMessages(whiteFlagMessages); err != nil {
+		// the white-flag messages are only used for inclusion proofs, so the confirmation is not affected
+		t.LogWarnf("storing white-flag messages of milestone %d failed: %s", confirmation.MilestoneIndex, err)
+	}
+}
+
+// storeTransactionMessages persists the messages carrying transactions that were referenced by the milestone,
+// together with the reason why a transaction was excluded from the ledger and the winner of a double spend.
+// The ledger needs to be locked and the mutations of the milestone need to be applied.
+func (t *Tangle) storeTransactionMessages(confirmation *whiteflag.Confirmation, cachedMessageFunc storage.CachedMessageFunc) {
+
+	transactionMessages := make([]*storage.TransactionMessage, 0, len(confirmation.Mutations.MessagesIncludedWithTransactions)+len(confirmation.Mutations.MessagesExcludedWithConflictingTransactions))
+
+	addTransactionMessage := func(messageID hornet.MessageID, conflict storage.Conflict, doubleSpentInput *iotago.OutputID) error {
+		cachedMsg, err := cachedMessageFunc(messageID) // message +1
+		if err != nil {
+			return err
+		}
+		if cachedMsg == nil {
+			return fmt.Errorf("message not found: %s", messageID.ToHex())
+		}
+		defer cachedMsg.Release(true) // message -1
+
+		transaction := cachedMsg.Message().Transaction()
+		if transaction == nil {
+			return fmt.Errorf("message does not contain a transaction: %s", messageID.ToHex())
+		}
+
+		transactionID, err := transaction.ID()
+		if err != nil {
+			return err
+		}
+
+		transactionMessage := &storage.TransactionMessage{
+			TransactionID:              *transactionID,
+			MessageID:                  messageID,
+			ReferencedByMilestoneIndex: confirmation.MilestoneIndex,
+			Conflict:                   conflict,
+		}
+
+		if doubleSpentInput != nil {
+			transactionMessage.DoubleSpend = t.doubleSpendWinner(doubleSpentInput)
+		}
+
+		transactionMessages = append(transactionMessages, transactionMessage)
+		return nil
+	}
+
+	for _, messageID := range confirmation.Mutations.MessagesIncludedWithTransactions {
+		if err := addTransactionMessage(messageID, storage.ConflictNone, nil); err != nil {
+			t.LogWarnf("creating transaction messages of milestone %d failed: %s", confirmation.MilestoneIndex, err)
+			return
+		}
+	}
+
+	for _, conflictedMessage := range confirmation.Mutations.MessagesExcludedWithConflictingTransactions {
+		if err := addTransactionMessage(conflictedMessage.MessageID, conflictedMessage.Conflict, conflictedMessage.DoubleSpentInput); err != nil {
+			t.LogWarnf("creating transaction messages of milestone %d failed: %s", confirmation.MilestoneIndex, err)
+			return
+		}
+	}
+
+	if err := t.storage.StoreTransactionMessages(transactionMessages); err != nil {
+		// the transaction messages are only used to look up the state of transactions, so the confirmation is not affected
+		t.LogWarnf("storing transaction messages of milestone %d failed: %s", confirmation.MilestoneIndex, err)
+	}
+}
+
+// doubleSpendWinner returns the transaction and the message that spent the given input.
+// It returns nil if the spent of the input doesn't exist anymore.
+func (t *Tangle) doubleSpendWinner(inputID *iotago.OutputID) *storage.DoubleSpend {
+
+	spent, err := t.storage.UTXOManager().ReadSpentForOutputIDWithoutLocking(inputID)
+	if err != nil {
+		return nil
+	}
+
+	doubleSpend := &storage.DoubleSpend{
+		InputID:              *inputID,
+		WinningTransactionID: *spent.TargetTransactionID(),
+	}
+
+	// every transaction has at least one output, which was created by the message of the transaction
+	winningOutputID := iotago.OutputIDFromTransactionIDAndIndex(doubleSpend.WinningTransactionID, 0)
+	if winningOutput, err := t.storage.UTXOManager().ReadOutputByOutputIDWithoutLocking(&winningOutputID); err == nil {
+		doubleSpend.WinningMessageID = winningOutput.MessageID()
+	}
+
+	return doubleSpend
+}
+
+// storeMilestoneStats persists the statistics of the confirmation of a milestone,
+// so that the confirmation performance can be analyzed afterwards.
+// The metric is nil if it couldn't be calculated.
+func (t *Tangle) storeMilestoneStats(milestonePayload *iotago.Milestone, confirmedMilestoneStats *whiteflag.ConfirmedMilestoneStats, confirmationMetrics *whiteflag.ConfirmationMetrics, durationSolidification time.Duration, metric *ConfirmedMilestoneMetric) {
+
+	stats := &storage.MilestoneStats{
+		Index:                               confirmedMilestoneStats.Index,
+		MilestoneTimestamp:                  milestonePayload.Timestamp,
+		ConfirmedAt:                         time.Now().UnixMilli(),
+		MessagesReferenced:                  uint32(confirmedMilestoneStats.MessagesReferenced),
+		MessagesIncludedWithTransactions:    uint32(confirmedMilestoneStats.MessagesIncludedWithTransactions),
+		MessagesExcludedWithoutTransactions: uint32(confirmedMilestoneStats.MessagesExcludedWithoutTransactions),
+		MessagesExcludedWithConflictingTransactions: uint32(confirmedMilestoneStats.MessagesExcludedWithConflictingTransactions),
+		ConflictsByReason: make(map[storage.Conflict]uint32, len(confirmedMilestoneStats.ConflictsByReason)),
+		OutputsCreated:    uint32(confirmedMilestoneStats.OutputsCreated),
+		OutputsConsumed:   uint32(confirmedMilestoneStats.OutputsConsumed),
+		Synced:            t.syncManager.IsNodeSynced(),
+		Durations: storage.MilestoneStatsDurations{
+			Solidification:                           durationSolidification,
+			Whiteflag:                                confirmationMetrics.DurationWhiteflag,
+			Receipts:                                 confirmationMetrics.DurationReceipts,
+			Confirmation:                             confirmationMetrics.DurationConfirmation,
+			ApplyIncludedWithTransactions:            confirmationMetrics.DurationApplyIncludedWithTransactions,
+			ApplyExcludedWithoutTransactions:         confirmationMetrics.DurationApplyExcludedWithoutTransactions,
+			ApplyExcludedWithConflictingTransactions: confirmationMetrics.DurationApplyExcludedWithConflictingTransactions,
+			OnMilestoneConfirmed:                     confirmationMetrics.DurationOnMilestoneConfirmed,
+			SetConfirmedMilestoneIndex:               confirmationMetrics.DurationSetConfirmedMilestoneIndex,
+			UpdateConeRootIndexes:                    confirmationMetrics.DurationUpdateConeRootIndexes,
+			ConfirmedMilestoneChanged:                confirmationMetrics.DurationConfirmedMilestoneChanged,
+			ConfirmedMilestoneIndexChanged:           confirmationMetrics.DurationConfirmedMilestoneIndexChanged,
+			MilestoneConfirmedSyncEvent:              confirmationMetrics.DurationMilestoneConfirmedSyncEvent,
+			MilestoneConfirmed:                       confirmationMetrics.DurationMilestoneConfirmed,
+			LedgerUpdated:                            confirmationMetrics.DurationLedgerUpdated,
+			TreasuryMutated:                          confirmationMetrics.DurationTreasuryMutated,
+			Total:                                    confirmationMetrics.DurationTotal,
+		},
+	}
+
+	for conflict, count := range confirmedMilestoneStats.ConflictsByReason {
+		stats.ConflictsByReason[conflict] = uint32(count)
+	}
+
+	if metric != nil {
+		stats.MPS = metric.MPS
+		stats.RMPS = metric.RMPS
+		stats.ReferencedRate = metric.ReferencedRate
+		stats.TimeSinceLastMilestone = metric.TimeSinceLastMilestone
+	}
+
+	if err := t.storage.StoreMilestoneStats(stats); err != nil {
+		// the stats are only used for analysis, so the confirmation is not affected
+		t.LogWarnf("storing stats of milestone %d failed: %s", stats.Index, err)
+		return
+	}
+
+	t.Events.MilestoneStatsStored.Trigger(stats)
+}
+
 func (t *Tangle) calcConfirmedMilestoneMetric(milestonePayloadToSolidify *iotago.Milestone) (*ConfirmedMilestoneMetric, error) {
 
 	index := milestone.Index(milestonePayloadToSolidify.Index)
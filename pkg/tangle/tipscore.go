@@ -2,6 +2,7 @@ package tangle
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/model/hornet"
@@ -19,6 +20,24 @@ const (
 	TipScoreHealthy
 )
 
+// String returns a human readable representation of the TipScore.
+func (s TipScore) String() string {
+	switch s {
+	case TipScoreNotFound:
+		return "notFound"
+	case TipScoreBelowMaxDepth:
+		return "belowMaxDepth"
+	case TipScoreYCRIThresholdReached:
+		return "ycriThresholdReached"
+	case TipScoreOCRIThresholdReached:
+		return "ocriThresholdReached"
+	case TipScoreHealthy:
+		return "healthy"
+	default:
+		return fmt.Sprintf("unknown (%d)", s)
+	}
+}
+
 type TipScoreCalculator struct {
 	storage *storage.Storage
 	// maxDeltaMsgYoungestConeRootIndexToCMI is the maximum allowed delta
@@ -53,20 +72,30 @@ func (t *TipScoreCalculator) TipScore(ctx context.Context, messageID hornet.Mess
 		return TipScoreNotFound, err
 	}
 
+	return t.TipScoreForConeRootIndexes(ycri, ocri, cmi), nil
+}
+
+// BelowMaxDepth returns the maximum allowed delta value between OCRI of a given message in relation to the current CMI before it gets lazy.
+func (t *TipScoreCalculator) BelowMaxDepth() milestone.Index {
+	return t.belowMaxDepth
+}
+
+// TipScoreForConeRootIndexes returns the score of a tip with the given cone root indexes in relation to the given CMI.
+func (t *TipScoreCalculator) TipScoreForConeRootIndexes(ycri milestone.Index, ocri milestone.Index, cmi milestone.Index) TipScore {
 	// if the OCRI to CMI delta is over BelowMaxDepth/below-max-depth, then the tip is lazy
 	if (cmi - ocri) > t.belowMaxDepth {
-		return TipScoreBelowMaxDepth, nil
+		return TipScoreBelowMaxDepth
 	}
 
 	// if the CMI to YCRI delta is over maxDeltaMsgYoungestConeRootIndexToCMI, then the tip is lazy
 	if (cmi - ycri) > t.maxDeltaMsgYoungestConeRootIndexToCMI {
-		return TipScoreYCRIThresholdReached, nil
+		return TipScoreYCRIThresholdReached
 	}
 
 	// if the OCRI to CMI delta is over maxDeltaMsgOldestConeRootIndexToCMI, the tip is semi-lazy
 	if (cmi - ocri) > t.maxDeltaMsgOldestConeRootIndexToCMI {
-		return TipScoreOCRIThresholdReached, nil
+		return TipScoreOCRIThresholdReached
 	}
 
-	return TipScoreHealthy, nil
+	return TipScoreHealthy
 }
@@ -53,6 +53,7 @@ func (te *TestEnvironment) configureCoordinator(cooPrivateKeys []ed25519.Private
 	}()
 
 	confirmedMilestoneStats, _, err := whiteflag.ConfirmMilestone(
+		context.Background(),
 		te.UTXOManager(),
 		memcachedParentsTraverserStorage,
 		messagesMemcache.CachedMessage,
@@ -149,6 +150,7 @@ func (te *TestEnvironment) PerformWhiteFlagConfirmation(milestonePayload *iotago
 
 	var wfConf *whiteflag.Confirmation
 	confirmedMilestoneStats, _, err := whiteflag.ConfirmMilestone(
+		context.Background(),
 		te.UTXOManager(),
 		memcachedParentsTraverserStorage,
 		messagesMemcache.CachedMessage,
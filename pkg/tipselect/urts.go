@@ -169,6 +169,31 @@ func New(
 	}
 }
 
+// SetRetentionRules replaces the retention rules of the tip pools.
+// The new rules are applied to all subsequent checks of the tips.
+func (ts *TipSelector) SetRetentionRules(
+	retentionRulesTipsLimitNonLazy int,
+	maxReferencedTipAgeNonLazy time.Duration,
+	maxChildrenNonLazy uint32,
+	spammerTipsThresholdNonLazy int,
+	retentionRulesTipsLimitSemiLazy int,
+	maxReferencedTipAgeSemiLazy time.Duration,
+	maxChildrenSemiLazy uint32,
+	spammerTipsThresholdSemiLazy int) {
+
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
+
+	ts.retentionRulesTipsLimitNonLazy = retentionRulesTipsLimitNonLazy
+	ts.maxReferencedTipAgeNonLazy = maxReferencedTipAgeNonLazy
+	ts.maxChildrenNonLazy = maxChildrenNonLazy
+	ts.spammerTipsThresholdNonLazy = spammerTipsThresholdNonLazy
+	ts.retentionRulesTipsLimitSemiLazy = retentionRulesTipsLimitSemiLazy
+	ts.maxReferencedTipAgeSemiLazy = maxReferencedTipAgeSemiLazy
+	ts.maxChildrenSemiLazy = maxChildrenSemiLazy
+	ts.spammerTipsThresholdSemiLazy = spammerTipsThresholdSemiLazy
+}
+
 // AddTip adds the given message as a tip.
 func (ts *TipSelector) AddTip(messageMeta *storage.MessageMetadata) {
 	ts.tipsLock.Lock()
@@ -396,7 +421,12 @@ func (ts *TipSelector) SelectNonLazyTips() (hornet.MessageIDs, error) {
 }
 
 func (ts *TipSelector) SelectSpammerTips() (isSemiLazy bool, tips hornet.MessageIDs, err error) {
-	if ts.spammerTipsThresholdSemiLazy != 0 && len(ts.semiLazyTipsMap) > ts.spammerTipsThresholdSemiLazy {
+	ts.tipsLock.Lock()
+	spammerTipsThresholdNonLazy := ts.spammerTipsThresholdNonLazy
+	spammerTipsThresholdSemiLazy := ts.spammerTipsThresholdSemiLazy
+	ts.tipsLock.Unlock()
+
+	if spammerTipsThresholdSemiLazy != 0 && len(ts.semiLazyTipsMap) > spammerTipsThresholdSemiLazy {
 		// threshold was defined and reached, return semi-lazy tips for the spammer
 		tips, err = ts.SelectSemiLazyTips()
 		if err != nil {
@@ -411,7 +441,7 @@ func (ts *TipSelector) SelectSpammerTips() (isSemiLazy bool, tips hornet.Message
 		return true, tips, nil
 	}
 
-	if ts.spammerTipsThresholdNonLazy != 0 && len(ts.nonLazyTipsMap) < ts.spammerTipsThresholdNonLazy {
+	if spammerTipsThresholdNonLazy != 0 && len(ts.nonLazyTipsMap) < spammerTipsThresholdNonLazy {
 		// if a threshold was defined and not reached, do not return tips for the spammer
 		return false, nil, fmt.Errorf("%w: non-lazy threshold not reached", ErrNoTipsAvailable)
 	}
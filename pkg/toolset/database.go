@@ -7,16 +7,19 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
 
 	databasecore "github.com/gohornet/hornet/core/database"
 	"github.com/gohornet/hornet/core/protocfg"
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/keymanager"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/milestonemanager"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/snapshot"
+	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/serializer/v2"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
@@ -26,21 +29,61 @@ var (
 	ErrCritical = errors.New("critical error")
 )
 
-func getMilestoneManagerFromConfigFile(filePath string) (*milestonemanager.MilestoneManager, error) {
+// getKeyManagerFromConfigFile returns the key manager with the public key ranges of the coordinator
+// and the amount of public keys in a milestone from the config file.
+// The public key ranges that were added at runtime are loaded from the key rotation file of the config.
+func getKeyManagerFromConfigFile(filePath string) (*keymanager.KeyManager, int, error) {
 
 	_, err := loadConfigFile(filePath, map[string]any{
 		"protocol": protocfg.ParamsProtocol,
 	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	keyManager, err := protocfg.KeyManagerWithConfigPublicKeyRanges(protocfg.ParamsProtocol.PublicKeyRanges)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := protocfg.LoadAuthorizedKeyRanges(keyManager, protocfg.ParamsProtocol.KeyRotation.FilePath); err != nil {
+		return nil, 0, err
+	}
+
+	return keyManager, protocfg.ParamsProtocol.MilestonePublicKeyCount, nil
+}
+
+// getProtocolParameters returns the protocol parameters from the config file,
+// or the default protocol parameters if no config file is given.
+func getProtocolParameters(configFilePath string) (*iotago.ProtocolParameters, error) {
+
+	config := configuration.New()
+	flagset := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	config.BindParameters(flagset, "protocol", protocfg.ParamsProtocol)
+
+	// load the default values of the parameters
+	if err := config.LoadFlagSet(flagset); err != nil {
+		return nil, fmt.Errorf("loading default protocol parameters failed: %w", err)
+	}
+
+	if len(configFilePath) > 0 {
+		if err := config.LoadFile(configFilePath); err != nil {
+			return nil, fmt.Errorf("loading config file failed: %w", err)
+		}
+	}
+	config.UpdateBoundParameters()
+
+	return protocfg.ProtocolParametersFromConfig(protocfg.ParamsProtocol), nil
+}
+
+func getMilestoneManagerFromConfigFile(filePath string) (*milestonemanager.MilestoneManager, error) {
+
+	keyManager, milestonePublicKeyCount, err := getKeyManagerFromConfigFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	return milestonemanager.New(nil, nil, keyManager, protocfg.ParamsProtocol.MilestonePublicKeyCount), nil
+	return milestonemanager.New(nil, nil, keyManager, milestonePublicKeyCount), nil
 }
 
 func checkDatabaseHealth(storage *storage.Storage, markTainted bool) error {
@@ -321,6 +321,7 @@ func copyAndVerifyMilestoneCone(
 	timeCopyMilestoneCone := time.Now()
 
 	confirmedMilestoneStats, _, err := whiteflag.ConfirmMilestone(
+		ctx,
 		utxoManagerTarget,
 		parentsTraverserStorageTarget,
 		cachedMessageFuncTarget,
@@ -0,0 +1,61 @@
+package toolset
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/core/protocfg"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/ioutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestGetKeyManagerFromConfigFile(t *testing.T) {
+
+	configKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	rotatedKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	tempDir := t.TempDir()
+	configFilePath := filepath.Join(tempDir, "config.json")
+	keyRangesFilePath := filepath.Join(tempDir, "keyranges.json")
+
+	require.NoError(t, ioutils.WriteJSONToFile(configFilePath, map[string]any{
+		"protocol": map[string]any{
+			"milestonePublicKeyCount": 1,
+			"publicKeyRanges": protocfg.ConfigPublicKeyRanges{
+				{Key: hex.EncodeToString(configKey), StartIndex: 0, EndIndex: 100},
+			},
+			"keyRotation": map[string]any{
+				"filePath": keyRangesFilePath,
+			},
+		},
+	}, 0660))
+
+	var rotatedPublicKey iotago.MilestonePublicKey
+	copy(rotatedPublicKey[:], rotatedKey)
+
+	// the key ranges file doesn't exist yet
+	keyManager, milestonePublicKeyCount, err := getKeyManagerFromConfigFile(configFilePath)
+	require.NoError(t, err)
+	require.Equal(t, 1, milestonePublicKeyCount)
+	require.Empty(t, keyManager.AuthorizedKeyRanges())
+	require.NotContains(t, keyManager.PublicKeysForMilestoneIndex(milestone.Index(150)), rotatedPublicKey)
+
+	// the rotated key range was added at runtime by the node
+	require.NoError(t, ioutils.WriteJSONToFile(keyRangesFilePath, protocfg.ConfigPublicKeyRanges{
+		{Key: hex.EncodeToString(rotatedKey), StartIndex: 90, EndIndex: 200},
+	}, 0660))
+
+	keyManager, _, err = getKeyManagerFromConfigFile(configFilePath)
+	require.NoError(t, err)
+	require.Len(t, keyManager.AuthorizedKeyRanges(), 1)
+	require.Contains(t, keyManager.PublicKeysForMilestoneIndex(milestone.Index(150)), rotatedPublicKey)
+	require.NotContains(t, keyManager.PublicKeysForMilestoneIndex(milestone.Index(201)), rotatedPublicKey)
+}
@@ -230,6 +230,7 @@ func verifyDatabase(
 		// we re-confirm the existing milestones in the source database, but apply the
 		// ledger changes to the temporary UTXOManager.
 		_, _, err = whiteflag.ConfirmMilestone(
+			ctx,
 			utxoManagerTemp,
 			storeSource,
 			storeSource.CachedMessage,
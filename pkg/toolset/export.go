@@ -0,0 +1,166 @@
+package toolset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	databasecore "github.com/gohornet/hornet/core/database"
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/export"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// defaultExportPartitionSize is the default amount of milestones per exported partition.
+	defaultExportPartitionSize = 10000
+)
+
+// getReadOnlyStorage opens the tangle and the utxo database in read-only mode.
+func getReadOnlyStorage(path string, dbEngineStr string) (*storage.Storage, error) {
+
+	dbEngine, err := database.DatabaseEngineFromStringAllowed(dbEngineStr, database.EnginePebble, database.EngineRocksDB, database.EngineAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseExists, err := database.DatabaseExists(path)
+	if err != nil {
+		return nil, err
+	}
+	if !databaseExists {
+		return nil, fmt.Errorf("database does not exist (%s)", path)
+	}
+
+	openStore := func(name string, directoryName string) (kvstore.KVStore, error) {
+		store, err := database.StoreReadOnly(filepath.Join(path, directoryName), dbEngine)
+		if err != nil {
+			return nil, fmt.Errorf("%s database initialization failed: %w", name, err)
+		}
+		return store, nil
+	}
+
+	storeTangle, err := openStore("tangle", databasecore.TangleDatabaseDirectoryName)
+	if err != nil {
+		return nil, err
+	}
+
+	storeUTXO, err := openStore("utxo", databasecore.UTXODatabaseDirectoryName)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStorage, err := storage.New(storeTangle, storeUTXO)
+	if err != nil {
+		return nil, fmt.Errorf("storage initialization failed: %w", err)
+	}
+
+	if err := checkSnapshotInfo(dbStorage); err != nil {
+		return nil, fmt.Errorf("storage initialization failed: %w", err)
+	}
+
+	return dbStorage, nil
+}
+
+func exportData(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	databasePathFlag := fs.String(FlagToolDatabasePath, "", "the path to the database (optional)")
+	databaseEngineFlag := fs.String(FlagToolDatabaseEngine, string(database.EngineAuto), "the engine of the database (optional, values: pebble, rocksdb, auto)")
+	fullSnapshotPathFlag := fs.String(FlagToolSnapshotPathFull, "", "the path to the full snapshot file (optional)")
+	deltaSnapshotPathFlag := fs.String(FlagToolSnapshotPathDelta, "", "the path to the delta snapshot file (optional)")
+	outputPathFlag := fs.String(FlagToolOutputPath, "", "the directory the partitions are written to")
+	partitionSizeFlag := fs.Uint32(FlagToolExportPartitionSize, defaultExportPartitionSize, "the amount of milestones per partition")
+	targetIndexFlag := fs.Uint32(FlagToolDatabaseTargetIndex, 0, "the target index (optional, default the last available milestone)")
+	hrpFlag := fs.String(FlagToolHRP, string(iotago.PrefixMainnet), "the HRP which should be used for the Bech32 addresses")
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the protocol parameters (optional, otherwise the default protocol parameters are used)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolExport)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %d",
+			ToolExport,
+			FlagToolDatabasePath,
+			DefaultValueMainnetDatabasePath,
+			FlagToolOutputPath,
+			"export",
+			FlagToolExportPartitionSize,
+			defaultExportPartitionSize))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*databasePathFlag) == 0 && len(*fullSnapshotPathFlag) == 0 {
+		return fmt.Errorf("either '%s' or '%s' must be specified", FlagToolDatabasePath, FlagToolSnapshotPathFull)
+	}
+	if len(*databasePathFlag) > 0 && len(*fullSnapshotPathFlag) > 0 {
+		return fmt.Errorf("'%s' and '%s' can't be combined", FlagToolDatabasePath, FlagToolSnapshotPathFull)
+	}
+	if len(*deltaSnapshotPathFlag) > 0 && len(*fullSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathFull)
+	}
+	if len(*outputPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolOutputPath)
+	}
+	if len(*hrpFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolHRP)
+	}
+
+	exporter, err := export.NewExporter(*outputPathFlag, *partitionSizeFlag, iotago.NetworkPrefix(*hrpFlag))
+	if err != nil {
+		return err
+	}
+
+	var source export.Source
+	if len(*databasePathFlag) > 0 {
+		dbStorage, err := getReadOnlyStorage(*databasePathFlag, *databaseEngineFlag)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			dbStorage.ShutdownStorages()
+			_ = dbStorage.FlushAndCloseStores()
+		}()
+
+		source = export.NewStorageSource(dbStorage)
+	} else {
+		protoParas, err := getProtocolParameters(*configFilePathFlag)
+		if err != nil {
+			return err
+		}
+
+		snapshotSource, err := export.NewSnapshotSource(*fullSnapshotPathFlag, *deltaSnapshotPathFlag, protoParas)
+		if err != nil {
+			return err
+		}
+
+		source = snapshotSource
+	}
+
+	state, err := exporter.State()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exporting to %s (last exported milestone: %d)...\n", *outputPathFlag, state.LastExportedIndex)
+
+	ts := time.Now()
+
+	lastExportedIndex, err := exporter.Export(getGracefulStopContext(), source, milestone.Index(*targetIndexFlag))
+	if err != nil {
+		return fmt.Errorf("export failed after milestone %d: %w", lastExportedIndex, err)
+	}
+
+	fmt.Printf("successfully exported up to milestone %d, took %v\n", lastExportedIndex, time.Since(ts).Truncate(time.Millisecond))
+
+	return nil
+}
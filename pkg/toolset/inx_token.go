@@ -0,0 +1,57 @@
+package toolset
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/configuration"
+
+	"github.com/gohornet/hornet/pkg/inxauth"
+)
+
+func generateINXToken(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	tokenFlag := fs.String(FlagToolINXToken, "", "an existing token of an INX extension (optional, a random token is generated if not set)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolINXToken)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s", ToolINXToken))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	token := *tokenFlag
+	if len(token) == 0 {
+		tokenBytes := make([]byte, 32)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			return err
+		}
+		token = hex.EncodeToString(tokenBytes)
+	}
+
+	result := struct {
+		Token     string `json:"token"`
+		TokenHash string `json:"tokenHash"`
+	}{
+		Token:     token,
+		TokenHash: inxauth.HashToken(token),
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	fmt.Println("Your INX extension token: ", result.Token)
+	fmt.Println("Your INX token hash:      ", result.TokenHash)
+
+	return nil
+}
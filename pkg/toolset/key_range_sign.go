@@ -0,0 +1,88 @@
+package toolset
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/crypto"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func signKeyRange(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	networkNameFlag := fs.String(FlagToolNetworkName, "", "the network name of the network the key range is meant for")
+	publicKeyFlag := fs.String(FlagToolPublicKey, "", "the ed25519 public key of the new key range")
+	startIndexFlag := fs.Uint32(FlagToolKeyRangeStartIndex, 0, "the milestone index the public key is valid from")
+	endIndexFlag := fs.Uint32(FlagToolKeyRangeEndIndex, 0, "the milestone index the public key is valid until (0 = forever)")
+	privateKeyFlag := fs.String(FlagToolPrivateKey, "", "the ed25519 private key of a currently valid milestone signer")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolKeyRangeSign)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %d --%s %d --%s %s",
+			ToolKeyRangeSign,
+			FlagToolNetworkName,
+			"chrysalis-mainnet",
+			FlagToolPublicKey,
+			"[PUB_KEY]",
+			FlagToolKeyRangeStartIndex,
+			1000,
+			FlagToolKeyRangeEndIndex,
+			0,
+			FlagToolPrivateKey,
+			"[PRIV_KEY]",
+		))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*networkNameFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolNetworkName)
+	}
+
+	publicKey, err := crypto.ParseEd25519PublicKeyFromString(*publicKeyFlag)
+	if err != nil {
+		return fmt.Errorf("can't decode '%s': %w", FlagToolPublicKey, err)
+	}
+
+	privateKey, err := crypto.ParseEd25519PrivateKeyFromString(*privateKeyFlag)
+	if err != nil {
+		return fmt.Errorf("can't decode '%s': %w", FlagToolPrivateKey, err)
+	}
+
+	keyRange := &keymanager.KeyRange{
+		StartIndex: milestone.Index(*startIndexFlag),
+		EndIndex:   milestone.Index(*endIndexFlag),
+	}
+	copy(keyRange.PublicKey[:], publicKey)
+
+	if keyRange.EndIndex != 0 && keyRange.EndIndex < keyRange.StartIndex {
+		return fmt.Errorf("'%s' must not be smaller than '%s'", FlagToolKeyRangeEndIndex, FlagToolKeyRangeStartIndex)
+	}
+
+	signature := keymanager.SignKeyRange(iotago.NetworkIDFromString(*networkNameFlag), keyRange, []ed25519.PrivateKey{privateKey})[0]
+
+	result := &keymanager.KeyRangeAuthorizationSignature{
+		PublicKey: iotago.EncodeHex(signature.PublicKey[:]),
+		Signature: iotago.EncodeHex(signature.Signature[:]),
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	fmt.Println("Signer public key: ", result.PublicKey)
+	fmt.Println("Signature:         ", result.Signature)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package toolset
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/iota.go/api"
+)
+
+func legacyArchive(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	apiAddressFlag := fs.String(FlagToolLegacyArchiveAPIAddress, "http://localhost:14266", "the address of the legacy node API")
+	apiTimeoutFlag := fs.Duration(FlagToolLegacyArchiveAPITimeout, 30*time.Second, "the timeout of API calls")
+	coordinatorAddressFlag := fs.String(FlagToolLegacyArchiveCoordinatorAddress, "UDYXTZBE9GZGPM9SSQV9LTZNDLJIZMPUVVXYXFYVBLIEUHLSEWFTKZZLXYRHHWVQV9MNNX9KZC9D9UZWZ", "the address of the legacy coordinator")
+	merkleTreeDepthFlag := fs.Int(FlagToolLegacyArchiveMerkleTreeDepth, 24, "the depth of the Merkle tree of the legacy coordinator")
+	startIndexFlag := fs.Uint32(FlagToolLegacyArchiveStartIndex, 0, "the index of the first legacy milestone to archive")
+	endIndexFlag := fs.Uint32(FlagToolLegacyArchiveEndIndex, 0, "the index of the last legacy milestone to archive (optional, default latest solid milestone)")
+	outputFilePathFlag := fs.String(FlagToolOutputPath, "legacy_archive.json", "the file path of the legacy archive")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolLegacyArchive)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %d --%s %s",
+			ToolLegacyArchive,
+			FlagToolLegacyArchiveAPIAddress,
+			"http://localhost:14266",
+			FlagToolLegacyArchiveStartIndex,
+			3000000,
+			FlagToolOutputPath,
+			"legacy_archive.json"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*apiAddressFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolLegacyArchiveAPIAddress)
+	}
+	if len(*coordinatorAddressFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolLegacyArchiveCoordinatorAddress)
+	}
+	if *startIndexFlag == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolLegacyArchiveStartIndex)
+	}
+	if len(*outputFilePathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolOutputPath)
+	}
+
+	iotaAPI, err := api.ComposeAPI(api.HTTPClientSettings{
+		URI:    *apiAddressFlag,
+		Client: &http.Client{Timeout: *apiTimeoutFlag},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize API: %w", err)
+	}
+
+	endIndex := *endIndexFlag
+	if endIndex == 0 {
+		info, err := iotaAPI.GetNodeInfo()
+		if err != nil {
+			return fmt.Errorf("unable to query node info: %w", err)
+		}
+		endIndex = uint32(info.LatestSolidSubtangleMilestoneIndex)
+	}
+
+	if endIndex < *startIndexFlag {
+		return fmt.Errorf("'%s' (%d) is smaller than '%s' (%d)", FlagToolLegacyArchiveEndIndex, endIndex, FlagToolLegacyArchiveStartIndex, *startIndexFlag)
+	}
+
+	fmt.Printf("fetching white-flag confirmations of legacy milestones %d-%d from %s...\n", *startIndexFlag, endIndex, *apiAddressFlag)
+
+	ts := time.Now()
+
+	archive, err := migrator.ExportLegacyArchive(iotaAPI, *startIndexFlag, endIndex)
+	if err != nil {
+		return err
+	}
+
+	// validate the fetched data against the legacy coordinator before writing the archive
+	validator := migrator.NewValidator(migrator.NewArchiveAPI(archive), *coordinatorAddressFlag, *merkleTreeDepthFlag)
+
+	var migrations int
+	var migratedFunds uint64
+	for msIndex := archive.StartIndex; msIndex <= archive.EndIndex; msIndex++ {
+		entries, err := validator.QueryMigratedFunds(msIndex)
+		if err != nil {
+			return fmt.Errorf("white-flag confirmation of milestone %d is invalid: %w", msIndex, err)
+		}
+
+		migrations += len(entries)
+		for _, entry := range entries {
+			migratedFunds += entry.Deposit
+		}
+	}
+
+	if err := migrator.WriteLegacyArchive(*outputFilePathFlag, archive); err != nil {
+		return fmt.Errorf("unable to write legacy archive: %w", err)
+	}
+
+	fmt.Printf(`    >
+        - Milestones:     %d-%d
+        - Migrations:     %d
+        - Migrated funds: %d
+        - Archive:        %s
+        - Took:           %v`+"\n",
+		archive.StartIndex,
+		archive.EndIndex,
+		migrations,
+		migratedFunds,
+		*outputFilePathFlag,
+		time.Since(ts).Truncate(time.Millisecond),
+	)
+
+	return nil
+}
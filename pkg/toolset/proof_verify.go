@@ -0,0 +1,110 @@
+package toolset
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/configuration"
+	iotago "github.com/iotaledger/iota.go/v3"
+
+	"github.com/gohornet/hornet/pkg/proofbundle"
+)
+
+func proofVerify(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the public key ranges of the coordinator")
+	bundlePathFlag := fs.String(FlagToolProofBundlePath, "", "the path to the proof bundle file")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolProofVerify)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s",
+			ToolProofVerify,
+			FlagToolConfigFilePath,
+			"config.json",
+			FlagToolProofBundlePath,
+			"proofbundles/1234_0x5bd7...json"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*configFilePathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolConfigFilePath)
+	}
+	if len(*bundlePathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolProofBundlePath)
+	}
+
+	keyManager, milestonePublicKeyCount, err := getKeyManagerFromConfigFile(*configFilePathFlag)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := proofbundle.ReadFile(*bundlePathFlag)
+	if err != nil {
+		return err
+	}
+
+	verified, err := bundle.Verify(keyManager, milestonePublicKeyCount)
+	if err != nil {
+		return err
+	}
+
+	milestoneID, err := verified.Milestone.ID()
+	if err != nil {
+		return err
+	}
+
+	var transactionID string
+	if transaction, ok := verified.Message.Payload.(*iotago.Transaction); ok {
+		txID, err := transaction.ID()
+		if err != nil {
+			return err
+		}
+		transactionID = iotago.EncodeHex(txID[:])
+	}
+
+	if *outputJSONFlag {
+		result := struct {
+			Valid              bool      `json:"valid"`
+			MessageID          string    `json:"messageId"`
+			TransactionID      string    `json:"transactionId,omitempty"`
+			MilestoneID        string    `json:"milestoneId"`
+			MilestoneIndex     uint32    `json:"milestoneIndex"`
+			MilestoneTimestamp time.Time `json:"milestoneTimestamp"`
+		}{
+			Valid:              true,
+			MessageID:          verified.MessageID.ToHex(),
+			TransactionID:      transactionID,
+			MilestoneID:        iotago.EncodeHex(milestoneID[:]),
+			MilestoneIndex:     verified.Milestone.Index,
+			MilestoneTimestamp: time.Unix(int64(verified.Milestone.Timestamp), 0),
+		}
+
+		return printJSON(result)
+	}
+
+	fmt.Printf(`    >
+        - Valid:               %s
+        - Message ID:          %s
+        - Transaction ID:      %s
+        - Milestone ID:        %s
+        - Milestone index:     %d
+        - Milestone timestamp: %s`+"\n",
+		yesOrNo(true),
+		verified.MessageID.ToHex(),
+		transactionID,
+		iotago.EncodeHex(milestoneID[:]),
+		verified.Milestone.Index,
+		time.Unix(int64(verified.Milestone.Timestamp), 0).Truncate(time.Second),
+	)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package toolset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	flag "github.com/spf13/pflag"
+
+	databasecore "github.com/gohornet/hornet/core/database"
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+func printReceiptAudit(result *migrator.AuditResult) {
+
+	for _, receipt := range result.Receipts {
+		treasuryInput := fmt.Sprintf("%d", receipt.TreasuryInputAmount)
+		if receipt.TreasuryInputPruned {
+			treasuryInput = "pruned"
+		}
+
+		fmt.Printf(`    > Receipt (milestone %d)
+        - Migrated at:     %d
+        - Final:           %s
+        - Entries:         %d
+        - Migrated funds:  %d
+        - Treasury input:  %s
+        - Treasury output: %d`+"\n",
+			receipt.MilestoneIndex,
+			receipt.MigratedAt,
+			yesOrNo(receipt.Final),
+			receipt.Entries,
+			receipt.MigratedFunds,
+			treasuryInput,
+			receipt.TreasuryOutputAmount,
+		)
+	}
+
+	backups := "not checked"
+	if result.BackupsChecked {
+		backups = fmt.Sprintf("checked, %d without stored receipt", len(result.BackupsWithoutReceipt))
+	}
+
+	fmt.Printf(`    >
+        - Receipts:             %d
+        - Total migrated funds: %d
+        - Unspent treasury:     %d
+        - Backups:              %s
+        - Issues:               %d`+"\n\n",
+		len(result.Receipts),
+		result.TotalMigratedFunds,
+		result.UnspentTreasuryAmount,
+		backups,
+		len(result.Issues),
+	)
+
+	for _, issue := range result.Issues {
+		fmt.Printf("[%s] milestone %d, migrated at %d: %s\n", issue.Type, issue.MilestoneIndex, issue.MigratedAt, issue.Message)
+	}
+}
+
+func receiptAudit(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	databasePathFlag := fs.String(FlagToolDatabasePath, DefaultValueMainnetDatabasePath, "the path to the database")
+	databaseEngineFlag := fs.String(FlagToolDatabaseEngine, string(database.EngineAuto), "the engine of the database (optional, values: pebble, rocksdb, auto)")
+	backupPathFlag := fs.String(FlagToolReceiptAuditBackupPath, "", "the path to the receipts backup folder (optional)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolReceiptAudit)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s",
+			ToolReceiptAudit,
+			FlagToolDatabasePath,
+			DefaultValueMainnetDatabasePath,
+			FlagToolReceiptAuditBackupPath,
+			"receipts"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*databasePathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolDatabasePath)
+	}
+
+	dbEngine, err := database.DatabaseEngineFromStringAllowed(*databaseEngineFlag, database.EnginePebble, database.EngineRocksDB, database.EngineAuto)
+	if err != nil {
+		return err
+	}
+
+	utxoDatabasePath := filepath.Join(*databasePathFlag, databasecore.UTXODatabaseDirectoryName)
+	if _, err := os.Stat(utxoDatabasePath); err != nil {
+		return fmt.Errorf("'%s' (%s) does not exist", FlagToolDatabasePath, utxoDatabasePath)
+	}
+
+	utxoStore, err := database.StoreReadOnly(utxoDatabasePath, dbEngine)
+	if err != nil {
+		return fmt.Errorf("%s database initialization failed: %w", databasecore.UTXODatabaseDirectoryName, err)
+	}
+	defer func() { _ = utxoStore.Close() }()
+
+	result, err := migrator.AuditReceipts(utxo.New(utxoStore), *backupPathFlag)
+	if err != nil {
+		return err
+	}
+
+	if *outputJSONFlag {
+		if err := printJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printReceiptAudit(result)
+	}
+
+	if !result.Passed() {
+		return fmt.Errorf("receipt audit found %d issues", len(result.Issues))
+	}
+
+	return nil
+}
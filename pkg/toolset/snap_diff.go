@@ -0,0 +1,212 @@
+package toolset
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/configuration"
+	iotago "github.com/iotaledger/iota.go/v3"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/snapshot"
+)
+
+const (
+	snapDiffFormatJSON = "json"
+	snapDiffFormatCSV  = "csv"
+
+	snapDiffChangeAdded   = "added"
+	snapDiffChangeRemoved = "removed"
+	snapDiffChangeBalance = "balance"
+)
+
+// snapshotBalanceDelta is the representation of a balance change of an address between two snapshot files.
+type snapshotBalanceDelta struct {
+	Address string `json:"address"`
+	Delta   int64  `json:"delta"`
+}
+
+// snapshotDiffResult is the representation of the difference between the ledger states of two snapshot files.
+type snapshotDiffResult struct {
+	SourceLedgerIndex milestone.Index         `json:"sourceLedgerIndex"`
+	TargetLedgerIndex milestone.Index         `json:"targetLedgerIndex"`
+	SourceTreasury    uint64                  `json:"sourceTreasury"`
+	TargetTreasury    uint64                  `json:"targetTreasury"`
+	Added             []*snapshotOutput       `json:"added"`
+	Removed           []*snapshotOutput       `json:"removed"`
+	BalanceDeltas     []*snapshotBalanceDelta `json:"balanceDeltas"`
+}
+
+func newSnapshotDiffResult(diff *snapshot.LedgerDiff, hrp iotago.NetworkPrefix) (*snapshotDiffResult, error) {
+
+	result := &snapshotDiffResult{
+		SourceLedgerIndex: diff.SourceHeader.LedgerMilestoneIndex,
+		TargetLedgerIndex: diff.TargetHeader.LedgerMilestoneIndex,
+		Added:             make([]*snapshotOutput, 0, len(diff.Added)),
+		Removed:           make([]*snapshotOutput, 0, len(diff.Removed)),
+		BalanceDeltas:     make([]*snapshotBalanceDelta, 0, len(diff.BalanceDeltas)),
+	}
+
+	if diff.SourceHeader.TreasuryOutput != nil {
+		result.SourceTreasury = diff.SourceHeader.TreasuryOutput.Amount
+	}
+	if diff.TargetHeader.TreasuryOutput != nil {
+		result.TargetTreasury = diff.TargetHeader.TreasuryOutput.Amount
+	}
+
+	for _, output := range diff.Added {
+		snapOutput, err := newSnapshotOutput(output, hrp, false)
+		if err != nil {
+			return nil, err
+		}
+		result.Added = append(result.Added, snapOutput)
+	}
+
+	for _, output := range diff.Removed {
+		snapOutput, err := newSnapshotOutput(output, hrp, false)
+		if err != nil {
+			return nil, err
+		}
+		result.Removed = append(result.Removed, snapOutput)
+	}
+
+	for _, delta := range diff.BalanceDeltas {
+		result.BalanceDeltas = append(result.BalanceDeltas, &snapshotBalanceDelta{
+			Address: delta.Address.Bech32(hrp),
+			Delta:   delta.Delta,
+		})
+	}
+
+	return result, nil
+}
+
+// writeSnapshotDiffCSV writes the added and removed outputs and the balance changes as CSV records.
+func writeSnapshotDiffCSV(writer io.Writer, result *snapshotDiffResult) error {
+
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write([]string{"change", "outputId", "outputType", "address", "amount"}); err != nil {
+		return err
+	}
+
+	writeOutputs := func(change string, outputs []*snapshotOutput) error {
+		for _, output := range outputs {
+			if err := csvWriter.Write([]string{change, output.OutputID, output.OutputType, output.Address, strconv.FormatUint(output.Amount, 10)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := writeOutputs(snapDiffChangeAdded, result.Added); err != nil {
+		return err
+	}
+	if err := writeOutputs(snapDiffChangeRemoved, result.Removed); err != nil {
+		return err
+	}
+
+	for _, delta := range result.BalanceDeltas {
+		if err := csvWriter.Write([]string{snapDiffChangeBalance, "", "", delta.Address, strconv.FormatInt(delta.Delta, 10)}); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func snapshotDiff(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	sourceSnapshotPathFlag := fs.String(FlagToolSnapshotPathSource, "", "the path to the source full snapshot file")
+	targetSnapshotPathFlag := fs.String(FlagToolSnapshotPathTarget, "", "the path to the target full snapshot file")
+	formatFlag := fs.String(FlagToolSnapDiffFormat, snapDiffFormatJSON, fmt.Sprintf("the output format of the diff (%s, %s)", snapDiffFormatJSON, snapDiffFormatCSV))
+	outputFilePathFlag := fs.String(FlagToolOutputPath, "", "the file path to write the diff to (optional, default stdout)")
+	hrpFlag := fs.String(FlagToolHRP, string(iotago.PrefixMainnet), "the HRP which should be used for the Bech32 addresses")
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the protocol parameters (optional, otherwise the default protocol parameters are used)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapDiff)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %s",
+			ToolSnapDiff,
+			FlagToolSnapshotPathSource,
+			"snapshots/mainnet/full_snapshot.bin",
+			FlagToolSnapshotPathTarget,
+			"external/full_snapshot.bin",
+			FlagToolSnapDiffFormat,
+			snapDiffFormatCSV))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*sourceSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathSource)
+	}
+	if len(*targetSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathTarget)
+	}
+	if len(*hrpFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolHRP)
+	}
+
+	switch *formatFlag {
+	case snapDiffFormatJSON, snapDiffFormatCSV:
+	default:
+		return fmt.Errorf("unknown '%s': %s", FlagToolSnapDiffFormat, *formatFlag)
+	}
+
+	sourceFile, err := os.Open(*sourceSnapshotPathFlag)
+	if err != nil {
+		return fmt.Errorf("unable to open source snapshot file: %w", err)
+	}
+	defer func() { _ = sourceFile.Close() }()
+
+	targetFile, err := os.Open(*targetSnapshotPathFlag)
+	if err != nil {
+		return fmt.Errorf("unable to open target snapshot file: %w", err)
+	}
+	defer func() { _ = targetFile.Close() }()
+
+	protoParas, err := getProtocolParameters(*configFilePathFlag)
+	if err != nil {
+		return err
+	}
+
+	diff, err := snapshot.ComputeLedgerDiff(sourceFile, targetFile, protoParas)
+	if err != nil {
+		return err
+	}
+
+	result, err := newSnapshotDiffResult(diff, iotago.NetworkPrefix(*hrpFlag))
+	if err != nil {
+		return err
+	}
+
+	var writer io.Writer = os.Stdout
+	if len(*outputFilePathFlag) > 0 {
+		outputFile, err := os.Create(*outputFilePathFlag)
+		if err != nil {
+			return fmt.Errorf("unable to create output file: %w", err)
+		}
+		defer func() { _ = outputFile.Close() }()
+
+		writer = outputFile
+	}
+
+	if *formatFlag == snapDiffFormatCSV {
+		return writeSnapshotDiffCSV(writer, result)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -8,6 +8,7 @@ import (
 
 	flag "github.com/spf13/pflag"
 
+	"github.com/gohornet/hornet/pkg/genesis"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/utxo"
@@ -19,10 +20,13 @@ import (
 func snapshotGen(args []string) error {
 
 	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
-	networkNameFlag := fs.String(FlagToolNetworkName, "", "the network ID for which this snapshot is meant for")
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the protocol parameters (optional, otherwise the default protocol parameters are used)")
+	networkNameFlag := fs.String(FlagToolNetworkName, "", "the network ID for which this snapshot is meant for (optional, otherwise the network name of the protocol parameters is used)")
 	mintAddressFlag := fs.String(FlagToolSnapGenMintAddress, "", "the initial ed25519 address all the tokens will be minted to")
-	treasuryAllocationFlag := fs.Uint64(FlagToolSnapGenTreasuryAllocation, 0, "the amount of tokens to reside within the treasury, the delta from the supply will be allocated to 'mintAddress'")
+	allocationsFileFlag := fs.String(FlagToolSnapGenAllocationsFile, "", "the JSON or CSV file with the allocations of the supply (can't be combined with 'mintAddress')")
+	treasuryAllocationFlag := fs.Uint64(FlagToolSnapGenTreasuryAllocation, 0, "the amount of tokens to reside within the treasury, the delta from the supply will be allocated to 'mintAddress' or the allocations")
 	outputFilePathFlag := fs.String(FlagToolOutputPath, "", "the file path to the generated snapshot file")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
 
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapGen)
@@ -37,46 +41,76 @@ func snapshotGen(args []string) error {
 			"500000000",
 			FlagToolOutputPath,
 			"snapshots/private_tangle/full_snapshot.bin"))
+		println(fmt.Sprintf("example: %s --%s %s --%s %s --%s %s --%s %s",
+			ToolSnapGen,
+			FlagToolConfigFilePath,
+			"config.json",
+			FlagToolSnapGenAllocationsFile,
+			"allocations.csv",
+			FlagToolSnapGenTreasuryAllocation,
+			"500000000",
+			FlagToolOutputPath,
+			"snapshots/private_tangle/full_snapshot.bin"))
 	}
 
 	if err := parseFlagSet(fs, args); err != nil {
 		return err
 	}
 
-	if len(*networkNameFlag) == 0 {
-		return fmt.Errorf("'%s' not specified", FlagToolNetworkName)
+	protoParas, err := getProtocolParameters(*configFilePathFlag)
+	if err != nil {
+		return err
 	}
 
-	protoParas := &iotago.ProtocolParameters{
-		Version:       2,
-		NetworkName:   *networkNameFlag,
-		Bech32HRP:     iotago.PrefixTestnet,
-		MinPoWScore:   0,
-		BelowMaxDepth: 15,
-		RentStructure: iotago.RentStructure{
-			VByteCost:    0,
-			VBFactorData: 0,
-			VBFactorKey:  0,
-		},
-		TokenSupply: 2_779_530_283_277_761,
+	if len(*networkNameFlag) > 0 {
+		protoParas.NetworkName = *networkNameFlag
 	}
 
-	// check mint address
-	if len(*mintAddressFlag) == 0 {
-		return fmt.Errorf("'%s' not specified", FlagToolSnapGenMintAddress)
-	}
-	addressBytes, err := hex.DecodeString(*mintAddressFlag)
-	if err != nil {
-		return fmt.Errorf("can't decode '%s': %w'", FlagToolSnapGenMintAddress, err)
-	}
-	if len(addressBytes) != iotago.Ed25519AddressBytesLength {
-		return fmt.Errorf("incorrect '%s' length: %d != %d (%s)", FlagToolSnapGenMintAddress, len(addressBytes), iotago.Ed25519AddressBytesLength, *mintAddressFlag)
+	treasury := *treasuryAllocationFlag
+	if treasury > protoParas.TokenSupply {
+		return fmt.Errorf("'%s' exceeds the token supply: %d > %d", FlagToolSnapGenTreasuryAllocation, treasury, protoParas.TokenSupply)
 	}
 
-	var address iotago.Ed25519Address
-	copy(address[:], addressBytes)
+	var outputs []iotago.Output
+	switch {
+	case len(*mintAddressFlag) > 0 && len(*allocationsFileFlag) > 0:
+		return fmt.Errorf("'%s' and '%s' can't be combined", FlagToolSnapGenMintAddress, FlagToolSnapGenAllocationsFile)
 
-	treasury := *treasuryAllocationFlag
+	case len(*allocationsFileFlag) > 0:
+		allocations, err := genesis.ReadAllocationsFile(*allocationsFileFlag)
+		if err != nil {
+			return err
+		}
+
+		outputs, err = genesis.Build(allocations, treasury, protoParas)
+		if err != nil {
+			return err
+		}
+
+	case len(*mintAddressFlag) > 0:
+		addressBytes, err := hex.DecodeString(*mintAddressFlag)
+		if err != nil {
+			return fmt.Errorf("can't decode '%s': %w'", FlagToolSnapGenMintAddress, err)
+		}
+		if len(addressBytes) != iotago.Ed25519AddressBytesLength {
+			return fmt.Errorf("incorrect '%s' length: %d != %d (%s)", FlagToolSnapGenMintAddress, len(addressBytes), iotago.Ed25519AddressBytesLength, *mintAddressFlag)
+		}
+
+		var address iotago.Ed25519Address
+		copy(address[:], addressBytes)
+
+		outputs = []iotago.Output{
+			&iotago.BasicOutput{
+				Amount: protoParas.TokenSupply - treasury,
+				Conditions: iotago.UnlockConditions{
+					&iotago.AddressUnlockCondition{Address: &address},
+				},
+			},
+		}
+
+	default:
+		return fmt.Errorf("'%s' or '%s' not specified", FlagToolSnapGenMintAddress, FlagToolSnapGenAllocationsFile)
+	}
 
 	// check filepath
 	if len(*outputFilePathFlag) == 0 {
@@ -127,20 +161,20 @@ func snapshotGen(args []string) error {
 	}
 
 	// unspent transaction outputs
-	outputAdded := false
+	summary := genesis.NewSummary()
+	outputIndex := 0
 	outputProducerFunc := func() (*utxo.Output, error) {
-		if outputAdded {
+		if outputIndex >= len(outputs) {
 			return nil, nil
 		}
 
-		outputAdded = true
+		output := outputs[outputIndex]
+		outputID := genesis.OutputID(uint32(outputIndex))
+		outputIndex++
 
-		return utxo.CreateOutput(&iotago.OutputID{}, hornet.NullMessageID(), 0, 0, &iotago.BasicOutput{
-			Amount: protoParas.TokenSupply - treasury,
-			Conditions: iotago.UnlockConditions{
-				&iotago.AddressUnlockCondition{Address: &address},
-			},
-		}), nil
+		summary.AddOutput(output)
+
+		return utxo.CreateOutput(&outputID, hornet.NullMessageID(), 0, 0, output), nil
 	}
 
 	// milestone diffs
@@ -163,6 +197,12 @@ func snapshotGen(args []string) error {
 		return fmt.Errorf("unable to rename temp snapshot file: %w", err)
 	}
 
+	if *outputJSONFlag {
+		return printJSON(summary)
+	}
+
 	fmt.Println("Snapshot creation successful!")
+	printAllocationSummary(summary)
+
 	return nil
 }
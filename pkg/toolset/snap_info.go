@@ -3,11 +3,16 @@ package toolset
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	flag "github.com/spf13/pflag"
 
 	"github.com/iotaledger/hive.go/configuration"
+	iotago "github.com/iotaledger/iota.go/v3"
 
+	"github.com/gohornet/hornet/pkg/genesis"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/snapshot"
 )
 
@@ -15,6 +20,8 @@ func snapshotInfo(args []string) error {
 
 	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
 	snapshotPathFlag := fs.String(FlagToolSnapshotPath, "", "the path to the snapshot file")
+	allocationsFlag := fs.Bool(FlagToolSnapInfoAllocations, false, "print a summary of the allocated outputs (full snapshots only)")
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the protocol parameters (optional, otherwise the default protocol parameters are used)")
 	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
 
 	fs.Usage = func() {
@@ -40,5 +47,89 @@ func snapshotInfo(args []string) error {
 		return err
 	}
 
-	return printSnapshotHeaderInfo("", filePath, readFileHeader, *outputJSONFlag)
+	if err := printSnapshotHeaderInfo("", filePath, readFileHeader, *outputJSONFlag); err != nil {
+		return err
+	}
+
+	if !*allocationsFlag {
+		return nil
+	}
+
+	if readFileHeader.Type != snapshot.Full {
+		return fmt.Errorf("'%s' is only supported for full snapshots", FlagToolSnapInfoAllocations)
+	}
+
+	protoParas, err := getProtocolParameters(*configFilePathFlag)
+	if err != nil {
+		return err
+	}
+
+	summary, err := snapshotAllocationSummary(filePath, protoParas)
+	if err != nil {
+		return err
+	}
+
+	if *outputJSONFlag {
+		return printJSON(summary)
+	}
+
+	printAllocationSummary(summary)
+
+	return nil
+}
+
+// snapshotAllocationSummary summarizes the outputs of a full snapshot file.
+func snapshotAllocationSummary(filePath string, protoParas *iotago.ProtocolParameters) (*genesis.Summary, error) {
+
+	snapshotFile, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open snapshot file: %w", err)
+	}
+	defer func() { _ = snapshotFile.Close() }()
+
+	summary := genesis.NewSummary()
+
+	if err := snapshot.StreamSnapshotDataFrom(snapshotFile,
+		protoParas,
+		func(_ *snapshot.ReadFileHeader) error { return nil },
+		func(_ hornet.MessageID) error { return nil },
+		func(output *utxo.Output) error {
+			summary.AddOutput(output.Output())
+			return nil
+		},
+		func(_ *utxo.TreasuryOutput) error { return nil },
+		func(_ *snapshot.MilestoneDiff) error { return nil },
+	); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot file: %w", err)
+	}
+
+	return summary, nil
+}
+
+func printAllocationSummary(summary *genesis.Summary) {
+
+	fmt.Printf(`    >
+        - Outputs:            %d
+        - Addresses:          %d
+        - Amount:             %d
+        - Timelocked outputs: %d
+        - Timelocked amount:  %d
+        - Native token outputs: %d`+"\n",
+		summary.Outputs,
+		summary.Addresses,
+		summary.Amount,
+		summary.TimelockedOutputs,
+		summary.TimelockedAmount,
+		summary.NativeTokenOutputs,
+	)
+
+	outputTypes := make([]string, 0, len(summary.OutputsByOutputType))
+	for outputType := range summary.OutputsByOutputType {
+		outputTypes = append(outputTypes, outputType)
+	}
+	sort.Strings(outputTypes)
+
+	for _, outputType := range outputTypes {
+		fmt.Printf("        - %s: %d outputs, amount %d\n", outputType, summary.OutputsByOutputType[outputType], summary.AmountByOutputType[outputType])
+	}
 }
@@ -0,0 +1,309 @@
+package toolset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/configuration"
+	iotago "github.com/iotaledger/iota.go/v3"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/snapshot"
+)
+
+// snapshotOutput is the representation of an output of a snapshot file.
+type snapshotOutput struct {
+	OutputID             string           `json:"outputId"`
+	OutputType           string           `json:"outputType"`
+	Address              string           `json:"address,omitempty"`
+	Amount               uint64           `json:"amount"`
+	MilestoneIndexBooked milestone.Index  `json:"milestoneIndexBooked"`
+	RawOutput            *json.RawMessage `json:"output,omitempty"`
+}
+
+// snapshotMilestoneDiff is the representation of a milestone diff of a snapshot file.
+type snapshotMilestoneDiff struct {
+	MilestoneIndex      milestone.Index   `json:"milestoneIndex"`
+	MilestoneID         string            `json:"milestoneId"`
+	Created             []*snapshotOutput `json:"created"`
+	Consumed            []*snapshotOutput `json:"consumed"`
+	SpentTreasury       bool              `json:"spentTreasury"`
+	SpentTreasuryTokens uint64            `json:"spentTreasuryTokens,omitempty"`
+}
+
+// snapshotTreasury is the representation of the unspent treasury output of a snapshot file.
+type snapshotTreasury struct {
+	MilestoneID string `json:"milestoneId"`
+	Tokens      uint64 `json:"tokens"`
+}
+
+func newSnapshotOutput(output *utxo.Output, hrp iotago.NetworkPrefix, withRawOutput bool) (*snapshotOutput, error) {
+
+	result := &snapshotOutput{
+		OutputID:             output.OutputID().ToHex(),
+		OutputType:           output.OutputType().String(),
+		Amount:               output.Deposit(),
+		MilestoneIndexBooked: output.MilestoneIndex(),
+	}
+
+	if address := snapshot.OutputOwner(output.Output()); address != nil {
+		result.Address = address.Bech32(hrp)
+	}
+
+	if withRawOutput {
+		rawOutputJSON, err := output.Output().MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("marshaling output %s failed: %w", output.OutputID().ToHex(), err)
+		}
+		rawOutput := json.RawMessage(rawOutputJSON)
+		result.RawOutput = &rawOutput
+	}
+
+	return result, nil
+}
+
+func printSnapshotOutput(output *snapshotOutput) {
+	fmt.Printf(`    > Output %s
+        - Type:            %s
+        - Address:         %s
+        - Amount:          %d
+        - Milestone index: %d`+"\n",
+		output.OutputID,
+		output.OutputType,
+		output.Address,
+		output.Amount,
+		output.MilestoneIndexBooked,
+	)
+}
+
+func snapshotInspect(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	snapshotPathFlag := fs.String(FlagToolSnapshotPath, "", "the path to the snapshot file")
+	outputIDFlag := fs.String(FlagToolSnapInspectOutputID, "", "print the output with the given ID (full snapshots only)")
+	addressFlag := fs.String(FlagToolSnapInspectAddress, "", "print the outputs owned by the given bech32 address (full snapshots only)")
+	treasuryFlag := fs.Bool(FlagToolSnapInspectTreasury, false, "print the unspent treasury output (full snapshots only)")
+	solidEntryPointsFlag := fs.Bool(FlagToolSnapInspectSolidEntryPoints, false, "print the solid entry points")
+	msDiffStartIndexFlag := fs.Uint32(FlagToolSnapInspectMilestoneDiffStartIndex, 0, "print the milestone diffs starting from this index (0 = disabled)")
+	msDiffEndIndexFlag := fs.Uint32(FlagToolSnapInspectMilestoneDiffEndIndex, 0, "print the milestone diffs up to this index (0 = last milestone diff)")
+	hrpFlag := fs.String(FlagToolHRP, string(iotago.PrefixMainnet), "the HRP which should be used for the Bech32 addresses")
+	configFilePathFlag := fs.String(FlagToolConfigFilePath, "", "the path to the config file that contains the protocol parameters (optional, otherwise the default protocol parameters are used)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapInspect)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s",
+			ToolSnapInspect,
+			FlagToolSnapshotPath,
+			"snapshots/mainnet/full_snapshot.bin",
+			FlagToolSnapInspectAddress,
+			"[BECH32_ADDRESS]"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*snapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPath)
+	}
+	if len(*hrpFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolHRP)
+	}
+	hrp := iotago.NetworkPrefix(*hrpFlag)
+
+	var outputID *iotago.OutputID
+	if len(*outputIDFlag) > 0 {
+		outputIDBytes, err := iotago.DecodeHex(*outputIDFlag)
+		if err != nil {
+			return fmt.Errorf("can't decode '%s': %w", FlagToolSnapInspectOutputID, err)
+		}
+		if len(outputIDBytes) != iotago.OutputIDLength {
+			return fmt.Errorf("incorrect '%s' length: %d != %d (%s)", FlagToolSnapInspectOutputID, len(outputIDBytes), iotago.OutputIDLength, *outputIDFlag)
+		}
+		outputID = &iotago.OutputID{}
+		copy(outputID[:], outputIDBytes)
+	}
+
+	var address iotago.Address
+	if len(*addressFlag) > 0 {
+		var err error
+		if _, address, err = iotago.ParseBech32(*addressFlag); err != nil {
+			return fmt.Errorf("can't decode '%s': %w", FlagToolSnapInspectAddress, err)
+		}
+	}
+
+	msDiffStartIndex := milestone.Index(*msDiffStartIndexFlag)
+	msDiffEndIndex := milestone.Index(*msDiffEndIndexFlag)
+	if msDiffEndIndex != 0 && msDiffEndIndex < msDiffStartIndex {
+		return fmt.Errorf("'%s' is smaller than '%s'", FlagToolSnapInspectMilestoneDiffEndIndex, FlagToolSnapInspectMilestoneDiffStartIndex)
+	}
+
+	if outputID == nil && address == nil && !*treasuryFlag && !*solidEntryPointsFlag && msDiffStartIndex == 0 {
+		return fmt.Errorf("no query specified, use '%s', '%s', '%s', '%s' or '%s'",
+			FlagToolSnapInspectOutputID,
+			FlagToolSnapInspectAddress,
+			FlagToolSnapInspectTreasury,
+			FlagToolSnapInspectSolidEntryPoints,
+			FlagToolSnapInspectMilestoneDiffStartIndex)
+	}
+
+	snapshotFile, err := os.Open(*snapshotPathFlag)
+	if err != nil {
+		return fmt.Errorf("unable to open snapshot file: %w", err)
+	}
+	defer func() { _ = snapshotFile.Close() }()
+
+	result := struct {
+		Outputs          []*snapshotOutput        `json:"outputs,omitempty"`
+		Treasury         *snapshotTreasury        `json:"treasury,omitempty"`
+		SolidEntryPoints []string                 `json:"solidEntryPoints,omitempty"`
+		MilestoneDiffs   []*snapshotMilestoneDiff `json:"milestoneDiffs,omitempty"`
+	}{}
+
+	var fileType snapshot.Type
+	headerConsumer := func(header *snapshot.ReadFileHeader) error {
+		fileType = header.Type
+		return nil
+	}
+
+	sepConsumer := func(solidEntryPoint hornet.MessageID) error {
+		if *solidEntryPointsFlag {
+			result.SolidEntryPoints = append(result.SolidEntryPoints, solidEntryPoint.ToHex())
+		}
+		return nil
+	}
+
+	outputConsumer := func(output *utxo.Output) error {
+		switch {
+		case outputID != nil && *output.OutputID() == *outputID:
+		case address != nil && address.Equal(snapshot.OutputOwner(output.Output())):
+		default:
+			return nil
+		}
+
+		snapOutput, err := newSnapshotOutput(output, hrp, *outputJSONFlag)
+		if err != nil {
+			return err
+		}
+		result.Outputs = append(result.Outputs, snapOutput)
+		return nil
+	}
+
+	treasuryConsumer := func(output *utxo.TreasuryOutput) error {
+		if *treasuryFlag && output != nil {
+			result.Treasury = &snapshotTreasury{
+				MilestoneID: iotago.EncodeHex(output.MilestoneID[:]),
+				Tokens:      output.Amount,
+			}
+		}
+		return nil
+	}
+
+	msDiffConsumer := func(msDiff *snapshot.MilestoneDiff) error {
+		msIndex := milestone.Index(msDiff.Milestone.Index)
+		if msDiffStartIndex == 0 || msIndex < msDiffStartIndex || (msDiffEndIndex != 0 && msIndex > msDiffEndIndex) {
+			return nil
+		}
+
+		milestoneID, err := msDiff.Milestone.ID()
+		if err != nil {
+			return err
+		}
+
+		snapMsDiff := &snapshotMilestoneDiff{
+			MilestoneIndex: msIndex,
+			MilestoneID:    iotago.EncodeHex(milestoneID[:]),
+			Created:        make([]*snapshotOutput, 0, len(msDiff.Created)),
+			Consumed:       make([]*snapshotOutput, 0, len(msDiff.Consumed)),
+		}
+
+		for _, output := range msDiff.Created {
+			snapOutput, err := newSnapshotOutput(output, hrp, *outputJSONFlag)
+			if err != nil {
+				return err
+			}
+			snapMsDiff.Created = append(snapMsDiff.Created, snapOutput)
+		}
+
+		for _, spent := range msDiff.Consumed {
+			snapOutput, err := newSnapshotOutput(spent.Output(), hrp, *outputJSONFlag)
+			if err != nil {
+				return err
+			}
+			snapMsDiff.Consumed = append(snapMsDiff.Consumed, snapOutput)
+		}
+
+		if msDiff.SpentTreasuryOutput != nil {
+			snapMsDiff.SpentTreasury = true
+			snapMsDiff.SpentTreasuryTokens = msDiff.SpentTreasuryOutput.Amount
+		}
+
+		result.MilestoneDiffs = append(result.MilestoneDiffs, snapMsDiff)
+		return nil
+	}
+
+	protoParas, err := getProtocolParameters(*configFilePathFlag)
+	if err != nil {
+		return err
+	}
+
+	if err := snapshot.StreamSnapshotDataFrom(snapshotFile, protoParas, headerConsumer, sepConsumer, outputConsumer, treasuryConsumer, msDiffConsumer); err != nil {
+		return fmt.Errorf("unable to read snapshot file: %w", err)
+	}
+
+	if fileType != snapshot.Full && (outputID != nil || address != nil || *treasuryFlag) {
+		return fmt.Errorf("'%s', '%s' and '%s' are only supported for full snapshots", FlagToolSnapInspectOutputID, FlagToolSnapInspectAddress, FlagToolSnapInspectTreasury)
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	if outputID != nil || address != nil {
+		fmt.Printf("Outputs: %d\n", len(result.Outputs))
+		for _, output := range result.Outputs {
+			printSnapshotOutput(output)
+		}
+	}
+
+	if *treasuryFlag {
+		if result.Treasury == nil {
+			fmt.Println("Treasury: no treasury output in snapshot")
+		} else {
+			fmt.Printf("Treasury: milestone ID %s, tokens %d\n", result.Treasury.MilestoneID, result.Treasury.Tokens)
+		}
+	}
+
+	if *solidEntryPointsFlag {
+		fmt.Printf("Solid entry points: %d\n", len(result.SolidEntryPoints))
+		for _, solidEntryPoint := range result.SolidEntryPoints {
+			fmt.Printf("    > %s\n", solidEntryPoint)
+		}
+	}
+
+	if msDiffStartIndex != 0 {
+		fmt.Printf("Milestone diffs: %d\n", len(result.MilestoneDiffs))
+		for _, msDiff := range result.MilestoneDiffs {
+			fmt.Printf(`    > Milestone %d
+        - Milestone ID:     %s
+        - Created outputs:  %d
+        - Consumed outputs: %d
+        - Spent treasury:   %s`+"\n",
+				msDiff.MilestoneIndex,
+				msDiff.MilestoneID,
+				len(msDiff.Created),
+				len(msDiff.Consumed),
+				yesOrNo(msDiff.SpentTreasury),
+			)
+		}
+	}
+
+	return nil
+}
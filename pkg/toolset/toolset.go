@@ -32,6 +32,7 @@ const (
 	FlagToolSnapshotPathFull   = "fullSnapshotPath"
 	FlagToolSnapshotPathDelta  = "deltaSnapshotPath"
 	FlagToolSnapshotPathTarget = "targetSnapshotPath"
+	FlagToolSnapshotPathSource = "sourceSnapshotPath"
 
 	FlagToolOutputPath = "outputPath"
 
@@ -55,9 +56,39 @@ const (
 
 	FlagToolCoordinatorFixStateCooStateFilePath = "stateFilePath"
 
+	FlagToolINXToken = "token"
+
+	FlagToolProofBundlePath = "bundlePath"
+
+	FlagToolKeyRangeStartIndex = "startIndex"
+	FlagToolKeyRangeEndIndex   = "endIndex"
+
 	FlagToolSnapGenMintAddress        = "mintAddress"
+	FlagToolSnapGenAllocationsFile    = "allocationsFile"
 	FlagToolSnapGenTreasuryAllocation = "treasuryAllocation"
 
+	FlagToolSnapInfoAllocations = "allocations"
+
+	FlagToolSnapInspectOutputID                = "outputID"
+	FlagToolSnapInspectAddress                 = "address"
+	FlagToolSnapInspectTreasury                = "treasury"
+	FlagToolSnapInspectSolidEntryPoints        = "solidEntryPoints"
+	FlagToolSnapInspectMilestoneDiffStartIndex = "msDiffStartIndex"
+	FlagToolSnapInspectMilestoneDiffEndIndex   = "msDiffEndIndex"
+
+	FlagToolSnapDiffFormat = "format"
+
+	FlagToolExportPartitionSize = "partitionSize"
+
+	FlagToolReceiptAuditBackupPath = "backupPath"
+
+	FlagToolLegacyArchiveAPIAddress         = "apiAddress"
+	FlagToolLegacyArchiveAPITimeout         = "apiTimeout"
+	FlagToolLegacyArchiveCoordinatorAddress = "coordinatorAddress"
+	FlagToolLegacyArchiveMerkleTreeDepth    = "merkleTreeDepth"
+	FlagToolLegacyArchiveStartIndex         = "startIndex"
+	FlagToolLegacyArchiveEndIndex           = "endIndex"
+
 	FlagToolDatabaseTargetIndex            = "targetIndex"
 	FlagToolDatabaseMergeNodeURL           = "nodeURL"
 	FlagToolDatabaseMergeChronicle         = "chronicleMode"
@@ -70,11 +101,15 @@ const (
 	ToolP2PExtractIdentity = "p2pidentity-extract"
 	ToolEd25519Key         = "ed25519-key"
 	ToolEd25519Addr        = "ed25519-addr"
+	ToolKeyRangeSign       = "key-range-sign"
 	ToolJWTApi             = "jwt-api"
+	ToolINXToken           = "inx-token"
 	ToolSnapGen            = "snap-gen"
 	ToolSnapMerge          = "snap-merge"
 	ToolSnapInfo           = "snap-info"
 	ToolSnapHash           = "snap-hash"
+	ToolSnapInspect        = "snap-inspect"
+	ToolSnapDiff           = "snap-diff"
 	ToolBenchmarkIO        = "bench-io"
 	ToolBenchmarkCPU       = "bench-cpu"
 	ToolDatabaseLedgerHash = "db-hash"
@@ -83,6 +118,10 @@ const (
 	ToolDatabaseMigration  = "db-migration"
 	ToolDatabaseSnapshot   = "db-snapshot"
 	ToolDatabaseVerify     = "db-verify"
+	ToolProofVerify        = "proof-verify"
+	ToolExport             = "export"
+	ToolReceiptAudit       = "receipt-audit"
+	ToolLegacyArchive      = "legacy-archive"
 )
 
 const (
@@ -127,11 +166,15 @@ func HandleTools() {
 		ToolP2PExtractIdentity: extractP2PIdentity,
 		ToolEd25519Key:         generateEd25519Key,
 		ToolEd25519Addr:        generateEd25519Address,
+		ToolKeyRangeSign:       signKeyRange,
 		ToolJWTApi:             generateJWTApiToken,
+		ToolINXToken:           generateINXToken,
 		ToolSnapGen:            snapshotGen,
 		ToolSnapMerge:          snapshotMerge,
 		ToolSnapInfo:           snapshotInfo,
 		ToolSnapHash:           snapshotHash,
+		ToolSnapInspect:        snapshotInspect,
+		ToolSnapDiff:           snapshotDiff,
 		ToolBenchmarkIO:        benchmarkIO,
 		ToolBenchmarkCPU:       benchmarkCPU,
 		ToolDatabaseLedgerHash: databaseLedgerHash,
@@ -140,6 +183,10 @@ func HandleTools() {
 		ToolDatabaseMigration:  databaseMigration,
 		ToolDatabaseSnapshot:   databaseSnapshot,
 		ToolDatabaseVerify:     databaseVerify,
+		ToolProofVerify:        proofVerify,
+		ToolExport:             exportData,
+		ToolReceiptAudit:       receiptAudit,
+		ToolLegacyArchive:      legacyArchive,
 	}
 
 	tool, exists := tools[strings.ToLower(args[1])]
@@ -168,11 +215,15 @@ func listTools() {
 	fmt.Printf("%-20s extracts the p2p identity from the private key file\n", fmt.Sprintf("%s:", ToolP2PExtractIdentity))
 	fmt.Printf("%-20s generates an ed25519 key pair\n", fmt.Sprintf("%s:", ToolEd25519Key))
 	fmt.Printf("%-20s generates an ed25519 address from a public key\n", fmt.Sprintf("%s:", ToolEd25519Addr))
+	fmt.Printf("%-20s signs the authorization of a new milestone public key range\n", fmt.Sprintf("%s:", ToolKeyRangeSign))
 	fmt.Printf("%-20s generates a JWT token for REST-API access\n", fmt.Sprintf("%s:", ToolJWTApi))
+	fmt.Printf("%-20s generates a token and its hash for INX extension access\n", fmt.Sprintf("%s:", ToolINXToken))
 	fmt.Printf("%-20s generates an initial snapshot for a private network\n", fmt.Sprintf("%s:", ToolSnapGen))
 	fmt.Printf("%-20s merges a full and delta snapshot into an updated full snapshot\n", fmt.Sprintf("%s:", ToolSnapMerge))
 	fmt.Printf("%-20s outputs information about a snapshot file\n", fmt.Sprintf("%s:", ToolSnapInfo))
 	fmt.Printf("%-20s calculates the sha256 hash of the ledger state inside a snapshot file\n", fmt.Sprintf("%s:", ToolSnapHash))
+	fmt.Printf("%-20s queries outputs, treasury, solid entry points and milestone diffs of a snapshot file\n", fmt.Sprintf("%s:", ToolSnapInspect))
+	fmt.Printf("%-20s compares the ledger states of two full snapshot files\n", fmt.Sprintf("%s:", ToolSnapDiff))
 	fmt.Printf("%-20s benchmarks the IO throughput\n", fmt.Sprintf("%s:", ToolBenchmarkIO))
 	fmt.Printf("%-20s benchmarks the CPU performance\n", fmt.Sprintf("%s:", ToolBenchmarkCPU))
 	fmt.Printf("%-20s calculates the sha256 hash of the ledger state of a database\n", fmt.Sprintf("%s:", ToolDatabaseLedgerHash))
@@ -181,6 +232,10 @@ func listTools() {
 	fmt.Printf("%-20s migrates the database to another engine\n", fmt.Sprintf("%s:", ToolDatabaseMigration))
 	fmt.Printf("%-20s creates a full snapshot from a database\n", fmt.Sprintf("%s:", ToolDatabaseSnapshot))
 	fmt.Printf("%-20s verifies a valid ledger state and the existence of all messages`\n", fmt.Sprintf("%s:", ToolDatabaseVerify))
+	fmt.Printf("%-20s verifies a proof bundle of a message against the public key ranges of the coordinator\n", fmt.Sprintf("%s:", ToolProofVerify))
+	fmt.Printf("%-20s exports the ledger, milestones and messages of a database or snapshot files as SQL dumps\n", fmt.Sprintf("%s:", ToolExport))
+	fmt.Printf("%-20s audits the receipts, the treasury outputs and the receipt backups of a database\n", fmt.Sprintf("%s:", ToolReceiptAudit))
+	fmt.Printf("%-20s fetches and validates the white-flag confirmations of legacy milestones and writes them to an archive file\n", fmt.Sprintf("%s:", ToolLegacyArchive))
 }
 
 func yesOrNo(value bool) string {
@@ -230,6 +285,11 @@ func loadConfigFile(filePath string, parameters map[string]any) (*configuration.
 		config.BindParameters(flagset, namespace, pointerToStruct)
 	}
 
+	// load the default values of the parameters that are not set in the config file
+	if err := config.LoadFlagSet(flagset); err != nil {
+		return nil, fmt.Errorf("loading default parameters failed: %w", err)
+	}
+
 	if err := config.LoadFile(filePath); err != nil {
 		return nil, fmt.Errorf("loading config file failed: %w", err)
 	}
@@ -0,0 +1,65 @@
+package tracing
+
+// ValueType is the type of the value of an attribute.
+type ValueType int
+
+const (
+	TypeString ValueType = iota
+	TypeInt64
+	TypeFloat64
+	TypeBool
+)
+
+// Attribute is a key value pair that describes a span.
+type Attribute struct {
+	Key         string
+	Type        ValueType
+	StringValue string
+	Int64Value  int64
+	Float64     float64
+	BoolValue   bool
+}
+
+// Value returns the value of the attribute.
+func (a Attribute) Value() interface{} {
+	switch a.Type {
+	case TypeInt64:
+		return a.Int64Value
+	case TypeFloat64:
+		return a.Float64
+	case TypeBool:
+		return a.BoolValue
+	default:
+		return a.StringValue
+	}
+}
+
+// String creates a string attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Type: TypeString, StringValue: value}
+}
+
+// Int creates an integer attribute.
+func Int(key string, value int) Attribute {
+	return Int64(key, int64(value))
+}
+
+// Int64 creates an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Type: TypeInt64, Int64Value: value}
+}
+
+// Uint32 creates an integer attribute.
+func Uint32(key string, value uint32) Attribute {
+	return Int64(key, int64(value))
+}
+
+// Float64 creates a floating point attribute.
+func Float64(key string, value float64) Attribute {
+	return Attribute{Key: key, Type: TypeFloat64, Float64: value}
+}
+
+// Bool creates a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Type: TypeBool, BoolValue: value}
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryExporter stores the exported spans in memory.
+// It is used in tests to check the recorded spans.
+type InMemoryExporter struct {
+	lock  sync.RWMutex
+	spans []*SpanData
+}
+
+// NewInMemoryExporter creates a new InMemoryExporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// ExportSpans stores the given spans.
+func (e *InMemoryExporter) ExportSpans(_ context.Context, spans []*SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// Spans returns all exported spans in the order they ended.
+func (e *InMemoryExporter) Spans() []*SpanData {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	spans := make([]*SpanData, len(e.spans))
+	copy(spans, e.spans)
+	return spans
+}
+
+// SpansByName returns all exported spans with the given name.
+func (e *InMemoryExporter) SpansByName(name string) []*SpanData {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	var spans []*SpanData
+	for _, span := range e.spans {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Reset removes all exported spans.
+func (e *InMemoryExporter) Reset() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.spans = nil
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextWithIncomingTraceParent returns a copy of the context that contains the span context
+// of the W3C trace context "traceparent" metadata of the gRPC call, if it was sent by the client.
+func contextWithIncomingTraceParent(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if values := md.Get(HeaderTraceParent); len(values) > 0 {
+		return ContextWithTraceParent(ctx, values[0])
+	}
+	return ctx
+}
+
+func endGRPCSpan(span *Span, err error) {
+	if err != nil {
+		span.SetAttributes(String("rpc.grpc.status_code", status.Code(err).String()))
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that records a span for every unary call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if GetTracerProvider() == nil {
+			return handler(ctx, req)
+		}
+
+		ctx, span := StartWithKind(contextWithIncomingTraceParent(ctx), SpanKindServer, info.FullMethod, String("rpc.system", "grpc"))
+		res, err := handler(ctx, req)
+		endGRPCSpan(span, err)
+
+		return res, err
+	}
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that records a span for every stream.
+// The span covers the whole lifetime of the stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if GetTracerProvider() == nil {
+			return handler(srv, ss)
+		}
+
+		ctx, span := StartWithKind(contextWithIncomingTraceParent(ss.Context()), SpanKindServer, info.FullMethod, String("rpc.system", "grpc"))
+		err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+		endGRPCSpan(span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,251 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// the name of the instrumentation scope of the exported spans.
+	otlpScopeName = "github.com/gohornet/hornet"
+
+	// OTLP span kinds.
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	// OTLP status codes.
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+// OTLPOptions define options for the OTLPExporter.
+type OTLPOptions struct {
+	// additional HTTP headers that are sent with every export request (e.g. for authentication).
+	headers map[string]string
+	// the timeout of a single export request.
+	timeout time.Duration
+	// the attributes that describe the exporting process (e.g. "service.name").
+	resourceAttributes []Attribute
+}
+
+// applies the given OTLPOption.
+func (so *OTLPOptions) apply(opts ...OTLPOption) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// the default options applied to the OTLPExporter.
+var defaultOTLPOptions = []OTLPOption{
+	WithOTLPTimeout(10 * time.Second),
+}
+
+// OTLPOption is a function setting an OTLPExporter option.
+type OTLPOption func(opts *OTLPOptions)
+
+// WithOTLPHeaders sets additional HTTP headers that are sent with every export request.
+func WithOTLPHeaders(headers map[string]string) OTLPOption {
+	return func(opts *OTLPOptions) {
+		opts.headers = headers
+	}
+}
+
+// WithOTLPTimeout sets the timeout of a single export request.
+func WithOTLPTimeout(timeout time.Duration) OTLPOption {
+	return func(opts *OTLPOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithOTLPResourceAttributes sets the attributes that describe the exporting process.
+func WithOTLPResourceAttributes(attributes ...Attribute) OTLPOption {
+	return func(opts *OTLPOptions) {
+		opts.resourceAttributes = attributes
+	}
+}
+
+// OTLPExporter exports spans to an OpenTelemetry collector using OTLP/HTTP with JSON encoding.
+type OTLPExporter struct {
+	endpoint string
+	opts     *OTLPOptions
+	client   *http.Client
+}
+
+// NewOTLPExporter creates a new OTLPExporter that sends the spans to the given endpoint
+// (e.g. "http://localhost:4318/v1/traces").
+func NewOTLPExporter(endpoint string, opts ...OTLPOption) *OTLPExporter {
+	options := &OTLPOptions{}
+	options.apply(defaultOTLPOptions...)
+	options.apply(opts...)
+
+	return &OTLPExporter{
+		endpoint: endpoint,
+		opts:     options,
+		client:   &http.Client{Timeout: options.timeout},
+	}
+}
+
+// ExportSpans sends the given spans to the collector.
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []*SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newOTLPExportTraceServiceRequest(e.opts.resourceAttributes, spans))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode spans")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create export request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.opts.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send spans")
+	}
+	defer res.Body.Close()
+
+	// drain the body, so that the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// the JSON representation of the OTLP protobuf messages.
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpExportTraceServiceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func newOTLPKeyValues(attributes []Attribute) []otlpKeyValue {
+	keyValues := make([]otlpKeyValue, 0, len(attributes))
+	for _, attribute := range attributes {
+		var value otlpAnyValue
+		switch attribute.Type {
+		case TypeInt64:
+			intValue := strconv.FormatInt(attribute.Int64Value, 10)
+			value.IntValue = &intValue
+		case TypeFloat64:
+			doubleValue := attribute.Float64
+			value.DoubleValue = &doubleValue
+		case TypeBool:
+			boolValue := attribute.BoolValue
+			value.BoolValue = &boolValue
+		default:
+			stringValue := attribute.StringValue
+			value.StringValue = &stringValue
+		}
+		keyValues = append(keyValues, otlpKeyValue{Key: attribute.Key, Value: value})
+	}
+	return keyValues
+}
+
+func otlpSpanKind(kind SpanKind) int {
+	if kind == SpanKindServer {
+		return otlpSpanKindServer
+	}
+	return otlpSpanKindInternal
+}
+
+func newOTLPExportTraceServiceRequest(resourceAttributes []Attribute, spans []*SpanData) *otlpExportTraceServiceRequest {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext.TraceID.String(),
+			SpanID:            span.SpanContext.SpanID.String(),
+			Name:              span.Name,
+			Kind:              otlpSpanKind(span.Kind),
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Attributes:        newOTLPKeyValues(span.Attributes),
+		}
+		if span.ParentSpanID.IsValid() {
+			s.ParentSpanID = span.ParentSpanID.String()
+		}
+		switch span.StatusCode {
+		case StatusOK:
+			s.Status.Code = otlpStatusCodeOK
+		case StatusError:
+			s.Status.Code = otlpStatusCodeError
+			s.Status.Message = span.StatusMessage
+		}
+		otlpSpans = append(otlpSpans, s)
+	}
+
+	return &otlpExportTraceServiceRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{Attributes: newOTLPKeyValues(resourceAttributes)},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: otlpScopeName},
+						Spans: otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
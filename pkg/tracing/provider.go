@@ -0,0 +1,334 @@
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+// Exporter exports ended spans to a tracing backend.
+type Exporter interface {
+	// ExportSpans exports a batch of spans.
+	ExportSpans(ctx context.Context, spans []*SpanData) error
+}
+
+// Options define options for the TracerProvider.
+type Options struct {
+	// the logger used to log events.
+	logger *logger.Logger
+	// the sampler that decides whether a span is recorded.
+	sampler Sampler
+	// the exporter the spans are exported to.
+	exporter Exporter
+	// whether the spans are exported synchronously when they end.
+	syncExport bool
+	// the maximum amount of ended spans that are queued for export.
+	maxQueueSize int
+	// the maximum amount of spans that are exported in a single batch.
+	maxExportBatchSize int
+	// the maximum time until queued spans are exported.
+	batchTimeout time.Duration
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// the default options applied to the TracerProvider.
+var defaultOptions = []Option{
+	WithSampler(ParentBased(AlwaysSample())),
+	WithMaxQueueSize(2048),
+	WithMaxExportBatchSize(512),
+	WithBatchTimeout(5 * time.Second),
+}
+
+// Option is a function setting a TracerProvider option.
+type Option func(opts *Options)
+
+// WithLogger sets the logger used by the TracerProvider.
+func WithLogger(logger *logger.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// WithSampler sets the sampler that decides whether a span is recorded.
+func WithSampler(sampler Sampler) Option {
+	return func(opts *Options) {
+		opts.sampler = sampler
+	}
+}
+
+// WithExporter sets the exporter the spans are exported to in batches.
+// The batches are exported by TracerProvider.Run.
+func WithExporter(exporter Exporter) Option {
+	return func(opts *Options) {
+		opts.exporter = exporter
+		opts.syncExport = false
+	}
+}
+
+// WithSyncer sets the exporter the spans are exported to synchronously when they end.
+// This should only be used in tests.
+func WithSyncer(exporter Exporter) Option {
+	return func(opts *Options) {
+		opts.exporter = exporter
+		opts.syncExport = true
+	}
+}
+
+// WithMaxQueueSize sets the maximum amount of ended spans that are queued for export.
+// If the queue is full, new spans are dropped.
+func WithMaxQueueSize(maxQueueSize int) Option {
+	return func(opts *Options) {
+		opts.maxQueueSize = maxQueueSize
+	}
+}
+
+// WithMaxExportBatchSize sets the maximum amount of spans that are exported in a single batch.
+func WithMaxExportBatchSize(maxExportBatchSize int) Option {
+	return func(opts *Options) {
+		opts.maxExportBatchSize = maxExportBatchSize
+	}
+}
+
+// WithBatchTimeout sets the maximum time until queued spans are exported.
+func WithBatchTimeout(batchTimeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.batchTimeout = batchTimeout
+	}
+}
+
+// TracerProvider creates spans and passes the recorded spans to an exporter.
+type TracerProvider struct {
+	// the logger used to log events.
+	*logger.WrappedLogger
+
+	opts      *Options
+	processor spanProcessor
+
+	idGeneratorLock sync.Mutex
+	idGenerator     *rand.Rand
+}
+
+// NewTracerProvider creates a new TracerProvider.
+func NewTracerProvider(opts ...Option) *TracerProvider {
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	var seed int64
+	if err := binary.Read(crand.Reader, binary.LittleEndian, &seed); err != nil {
+		seed = time.Now().UnixNano()
+	}
+
+	p := &TracerProvider{
+		WrappedLogger: logger.NewWrappedLogger(options.logger),
+		opts:          options,
+		idGenerator:   rand.New(rand.NewSource(seed)),
+	}
+
+	switch {
+	case options.exporter == nil:
+		p.processor = &noopProcessor{}
+	case options.syncExport:
+		p.processor = &syncProcessor{exporter: options.exporter, log: p.WrappedLogger}
+	default:
+		p.processor = newBatchProcessor(options.exporter, options.maxQueueSize, options.maxExportBatchSize, options.batchTimeout, p.WrappedLogger)
+	}
+
+	return p
+}
+
+func (p *TracerProvider) newIDs(traceID *TraceID, spanID *SpanID) {
+	p.idGeneratorLock.Lock()
+	defer p.idGeneratorLock.Unlock()
+
+	if traceID != nil {
+		for !traceID.IsValid() {
+			_, _ = p.idGenerator.Read(traceID[:])
+		}
+	}
+	for !spanID.IsValid() {
+		_, _ = p.idGenerator.Read(spanID[:])
+	}
+}
+
+// Start starts a new internal span as a child of the current span of the context.
+// It returns a copy of the context that contains the new span.
+func (p *TracerProvider) Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	return p.StartWithKind(ctx, SpanKindInternal, name, attributes...)
+}
+
+// StartWithKind starts a new span of the given kind as a child of the current span of the context.
+// It returns a copy of the context that contains the new span.
+func (p *TracerProvider) StartWithKind(ctx context.Context, kind SpanKind, name string, attributes ...Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx).SpanContext()
+
+	spanContext := SpanContext{}
+	if parent.IsValid() {
+		spanContext.TraceID = parent.TraceID
+		p.newIDs(nil, &spanContext.SpanID)
+	} else {
+		p.newIDs(&spanContext.TraceID, &spanContext.SpanID)
+	}
+	spanContext.Sampled = p.opts.sampler.ShouldSample(parent, spanContext.TraceID)
+
+	span := &Span{spanContext: spanContext}
+	if spanContext.Sampled {
+		span.provider = p
+		span.data = &SpanData{
+			Name:         name,
+			Kind:         kind,
+			SpanContext:  spanContext,
+			ParentSpanID: parent.SpanID,
+			StartTime:    time.Now(),
+			Attributes:   append(make([]Attribute, 0, len(attributes)), attributes...),
+		}
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Run exports the queued spans in batches until the context is canceled.
+// Afterwards the remaining spans are exported with the given timeout.
+func (p *TracerProvider) Run(ctx context.Context, shutdownTimeout time.Duration) {
+	p.processor.run(ctx, shutdownTimeout)
+}
+
+// DroppedSpans returns the amount of spans that were dropped because the export queue was full.
+func (p *TracerProvider) DroppedSpans() uint64 {
+	return p.processor.droppedSpans()
+}
+
+// spanProcessor passes the ended spans to the exporter.
+type spanProcessor interface {
+	onEnd(span *SpanData)
+	run(ctx context.Context, shutdownTimeout time.Duration)
+	droppedSpans() uint64
+}
+
+type noopProcessor struct{}
+
+func (*noopProcessor) onEnd(*SpanData) {}
+func (*noopProcessor) run(ctx context.Context, _ time.Duration) {
+	<-ctx.Done()
+}
+func (*noopProcessor) droppedSpans() uint64 { return 0 }
+
+type syncProcessor struct {
+	exporter Exporter
+	log      *logger.WrappedLogger
+}
+
+func (s *syncProcessor) onEnd(span *SpanData) {
+	if err := s.exporter.ExportSpans(context.Background(), []*SpanData{span}); err != nil {
+		s.log.LogWarnf("failed to export span: %s", err)
+	}
+}
+
+func (s *syncProcessor) run(ctx context.Context, _ time.Duration) {
+	<-ctx.Done()
+}
+
+func (s *syncProcessor) droppedSpans() uint64 { return 0 }
+
+type batchProcessor struct {
+	exporter           Exporter
+	log                *logger.WrappedLogger
+	queue              chan *SpanData
+	maxExportBatchSize int
+	batchTimeout       time.Duration
+
+	droppedLock sync.Mutex
+	dropped     uint64
+}
+
+func newBatchProcessor(exporter Exporter, maxQueueSize int, maxExportBatchSize int, batchTimeout time.Duration, log *logger.WrappedLogger) *batchProcessor {
+	return &batchProcessor{
+		exporter:           exporter,
+		log:                log,
+		queue:              make(chan *SpanData, maxQueueSize),
+		maxExportBatchSize: maxExportBatchSize,
+		batchTimeout:       batchTimeout,
+	}
+}
+
+func (b *batchProcessor) onEnd(span *SpanData) {
+	select {
+	case b.queue <- span:
+	default:
+		// the queue is full, we never block the instrumented code
+		b.droppedLock.Lock()
+		b.dropped++
+		b.droppedLock.Unlock()
+	}
+}
+
+func (b *batchProcessor) droppedSpans() uint64 {
+	b.droppedLock.Lock()
+	defer b.droppedLock.Unlock()
+
+	return b.dropped
+}
+
+func (b *batchProcessor) export(ctx context.Context, batch []*SpanData) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := b.exporter.ExportSpans(ctx, batch); err != nil {
+		b.log.LogWarnf("failed to export %d spans: %s", len(batch), err)
+	}
+}
+
+func (b *batchProcessor) run(ctx context.Context, shutdownTimeout time.Duration) {
+	ticker := time.NewTicker(b.batchTimeout)
+	defer ticker.Stop()
+
+	batch := make([]*SpanData, 0, b.maxExportBatchSize)
+
+	exportBatch := func(ctx context.Context) {
+		b.export(ctx, batch)
+		batch = make([]*SpanData, 0, b.maxExportBatchSize)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// export the remaining spans
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			for {
+				select {
+				case span := <-b.queue:
+					batch = append(batch, span)
+					if len(batch) >= b.maxExportBatchSize {
+						exportBatch(shutdownCtx)
+					}
+				default:
+					exportBatch(shutdownCtx)
+					return
+				}
+			}
+
+		case span := <-b.queue:
+			batch = append(batch, span)
+			if len(batch) >= b.maxExportBatchSize {
+				exportBatch(ctx)
+			}
+
+		case <-ticker.C:
+			exportBatch(ctx)
+		}
+	}
+}
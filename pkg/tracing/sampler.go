@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Sampler decides whether a new span is recorded.
+type Sampler interface {
+	// ShouldSample returns whether the span with the given trace ID should be recorded.
+	// The parent span context is invalid for root spans.
+	ShouldSample(parent SpanContext, traceID TraceID) bool
+	// Description returns a human readable description of the sampler.
+	Description() string
+}
+
+type alwaysSampler struct{}
+
+func (alwaysSampler) ShouldSample(SpanContext, TraceID) bool { return true }
+func (alwaysSampler) Description() string                    { return "AlwaysOnSampler" }
+
+type neverSampler struct{}
+
+func (neverSampler) ShouldSample(SpanContext, TraceID) bool { return false }
+func (neverSampler) Description() string                    { return "AlwaysOffSampler" }
+
+// AlwaysSample returns a sampler that records every span.
+func AlwaysSample() Sampler {
+	return alwaysSampler{}
+}
+
+// NeverSample returns a sampler that never records a span.
+func NeverSample() Sampler {
+	return neverSampler{}
+}
+
+type traceIDRatioSampler struct {
+	upperBound  uint64
+	description string
+}
+
+func (s traceIDRatioSampler) ShouldSample(_ SpanContext, traceID TraceID) bool {
+	return binary.BigEndian.Uint64(traceID[8:16])>>1 < s.upperBound
+}
+
+func (s traceIDRatioSampler) Description() string {
+	return s.description
+}
+
+// TraceIDRatioBased returns a sampler that records the given fraction of the traces.
+// The decision is derived from the trace ID, so all processes that use the same ratio
+// make the same decision for a trace.
+func TraceIDRatioBased(fraction float64) Sampler {
+	if fraction >= 1 {
+		return AlwaysSample()
+	}
+	if fraction <= 0 {
+		return NeverSample()
+	}
+
+	return traceIDRatioSampler{
+		upperBound:  uint64(fraction * (1 << 63)),
+		description: fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
+	}
+}
+
+type parentBasedSampler struct {
+	root Sampler
+}
+
+func (s parentBasedSampler) ShouldSample(parent SpanContext, traceID TraceID) bool {
+	if parent.IsValid() {
+		return parent.Sampled
+	}
+	return s.root.ShouldSample(parent, traceID)
+}
+
+func (s parentBasedSampler) Description() string {
+	return fmt.Sprintf("ParentBased{root:%s}", s.root.Description())
+}
+
+// ParentBased returns a sampler that follows the decision of the parent span,
+// and uses the given sampler for root spans.
+func ParentBased(root Sampler) Sampler {
+	return parentBasedSampler{root: root}
+}
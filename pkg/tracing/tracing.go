@@ -0,0 +1,325 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidTraceParent is returned if a W3C trace context "traceparent" header can't be parsed.
+	ErrInvalidTraceParent = errors.New("invalid traceparent")
+)
+
+const (
+	// HeaderTraceParent is the W3C trace context header that is used to propagate traces between processes.
+	HeaderTraceParent = "traceparent"
+)
+
+// TraceID is the identifier of a trace.
+type TraceID [16]byte
+
+// IsValid returns whether the trace ID is not zero.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String returns the hex encoded trace ID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID is the identifier of a span.
+type SpanID [8]byte
+
+// IsValid returns whether the span ID is not zero.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String returns the hex encoded span ID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// Sampled defines whether the span is recorded and exported.
+	Sampled bool
+	// Remote defines whether the span context was propagated from another process.
+	Remote bool
+}
+
+// IsValid returns whether the span context has a valid trace ID and span ID.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// TraceParent returns the W3C trace context "traceparent" header of the span context.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C trace context "traceparent" header.
+func ParseTraceParent(traceParent string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "unsupported format: %s", traceParent)
+	}
+
+	sc := SpanContext{Remote: true}
+
+	if len(parts[1]) != hex.EncodedLen(len(sc.TraceID)) {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid trace ID: %s", parts[1])
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid trace ID: %s", parts[1])
+	}
+
+	if len(parts[2]) != hex.EncodedLen(len(sc.SpanID)) {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid span ID: %s", parts[2])
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid span ID: %s", parts[2])
+	}
+
+	var flags [1]byte
+	if len(parts[3]) != 2 {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid flags: %s", parts[3])
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "invalid flags: %s", parts[3])
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+
+	if !sc.IsValid() {
+		return SpanContext{}, errors.WithMessagef(ErrInvalidTraceParent, "zero trace ID or span ID: %s", traceParent)
+	}
+
+	return sc, nil
+}
+
+// StatusCode is the status of a span.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = iota
+	StatusOK
+	StatusError
+)
+
+// SpanKind is the role of a span in a trace.
+type SpanKind int
+
+const (
+	// SpanKindInternal is an internal operation of the node.
+	SpanKindInternal SpanKind = iota
+	// SpanKindServer is the handling of a request of a remote client (e.g. a REST API request or an INX call).
+	SpanKindServer
+)
+
+// SpanData is the recorded data of an ended span.
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	ParentSpanID  SpanID
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    []Attribute
+	StatusCode    StatusCode
+	StatusMessage string
+}
+
+// Span is a single operation within a trace.
+// All methods are safe to be called on a nil span, which is returned if tracing is disabled.
+type Span struct {
+	// the provider is only set if the span is recorded.
+	provider    *TracerProvider
+	spanContext SpanContext
+
+	lock sync.Mutex
+	data *SpanData
+}
+
+// SpanContext returns the span context of the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.spanContext
+}
+
+// IsRecording returns whether the span is recorded and exported.
+func (s *Span) IsRecording() bool {
+	if s == nil || s.provider == nil {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.data != nil
+}
+
+// SetName changes the name of the span.
+func (s *Span) SetName(name string) {
+	if s == nil || s.provider == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.data != nil {
+		s.data.Name = name
+	}
+}
+
+// SetAttributes sets the given attributes on the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil || s.provider == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.data != nil {
+		s.data.Attributes = append(s.data.Attributes, attributes...)
+	}
+}
+
+// SetStatus sets the status of the span.
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil || s.provider == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.data != nil {
+		s.data.StatusCode = code
+		s.data.StatusMessage = message
+	}
+}
+
+// RecordError sets the status of the span to error with the message of the given error.
+// Nil errors are ignored.
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// End ends the span and passes it to the exporter of the provider.
+// Calling End multiple times has no effect.
+func (s *Span) End() {
+	if s == nil || s.provider == nil {
+		return
+	}
+
+	s.lock.Lock()
+	data := s.data
+	s.data = nil
+	s.lock.Unlock()
+
+	if data == nil {
+		// already ended
+		return
+	}
+
+	data.EndTime = time.Now()
+	s.provider.processor.onEnd(data)
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of the context that contains the given span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// ContextWithRemoteSpanContext returns a copy of the context that contains a span context propagated from another process.
+func ContextWithRemoteSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	spanContext.Remote = true
+	return ContextWithSpan(ctx, &Span{spanContext: spanContext})
+}
+
+// ContextWithTraceParent returns a copy of the context that contains the span context of the given
+// W3C trace context "traceparent" header. The context is returned unchanged if the header is invalid.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+
+	spanContext, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return ctx
+	}
+
+	return ContextWithRemoteSpanContext(ctx, spanContext)
+}
+
+// SpanFromContext returns the current span of the context or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// globalProvider holds the provider that is used by Start.
+var globalProvider atomic.Value
+
+type providerHolder struct {
+	provider *TracerProvider
+}
+
+// SetTracerProvider sets the provider that is used to start spans.
+// Passing nil disables tracing.
+func SetTracerProvider(provider *TracerProvider) {
+	globalProvider.Store(providerHolder{provider: provider})
+}
+
+// GetTracerProvider returns the provider that is used to start spans or nil if tracing is disabled.
+func GetTracerProvider() *TracerProvider {
+	holder, ok := globalProvider.Load().(providerHolder)
+	if !ok {
+		return nil
+	}
+	return holder.provider
+}
+
+// Start starts a new span as a child of the current span of the context.
+// It returns a copy of the context that contains the new span.
+// If tracing is disabled, the context is returned unchanged and the span is nil.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	return StartWithKind(ctx, SpanKindInternal, name, attributes...)
+}
+
+// StartWithKind starts a new span of the given kind with the global tracer provider.
+func StartWithKind(ctx context.Context, kind SpanKind, name string, attributes ...Attribute) (context.Context, *Span) {
+	provider := GetTracerProvider()
+	if provider == nil {
+		return ctx, nil
+	}
+
+	return provider.StartWithKind(ctx, kind, name, attributes...)
+}
@@ -0,0 +1,176 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/tracing"
+)
+
+func TestSpans(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(tracing.WithSyncer(exporter))
+
+	ctx, parent := provider.StartWithKind(context.Background(), tracing.SpanKindServer, "parent", tracing.Int("index", 5))
+	require.True(t, parent.IsRecording())
+
+	_, child := provider.Start(ctx, "child")
+	child.RecordError(errors.New("failed"))
+	child.End()
+	child.End()
+
+	parent.SetAttributes(tracing.Bool("done", true))
+	parent.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+
+	childData, parentData := spans[0], spans[1]
+	require.Equal(t, "child", childData.Name)
+	require.Equal(t, "parent", parentData.Name)
+	require.Equal(t, parentData.SpanContext.TraceID, childData.SpanContext.TraceID)
+	require.Equal(t, parentData.SpanContext.SpanID, childData.ParentSpanID)
+	require.False(t, parentData.ParentSpanID.IsValid())
+	require.Equal(t, tracing.StatusError, childData.StatusCode)
+	require.Equal(t, "failed", childData.StatusMessage)
+	require.Equal(t, []tracing.Attribute{tracing.Int("index", 5), tracing.Bool("done", true)}, parentData.Attributes)
+	require.False(t, parentData.EndTime.Before(parentData.StartTime))
+	require.Equal(t, tracing.SpanKindServer, parentData.Kind)
+	require.Equal(t, tracing.SpanKindInternal, childData.Kind)
+}
+
+func TestDisabled(t *testing.T) {
+	tracing.SetTracerProvider(nil)
+
+	ctx := context.Background()
+	spanCtx, span := tracing.Start(ctx, "disabled")
+	require.Nil(t, span)
+	require.Equal(t, ctx, spanCtx)
+
+	// all methods of a nil span are no-ops
+	require.False(t, span.IsRecording())
+	span.SetAttributes(tracing.String("key", "value"))
+	span.RecordError(errors.New("failed"))
+	span.End()
+}
+
+func TestSampler(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(tracing.WithSyncer(exporter), tracing.WithSampler(tracing.ParentBased(tracing.NeverSample())))
+
+	ctx, root := provider.Start(context.Background(), "root")
+	require.False(t, root.IsRecording())
+	require.True(t, root.SpanContext().IsValid())
+
+	// children of unsampled spans are not sampled either
+	_, child := provider.Start(ctx, "child")
+	require.False(t, child.IsRecording())
+	child.End()
+	root.End()
+	require.Empty(t, exporter.Spans())
+
+	// a sampled remote parent overrides the root sampler
+	remoteCtx := tracing.ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, remoteChild := provider.Start(remoteCtx, "remote-child")
+	require.True(t, remoteChild.IsRecording())
+	remoteChild.End()
+
+	spans := exporter.SpansByName("remote-child")
+	require.Len(t, spans, 1)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID.String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].ParentSpanID.String())
+
+	require.Equal(t, tracing.AlwaysSample(), tracing.TraceIDRatioBased(1))
+	require.Equal(t, tracing.NeverSample(), tracing.TraceIDRatioBased(0))
+
+	// the decision of the ratio sampler only depends on the trace ID
+	ratioSampler := tracing.TraceIDRatioBased(0.5)
+	var low, high tracing.TraceID
+	low[15] = 1
+	for i := range high {
+		high[i] = 0xff
+	}
+	require.True(t, ratioSampler.ShouldSample(tracing.SpanContext{}, low))
+	require.False(t, ratioSampler.ShouldSample(tracing.SpanContext{}, high))
+}
+
+func TestTraceParent(t *testing.T) {
+	sc, err := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NoError(t, err)
+	require.True(t, sc.IsValid())
+	require.True(t, sc.Sampled)
+	require.True(t, sc.Remote)
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.TraceParent())
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bx-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		_, err := tracing.ParseTraceParent(invalid)
+		require.ErrorIs(t, err, tracing.ErrInvalidTraceParent, invalid)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		requests <- request
+	}))
+	defer server.Close()
+
+	exporter := tracing.NewOTLPExporter(server.URL,
+		tracing.WithOTLPHeaders(map[string]string{"Authorization": "secret"}),
+		tracing.WithOTLPResourceAttributes(tracing.String("service.name", "hornet")),
+	)
+	provider := tracing.NewTracerProvider(tracing.WithExporter(exporter), tracing.WithBatchTimeout(time.Hour))
+
+	_, span := provider.Start(context.Background(), "milestone", tracing.Uint32("milestone.index", 42))
+	span.End()
+
+	// the remaining spans are exported at shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	provider.Run(ctx, time.Second)
+
+	var request map[string]interface{}
+	select {
+	case request = <-requests:
+	default:
+		require.FailNow(t, "no spans exported")
+	}
+
+	resourceSpans := request["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	resourceAttribute := resourceSpans["resource"].(map[string]interface{})["attributes"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "service.name", resourceAttribute["key"])
+
+	exportedSpan := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "milestone", exportedSpan["name"])
+	require.EqualValues(t, 1, exportedSpan["kind"]) // internal
+	require.Equal(t, span.SpanContext().TraceID.String(), exportedSpan["traceId"])
+	require.Equal(t, span.SpanContext().SpanID.String(), exportedSpan["spanId"])
+
+	attribute := exportedSpan["attributes"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "milestone.index", attribute["key"])
+	require.Equal(t, "42", attribute["value"].(map[string]interface{})["intValue"])
+}
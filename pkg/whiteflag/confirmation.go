@@ -12,6 +12,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/tracing"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
@@ -22,6 +23,12 @@ type ConfirmedMilestoneStats struct {
 	MessagesExcludedWithConflictingTransactions int
 	MessagesIncludedWithTransactions            int
 	MessagesExcludedWithoutTransactions         int
+	// the amount of messages with conflicting transactions by the reason of the conflict.
+	ConflictsByReason map[storage.Conflict]int
+	// the amount of outputs that were created, including the migrated outputs of a receipt.
+	OutputsCreated int
+	// the amount of outputs that were consumed.
+	OutputsConsumed int
 }
 
 // ConfirmationMetrics holds metrics about a confirmation run.
@@ -60,7 +67,9 @@ var (
 // then the ledger diffs are calculated, the ledger state is checked and all msg are marked as referenced.
 // Additionally, this function also examines the milestone for a receipt and generates new migrated outputs
 // if one is present. The treasury is mutated accordingly.
+// The context is only used to pass the tracing span, the confirmation itself can't be canceled.
 func ConfirmMilestone(
+	ctx context.Context,
 	utxoManager *utxo.Manager,
 	parentsTraverserStorage dag.ParentsTraverserStorage,
 	cachedMessageFunc storage.CachedMessageFunc,
@@ -74,7 +83,21 @@ func ConfirmMilestone(
 	onMilestoneConfirmed func(confirmation *Confirmation),
 	onLedgerUpdated func(index milestone.Index, newOutputs utxo.Outputs, newSpents utxo.Spents),
 	onTreasuryMutated func(index milestone.Index, tuple *utxo.TreasuryMutationTuple),
-	onReceipt func(r *utxo.ReceiptTuple) error) (*ConfirmedMilestoneStats, *ConfirmationMetrics, error) {
+	onReceipt func(r *utxo.ReceiptTuple) error) (confirmedMilestoneStats *ConfirmedMilestoneStats, confirmationMetrics *ConfirmationMetrics, err error) {
+
+	ctx, span := tracing.Start(ctx, "whiteflag.ConfirmMilestone", tracing.Uint32("milestone.index", milestonePayload.Index))
+	defer func() {
+		if confirmedMilestoneStats != nil {
+			span.SetAttributes(
+				tracing.Int("messages.referenced", confirmedMilestoneStats.MessagesReferenced),
+				tracing.Int("messages.included_with_transactions", confirmedMilestoneStats.MessagesIncludedWithTransactions),
+				tracing.Int("messages.excluded_without_transactions", confirmedMilestoneStats.MessagesExcludedWithoutTransactions),
+				tracing.Int("messages.excluded_with_conflicting_transactions", confirmedMilestoneStats.MessagesExcludedWithConflictingTransactions),
+			)
+		}
+		span.RecordError(err)
+		span.End()
+	}()
 
 	utxoManager.WriteLockLedger()
 	defer utxoManager.WriteUnlockLedger()
@@ -94,6 +117,8 @@ func ConfirmMilestone(
 
 	parentsTraverser := dag.NewParentsTraverser(parentsTraverserStorage)
 
+	_, whiteFlagSpan := tracing.Start(ctx, "whiteflag.ComputeWhiteFlagMutations")
+
 	// we pass a background context here to not cancel the whiteflag computation!
 	// otherwise the node could panic at shutdown.
 	mutations, err := ComputeWhiteFlagMutations(
@@ -106,6 +131,8 @@ func ConfirmMilestone(
 		milestoneParents,
 		previousMilestoneID,
 		whiteFlagTraversalCondition)
+	whiteFlagSpan.RecordError(err)
+	whiteFlagSpan.End()
 	if err != nil {
 		// According to the RFC we should panic if we encounter any invalid messages during confirmation
 		return nil, nil, fmt.Errorf("confirmMilestone: whiteflag.ComputeConfirmation failed with Error: %w", err)
@@ -189,7 +216,14 @@ func ConfirmMilestone(
 		newSpents = append(newSpents, spent)
 	}
 
-	if err = utxoManager.ApplyConfirmationWithoutLocking(milestoneIndex, newOutputs, newSpents, tm, rt); err != nil {
+	_, applyConfirmationSpan := tracing.Start(ctx, "utxo.Manager.ApplyConfirmation",
+		tracing.Int("outputs.created", len(newOutputs)),
+		tracing.Int("outputs.consumed", len(newSpents)),
+	)
+	err = utxoManager.ApplyConfirmationWithoutLocking(milestoneIndex, newOutputs, newSpents, tm, rt)
+	applyConfirmationSpan.RecordError(err)
+	applyConfirmationSpan.End()
+	if err != nil {
 		return nil, nil, fmt.Errorf("confirmMilestone: utxo.ApplyConfirmation failed: %w", err)
 	}
 	timeConfirmation := time.Now()
@@ -208,20 +242,24 @@ func ConfirmMilestone(
 		return nil
 	}
 
-	confirmedMilestoneStats := &ConfirmedMilestoneStats{
-		Index: milestoneIndex,
+	stats := &ConfirmedMilestoneStats{
+		Index:             milestoneIndex,
+		ConflictsByReason: make(map[storage.Conflict]int),
+		OutputsCreated:    len(newOutputs),
+		OutputsConsumed:   len(newSpents),
 	}
 
 	confirmationTime := milestonePayload.Timestamp
 
+	_, metadataSpan := tracing.Start(ctx, "whiteflag.updateMessageMetadata")
 	// confirm all included messages
 	for _, messageID := range mutations.MessagesIncludedWithTransactions {
 		if err := forMessageMetadataWithMessageID(messageID, func(meta *storage.CachedMetadata) {
 			if !checkMessageReferencedFunc(meta.Metadata()) {
 				setMessageReferencedFunc(meta.Metadata(), true, milestoneIndex)
 				meta.Metadata().SetConeRootIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
-				confirmedMilestoneStats.MessagesReferenced++
-				confirmedMilestoneStats.MessagesIncludedWithTransactions++
+				stats.MessagesReferenced++
+				stats.MessagesIncludedWithTransactions++
 				if serverMetrics != nil {
 					serverMetrics.IncludedTransactionMessages.Inc()
 					serverMetrics.ReferencedMessages.Inc()
@@ -231,6 +269,8 @@ func ConfirmMilestone(
 				}
 			}
 		}); err != nil {
+			metadataSpan.RecordError(err)
+			metadataSpan.End()
 			return nil, nil, err
 		}
 	}
@@ -243,8 +283,8 @@ func ConfirmMilestone(
 			if !checkMessageReferencedFunc(meta.Metadata()) {
 				setMessageReferencedFunc(meta.Metadata(), true, milestoneIndex)
 				meta.Metadata().SetConeRootIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
-				confirmedMilestoneStats.MessagesReferenced++
-				confirmedMilestoneStats.MessagesExcludedWithoutTransactions++
+				stats.MessagesReferenced++
+				stats.MessagesExcludedWithoutTransactions++
 				if serverMetrics != nil {
 					serverMetrics.NoTransactionMessages.Inc()
 					serverMetrics.ReferencedMessages.Inc()
@@ -254,6 +294,8 @@ func ConfirmMilestone(
 				}
 			}
 		}); err != nil {
+			metadataSpan.RecordError(err)
+			metadataSpan.End()
 			return nil, nil, err
 		}
 	}
@@ -266,8 +308,9 @@ func ConfirmMilestone(
 			if !checkMessageReferencedFunc(meta.Metadata()) {
 				setMessageReferencedFunc(meta.Metadata(), true, milestoneIndex)
 				meta.Metadata().SetConeRootIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
-				confirmedMilestoneStats.MessagesReferenced++
-				confirmedMilestoneStats.MessagesExcludedWithConflictingTransactions++
+				stats.MessagesReferenced++
+				stats.MessagesExcludedWithConflictingTransactions++
+				stats.ConflictsByReason[conflictedMessage.Conflict]++
 				if serverMetrics != nil {
 					serverMetrics.ConflictingTransactionMessages.Inc()
 					serverMetrics.ReferencedMessages.Inc()
@@ -277,27 +320,36 @@ func ConfirmMilestone(
 				}
 			}
 		}); err != nil {
+			metadataSpan.RecordError(err)
+			metadataSpan.End()
 			return nil, nil, err
 		}
 	}
 	timeApplyExcludedWithConflictingTransactions := time.Now()
+	metadataSpan.End()
 
 	if onMilestoneConfirmed != nil {
+		_, eventSpan := tracing.Start(ctx, "whiteflag.onMilestoneConfirmed")
 		onMilestoneConfirmed(confirmation)
+		eventSpan.End()
 	}
 	timeOnMilestoneConfirmed := time.Now()
 
 	if onLedgerUpdated != nil {
+		_, eventSpan := tracing.Start(ctx, "whiteflag.onLedgerUpdated")
 		onLedgerUpdated(milestoneIndex, newOutputs, newSpents)
+		eventSpan.End()
 	}
 	timeLedgerUpdated := time.Now()
 
 	if onTreasuryMutated != nil && tm != nil {
+		_, eventSpan := tracing.Start(ctx, "whiteflag.onTreasuryMutated")
 		onTreasuryMutated(milestoneIndex, tm)
+		eventSpan.End()
 	}
 	timeTreasuryMutated := time.Now()
 
-	return confirmedMilestoneStats, &ConfirmationMetrics{
+	return stats, &ConfirmationMetrics{
 		DurationWhiteflag:                                timeWhiteflag.Sub(timeStart),
 		DurationReceipts:                                 timeReceipts.Sub(timeWhiteflag),
 		DurationConfirmation:                             timeConfirmation.Sub(timeReceipts),
@@ -0,0 +1,184 @@
+package whiteflag
+
+import (
+	"crypto"
+	"encoding"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrMessageNotReferenced is returned when an inclusion proof is requested for a message that was not referenced by the milestone.
+	ErrMessageNotReferenced = errors.New("message was not referenced by the milestone")
+	// ErrInclusionProofUnavailable is returned when the data needed to create an inclusion proof is not available (anymore).
+	ErrInclusionProofUnavailable = errors.New("inclusion proof unavailable")
+	// ErrInvalidInclusionProof is returned when an inclusion proof does not match the merkle roots of the milestone.
+	ErrInvalidInclusionProof = errors.New("invalid inclusion proof")
+)
+
+// InclusionProof proves that a message was referenced by a milestone
+// and, if the message contains a transaction that mutated the ledger, that it was applied by the milestone.
+type InclusionProof struct {
+	// The ID of the message.
+	MessageID hornet.MessageID
+	// The milestone that referenced the message.
+	Milestone *iotago.Milestone
+	// The audit path of the message in the merkle tree of the referenced messages (ConfirmedMerkleRoot).
+	Confirmed *MerkleProof
+	// The audit path of the message in the merkle tree of the applied messages (AppliedMerkleRoot).
+	// It is nil if the message didn't mutate the ledger.
+	Applied *MerkleProof
+}
+
+// jsonInclusionProof defines the JSON representation of an InclusionProof.
+type jsonInclusionProof struct {
+	MessageID string            `json:"messageId"`
+	Milestone *iotago.Milestone `json:"milestone"`
+	Confirmed *MerkleProof      `json:"confirmedProof"`
+	Applied   *MerkleProof      `json:"appliedProof,omitempty"`
+}
+
+func (p *InclusionProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonInclusionProof{
+		MessageID: p.MessageID.ToHex(),
+		Milestone: p.Milestone,
+		Confirmed: p.Confirmed,
+		Applied:   p.Applied,
+	})
+}
+
+func (p *InclusionProof) UnmarshalJSON(data []byte) error {
+	jProof := &jsonInclusionProof{}
+	if err := json.Unmarshal(data, jProof); err != nil {
+		return err
+	}
+
+	messageID, err := hornet.MessageIDFromHex(jProof.MessageID)
+	if err != nil {
+		return err
+	}
+
+	p.MessageID = messageID
+	p.Milestone = jProof.Milestone
+	p.Confirmed = jProof.Confirmed
+	p.Applied = jProof.Applied
+	return nil
+}
+
+// merkleProofForMessage computes the audit path of the message in the merkle tree of the given messages.
+// It returns nil if the message is not part of the given messages.
+func merkleProofForMessage(hasher *Hasher, messageID hornet.MessageID, messageIDs hornet.MessageIDs) (*MerkleProof, error) {
+	index := -1
+	marshalers := make([]encoding.BinaryMarshaler, len(messageIDs))
+	for i := range messageIDs {
+		marshalers[i] = messageIDs[i]
+		if index == -1 && messageIDs[i].ToMapKey() == messageID.ToMapKey() {
+			index = i
+		}
+	}
+
+	if index == -1 {
+		return nil, nil
+	}
+
+	return hasher.ComputeProof(marshalers, index)
+}
+
+// NewInclusionProof creates an inclusion proof for the given message.
+// The referenced and applied messages need to be in white-flag order, as they were used to compute the merkle roots of the milestone.
+func NewInclusionProof(messageID hornet.MessageID, milestonePayload *iotago.Milestone, referenced hornet.MessageIDs, applied hornet.MessageIDs) (*InclusionProof, error) {
+	hasher := NewHasher(crypto.BLAKE2b_256)
+
+	confirmedProof, err := merkleProofForMessage(hasher, messageID, referenced)
+	if err != nil {
+		return nil, err
+	}
+	if confirmedProof == nil {
+		return nil, errors.WithMessagef(ErrMessageNotReferenced, "message %s, milestone %d", messageID.ToHex(), milestonePayload.Index)
+	}
+
+	appliedProof, err := merkleProofForMessage(hasher, messageID, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InclusionProof{
+		MessageID: messageID,
+		Milestone: milestonePayload,
+		Confirmed: confirmedProof,
+		Applied:   appliedProof,
+	}, nil
+}
+
+// InclusionProofForMessage creates an inclusion proof for the given message
+// with the white-flag messages that were stored for the milestone that referenced the message.
+func InclusionProofForMessage(dbStorage *storage.Storage, messageID hornet.MessageID) (*InclusionProof, error) {
+
+	cachedMsgMeta := dbStorage.CachedMessageMetadataOrNil(messageID) // meta +1
+	if cachedMsgMeta == nil {
+		return nil, errors.WithMessagef(common.ErrMessageNotFound, "message ID: %s", messageID.ToHex())
+	}
+	referenced, msIndex := cachedMsgMeta.Metadata().ReferencedWithIndex()
+	cachedMsgMeta.Release(true) // meta -1
+
+	if !referenced {
+		return nil, errors.WithMessagef(ErrMessageNotReferenced, "message %s", messageID.ToHex())
+	}
+
+	cachedMilestone := dbStorage.CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil, errors.WithMessagef(ErrInclusionProofUnavailable, "milestone not found: %d", msIndex)
+	}
+	milestonePayload := cachedMilestone.Milestone().Milestone()
+	cachedMilestone.Release(true) // milestone -1
+
+	whiteFlagMessages, err := dbStorage.WhiteFlagMessages(msIndex)
+	if err != nil {
+		return nil, err
+	}
+	if whiteFlagMessages == nil {
+		return nil, errors.WithMessagef(ErrInclusionProofUnavailable, "no white-flag messages for milestone %d", msIndex)
+	}
+
+	return NewInclusionProof(messageID, milestonePayload, whiteFlagMessages.Referenced, whiteFlagMessages.Applied())
+}
+
+// VerifyInclusionProof verifies the audit paths of the inclusion proof against the merkle roots of the milestone.
+// It returns whether the message was applied by the milestone, or an error if the proof is invalid.
+// The milestone itself is not verified, the caller needs to check the signatures
+// of the milestone with the public keys of the coordinator (see iotago.Milestone.VerifySignatures).
+func VerifyInclusionProof(proof *InclusionProof) (bool, error) {
+	if proof == nil || proof.Milestone == nil || proof.Confirmed == nil {
+		return false, errors.WithMessage(ErrInvalidInclusionProof, "proof is incomplete")
+	}
+
+	hasher := NewHasher(crypto.BLAKE2b_256)
+
+	valid, err := hasher.VerifyProof(proof.MessageID, proof.Confirmed, proof.Milestone.ConfirmedMerkleRoot[:])
+	if err != nil {
+		return false, errors.WithMessagef(ErrInvalidInclusionProof, "confirmed proof: %s", err)
+	}
+	if !valid {
+		return false, errors.WithMessage(ErrInvalidInclusionProof, "confirmed proof does not match the confirmed merkle root of the milestone")
+	}
+
+	if proof.Applied == nil {
+		return false, nil
+	}
+
+	valid, err = hasher.VerifyProof(proof.MessageID, proof.Applied, proof.Milestone.AppliedMerkleRoot[:])
+	if err != nil {
+		return false, errors.WithMessagef(ErrInvalidInclusionProof, "applied proof: %s", err)
+	}
+	if !valid {
+		return false, errors.WithMessage(ErrInvalidInclusionProof, "applied proof does not match the applied merkle root of the milestone")
+	}
+
+	return true, nil
+}
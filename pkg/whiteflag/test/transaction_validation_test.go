@@ -0,0 +1,145 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/testsuite"
+	"github.com/gohornet/hornet/pkg/testsuite/utils"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func validateTransaction(te *testsuite.TestEnvironment, message *testsuite.Message) *whiteflag.TransactionValidation {
+	transaction, ok := message.IotaMessage().Payload.(*iotago.Transaction)
+	require.True(te.TestInterface, ok)
+
+	te.UTXOManager().ReadLockLedger()
+	defer te.UTXOManager().ReadUnlockLedger()
+
+	validation, err := whiteflag.ValidateTransaction(te.UTXOManager(), transaction, te.ProtocolParameters(), uint32(time.Now().Unix()))
+	require.NoError(te.TestInterface, err)
+	return validation
+}
+
+func TestValidateTransaction(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, BelowMaxDepth, MinPoWScore, showConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		Build()
+
+	// the transaction is valid against the current ledger state
+	validation := validateTransaction(te, messageA)
+	require.True(t, validation.Valid(), "%v", validation.Errors)
+	require.Equal(t, storage.ConflictNone, validation.Conflict)
+	require.Len(t, validation.Inputs, 1)
+	require.Equal(t, storage.ConflictNone, validation.Inputs[0].Conflict)
+	require.Equal(t, te.ProtocolParameters().TokenSupply, validation.Inputs[0].Output.Deposit())
+
+	// token amounts are sent as strings like in the rest of the API
+	validationJSON, err := json.Marshal(validation)
+	require.NoError(t, err)
+	require.Contains(t, string(validationJSON), fmt.Sprintf(`"amount":"%d"`, te.ProtocolParameters().TokenSupply))
+
+	messageA.Store().BookOnWallets()
+	_, confStats := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageA.StoredMessageID()}, true)
+	require.Equal(t, 1, confStats.MessagesIncludedWithTransactions)
+
+	// the input was spent by the milestone
+	validation = validateTransaction(te, messageA)
+	require.False(t, validation.Valid())
+	require.EqualValues(t, storage.ConflictInputUTXOAlreadySpent, validation.Conflict)
+	require.Len(t, validation.Errors, 1)
+	require.Equal(t, whiteflag.TransactionValidationCheckInputs, validation.Errors[0].Check)
+	require.Equal(t, uint16(0), *validation.Errors[0].InputIndex)
+
+	// the inputs don't exist in the ledger
+	messageB := te.NewMessageBuilder("B").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed2Wallet).
+		ToWallet(seed1Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		FakeInputs().
+		Build()
+
+	validation = validateTransaction(te, messageB)
+	require.False(t, validation.Valid())
+	require.EqualValues(t, storage.ConflictInputUTXONotFound, validation.Conflict)
+	require.Nil(t, validation.Inputs[0].Output)
+}
+
+func TestValidateTransactionUnlocks(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, BelowMaxDepth, MinPoWScore, showConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	// split the genesis output, so that the next transaction consumes two inputs of the same address
+	messageA := te.NewMessageBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		ToWallet(seed1Wallet).
+		Amount(te.ProtocolParameters().TokenSupply / 3).
+		Build().
+		Store().
+		BookOnWallets()
+
+	_, confStats := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageA.StoredMessageID()}, true)
+	require.Equal(t, 1, confStats.MessagesIncludedWithTransactions)
+
+	messageB := te.NewMessageBuilder("B").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		Build()
+
+	transaction, ok := messageB.IotaMessage().Payload.(*iotago.Transaction)
+	require.True(t, ok)
+	require.Len(t, transaction.Essence.Inputs, 2)
+	require.IsType(t, &iotago.ReferenceUnlockBlock{}, transaction.UnlockBlocks[1])
+
+	validation := validateTransaction(te, messageB)
+	require.True(t, validation.Valid(), "%v", validation.Errors)
+
+	// invalidate the signature of the first input
+	signatureUnlockBlock, ok := transaction.UnlockBlocks[0].(*iotago.SignatureUnlockBlock)
+	require.True(t, ok)
+	signature, ok := signatureUnlockBlock.Signature.(*iotago.Ed25519Signature)
+	require.True(t, ok)
+	signature.Signature[0] ^= 0xFF
+
+	// both inputs are reported, since the second input references the invalid signature
+	validation = validateTransaction(te, messageB)
+	require.False(t, validation.Valid())
+	require.EqualValues(t, storage.ConflictInvalidSignature, validation.Conflict)
+	require.Len(t, validation.Errors, 2)
+	for i, validationError := range validation.Errors {
+		require.Equal(t, whiteflag.TransactionValidationCheckUnlocks, validationError.Check)
+		require.Equal(t, uint16(i), *validationError.InputIndex)
+		require.Equal(t, uint16(0), *validationError.UnlockBlockIndex)
+	}
+	require.EqualValues(t, storage.ConflictInvalidSignature, validation.Errors[0].Conflict)
+	require.EqualValues(t, storage.ConflictInvalidInputUnlock, validation.Errors[1].Conflict)
+}
@@ -45,3 +45,130 @@ func TestWhiteFlagMerkleTreeHash(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, bytes.Equal(hash, expectedHash))
 }
+
+func TestWhiteFlagMerkleProof(t *testing.T) {
+
+	hasher := whiteflag.NewHasher(crypto.BLAKE2b_256)
+
+	for leafCount := 1; leafCount <= 17; leafCount++ {
+		var includedMessages []encoding.BinaryMarshaler
+		for i := 0; i < leafCount; i++ {
+			messageID := hornet.NullMessageID()
+			messageID[0] = byte(i)
+			messageID[1] = byte(leafCount)
+			includedMessages = append(includedMessages, messageID)
+		}
+
+		root, err := hasher.Hash(includedMessages)
+		require.NoError(t, err)
+
+		for index := 0; index < leafCount; index++ {
+			proof, err := hasher.ComputeProof(includedMessages, index)
+			require.NoError(t, err)
+
+			valid, err := hasher.VerifyProof(includedMessages[index], proof, root)
+			require.NoError(t, err)
+			require.True(t, valid, "leafCount: %d, index: %d", leafCount, index)
+
+			// the proof is not valid for other messages
+			valid, err = hasher.VerifyProof(includedMessages[(index+1)%leafCount], proof, root)
+			require.NoError(t, err)
+			require.Equal(t, leafCount == 1, valid)
+
+			// the proof survives a JSON round-trip
+			proofJSON, err := proof.MarshalJSON()
+			require.NoError(t, err)
+			restored := &whiteflag.MerkleProof{}
+			require.NoError(t, restored.UnmarshalJSON(proofJSON))
+			require.Equal(t, proof, restored)
+
+			if len(proof.Path) == 0 {
+				continue
+			}
+
+			// a tampered audit path is rejected
+			proof.Path[0][0] ^= 0xFF
+			valid, err = hasher.VerifyProof(includedMessages[index], proof, root)
+			require.NoError(t, err)
+			require.False(t, valid)
+
+			// an audit path with a missing sibling is rejected
+			proof.Path = proof.Path[1:]
+			_, err = hasher.VerifyProof(includedMessages[index], proof, root)
+			require.ErrorIs(t, err, whiteflag.ErrInvalidMerkleProof)
+		}
+	}
+
+	_, err := hasher.ComputeProof(nil, 0)
+	require.ErrorIs(t, err, whiteflag.ErrInvalidMerkleProof)
+}
+
+func TestInclusionProof(t *testing.T) {
+
+	hasher := whiteflag.NewHasher(crypto.BLAKE2b_256)
+
+	referenced := hornet.MessageIDs{}
+	for i := byte(1); i <= 6; i++ {
+		messageID := hornet.NullMessageID()
+		messageID[0] = i
+		referenced = append(referenced, messageID)
+	}
+	applied := hornet.MessageIDs{referenced[0], referenced[2], referenced[5]}
+
+	toMarshalers := func(messageIDs hornet.MessageIDs) []encoding.BinaryMarshaler {
+		marshalers := make([]encoding.BinaryMarshaler, len(messageIDs))
+		for i := range messageIDs {
+			marshalers[i] = messageIDs[i]
+		}
+		return marshalers
+	}
+
+	confirmedRoot, err := hasher.Hash(toMarshalers(referenced))
+	require.NoError(t, err)
+	appliedRoot, err := hasher.Hash(toMarshalers(applied))
+	require.NoError(t, err)
+
+	milestonePayload := &iotago.Milestone{Index: 5, Timestamp: 1650000000}
+	copy(milestonePayload.ConfirmedMerkleRoot[:], confirmedRoot)
+	copy(milestonePayload.AppliedMerkleRoot[:], appliedRoot)
+
+	// applied message
+	proof, err := whiteflag.NewInclusionProof(referenced[2], milestonePayload, referenced, applied)
+	require.NoError(t, err)
+	require.NotNil(t, proof.Applied)
+
+	isApplied, err := whiteflag.VerifyInclusionProof(proof)
+	require.NoError(t, err)
+	require.True(t, isApplied)
+
+	// the proof survives a JSON round-trip
+	proofJSON, err := proof.MarshalJSON()
+	require.NoError(t, err)
+	restored := &whiteflag.InclusionProof{}
+	require.NoError(t, restored.UnmarshalJSON(proofJSON))
+	isApplied, err = whiteflag.VerifyInclusionProof(restored)
+	require.NoError(t, err)
+	require.True(t, isApplied)
+
+	// referenced but not applied message
+	proof, err = whiteflag.NewInclusionProof(referenced[1], milestonePayload, referenced, applied)
+	require.NoError(t, err)
+	require.Nil(t, proof.Applied)
+
+	isApplied, err = whiteflag.VerifyInclusionProof(proof)
+	require.NoError(t, err)
+	require.False(t, isApplied)
+
+	// the proof doesn't match another milestone
+	otherMilestone := *milestonePayload
+	otherMilestone.ConfirmedMerkleRoot[0] ^= 0xFF
+	proof.Milestone = &otherMilestone
+	_, err = whiteflag.VerifyInclusionProof(proof)
+	require.ErrorIs(t, err, whiteflag.ErrInvalidInclusionProof)
+
+	// unreferenced message
+	unreferenced := hornet.NullMessageID()
+	unreferenced[0] = 0xFF
+	_, err = whiteflag.NewInclusionProof(unreferenced, milestonePayload, referenced, applied)
+	require.ErrorIs(t, err, whiteflag.ErrMessageNotReferenced)
+}
@@ -0,0 +1,467 @@
+package whiteflag
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// TransactionValidationCheck is the name of a check that is applied during the validation of a transaction.
+type TransactionValidationCheck string
+
+const (
+	// TransactionValidationCheckSyntax validates the syntax of the transaction (including the network ID).
+	TransactionValidationCheckSyntax TransactionValidationCheck = "syntax"
+	// TransactionValidationCheckInputs validates that the inputs exist in the ledger and are unspent.
+	TransactionValidationCheckInputs TransactionValidationCheck = "inputs"
+	// TransactionValidationCheckTimelock validates that the timelocks of the inputs are expired.
+	TransactionValidationCheckTimelock TransactionValidationCheck = "timelock"
+	// TransactionValidationCheckUnlocks validates the inputs commitment and the unlock blocks of the inputs.
+	TransactionValidationCheckUnlocks TransactionValidationCheck = "unlocks"
+	// TransactionValidationCheckSender validates that the sender features of the outputs are unlocked.
+	TransactionValidationCheckSender TransactionValidationCheck = "sender"
+	// TransactionValidationCheckDeposit validates the balance of the deposits and the storage deposit returns.
+	TransactionValidationCheckDeposit TransactionValidationCheck = "deposit"
+	// TransactionValidationCheckNativeTokens validates the balance of the native tokens.
+	TransactionValidationCheckNativeTokens TransactionValidationCheck = "nativeTokens"
+	// TransactionValidationCheckChains validates the state transitions of the alias, foundry and NFT outputs.
+	TransactionValidationCheckChains TransactionValidationCheck = "chains"
+)
+
+// TransactionValidationError is a failed check of the validation of a transaction.
+type TransactionValidationError struct {
+	// The check that failed.
+	Check TransactionValidationCheck `json:"check"`
+	// The index of the input the error belongs to, nil if the error doesn't belong to a single input.
+	InputIndex *uint16 `json:"inputIndex,omitempty"`
+	// The index of the unlock block that failed to unlock the input, nil if the error doesn't belong to an unlock block.
+	// For reference unlock blocks, it is the index of the referenced unlock block.
+	UnlockBlockIndex *uint16 `json:"unlockBlockIndex,omitempty"`
+	// The conflict a milestone would mark the transaction with.
+	// It is ConflictNone for syntactic errors, since such transactions are never part of a milestone cone.
+	Conflict storage.Conflict `json:"conflictReason"`
+	// The error message.
+	Error string `json:"error"`
+}
+
+// InputValidation is the result of the validation of a single input of a transaction.
+type InputValidation struct {
+	// The index of the input in the transaction essence.
+	Index uint16
+	// The ID of the output that is consumed by the input.
+	OutputID *iotago.OutputID
+	// The conflict of the input, ConflictNone if the input can be consumed.
+	Conflict storage.Conflict
+	// The output that is consumed by the input, nil if it was not found in the ledger.
+	Output *utxo.Output
+}
+
+// jsonInputValidation defines the JSON representation of an InputValidation.
+type jsonInputValidation struct {
+	Index    uint16           `json:"index"`
+	OutputID string           `json:"outputId"`
+	Conflict storage.Conflict `json:"conflictReason"`
+	Amount   string           `json:"amount,omitempty"`
+}
+
+func (v *InputValidation) MarshalJSON() ([]byte, error) {
+	jInput := &jsonInputValidation{
+		Index:    v.Index,
+		OutputID: v.OutputID.ToHex(),
+		Conflict: v.Conflict,
+	}
+	if v.Output != nil {
+		jInput.Amount = iotago.EncodeUint64(v.Output.Deposit())
+	}
+	return json.Marshal(jInput)
+}
+
+func (v *InputValidation) UnmarshalJSON(data []byte) error {
+	jInput := &jsonInputValidation{}
+	if err := json.Unmarshal(data, jInput); err != nil {
+		return err
+	}
+
+	outputIDBytes, err := iotago.DecodeHex(jInput.OutputID)
+	if err != nil {
+		return err
+	}
+	if len(outputIDBytes) != iotago.OutputIDLength {
+		return errors.Errorf("invalid output ID length: %d", len(outputIDBytes))
+	}
+
+	outputID := &iotago.OutputID{}
+	copy(outputID[:], outputIDBytes)
+
+	v.Index = jInput.Index
+	v.OutputID = outputID
+	v.Conflict = jInput.Conflict
+	return nil
+}
+
+// TransactionValidation is the result of the validation of a transaction against the ledger state.
+type TransactionValidation struct {
+	// The ID of the transaction.
+	TransactionID *iotago.TransactionID
+	// The ledger index the transaction was validated against.
+	LedgerIndex milestone.Index
+	// The conflict a milestone would mark the transaction with, ConflictNone if it would be applied.
+	Conflict storage.Conflict
+	// The validation results of the inputs. It is empty if the syntactic validation failed.
+	Inputs []*InputValidation
+	// The failed checks in the order they were applied.
+	Errors []*TransactionValidationError
+}
+
+// Valid returns whether the transaction passed all checks.
+func (v *TransactionValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// jsonTransactionValidation defines the JSON representation of a TransactionValidation.
+type jsonTransactionValidation struct {
+	TransactionID string                        `json:"transactionId"`
+	LedgerIndex   milestone.Index               `json:"ledgerIndex"`
+	Valid         bool                          `json:"valid"`
+	Conflict      storage.Conflict              `json:"conflictReason"`
+	Inputs        []*InputValidation            `json:"inputs"`
+	Errors        []*TransactionValidationError `json:"errors"`
+}
+
+func (v *TransactionValidation) MarshalJSON() ([]byte, error) {
+	jValidation := &jsonTransactionValidation{
+		LedgerIndex: v.LedgerIndex,
+		Valid:       v.Valid(),
+		Conflict:    v.Conflict,
+		Inputs:      v.Inputs,
+		Errors:      v.Errors,
+	}
+	if v.TransactionID != nil {
+		jValidation.TransactionID = v.TransactionID.ToHex()
+	}
+	if jValidation.Inputs == nil {
+		jValidation.Inputs = make([]*InputValidation, 0)
+	}
+	if jValidation.Errors == nil {
+		jValidation.Errors = make([]*TransactionValidationError, 0)
+	}
+	return json.Marshal(jValidation)
+}
+
+func (v *TransactionValidation) UnmarshalJSON(data []byte) error {
+	jValidation := &jsonTransactionValidation{}
+	if err := json.Unmarshal(data, jValidation); err != nil {
+		return err
+	}
+
+	if len(jValidation.TransactionID) > 0 {
+		transactionIDBytes, err := iotago.DecodeHex(jValidation.TransactionID)
+		if err != nil {
+			return err
+		}
+		if len(transactionIDBytes) != iotago.TransactionIDLength {
+			return errors.Errorf("invalid transaction ID length: %d", len(transactionIDBytes))
+		}
+
+		transactionID := &iotago.TransactionID{}
+		copy(transactionID[:], transactionIDBytes)
+		v.TransactionID = transactionID
+	}
+
+	v.LedgerIndex = jValidation.LedgerIndex
+	v.Conflict = jValidation.Conflict
+	v.Inputs = jValidation.Inputs
+	v.Errors = jValidation.Errors
+	return nil
+}
+
+func (v *TransactionValidation) addError(check TransactionValidationCheck, inputIndex *uint16, conflict storage.Conflict, err error) {
+	v.addUnlockError(check, inputIndex, nil, conflict, err)
+}
+
+func (v *TransactionValidation) addUnlockError(check TransactionValidationCheck, inputIndex *uint16, unlockBlockIndex *uint16, conflict storage.Conflict, err error) {
+	if v.Conflict == storage.ConflictNone {
+		// the milestone marks the transaction with the first conflict it encounters
+		v.Conflict = conflict
+	}
+	v.Errors = append(v.Errors, &TransactionValidationError{
+		Check:            check,
+		InputIndex:       inputIndex,
+		UnlockBlockIndex: unlockBlockIndex,
+		Conflict:         conflict,
+		Error:            err.Error(),
+	})
+}
+
+// identToUnlock returns the identity that needs to be unlocked to consume the input at the given index.
+// It mirrors the unlock logic of iotago.TxSemanticInputUnlocks, which stops at the first input that can't be unlocked.
+func identToUnlock(svCtx *iotago.SemanticValidationContext, input iotago.Output, inputIndex uint16) (iotago.Address, error) {
+
+	// if the expiration is reached, only the return identity can unlock the input
+	if expiration := input.UnlockConditions().MustSet().Expiration(); expiration != nil {
+		msIndexExpired := expiration.MilestoneIndex != 0 && expiration.MilestoneIndex <= svCtx.ExtParas.ConfMsIndex
+		unixExpired := expiration.UnixTime != 0 && expiration.UnixTime <= svCtx.ExtParas.ConfUnix
+
+		switch {
+		case expiration.MilestoneIndex != 0 && expiration.UnixTime != 0:
+			if msIndexExpired && unixExpired {
+				return expiration.ReturnAddress, nil
+			}
+		case msIndexExpired || unixExpired:
+			return expiration.ReturnAddress, nil
+		}
+	}
+
+	switch in := input.(type) {
+	case iotago.TransIndepIdentOutput:
+		return in.Ident(), nil
+
+	case iotago.TransDepIdentOutput:
+		chainID := in.Chain()
+		if chainID.Empty() {
+			utxoChainID, is := chainID.(iotago.UTXOIDChainID)
+			if !is {
+				return nil, iotago.ErrTransDepIdentOutputNonUTXOChainID
+			}
+			chainID = utxoChainID.FromOutputID(svCtx.WorkingSet.UTXOInputAtIndex(inputIndex).Ref())
+		}
+
+		next := svCtx.WorkingSet.OutChains[chainID]
+		if next == nil {
+			return in.Ident(nil)
+		}
+
+		nextTransDepIdentOutput, ok := next.(iotago.TransDepIdentOutput)
+		if !ok {
+			return nil, iotago.ErrTransDepIdentOutputNextInvalid
+		}
+
+		return in.Ident(nextTransDepIdentOutput)
+
+	default:
+		return nil, errors.Errorf("unknown ident output type: %T", input)
+	}
+}
+
+// unlockInput unlocks the identity of the input at the given index with its unlock block.
+// It returns the index of the unlock block that was used to unlock the input.
+func unlockInput(svCtx *iotago.SemanticValidationContext, input iotago.Output, inputIndex uint16) (uint16, error) {
+	ownerIdent, err := identToUnlock(svCtx, input, inputIndex)
+	if err != nil {
+		return inputIndex, errors.Wrapf(err, "unable to retrieve ident to unlock of input %d", inputIndex)
+	}
+
+	unlockBlock := svCtx.WorkingSet.Tx.UnlockBlocks[inputIndex]
+
+	switch owner := ownerIdent.(type) {
+	case iotago.ChainConstrainedAddress:
+		refUnlockBlock, isReferentialUnlockBlock := unlockBlock.(iotago.ReferentialUnlockBlock)
+		if !isReferentialUnlockBlock || !refUnlockBlock.Chainable() || !refUnlockBlock.SourceAllowed(ownerIdent) {
+			return inputIndex, errors.WithMessagef(iotago.ErrInvalidInputUnlock, "input %d has a chain constrained address (%T) but its corresponding unlock block is of type %T", inputIndex, owner, unlockBlock)
+		}
+
+		return refUnlockBlock.Ref(), svCtx.WorkingSet.UnlockedIdents.RefUnlock(owner.Key(), refUnlockBlock.Ref(), inputIndex)
+
+	case iotago.DirectUnlockableAddress:
+		switch uBlock := unlockBlock.(type) {
+		case iotago.ReferentialUnlockBlock:
+			if uBlock.Chainable() || !uBlock.SourceAllowed(ownerIdent) {
+				return inputIndex, errors.WithMessagef(iotago.ErrInvalidInputUnlock, "input %d has none chain constrained address of %s but its corresponding unlock block is of type %s", inputIndex, owner.Type(), unlockBlock.Type())
+			}
+
+			return uBlock.Ref(), svCtx.WorkingSet.UnlockedIdents.RefUnlock(owner.Key(), uBlock.Ref(), inputIndex)
+
+		case *iotago.SignatureUnlockBlock:
+			// owner must not be unlocked already
+			if unlockedIdent, wasAlreadyUnlocked := svCtx.WorkingSet.UnlockedIdents[owner.Key()]; wasAlreadyUnlocked {
+				return inputIndex, errors.WithMessagef(iotago.ErrInvalidInputUnlock, "input %d's address is already unlocked through input %d's unlock block but the input uses a non referential unlock block", inputIndex, unlockedIdent.UnlockedAt)
+			}
+
+			return inputIndex, svCtx.WorkingSet.UnlockedIdents.SigUnlock(owner, svCtx.WorkingSet.EssenceMsgToSign, uBlock.Signature, inputIndex)
+
+		default:
+			return inputIndex, errors.WithMessagef(iotago.ErrInvalidInputUnlock, "input %d has an unsupported unlock block of type %T", inputIndex, unlockBlock)
+		}
+
+	default:
+		return inputIndex, errors.Errorf("unknown address type in unlocks: %T", ownerIdent)
+	}
+}
+
+// ValidateTransaction applies the same syntactic and semantic validation to the transaction that is applied
+// during the white-flag confirmation, as if the transaction was referenced by the next milestone
+// with the given timestamp. The ledger is not modified.
+// In contrast to the white-flag confirmation, all inputs are checked even if one of them is conflicting,
+// and the semantic checks continue after a failed check as long as the later checks don't depend on it.
+// The caller needs to hold the ledger lock.
+func ValidateTransaction(utxoManager *utxo.Manager, transaction *iotago.Transaction, protoParas *iotago.ProtocolParameters, msTimestamp uint32) (*TransactionValidation, error) {
+
+	ledgerIndex, err := utxoManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &TransactionValidation{
+		LedgerIndex: ledgerIndex,
+		Conflict:    storage.ConflictNone,
+		Inputs:      make([]*InputValidation, 0),
+		Errors:      make([]*TransactionValidationError, 0),
+	}
+
+	if transaction.Essence == nil {
+		validation.addError(TransactionValidationCheckSyntax, nil, storage.ConflictNone, errors.New("transaction essence missing"))
+		return validation, nil
+	}
+
+	// the syntactic validation is part of the serialization
+	if _, err := transaction.Serialize(serializer.DeSeriModePerformValidation, protoParas); err != nil {
+		validation.addError(TransactionValidationCheckSyntax, nil, storage.ConflictNone, err)
+		return validation, nil
+	}
+
+	validation.TransactionID, err = transaction.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	essence := transaction.Essence
+	inputOutputs := utxo.Outputs{}
+	for i, input := range essence.Inputs {
+		inputIndex := uint16(i)
+
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok {
+			validation.addError(TransactionValidationCheckSyntax, &inputIndex, storage.ConflictNone, errors.Errorf("unsupported input type: %T", input))
+			continue
+		}
+
+		outputID := utxoInput.ID()
+		inputValidation := &InputValidation{
+			Index:    inputIndex,
+			OutputID: &outputID,
+			Conflict: storage.ConflictNone,
+		}
+		validation.Inputs = append(validation.Inputs, inputValidation)
+
+		output, err := utxoManager.ReadOutputByOutputIDWithoutLocking(inputValidation.OutputID)
+		if err != nil {
+			if !errors.Is(err, kvstore.ErrKeyNotFound) {
+				return nil, err
+			}
+			inputValidation.Conflict = storage.ConflictInputUTXONotFound
+			validation.addError(TransactionValidationCheckInputs, &inputIndex, inputValidation.Conflict, errors.Errorf("output %s not found", inputValidation.OutputID.ToHex()))
+			continue
+		}
+		inputValidation.Output = output
+
+		unspent, err := utxoManager.IsOutputUnspentWithoutLocking(output)
+		if err != nil {
+			return nil, err
+		}
+
+		if !unspent {
+			inputValidation.Conflict = storage.ConflictInputUTXOAlreadySpent
+			validation.addError(TransactionValidationCheckInputs, &inputIndex, inputValidation.Conflict, errors.Errorf("output %s already spent", inputValidation.OutputID.ToHex()))
+			continue
+		}
+
+		inputOutputs = append(inputOutputs, output)
+	}
+
+	if !validation.Valid() {
+		// the semantic validation needs all inputs
+		return validation, nil
+	}
+
+	semValCtx := &iotago.SemanticValidationContext{
+		ExtParas: &iotago.ExternalUnlockParameters{
+			ConfMsIndex: uint32(ledgerIndex + 1),
+			ConfUnix:    msTimestamp,
+		},
+	}
+
+	// the timelocks are checked per input, so that all locked inputs are reported
+	timelockCheck := func(svCtx *iotago.SemanticValidationContext) error {
+		for i, input := range svCtx.WorkingSet.Inputs {
+			if err := input.UnlockConditions().MustSet().TimelocksExpired(svCtx.ExtParas); err != nil {
+				inputIndex := uint16(i)
+				validation.addError(TransactionValidationCheckTimelock, &inputIndex, storage.ConflictFromSemanticValidationError(err), errors.Wrapf(err, "input at index %d's timelocks are not expired", inputIndex))
+			}
+		}
+		return nil
+	}
+
+	// the unlocks are checked per input, so that all inputs that can't be unlocked are reported
+	unlocksCheck := func(svCtx *iotago.SemanticValidationContext) error {
+		actualInputsCommitment, err := svCtx.WorkingSet.Inputs.Commitment()
+		if err != nil {
+			err = errors.Wrap(err, "unable to compute hash of inputs")
+			validation.addError(TransactionValidationCheckUnlocks, nil, storage.ConflictFromSemanticValidationError(err), err)
+			return err
+		}
+
+		if !bytes.Equal(svCtx.WorkingSet.Tx.Essence.InputsCommitment[:], actualInputsCommitment) {
+			err := errors.WithMessagef(iotago.ErrInvalidInputsCommitment, "specified %v but got %v", svCtx.WorkingSet.Tx.Essence.InputsCommitment[:], actualInputsCommitment)
+			validation.addError(TransactionValidationCheckUnlocks, nil, storage.ConflictFromSemanticValidationError(err), err)
+			return err
+		}
+
+		var firstErr error
+		for i, input := range svCtx.WorkingSet.Inputs {
+			inputIndex := uint16(i)
+
+			unlockBlockIndex, err := unlockInput(svCtx, input, inputIndex)
+			if err != nil {
+				validation.addUnlockError(TransactionValidationCheckUnlocks, &inputIndex, &unlockBlockIndex, storage.ConflictFromSemanticValidationError(err), err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+
+			// since this input is now unlocked, and it is a ChainConstrainedOutput, the chain's address becomes automatically unlocked
+			if chainConstrOutput, is := input.(iotago.ChainConstrainedOutput); is && chainConstrOutput.Chain().Addressable() {
+				chainID := chainConstrOutput.Chain()
+				if chainID.Empty() {
+					chainID = chainID.(iotago.UTXOIDChainID).FromOutputID(svCtx.WorkingSet.UTXOInputAtIndex(inputIndex).Ref())
+				}
+				svCtx.WorkingSet.UnlockedIdents.AddUnlockedChain(chainID.ToAddress(), inputIndex)
+			}
+		}
+
+		// the following checks depend on the identities that were unlocked by the inputs
+		return firstErr
+	}
+
+	// the checks are applied in the same order as in iotago.Transaction.SemanticallyValidate
+	check := func(name TransactionValidationCheck, checkFunc iotago.TxSemanticValidationFunc) iotago.TxSemanticValidationFunc {
+		return func(svCtx *iotago.SemanticValidationContext) error {
+			if err := checkFunc(svCtx); err != nil {
+				validation.addError(name, nil, storage.ConflictFromSemanticValidationError(err), err)
+			}
+			return nil
+		}
+	}
+
+	if err := transaction.SemanticallyValidate(semValCtx, inputOutputs.ToOutputSet(),
+		timelockCheck,
+		unlocksCheck,
+		check(TransactionValidationCheckSender, iotago.TxSemanticOutputsSender()),
+		check(TransactionValidationCheckDeposit, iotago.TxSemanticDeposit()),
+		check(TransactionValidationCheckNativeTokens, iotago.TxSemanticNativeTokens()),
+		check(TransactionValidationCheckChains, iotago.TxSemanticSTVFOnChains()),
+	); err != nil && validation.Valid() {
+		// the working set could not be created
+		validation.addError(TransactionValidationCheckInputs, nil, storage.ConflictFromSemanticValidationError(err), err)
+	}
+
+	return validation, nil
+}
@@ -52,6 +52,8 @@ type Confirmation struct {
 type MessageWithConflict struct {
 	MessageID hornet.MessageID
 	Conflict  storage.Conflict
+	// The input that was already spent, only set if the conflict was a double spend.
+	DoubleSpentInput *iotago.OutputID
 }
 
 // WhiteFlagMutations contains the ledger mutations and referenced messages applied to a cone under the "white-flag" approach.
@@ -180,6 +182,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 		}
 
 		var conflict = storage.ConflictNone
+		var doubleSpentInput *iotago.OutputID
 
 		transaction := message.Transaction()
 		transactionID, err := transaction.ID()
@@ -198,6 +201,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 				if hasSpent {
 					// UTXO already spent, so mark as conflict
 					conflict = storage.ConflictInputUTXOAlreadySpentInThisMilestone
+					doubleSpentInput = input
 					break
 				}
 
@@ -229,6 +233,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 				if !unspent {
 					// output is already spent, so mark as conflict
 					conflict = storage.ConflictInputUTXOAlreadySpent
+					doubleSpentInput = input
 					break
 				}
 
@@ -265,8 +270,9 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 
 		if conflict != storage.ConflictNone {
 			wfConf.MessagesExcludedWithConflictingTransactions = append(wfConf.MessagesExcludedWithConflictingTransactions, MessageWithConflict{
-				MessageID: messageID,
-				Conflict:  conflict,
+				MessageID:        messageID,
+				Conflict:         conflict,
+				DoubleSpentInput: doubleSpentInput,
 			})
 			return nil
 		}
@@ -1,9 +1,15 @@
 package whiteflag
 
 import (
+	"bytes"
 	"crypto"
 	"encoding"
+	"encoding/json"
 	"math/bits"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
 )
 
 // Domain separation prefixes
@@ -12,6 +18,11 @@ const (
 	NodeHashPrefix = 1
 )
 
+var (
+	// ErrInvalidMerkleProof is returned when a merkle proof does not fit to the tree it should be part of.
+	ErrInvalidMerkleProof = errors.New("invalid merkle proof")
+)
+
 // Hasher implements the hashing algorithm described in the IOTA protocol RFC-12.
 type Hasher struct {
 	hash crypto.Hash
@@ -54,6 +65,109 @@ func (t *Hasher) Hash(data []encoding.BinaryMarshaler) ([]byte, error) {
 	return t.hashNode(l, r), nil
 }
 
+// ComputeProof computes the audit path of the leaf at the given index of the Merkle tree of the provided data encodings.
+func (t *Hasher) ComputeProof(data []encoding.BinaryMarshaler, index int) (*MerkleProof, error) {
+	if index < 0 || index >= len(data) {
+		return nil, errors.Wrapf(ErrInvalidMerkleProof, "index %d out of range (%d leaves)", index, len(data))
+	}
+
+	path, err := t.auditPath(data, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MerkleProof{
+		Index:     index,
+		LeafCount: len(data),
+		Path:      path,
+	}, nil
+}
+
+// auditPath returns the hashes of the siblings on the path from the leaf at the given index to the root,
+// starting with the sibling of the leaf.
+func (t *Hasher) auditPath(data []encoding.BinaryMarshaler, index int) ([][]byte, error) {
+	if len(data) == 1 {
+		return [][]byte{}, nil
+	}
+
+	k := largestPowerOfTwo(len(data))
+	if index < k {
+		path, err := t.auditPath(data[:k], index)
+		if err != nil {
+			return nil, err
+		}
+		sibling, err := t.Hash(data[k:])
+		if err != nil {
+			return nil, err
+		}
+		return append(path, sibling), nil
+	}
+
+	path, err := t.auditPath(data[k:], index-k)
+	if err != nil {
+		return nil, err
+	}
+	sibling, err := t.Hash(data[:k])
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// VerifyProof checks whether the given data encoding is part of the Merkle tree with the given root.
+func (t *Hasher) VerifyProof(data encoding.BinaryMarshaler, proof *MerkleProof, root []byte) (bool, error) {
+	if proof == nil || proof.Index < 0 || proof.Index >= proof.LeafCount {
+		return false, ErrInvalidMerkleProof
+	}
+
+	leaf, err := t.hashLeaf(data)
+	if err != nil {
+		return false, err
+	}
+
+	computedRoot, err := t.rootFromAuditPath(leaf, proof.Index, proof.LeafCount, proof.Path)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(computedRoot, root), nil
+}
+
+// rootFromAuditPath computes the root of the Merkle tree with the given amount of leaves
+// from the hash of the leaf at the given index and its audit path.
+func (t *Hasher) rootFromAuditPath(leaf []byte, index int, leafCount int, path [][]byte) ([]byte, error) {
+	if leafCount == 1 {
+		if len(path) != 0 {
+			return nil, errors.Wrap(ErrInvalidMerkleProof, "audit path too long")
+		}
+		return leaf, nil
+	}
+
+	if len(path) == 0 {
+		return nil, errors.Wrap(ErrInvalidMerkleProof, "audit path too short")
+	}
+
+	sibling := path[len(path)-1]
+	if len(sibling) != t.Size() {
+		return nil, errors.Wrapf(ErrInvalidMerkleProof, "invalid hash length %d", len(sibling))
+	}
+
+	k := largestPowerOfTwo(leafCount)
+	if index < k {
+		l, err := t.rootFromAuditPath(leaf, index, k, path[:len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		return t.hashNode(l, sibling), nil
+	}
+
+	r, err := t.rootFromAuditPath(leaf, index-k, leafCount-k, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	return t.hashNode(sibling, r), nil
+}
+
 // hashLeaf returns the Merkle tree leaf hash of data.
 func (t *Hasher) hashLeaf(data encoding.BinaryMarshaler) ([]byte, error) {
 	b, err := data.MarshalBinary()
@@ -82,3 +196,54 @@ func largestPowerOfTwo(x int) int {
 	}
 	return 1 << (bits.Len(uint(x-1)) - 1)
 }
+
+// MerkleProof is the audit path of a leaf in a Merkle tree.
+type MerkleProof struct {
+	// The index of the leaf.
+	Index int
+	// The amount of leaves in the tree.
+	LeafCount int
+	// The hashes of the siblings on the path from the leaf to the root, starting with the sibling of the leaf.
+	Path [][]byte
+}
+
+// jsonMerkleProof defines the JSON representation of a MerkleProof.
+type jsonMerkleProof struct {
+	Index     int      `json:"index"`
+	LeafCount int      `json:"leafCount"`
+	Path      []string `json:"path"`
+}
+
+func (p *MerkleProof) MarshalJSON() ([]byte, error) {
+	path := make([]string, len(p.Path))
+	for i, hash := range p.Path {
+		path[i] = iotago.EncodeHex(hash)
+	}
+
+	return json.Marshal(&jsonMerkleProof{
+		Index:     p.Index,
+		LeafCount: p.LeafCount,
+		Path:      path,
+	})
+}
+
+func (p *MerkleProof) UnmarshalJSON(data []byte) error {
+	jProof := &jsonMerkleProof{}
+	if err := json.Unmarshal(data, jProof); err != nil {
+		return err
+	}
+
+	path := make([][]byte, len(jProof.Path))
+	for i, hexHash := range jProof.Path {
+		hash, err := iotago.DecodeHex(hexHash)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidMerkleProof, "invalid hash in path: %s", err)
+		}
+		path[i] = hash
+	}
+
+	p.Index = jProof.Index
+	p.LeafCount = jProof.LeafCount
+	p.Path = path
+	return nil
+}
@@ -0,0 +1,74 @@
+package alerting
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/alerting"
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+func rules() *rulesResponse {
+	rules := engine.Rules()
+
+	resp := &rulesResponse{Rules: make([]*ruleResponse, len(rules))}
+	for i, rule := range rules {
+		resp.Rules[i] = &ruleResponse{
+			Name:      rule.Name,
+			Condition: rule.Condition(),
+			For:       rule.For.String(),
+			Severity:  rule.Severity,
+		}
+	}
+	return resp
+}
+
+func silences() *silencesResponse {
+	return &silencesResponse{Silences: engine.Silences(time.Now())}
+}
+
+func addSilence(c echo.Context) (*alerting.Silence, error) {
+
+	request := &addSilenceRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil || duration <= 0 {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid duration: %s", request.Duration)
+	}
+
+	startsAt := time.Now()
+	if request.StartsAt != nil {
+		startsAt = time.Unix(*request.StartsAt, 0)
+	}
+
+	silence, err := engine.AddSilence(request.Rule, startsAt, startsAt.Add(duration), request.Comment)
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "adding silence failed, error: %s", err)
+	}
+
+	return silence, nil
+}
+
+func removeSilence(c echo.Context) error {
+
+	silenceIDParam := c.Param(ParameterSilenceID)
+	silenceID, err := strconv.ParseUint(silenceIDParam, 10, 64)
+	if err != nil {
+		return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid silence ID: %s, error: %s", silenceIDParam, err)
+	}
+
+	if err := engine.RemoveSilence(silenceID); err != nil {
+		if errors.Is(err, alerting.ErrSilenceNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "silence not found: %d", silenceID)
+		}
+		return errors.WithMessagef(echo.ErrInternalServerError, "removing silence failed, error: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/alerting"
+	"github.com/gohornet/hornet/pkg/inxregistry"
+	"github.com/iotaledger/hive.go/events"
+)
+
+const (
+	// the time window the database growth is calculated over.
+	databaseGrowthWindow = time.Hour
+)
+
+var (
+	pruningFailures *alerting.EventCounter
+	inxDisconnects  *alerting.EventCounter
+
+	databaseSizeSamplesLock sync.Mutex
+	databaseSizeSamples     []*databaseSizeSample
+)
+
+type databaseSizeSample struct {
+	size int64
+	time time.Time
+}
+
+// configureMetrics creates the metrics the rules can be evaluated on.
+func configureMetrics() map[string]alerting.MetricFunc {
+
+	pruningFailures = alerting.NewEventCounter(ParamsAlerting.EventsWindow)
+	inxDisconnects = alerting.NewEventCounter(ParamsAlerting.EventsWindow)
+
+	deps.SnapshotManager.Events.PruningFailed.Attach(events.NewClosure(func(_ error) {
+		pruningFailures.Add(time.Now())
+	}))
+
+	if deps.INXRegistry != nil {
+		deps.INXRegistry.Events.ExtensionRemoved.Attach(events.NewClosure(func(_ *inxregistry.Extension) {
+			inxDisconnects.Add(time.Now())
+		}))
+	}
+
+	return map[string]alerting.MetricFunc{
+		// the amount of milestones the confirmed milestone is behind the latest milestone.
+		"syncLag": func() (float64, error) {
+			lmi := deps.SyncManager.LatestMilestoneIndex()
+			cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+			if cmi > lmi {
+				return 0, nil
+			}
+			return float64(lmi - cmi), nil
+		},
+		// the amount of connected peers.
+		"peerCount": func() (float64, error) {
+			return float64(deps.PeeringManager.ConnectedCount()), nil
+		},
+		// the age of the latest milestone in seconds.
+		"latestMilestoneAge": func() (float64, error) {
+			milestoneTimestamp, err := deps.Storage.MilestoneTimestampByIndex(deps.SyncManager.LatestMilestoneIndex())
+			if err != nil {
+				// no milestone known yet
+				return 0, alerting.ErrNoValue
+			}
+			return time.Since(milestoneTimestamp).Seconds(), nil
+		},
+		// the amount of queued and pending requests.
+		"requestQueueDepth": func() (float64, error) {
+			queued, pending, _ := deps.RequestQueue.Size()
+			return float64(queued + pending), nil
+		},
+		// the total size of the databases in bytes.
+		"databaseSize": func() (float64, error) {
+			size, err := databaseSize()
+			if err != nil {
+				return 0, err
+			}
+			return float64(size), nil
+		},
+		// the growth of the databases in bytes per hour.
+		"databaseGrowth": databaseGrowth,
+		// the amount of milestones that could not be pruned within the events window.
+		"pruningFailures": func() (float64, error) {
+			return float64(pruningFailures.Count(time.Now())), nil
+		},
+		// the amount of INX extensions that were removed because they were not alive anymore within the events window.
+		"inxDisconnects": func() (float64, error) {
+			return float64(inxDisconnects.Count(time.Now())), nil
+		},
+	}
+}
+
+func databaseSize() (int64, error) {
+	tangleDatabaseSize, err := deps.TangleDatabase.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	utxoDatabaseSize, err := deps.UTXODatabase.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	return tangleDatabaseSize + utxoDatabaseSize, nil
+}
+
+// databaseGrowth returns the growth of the databases in bytes per hour,
+// extrapolated from the oldest sample within the growth window.
+func databaseGrowth() (float64, error) {
+	size, err := databaseSize()
+	if err != nil {
+		return 0, err
+	}
+
+	databaseSizeSamplesLock.Lock()
+	defer databaseSizeSamplesLock.Unlock()
+
+	now := time.Now()
+	databaseSizeSamples = append(databaseSizeSamples, &databaseSizeSample{size: size, time: now})
+
+	// drop the samples that left the window
+	for len(databaseSizeSamples) > 1 && now.Sub(databaseSizeSamples[0].time) > databaseGrowthWindow {
+		databaseSizeSamples = databaseSizeSamples[1:]
+	}
+
+	oldest := databaseSizeSamples[0]
+	elapsed := now.Sub(oldest.time)
+	if elapsed < time.Minute {
+		// not enough samples yet
+		return 0, alerting.ErrNoValue
+	}
+
+	return float64(size-oldest.size) / elapsed.Hours(), nil
+}
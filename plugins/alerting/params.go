@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersAlerting contains the definition of the parameters used by the alerting plugin.
+type ParametersAlerting struct {
+	// the interval in which the rules are evaluated.
+	EvaluationInterval time.Duration `default:"10s" usage:"the interval in which the rules are evaluated"`
+	// the interval in which the notifications of still firing alerts are repeated (0 = never).
+	RepeatInterval time.Duration `default:"1h" usage:"the interval in which the notifications of still firing alerts are repeated (0 = never)"`
+	// the maximum amount of alerts kept in the history.
+	HistorySize int `default:"100" usage:"the maximum amount of alerts kept in the history"`
+	// the time window in which events like pruning failures and INX disconnects are counted.
+	EventsWindow time.Duration `default:"1h" usage:"the time window in which events like pruning failures and INX disconnects are counted"`
+	// the rules in the format "<name>: <metric> <operator> <threshold> [for <duration>] [severity <severity>]".
+	Rules []string `usage:"the rules in the format \"<name>: <metric> <operator> <threshold> [for <duration>] [severity <severity>]\""`
+
+	Log struct {
+		// whether alerts are written to the log.
+		Enabled bool `default:"true" usage:"whether alerts are written to the log"`
+	}
+
+	Webhook struct {
+		// the URL the alerts are posted to (empty to disable).
+		URL string `default:"" name:"url" usage:"the URL the alerts are posted to (empty to disable)"`
+		// additional HTTP headers that are sent with every request.
+		Headers map[string]string `noflag:"true" usage:"additional HTTP headers that are sent with every request"`
+		// the timeout of a single request.
+		Timeout time.Duration `default:"5s" usage:"the timeout of a single request"`
+	}
+}
+
+var ParamsAlerting = &ParametersAlerting{
+	Rules: []string{
+		"node-unsynced: syncLag > 2 for 1m severity critical",
+		"no-peers: peerCount < 1 for 1m severity critical",
+		"milestone-outdated: latestMilestoneAge > 300 for 1m severity critical",
+		"request-queue-congested: requestQueueDepth > 10000 for 5m severity warning",
+		"database-growth: databaseGrowth > 1073741824 for 10m severity warning",
+		"pruning-failed: pruningFailures > 0 severity warning",
+		"inx-disconnects: inxDisconnects > 3 severity warning",
+	},
+}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"alerting": ParamsAlerting,
+	},
+	Masked: []string{"alerting.webhook.headers"},
+}
@@ -0,0 +1,168 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+
+	"github.com/gohornet/hornet/pkg/alerting"
+	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/inxregistry"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
+	restapipkg "github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/snapshot"
+	"github.com/gohornet/hornet/plugins/restapi"
+	"github.com/iotaledger/hive.go/app"
+)
+
+const (
+	// ParameterSilenceID is used to identify a silence.
+	ParameterSilenceID = "silenceID"
+)
+
+const (
+	// RouteAlerts is the route for getting the currently firing alerts.
+	// GET returns the firing alerts.
+	RouteAlerts = "/alerts"
+
+	// RouteAlertsHistory is the route for getting the recently fired and resolved alerts.
+	// GET returns the alert history, newest first.
+	RouteAlertsHistory = "/alerts/history"
+
+	// RouteRules is the route for getting the configured rules.
+	// GET returns the rules.
+	RouteRules = "/rules"
+
+	// RouteSilences is the route for the silences of alerts.
+	// GET returns the active and upcoming silences.
+	// POST creates a new silence.
+	RouteSilences = "/silences"
+
+	// RouteSilence is the route for a single silence.
+	// DELETE removes the silence.
+	RouteSilence = "/silences/:" + ParameterSilenceID
+)
+
+func init() {
+	Plugin = &app.Plugin{
+		Status: app.StatusDisabled,
+		Component: &app.Component{
+			Name:      "Alerting",
+			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
+			Configure: configure,
+			Run:       run,
+		},
+	}
+}
+
+var (
+	Plugin *app.Plugin
+	deps   dependencies
+
+	engine *alerting.Engine
+)
+
+type dependencies struct {
+	dig.In
+	Storage           *storage.Storage
+	SyncManager       *syncmanager.SyncManager
+	SnapshotManager   *snapshot.SnapshotManager
+	PeeringManager    *p2p.Manager
+	RequestQueue      gossip.RequestQueue
+	TangleDatabase    *database.Database         `name:"tangleDatabase"`
+	UTXODatabase      *database.Database         `name:"utxoDatabase"`
+	INXRegistry       *inxregistry.Registry      `optional:"true"`
+	RestPluginManager *restapi.RestPluginManager `optional:"true"`
+}
+
+func configure() error {
+
+	rules := make([]*alerting.Rule, len(ParamsAlerting.Rules))
+	for i, ruleString := range ParamsAlerting.Rules {
+		rule, err := alerting.ParseRule(ruleString)
+		if err != nil {
+			Plugin.LogPanicf("failed to parse alerting rule: %s", err)
+		}
+		rules[i] = rule
+	}
+
+	var notifiers []alerting.Notifier
+	if ParamsAlerting.Log.Enabled {
+		notifiers = append(notifiers, alerting.NewLogNotifier(Plugin.Logger()))
+	}
+	if ParamsAlerting.Webhook.URL != "" {
+		notifiers = append(notifiers, alerting.NewWebhookNotifier(ParamsAlerting.Webhook.URL, ParamsAlerting.Webhook.Headers, ParamsAlerting.Webhook.Timeout))
+	}
+
+	var err error
+	engine, err = alerting.New(rules, configureMetrics(),
+		alerting.WithLogger(Plugin.Logger()),
+		alerting.WithNotifiers(notifiers...),
+		alerting.WithRepeatInterval(ParamsAlerting.RepeatInterval),
+		alerting.WithHistorySize(ParamsAlerting.HistorySize),
+	)
+	if err != nil {
+		Plugin.LogPanicf("failed to create alerting engine: %s", err)
+	}
+
+	// the alert history is only exposed if the RestAPI plugin is enabled
+	if deps.RestPluginManager != nil {
+		setupRoutes(deps.RestPluginManager.AddPlugin("alerting/v1"))
+	}
+
+	return nil
+}
+
+func run() error {
+
+	if err := Plugin.Daemon().BackgroundWorker("Alerting", func(ctx context.Context) {
+		Plugin.LogInfof("Starting Alerting with %d rules ... done", len(engine.Rules()))
+		engine.Run(ctx, ParamsAlerting.EvaluationInterval)
+		Plugin.LogInfo("Stopping Alerting ... done")
+	}, daemon.PriorityAlerting); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
+
+func setupRoutes(routeGroup *echo.Group) {
+
+	routeGroup.GET(RouteAlerts, func(c echo.Context) error {
+		return restapipkg.JSONResponse(c, http.StatusOK, &alertsResponse{Alerts: engine.Alerts()})
+	})
+
+	routeGroup.GET(RouteAlertsHistory, func(c echo.Context) error {
+		return restapipkg.JSONResponse(c, http.StatusOK, &alertsResponse{Alerts: engine.History()})
+	})
+
+	routeGroup.GET(RouteRules, func(c echo.Context) error {
+		return restapipkg.JSONResponse(c, http.StatusOK, rules())
+	})
+
+	routeGroup.GET(RouteSilences, func(c echo.Context) error {
+		return restapipkg.JSONResponse(c, http.StatusOK, silences())
+	})
+
+	routeGroup.POST(RouteSilences, func(c echo.Context) error {
+		resp, err := addSilence(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusCreated, resp)
+	})
+
+	routeGroup.DELETE(RouteSilence, func(c echo.Context) error {
+		if err := removeSilence(c); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
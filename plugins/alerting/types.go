@@ -0,0 +1,47 @@
+package alerting
+
+import (
+	"github.com/gohornet/hornet/pkg/alerting"
+)
+
+// alertsResponse defines the response of a GET alerts REST API call.
+type alertsResponse struct {
+	// The alerts.
+	Alerts []*alerting.Alert `json:"alerts"`
+}
+
+// ruleResponse defines a rule in the response of a GET rules REST API call.
+type ruleResponse struct {
+	// The name of the rule.
+	Name string `json:"name"`
+	// The condition of the rule.
+	Condition string `json:"condition"`
+	// The time the condition needs to hold before the alert fires.
+	For string `json:"for"`
+	// The severity of the alerts of the rule.
+	Severity alerting.Severity `json:"severity"`
+}
+
+// rulesResponse defines the response of a GET rules REST API call.
+type rulesResponse struct {
+	// The configured rules.
+	Rules []*ruleResponse `json:"rules"`
+}
+
+// addSilenceRequest defines the request of a POST silences REST API call.
+type addSilenceRequest struct {
+	// The name of the rule whose alerts are silenced (empty for all rules).
+	Rule string `json:"rule,omitempty"`
+	// The unix timestamp in seconds the silence starts at (now if omitted).
+	StartsAt *int64 `json:"startsAt,omitempty"`
+	// The duration of the silence (e.g. "2h").
+	Duration string `json:"duration"`
+	// The reason of the silence.
+	Comment string `json:"comment,omitempty"`
+}
+
+// silencesResponse defines the response of a GET silences REST API call.
+type silencesResponse struct {
+	// The active and upcoming silences.
+	Silences []*alerting.Silence `json:"silences"`
+}
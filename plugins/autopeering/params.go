@@ -22,6 +22,15 @@ type ParametersAutopeering struct {
 	OutboundPeers int `default:"2" usage:"the number of outbound autopeers"`
 	// SaltLifetime lifetime of the private and public local salt.
 	SaltLifetime time.Duration `default:"2h" usage:"lifetime of the private and public local salt"`
+	// PrivateNetwork restricts autopeering to the members of a private network.
+	PrivateNetwork struct {
+		// Enabled defines whether autopeering is restricted to the members of a private network.
+		Enabled bool `default:"false" usage:"whether autopeering is restricted to the members of a private network"`
+		// PresharedKey is the hex encoded 32 byte key all discovery and peering packets are authenticated with.
+		PresharedKey string `default:"" usage:"the hex encoded 32 byte key all discovery and peering packets are authenticated with (optional if allowedPeerIDs are defined)"`
+		// AllowedPeerIDs are the peer IDs that are allowed to take part in autopeering.
+		AllowedPeerIDs []string `default:"" usage:"the peer IDs that are allowed to take part in autopeering (empty = all members knowing the preshared key)"`
+	}
 }
 
 var ParamsAutopeering = &ParametersAutopeering{}
@@ -30,5 +39,5 @@ var params = &app.ComponentParams{
 	Params: map[string]any{
 		"p2p.autopeering": ParamsAutopeering,
 	},
-	Masked: nil,
+	Masked: []string{"p2p.autopeering.privateNetwork.presharedKey"},
 }
@@ -158,12 +158,23 @@ func provide(c *dig.Container) error {
 	}
 
 	if err := c.Provide(func(deps autopeeringDeps) *autopeering.AutopeeringManager {
+
+		var privateNetwork *autopeering.PrivateNetwork
+		if ParamsAutopeering.PrivateNetwork.Enabled {
+			var err error
+			privateNetwork, err = loadPrivateNetwork()
+			if err != nil {
+				Plugin.LogPanicf("invalid private network configuration: %s", err)
+			}
+		}
+
 		return autopeering.NewAutopeeringManager(
 			Plugin.Logger(),
 			ParamsAutopeering.BindAddress,
 			ParamsAutopeering.EntryNodes,
 			ParamsAutopeering.EntryNodesPreferIPv6,
 			service.Key(deps.ProtocolParameters.NetworkName),
+			privateNetwork,
 		)
 	}); err != nil {
 		Plugin.LogPanic(err)
@@ -172,6 +183,34 @@ func provide(c *dig.Container) error {
 	return nil
 }
 
+// loadPrivateNetwork parses the private network configuration.
+func loadPrivateNetwork() (*autopeering.PrivateNetwork, error) {
+
+	var presharedKey []byte
+	if ParamsAutopeering.PrivateNetwork.PresharedKey != "" {
+		var err error
+		presharedKey, err = autopeering.ParsePresharedKey(ParamsAutopeering.PrivateNetwork.PresharedKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var allowedPeerIDs []libp2p.ID
+	for _, peerIDStr := range ParamsAutopeering.PrivateNetwork.AllowedPeerIDs {
+		if peerIDStr == "" {
+			continue
+		}
+
+		peerID, err := libp2p.Decode(peerIDStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowed peer ID: %s", peerIDStr)
+		}
+		allowedPeerIDs = append(allowedPeerIDs, peerID)
+	}
+
+	return autopeering.NewPrivateNetwork(presharedKey, allowedPeerIDs)
+}
+
 func configure() error {
 	selection.SetParameters(selection.Parameters{
 		InboundNeighborSize:  ParamsAutopeering.InboundPeers,
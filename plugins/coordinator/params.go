@@ -0,0 +1,54 @@
+package coordinator
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersCoordinator contains the definition of the parameters used by the coordinator.
+type ParametersCoordinator struct {
+	// the path to the state file of the coordinator.
+	StateFilePath string `default:"coordinator.state" usage:"the path to the state file of the coordinator"`
+	// the interval milestones are issued.
+	Interval time.Duration `default:"10s" usage:"the interval milestones are issued"`
+	// the amount of workers used for calculating PoW when issuing checkpoints.
+	PoWWorkerCount int `name:"powWorkerCount" default:"0" usage:"the amount of workers used for calculating PoW when issuing checkpoints"`
+
+	Checkpoints struct {
+		// the maximum amount of known messages for milestone tipselection.
+		// if this limit is exceeded, a new checkpoint is issued.
+		MaxTrackedMessages int `default:"10000" usage:"maximum amount of known messages for milestone tipselection"`
+	}
+
+	TipSel struct {
+		// the minimum threshold of unreferenced messages in the heaviest branch for milestone tipselection.
+		// if the value falls below that threshold, no more heaviest branch tips are picked.
+		MinHeaviestBranchUnreferencedMessagesThreshold int `default:"20" usage:"minimum threshold of unreferenced messages in the heaviest branch for milestone tipselection"`
+		// the maximum amount of heaviest branch tips that are picked for a checkpoint
+		// if the heaviest branch is not below "MinHeaviestBranchUnreferencedMessagesThreshold" before.
+		MaxHeaviestBranchTipsPerCheckpoint int `default:"10" usage:"maximum amount of heaviest branch tips per checkpoint"`
+		// the amount of random tips of the URTS tip-pool that are added if a checkpoint is issued.
+		RandomTipsPerCheckpoint int `default:"3" usage:"amount of random tips per checkpoint"`
+		// the maximum duration to select the heaviest branch tips.
+		HeaviestBranchSelectionTimeout time.Duration `default:"100ms" usage:"the maximum duration to select the heaviest branch tips"`
+	}
+
+	Signing struct {
+		// the signing provider the coordinator uses to sign a milestone (local/file/remote).
+		Provider string `default:"local" usage:"the signing provider the coordinator uses to sign a milestone (local/file/remote)"`
+		// the path to the file containing the hex encoded private keys of the signers (one per line).
+		KeyFilePath string `default:"coordinator.keys" usage:"the path to the file containing the private keys of the signers if the file signing provider is used"`
+		// the address of the remote signing provider (insecure connection!).
+		RemoteAddress string `default:"localhost:12345" usage:"the address of the remote signing provider (insecure connection!)"`
+	}
+}
+
+var ParamsCoordinator = &ParametersCoordinator{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"coordinator": ParamsCoordinator,
+	},
+	Masked: nil,
+}
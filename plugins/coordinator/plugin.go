@@ -0,0 +1,365 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
+	"go.uber.org/dig"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/coordinator"
+	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/pow"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/tipselect"
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/app/core/shutdown"
+	"github.com/iotaledger/hive.go/events"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// whether to bootstrap the network
+	CfgCoordinatorBootstrap = "cooBootstrap"
+	// the index of the first milestone at bootstrap
+	CfgCoordinatorStartIndex = "cooStartIndex"
+
+	// the environment variable that contains the private keys of the signers if the local signing provider is used
+	EnvCoordinatorPrivateKeys = "COO_PRV_KEYS"
+
+	// the maximum time to wait for a message of the coordinator to be processed
+	messageProcessedTimeout = 1 * time.Second
+)
+
+func init() {
+	Plugin = &app.Plugin{
+		Status: app.StatusDisabled,
+		Component: &app.Component{
+			Name:      "Coordinator",
+			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
+			Provide:   provide,
+			Configure: configure,
+			Run:       run,
+		},
+	}
+}
+
+var (
+	Plugin *app.Plugin
+	deps   dependencies
+
+	bootstrap  = flag.Bool(CfgCoordinatorBootstrap, false, "bootstrap the network")
+	startIndex = flag.Uint32(CfgCoordinatorStartIndex, 0, "index of the first milestone at bootstrap")
+
+	selector *coordinator.HeaviestSelector
+
+	// triggers a checkpoint if the heaviest selector tracks too many messages
+	nextCheckpointSignal chan struct{}
+
+	// closures
+	onMessageSolid            *events.Closure
+	onIssuedCheckpointMessage *events.Closure
+	onIssuedMilestone         *events.Closure
+)
+
+type dependencies struct {
+	dig.In
+	Coordinator     *coordinator.Coordinator
+	Storage         *storage.Storage
+	Tangle          *tangle.Tangle
+	SyncManager     *syncmanager.SyncManager
+	TipSelector     *tipselect.TipSelector `optional:"true"`
+	ShutdownHandler *shutdown.ShutdownHandler
+}
+
+func provide(c *dig.Container) error {
+
+	type coordinatorDeps struct {
+		dig.In
+		Tangle                  *tangle.Tangle
+		PoWHandler              *pow.Handler
+		KeyManager              *keymanager.KeyManager
+		MilestonePublicKeyCount int `name:"milestonePublicKeyCount"`
+		ProtocolParameters      *iotago.ProtocolParameters
+	}
+
+	if err := c.Provide(func(deps coordinatorDeps) *coordinator.Coordinator {
+
+		signerProvider, err := initSignerProvider(deps.KeyManager, deps.MilestonePublicKeyCount)
+		if err != nil {
+			Plugin.LogPanicf("failed to initialize signing provider: %s", err)
+		}
+
+		attacher := deps.Tangle.MessageAttacher(
+			tangle.WithTimeout(messageProcessedTimeout),
+			tangle.WithPoW(deps.PoWHandler, ParamsCoordinator.PoWWorkerCount),
+		)
+
+		return coordinator.New(
+			deps.Tangle.CheckSolidityAndComputeWhiteFlagMutations,
+			attacher.AttachMessage,
+			signerProvider,
+			deps.ProtocolParameters,
+			coordinator.WithLogger(Plugin.Logger()),
+			coordinator.WithStateFilePath(ParamsCoordinator.StateFilePath),
+			coordinator.WithMilestoneInterval(ParamsCoordinator.Interval),
+		)
+	}); err != nil {
+		Plugin.LogPanic(err)
+	}
+
+	return nil
+}
+
+// initSignerProvider creates the milestone signer provider that is configured.
+func initSignerProvider(keyManager *keymanager.KeyManager, milestonePublicKeyCount int) (coordinator.MilestoneSignerProvider, error) {
+
+	switch ParamsCoordinator.Signing.Provider {
+	case "local":
+		privateKeys, err := coordinator.LoadPrivateKeysFromEnvironment(EnvCoordinatorPrivateKeys)
+		if err != nil {
+			return nil, err
+		}
+		return coordinator.NewInMemoryEd25519MilestoneSignerProvider(privateKeys, keyManager, milestonePublicKeyCount), nil
+
+	case "file":
+		privateKeys, err := coordinator.LoadPrivateKeysFromFile(ParamsCoordinator.Signing.KeyFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return coordinator.NewInMemoryEd25519MilestoneSignerProvider(privateKeys, keyManager, milestonePublicKeyCount), nil
+
+	case "remote":
+		return coordinator.NewInsecureRemoteEd25519MilestoneSignerProvider(ParamsCoordinator.Signing.RemoteAddress, keyManager, milestonePublicKeyCount), nil
+
+	default:
+		return nil, fmt.Errorf("unknown signing provider: %s", ParamsCoordinator.Signing.Provider)
+	}
+}
+
+func configure() error {
+
+	latestMilestone, err := latestMilestoneOfNode()
+	if err != nil {
+		Plugin.LogPanic(err)
+	}
+
+	if err := deps.Coordinator.InitState(*bootstrap, milestone.Index(*startIndex), latestMilestone); err != nil {
+		Plugin.LogPanicf("failed to initialize coordinator state: %s", err)
+	}
+
+	selector = coordinator.NewHeaviestSelector(
+		ParamsCoordinator.TipSel.MinHeaviestBranchUnreferencedMessagesThreshold,
+		ParamsCoordinator.TipSel.MaxHeaviestBranchTipsPerCheckpoint,
+		ParamsCoordinator.TipSel.HeaviestBranchSelectionTimeout,
+	)
+
+	nextCheckpointSignal = make(chan struct{}, 1)
+
+	configureEvents()
+
+	return nil
+}
+
+// latestMilestoneOfNode returns the latest milestone known by the node, or nil if the node doesn't know any milestone.
+func latestMilestoneOfNode() (*coordinator.LatestMilestone, error) {
+
+	latestMilestoneIndex := deps.SyncManager.LatestMilestoneIndex()
+	if latestMilestoneIndex == 0 {
+		return nil, nil
+	}
+
+	cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(latestMilestoneIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil, fmt.Errorf("latest milestone %d not found in the database", latestMilestoneIndex)
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	messageID, err := deps.Storage.MilestoneMessageIDByIndex(latestMilestoneIndex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "message of the latest milestone %d not found in the database", latestMilestoneIndex)
+	}
+
+	return &coordinator.LatestMilestone{
+		Index:       latestMilestoneIndex,
+		MessageID:   messageID,
+		MilestoneID: cachedMilestone.Milestone().MilestoneID(),
+		Timestamp:   cachedMilestone.Milestone().Timestamp(),
+	}, nil
+}
+
+func run() error {
+
+	if err := Plugin.Daemon().BackgroundWorker("Coordinator[Events]", func(ctx context.Context) {
+		attachEvents()
+		<-ctx.Done()
+		detachEvents()
+	}, daemon.PriorityCoordinator); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("Coordinator", func(ctx context.Context) {
+		ticker := time.NewTicker(deps.Coordinator.MilestoneInterval())
+		defer ticker.Stop()
+
+		checkpointIndex := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-nextCheckpointSignal:
+				// the heaviest selector tracks too many messages, reference them with a checkpoint
+				if !deps.Coordinator.Bootstrapped() {
+					// there is nothing to reference before the first milestone
+					selector.Reset()
+					continue
+				}
+
+				if issueCheckpoint(ctx, checkpointIndex) {
+					checkpointIndex++
+				}
+
+			case <-ticker.C:
+				if !deps.Coordinator.Bootstrapped() {
+					bootstrapNetwork(ctx)
+					continue
+				}
+
+				// the node needs to be synced to compute the white-flag mutations of the next milestone
+				if !deps.SyncManager.IsNodeSynced() {
+					Plugin.LogWarn("node is not synced, skipping milestone")
+					continue
+				}
+
+				// reference the remaining tips with a last checkpoint before the milestone
+				issueCheckpoint(ctx, checkpointIndex)
+				checkpointIndex = 0
+
+				issueMilestone(ctx)
+			}
+		}
+	}, daemon.PriorityCoordinator); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
+
+// bootstrapNetwork issues the first milestone of the network.
+func bootstrapNetwork(ctx context.Context) {
+
+	if _, err := deps.Coordinator.Bootstrap(ctx); err != nil {
+		handleError(err, "bootstrapping the network failed")
+		return
+	}
+
+	Plugin.LogInfo("network bootstrapped")
+}
+
+// issueCheckpoint issues a checkpoint on top of the heaviest branch tips and some random tips of the tip-pool.
+// it returns whether a checkpoint was issued.
+func issueCheckpoint(ctx context.Context, checkpointIndex int) bool {
+
+	tips, err := selector.SelectTips(0)
+	if err != nil {
+		Plugin.LogWarnf("selecting heaviest branch tips failed: %s", err)
+		return false
+	}
+
+	if ParamsCoordinator.TipSel.RandomTipsPerCheckpoint > 0 && deps.TipSelector != nil {
+		randomTips := hornet.MessageIDs{}
+		for len(randomTips) < ParamsCoordinator.TipSel.RandomTipsPerCheckpoint {
+			selectedTips, err := deps.TipSelector.SelectNonLazyTips()
+			if err != nil {
+				if !errors.Is(err, tipselect.ErrNoTipsAvailable) && !errors.Is(err, common.ErrNodeNotSynced) {
+					Plugin.LogWarnf("selecting random tips failed: %s", err)
+				}
+				break
+			}
+			randomTips = append(randomTips, selectedTips...)
+		}
+
+		if len(randomTips) > ParamsCoordinator.TipSel.RandomTipsPerCheckpoint {
+			randomTips = randomTips[:ParamsCoordinator.TipSel.RandomTipsPerCheckpoint]
+		}
+		tips = append(tips, randomTips...).RemoveDupsAndSortByLexicalOrder()
+	}
+
+	if len(tips) == 0 {
+		return false
+	}
+
+	if _, err := deps.Coordinator.IssueCheckpoint(ctx, checkpointIndex, tips); err != nil {
+		handleError(err, "issuing checkpoint failed")
+		return false
+	}
+
+	return true
+}
+
+// issueMilestone issues the next milestone on top of the last checkpoint.
+func issueMilestone(ctx context.Context) {
+
+	if _, err := deps.Coordinator.IssueMilestone(ctx); err != nil {
+		handleError(err, "issuing milestone failed")
+	}
+}
+
+// handleError logs the given error and shuts down the node if the error is critical.
+func handleError(err error, msg string) {
+
+	if errors.Is(err, coordinator.ErrCritical) {
+		deps.ShutdownHandler.SelfShutdown(fmt.Sprintf("coordinator plugin hit a critical error: %s: %s", msg, err), true)
+		return
+	}
+
+	Plugin.LogWarnf("%s: %s", msg, err)
+}
+
+func configureEvents() {
+
+	onMessageSolid = events.NewClosure(func(cachedMsgMeta *storage.CachedMetadata) {
+		cachedMsgMeta.ConsumeMetadata(func(metadata *storage.MessageMetadata) { // meta -1
+			if selector.OnNewSolidMessage(metadata) < ParamsCoordinator.Checkpoints.MaxTrackedMessages {
+				return
+			}
+
+			Plugin.LogDebug("coordinator tipselection tracks too many messages, triggering checkpoint")
+			select {
+			case nextCheckpointSignal <- struct{}{}:
+			default:
+				// a checkpoint is already pending
+			}
+		})
+	})
+
+	onIssuedCheckpointMessage = events.NewClosure(func(checkpointIndex int, tipIndex int, tipsTotal int, messageID hornet.MessageID) {
+		Plugin.LogInfof("checkpoint (%d) message issued (%d/%d): %v", checkpointIndex+1, tipIndex+1, tipsTotal, messageID.ToHex())
+	})
+
+	onIssuedMilestone = events.NewClosure(func(index milestone.Index, milestoneID iotago.MilestoneID, messageID hornet.MessageID) {
+		Plugin.LogInfof("milestone issued (%d): milestone ID: %s, message ID: %v", index, iotago.EncodeHex(milestoneID[:]), messageID.ToHex())
+	})
+}
+
+func attachEvents() {
+	deps.Tangle.Events.MessageSolid.Attach(onMessageSolid)
+	deps.Coordinator.Events.IssuedCheckpointMessage.Attach(onIssuedCheckpointMessage)
+	deps.Coordinator.Events.IssuedMilestone.Attach(onIssuedMilestone)
+}
+
+func detachEvents() {
+	deps.Tangle.Events.MessageSolid.Detach(onMessageSolid)
+	deps.Coordinator.Events.IssuedCheckpointMessage.Detach(onIssuedCheckpointMessage)
+	deps.Coordinator.Events.IssuedMilestone.Detach(onIssuedMilestone)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/daemon"
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/inxregistry"
 	"github.com/gohornet/hornet/pkg/jwt"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/milestone"
@@ -90,6 +91,7 @@ type dependencies struct {
 	Host                     host.Host
 	NodePrivateKey           crypto.PrivKey          `name:"nodePrivateKey"`
 	DashboardAllowedAPIRoute restapipkg.AllowedRoute `name:"dashboardAllowedAPIRoute" optional:"true"`
+	INXRegistry              *inxregistry.Registry   `optional:"true"`
 }
 
 func initConfigPars(c *dig.Container) error {
@@ -291,6 +293,18 @@ type NodeStatus struct {
 	ServerMetrics          *ServerMetrics  `json:"server_metrics"`
 	Mem                    *MemMetrics     `json:"mem"`
 	Caches                 *CachesMetric   `json:"caches"`
+	INXExtensions          []*INXExtension `json:"inx_extensions"`
+}
+
+// INXExtension represents a connected INX extension.
+type INXExtension struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	RemoteAddress string   `json:"remote_address"`
+	Uptime        int64    `json:"uptime"`
+	ActiveStreams int      `json:"active_streams"`
+	Routes        []string `json:"routes"`
 }
 
 // ServerMetrics are global metrics of the server.
@@ -433,6 +447,27 @@ func currentNodeStatus() *NodeStatus {
 		ValidatedMessages:    deps.ServerMetrics.ValidatedMessages.Load(),
 	}
 
+	// INX extensions
+	status.INXExtensions = []*INXExtension{}
+	if deps.INXRegistry != nil {
+		for _, extension := range deps.INXRegistry.Extensions() {
+			routes := make([]string, 0, len(extension.Routes))
+			for _, route := range extension.Routes {
+				routes = append(routes, route.Route)
+			}
+
+			status.INXExtensions = append(status.INXExtensions, &INXExtension{
+				ID:            extension.ID,
+				Name:          extension.Name,
+				Version:       extension.Version,
+				RemoteAddress: extension.RemoteAddress,
+				Uptime:        time.Since(extension.ConnectedAt).Milliseconds(),
+				ActiveStreams: extension.ActiveStreams,
+				Routes:        routes,
+			})
+		}
+	}
+
 	// memory metrics
 	status.Mem = &MemMetrics{
 		Sys:          m.Sys,
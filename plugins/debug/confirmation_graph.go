@@ -0,0 +1,196 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+const (
+	// QueryParameterFormat is used to select the format of the confirmation graph.
+	QueryParameterFormat = "format"
+
+	// formatDOT is used to export the confirmation graph as a graphviz DOT file.
+	formatDOT = "dot"
+
+	// MIMETextVndGraphviz => graphviz DOT file.
+	MIMETextVndGraphviz = "text/vnd.graphviz"
+)
+
+const (
+	confirmationStateMilestone                  = "milestone"
+	confirmationStateIncluded                   = "included"
+	confirmationStateConflicting                = "conflicting"
+	confirmationStateNoTransaction              = "noTransaction"
+	confirmationStateReferencedByOlderMilestone = "referencedByOlderMilestone"
+	confirmationStateSolidEntryPoint            = "solidEntryPoint"
+)
+
+func milestoneConfirmationGraph(c echo.Context) (*confirmationGraphResponse, error) {
+
+	msIndex, err := restapi.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return milestoneConfirmationGraphByIndex(Plugin.Daemon().ContextStopped(), msIndex)
+}
+
+// milestoneConfirmationGraphByIndex returns the messages referenced by the milestone in white flag order,
+// together with the messages outside of the cone of the milestone that are referenced by them.
+func milestoneConfirmationGraphByIndex(ctx context.Context, msIndex milestone.Index) (*confirmationGraphResponse, error) {
+
+	if msIndex > deps.SyncManager.ConfirmedMilestoneIndex() {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "milestone not confirmed yet: %d", msIndex)
+	}
+
+	milestoneParents, err := deps.Storage.MilestoneParentsByIndex(msIndex)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "milestone not found: %d", msIndex)
+	}
+
+	// the messages referenced by the milestone in white flag order
+	cone := []*confirmationGraphNode{}
+	// the messages outside of the cone of the milestone that are referenced by messages in the cone
+	boundary := []*confirmationGraphNode{}
+	boundaryMessages := make(map[string]struct{})
+
+	addBoundaryNode := func(node *confirmationGraphNode, messageID hornet.MessageID) {
+		if _, exists := boundaryMessages[messageID.ToMapKey()]; exists {
+			return
+		}
+		boundaryMessages[messageID.ToMapKey()] = struct{}{}
+		boundary = append(boundary, node)
+	}
+
+	if err := dag.TraverseParents(
+		ctx,
+		deps.Storage,
+		milestoneParents,
+		// traversal stops if no more messages pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedMsgMeta *storage.CachedMetadata) (bool, error) { // meta +1
+			defer cachedMsgMeta.Release(true) // meta -1
+
+			referenced, at := cachedMsgMeta.Metadata().ReferencedWithIndex()
+			if referenced && at == msIndex {
+				return true, nil
+			}
+
+			addBoundaryNode(&confirmationGraphNode{
+				MessageID:             cachedMsgMeta.Metadata().MessageID().ToHex(),
+				Index:                 -1,
+				State:                 confirmationStateReferencedByOlderMilestone,
+				ReferencedByMilestone: at,
+			}, cachedMsgMeta.Metadata().MessageID())
+
+			return false, nil
+		},
+		// consumer
+		func(cachedMsgMeta *storage.CachedMetadata) error { // meta +1
+			cachedMsgMeta.ConsumeMetadata(func(metadata *storage.MessageMetadata) { // meta -1
+				node := &confirmationGraphNode{
+					MessageID:             metadata.MessageID().ToHex(),
+					Index:                 len(cone),
+					ReferencedByMilestone: msIndex,
+					Parents:               metadata.Parents().ToHex(),
+				}
+
+				switch {
+				case metadata.IsMilestone():
+					node.State = confirmationStateMilestone
+				case metadata.IsConflictingTx():
+					conflict := metadata.Conflict()
+					node.State = confirmationStateConflicting
+					node.ConflictReason = &conflict
+				case metadata.IsNoTransaction():
+					node.State = confirmationStateNoTransaction
+				default:
+					node.State = confirmationStateIncluded
+				}
+
+				cone = append(cone, node)
+			})
+
+			return nil
+		},
+		// called on missing parents
+		// return error on missing parents
+		nil,
+		// called on solid entry points
+		func(messageID hornet.MessageID) error {
+			entryPointIndex, _, err := deps.Storage.SolidEntryPointsIndex(messageID)
+			if err != nil {
+				return err
+			}
+
+			addBoundaryNode(&confirmationGraphNode{
+				MessageID:             messageID.ToHex(),
+				Index:                 -1,
+				State:                 confirmationStateSolidEntryPoint,
+				ReferencedByMilestone: entryPointIndex,
+			}, messageID)
+
+			return nil
+		},
+		false); err != nil {
+		return nil, traversalError(err)
+	}
+
+	return &confirmationGraphResponse{
+		MilestoneIndex:   msIndex,
+		MilestoneParents: milestoneParents.ToHex(),
+		Nodes:            append(cone, boundary...),
+	}, nil
+}
+
+// shortenedMessageID returns a shortened hex encoded message ID that is used as label in the DOT file.
+func shortenedMessageID(messageID string) string {
+	if len(messageID) < 14 {
+		return messageID
+	}
+	return messageID[0:6] + "..." + messageID[len(messageID)-4:]
+}
+
+// confirmationGraphDOT generates a graphviz DOT file from the confirmation graph of a milestone.
+func confirmationGraphDOT(graph *confirmationGraphResponse) string {
+
+	var dotFile strings.Builder
+
+	fmt.Fprintf(&dotFile, "digraph milestone_%d\n{\n", graph.MilestoneIndex)
+
+	for _, node := range graph.Nodes {
+		label := shortenedMessageID(node.MessageID)
+
+		switch node.State {
+		case confirmationStateMilestone:
+			fmt.Fprintf(&dotFile, "\"%s\" [ label=\"[%d] %s\", shape=Msquare ];\n", node.MessageID, node.Index, label)
+		case confirmationStateConflicting:
+			fmt.Fprintf(&dotFile, "\"%s\" [ label=\"[%d] %s (%d)\", style=filled, color=red ];\n", node.MessageID, node.Index, label, *node.ConflictReason)
+		case confirmationStateNoTransaction:
+			fmt.Fprintf(&dotFile, "\"%s\" [ label=\"[%d] %s\", style=filled, color=gray ];\n", node.MessageID, node.Index, label)
+		case confirmationStateIncluded:
+			fmt.Fprintf(&dotFile, "\"%s\" [ label=\"[%d] %s\", style=filled, color=green ];\n", node.MessageID, node.Index, label)
+		default:
+			// messages outside of the cone of the milestone
+			fmt.Fprintf(&dotFile, "\"%s\" [ label=\"%s (%d)\", style=dashed ];\n", node.MessageID, label, node.ReferencedByMilestone)
+		}
+
+		for i, parent := range node.Parents {
+			fmt.Fprintf(&dotFile, "\"%s\" -> \"%s\" [ label=\"Parent%d\" ];\n", node.MessageID, parent, i+1)
+		}
+	}
+
+	dotFile.WriteString("}\n")
+
+	return dotFile.String()
+}
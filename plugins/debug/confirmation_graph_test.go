@@ -0,0 +1,105 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+)
+
+func TestMilestoneConfirmationGraph(t *testing.T) {
+	te, seed1Wallet, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	// the first milestone references the genesis, which is a solid entry point
+	graph, err := milestoneConfirmationGraphByIndex(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(1), graph.MilestoneIndex)
+
+	nodeStates := make(map[string]string)
+	for _, node := range graph.Nodes {
+		nodeStates[node.MessageID] = node.State
+	}
+	require.Equal(t, confirmationStateSolidEntryPoint, nodeStates[hornet.NullMessageID().ToHex()])
+
+	previousMilestoneMessageID := te.LastMilestoneMessageID()
+	olderMilestoneParents := te.LastMilestoneParents()
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(olderMilestoneParents).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		Build().
+		Store().
+		BookOnWallets()
+
+	messageB := te.NewMessageBuilder("B").
+		Parents(hornet.MessageIDs{messageA.StoredMessageID()}).
+		FromWallet(seed2Wallet).
+		ToWallet(seed1Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		FakeInputs().
+		Build().
+		Store()
+
+	messageC := te.NewMessageBuilder("C").
+		Parents(hornet.MessageIDs{messageB.StoredMessageID()}).
+		BuildTaggedData().
+		Store()
+
+	conf, _ := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageC.StoredMessageID()}, false)
+
+	graph, err = milestoneConfirmationGraphByIndex(context.Background(), conf.MilestoneIndex)
+	require.NoError(t, err)
+	require.Equal(t, conf.MilestoneIndex, graph.MilestoneIndex)
+
+	// the cone is in white flag order, followed by the messages of older milestones
+	coneSize := len(conf.Mutations.MessagesReferenced)
+	require.Len(t, graph.Nodes, coneSize+len(olderMilestoneParents))
+
+	for i, messageID := range conf.Mutations.MessagesReferenced {
+		require.Equal(t, messageID.ToHex(), graph.Nodes[i].MessageID)
+		require.Equal(t, i, graph.Nodes[i].Index)
+		require.Equal(t, conf.MilestoneIndex, graph.Nodes[i].ReferencedByMilestone)
+	}
+
+	nodes := make(map[string]*confirmationGraphNode)
+	for _, node := range graph.Nodes {
+		nodes[node.MessageID] = node
+	}
+
+	require.Equal(t, confirmationStateMilestone, nodes[previousMilestoneMessageID.ToHex()].State)
+	require.Equal(t, confirmationStateIncluded, nodes[messageA.StoredMessageID().ToHex()].State)
+	require.Equal(t, confirmationStateConflicting, nodes[messageB.StoredMessageID().ToHex()].State)
+	require.EqualValues(t, storage.ConflictInputUTXONotFound, *nodes[messageB.StoredMessageID().ToHex()].ConflictReason)
+	require.Equal(t, confirmationStateNoTransaction, nodes[messageC.StoredMessageID().ToHex()].State)
+	require.Equal(t, []string{messageB.StoredMessageID().ToHex()}, nodes[messageC.StoredMessageID().ToHex()].Parents)
+
+	for _, messageID := range olderMilestoneParents {
+		node := nodes[messageID.ToHex()]
+		require.NotNil(t, node)
+		require.Equal(t, confirmationStateReferencedByOlderMilestone, node.State)
+		require.Equal(t, -1, node.Index)
+		require.Less(t, node.ReferencedByMilestone, conf.MilestoneIndex)
+	}
+
+	dotFile := confirmationGraphDOT(graph)
+	require.Contains(t, dotFile, fmt.Sprintf("digraph milestone_%d\n{\n", conf.MilestoneIndex))
+	require.Contains(t, dotFile, fmt.Sprintf("\"%s\" [ label=\"[%d] %s\", shape=Msquare ];\n", previousMilestoneMessageID.ToHex(), nodes[previousMilestoneMessageID.ToHex()].Index, shortenedMessageID(previousMilestoneMessageID.ToHex())))
+	require.Contains(t, dotFile, fmt.Sprintf("\"%s\" [ label=\"[%d] %s (%d)\", style=filled, color=red ];\n", messageB.StoredMessageID().ToHex(), nodes[messageB.StoredMessageID().ToHex()].Index, shortenedMessageID(messageB.StoredMessageID().ToHex()), storage.ConflictInputUTXONotFound))
+	require.Contains(t, dotFile, fmt.Sprintf("\"%s\" -> \"%s\" [ label=\"Parent1\" ];\n", messageC.StoredMessageID().ToHex(), messageB.StoredMessageID().ToHex()))
+	require.Contains(t, dotFile, fmt.Sprintf("\"%s\" [ label=\"%s (%d)\", style=dashed ];\n", olderMilestoneParents[0].ToHex(), shortenedMessageID(olderMilestoneParents[0].ToHex()), nodes[olderMilestoneParents[0].ToHex()].ReferencedByMilestone))
+	require.True(t, strings.HasSuffix(dotFile, "}\n"))
+
+	// the next milestone is not confirmed yet
+	_, err = milestoneConfirmationGraphByIndex(context.Background(), conf.MilestoneIndex+1)
+	require.ErrorIs(t, err, echo.ErrNotFound)
+}
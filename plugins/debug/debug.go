@@ -13,7 +13,6 @@ import (
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/restapi"
 	restapiv2 "github.com/gohornet/hornet/plugins/restapi/v2"
-	"github.com/iotaledger/hive.go/kvstore"
 )
 
 func outputsIDs(c echo.Context) (*outputIDsResponse, error) {
@@ -115,45 +114,14 @@ func spentOutputsIDs(c echo.Context) (*outputIDsResponse, error) {
 	}, nil
 }
 
-func milestoneDiff(c echo.Context) (*milestoneDiffResponse, error) {
+func milestoneDiff(c echo.Context) (*restapiv2.MilestoneDiffResponse, error) {
 
 	msIndex, err := restapi.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
 	if err != nil {
 		return nil, err
 	}
 
-	diff, err := deps.UTXOManager.MilestoneDiffWithoutLocking(msIndex)
-	if err != nil {
-		if errors.Is(err, kvstore.ErrKeyNotFound) {
-			return nil, errors.WithMessagef(echo.ErrNotFound, "can't load milestone diff for index: %d, error: %s", msIndex, err)
-		}
-		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diff for index: %d, error: %s", msIndex, err)
-	}
-
-	outputs := make([]*restapiv2.OutputResponse, len(diff.Outputs))
-	spents := make([]*restapiv2.OutputResponse, len(diff.Spents))
-
-	for i, output := range diff.Outputs {
-		o, err := restapiv2.NewOutputResponse(output, diff.Index)
-		if err != nil {
-			return nil, err
-		}
-		outputs[i] = o
-	}
-
-	for i, spent := range diff.Spents {
-		o, err := restapiv2.NewSpentResponse(spent, diff.Index)
-		if err != nil {
-			return nil, err
-		}
-		spents[i] = o
-	}
-
-	return &milestoneDiffResponse{
-		MilestoneIndex: msIndex,
-		Outputs:        outputs,
-		Spents:         spents,
-	}, nil
+	return restapiv2.MilestoneDiff(msIndex)
 }
 
 //nolint:unparam // even if the error is never used, the structure of all routes should be the same
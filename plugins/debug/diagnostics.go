@@ -0,0 +1,481 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// the maximum amount of milestones after the confirmed milestone that are checked for solidification blockers.
+	solidificationBlockersMaxMilestones = 10
+)
+
+const (
+	requestStateQueued       = "queued"
+	requestStatePending      = "pending"
+	requestStateProcessing   = "processing"
+	requestStateNotRequested = "notRequested"
+)
+
+func traversalError(err error) error {
+	if errors.Is(err, common.ErrOperationAborted) {
+		return errors.WithMessagef(echo.ErrServiceUnavailable, "traverse parents failed, error: %s", err)
+	}
+	return errors.WithMessagef(echo.ErrInternalServerError, "traverse parents failed, error: %s", err)
+}
+
+func orphanParentInfo(parentID hornet.MessageID) (*orphanParent, error) {
+	parent := &orphanParent{
+		MessageID: parentID.ToHex(),
+	}
+
+	entryPointIndex, isSolidEntryPoint, err := deps.Storage.SolidEntryPointsIndex(parentID)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading solid entry points failed, error: %s", err)
+	}
+
+	if isSolidEntryPoint {
+		// solid entry points are always solid, but the message itself may already be pruned
+		parent.Exists = deps.Storage.ContainsMessage(parentID)
+		parent.IsSolid = true
+		parent.IsSolidEntryPoint = true
+		parent.ReferencedByMilestone = &entryPointIndex
+		parent.YoungestConeRootIndex = entryPointIndex
+		parent.OldestConeRootIndex = entryPointIndex
+		return parent, nil
+	}
+
+	cachedParentMeta := deps.Storage.CachedMessageMetadataOrNil(parentID) // meta +1
+	if cachedParentMeta == nil {
+		return parent, nil
+	}
+	defer cachedParentMeta.Release(true) // meta -1
+
+	parent.Exists = true
+	parent.IsSolid = cachedParentMeta.Metadata().IsSolid()
+
+	if referenced, at := cachedParentMeta.Metadata().ReferencedWithIndex(); referenced {
+		parent.ReferencedByMilestone = &at
+		parent.YoungestConeRootIndex = at
+		parent.OldestConeRootIndex = at
+		return parent, nil
+	}
+
+	parent.YoungestConeRootIndex, parent.OldestConeRootIndex, _ = cachedParentMeta.Metadata().ConeRootIndexes()
+
+	return parent, nil
+}
+
+func orphanAnalysis(c echo.Context) (*orphanAnalysisResponse, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return orphanAnalysisByMessageID(Plugin.Daemon().ContextStopped(), messageID)
+}
+
+// orphanAnalysisByMessageID explains why the given message was not referenced by a milestone.
+func orphanAnalysisByMessageID(ctx context.Context, messageID hornet.MessageID) (*orphanAnalysisResponse, error) {
+
+	cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID) // meta +1
+	if cachedMsgMeta == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+	}
+	defer cachedMsgMeta.Release(true) // meta -1
+
+	metadata := cachedMsgMeta.Metadata()
+
+	parents := make([]*orphanParent, len(metadata.Parents()))
+	for i, parentID := range metadata.Parents() {
+		parent, err := orphanParentInfo(parentID)
+		if err != nil {
+			return nil, err
+		}
+		parents[i] = parent
+	}
+
+	children, err := deps.Storage.ChildrenMessageIDs(messageID)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading children failed, error: %s", err)
+	}
+
+	cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+
+	resp := &orphanAnalysisResponse{
+		MessageID:               messageID.ToHex(),
+		IsSolid:                 metadata.IsSolid(),
+		ConfirmedMilestoneIndex: cmi,
+		Parents:                 parents,
+		ChildrenCount:           len(children),
+	}
+
+	if referenced, at := metadata.ReferencedWithIndex(); referenced {
+		resp.ReferencedByMilestone = &at
+		resp.Reason = fmt.Sprintf("the message was referenced by milestone %d", at)
+		return resp, nil
+	}
+
+	if !metadata.IsSolid() {
+		resp.Reason = "the message is not solid, the past cone of the message is incomplete"
+		return resp, nil
+	}
+
+	ycri, ocri, err := dag.ConeRootIndexes(ctx, deps.Storage, cachedMsgMeta.Retain(), cmi) // meta pass +1
+	if err != nil {
+		if errors.Is(err, common.ErrOperationAborted) {
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "calculating cone root indexes failed, error: %s", err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "calculating cone root indexes failed, error: %s", err)
+	}
+
+	if ycri == 0 && ocri == 0 {
+		resp.Reason = "the cone root indexes could not be calculated, the past cone of the message is incomplete"
+		return resp, nil
+	}
+
+	// the message can't be older than the youngest milestone in its past cone,
+	// so this is the best guess for the confirmed milestone index at the time the message was attached.
+	tipScoreAtAttachment := deps.TipScoreCalculator.TipScoreForConeRootIndexes(ycri, ocri, ycri)
+	tipScore := deps.TipScoreCalculator.TipScoreForConeRootIndexes(ycri, ocri, cmi)
+
+	resp.YoungestConeRootIndex = ycri
+	resp.OldestConeRootIndex = ocri
+	resp.AttachmentMilestoneIndex = ycri
+	resp.TipScoreAtAttachment = tipScoreAtAttachment.String()
+	resp.TipScore = tipScore.String()
+	resp.BelowMaxDepth = tipScore == tangle.TipScoreBelowMaxDepth
+
+	var reasons []string
+	switch tipScoreAtAttachment {
+	case tangle.TipScoreBelowMaxDepth:
+		reasons = append(reasons, fmt.Sprintf("the message was attached to tips that were already below max depth (OCRI %d, attached at CMI %d), the message needs to be reattached", ocri, ycri))
+	case tangle.TipScoreOCRIThresholdReached, tangle.TipScoreYCRIThresholdReached:
+		reasons = append(reasons, fmt.Sprintf("the message was attached to lazy tips (%s), the tip selection prefers other tips", tipScoreAtAttachment))
+	}
+
+	if tipScoreAtAttachment != tangle.TipScoreBelowMaxDepth && resp.BelowMaxDepth {
+		reasons = append(reasons, fmt.Sprintf("the message is below max depth (OCRI %d is more than %d milestones behind CMI %d), the message needs to be reattached", ocri, deps.TipScoreCalculator.BelowMaxDepth(), cmi))
+	}
+
+	if len(children) == 0 {
+		reasons = append(reasons, fmt.Sprintf("no other message references the message (tip score %s)", tipScore))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("the message is referenced by %d messages, but they were not referenced by a milestone yet (tip score %s)", len(children), tipScore))
+	}
+
+	resp.Reason = strings.Join(reasons, "; ")
+
+	return resp, nil
+}
+
+func conflictInputWithoutLocking(outputID *iotago.OutputID, transactionID *iotago.TransactionID) (*conflictInput, error) {
+	input := &conflictInput{
+		OutputID: outputID.ToHex(),
+	}
+
+	output, err := deps.UTXOManager.ReadOutputByOutputIDWithoutLocking(outputID)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return input, nil
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", outputID.ToHex(), err)
+	}
+
+	input.Exists = true
+	input.CreatedByMessageID = output.MessageID().ToHex()
+	input.MilestoneIndexBooked = output.MilestoneIndex()
+
+	isUnspent, err := deps.UTXOManager.IsOutputIDUnspentWithoutLocking(outputID)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output spent status failed: %s, error: %s", outputID.ToHex(), err)
+	}
+
+	if isUnspent {
+		return input, nil
+	}
+
+	spent, err := deps.UTXOManager.ReadSpentForOutputIDWithoutLocking(outputID)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading spent failed: %s, error: %s", outputID.ToHex(), err)
+	}
+
+	input.IsSpent = true
+	input.SpentByTransactionID = spent.TargetTransactionID().ToHex()
+	input.MilestoneIndexSpent = spent.MilestoneIndex()
+	input.SpentByThisTransaction = *spent.TargetTransactionID() == *transactionID
+
+	// the message of the spending transaction is found via the first output of that transaction
+	spendingOutputID := iotago.OutputIDFromTransactionIDAndIndex(*spent.TargetTransactionID(), 0)
+	spendingOutput, err := deps.UTXOManager.ReadOutputByOutputIDWithoutLocking(&spendingOutputID)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return input, nil
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", spendingOutputID.ToHex(), err)
+	}
+	input.SpentByMessageID = spendingOutput.MessageID().ToHex()
+
+	return input, nil
+}
+
+func conflictExplanation(conflict storage.Conflict, inputs []*conflictInput) string {
+	var details []string
+
+	switch conflict {
+	case storage.ConflictInputUTXOAlreadySpent, storage.ConflictInputUTXOAlreadySpentInThisMilestone:
+		for _, input := range inputs {
+			if input.IsSpent && !input.SpentByThisTransaction {
+				details = append(details, fmt.Sprintf("input %s was spent by transaction %s at milestone %d", input.OutputID, input.SpentByTransactionID, input.MilestoneIndexSpent))
+			}
+		}
+
+	case storage.ConflictInputUTXONotFound:
+		for _, input := range inputs {
+			if !input.Exists {
+				details = append(details, fmt.Sprintf("input %s does not exist", input.OutputID))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return conflict.String()
+	}
+
+	return fmt.Sprintf("%s: %s", conflict, strings.Join(details, ", "))
+}
+
+func conflictAnalysis(c echo.Context) (*conflictAnalysisResponse, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedMsg := deps.Storage.CachedMessageOrNil(messageID) // message +1
+	if cachedMsg == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+	}
+	defer cachedMsg.Release(true) // message -1
+
+	transaction := cachedMsg.Message().Transaction()
+	if transaction == nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "message does not contain a transaction: %s", messageID.ToHex())
+	}
+
+	transactionID, err := transaction.ID()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "computing transaction ID failed, error: %s", err)
+	}
+
+	// we need to lock the ledger here to have a consistent view of the inputs.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger index failed, error: %s", err)
+	}
+
+	inputIDs := cachedMsg.Message().TransactionEssenceUTXOInputs()
+	inputs := make([]*conflictInput, len(inputIDs))
+	for i, inputID := range inputIDs {
+		input, err := conflictInputWithoutLocking(inputID, transactionID)
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = input
+	}
+
+	metadata := cachedMsg.Metadata()
+	conflict := metadata.Conflict()
+
+	resp := &conflictAnalysisResponse{
+		MessageID:      messageID.ToHex(),
+		TransactionID:  transactionID.ToHex(),
+		ConflictReason: conflict,
+		Inputs:         inputs,
+		LedgerIndex:    ledgerIndex,
+	}
+
+	referenced, at := metadata.ReferencedWithIndex()
+	switch {
+	case !referenced:
+		var details []string
+		for _, input := range inputs {
+			switch {
+			case !input.Exists:
+				details = append(details, fmt.Sprintf("input %s does not exist", input.OutputID))
+			case input.IsSpent && !input.SpentByThisTransaction:
+				details = append(details, fmt.Sprintf("input %s was already spent by transaction %s at milestone %d", input.OutputID, input.SpentByTransactionID, input.MilestoneIndexSpent))
+			}
+		}
+
+		resp.Explanation = "the message was not referenced by a milestone yet"
+		if len(details) > 0 {
+			resp.Explanation = fmt.Sprintf("%s, the transaction will be conflicting: %s", resp.Explanation, strings.Join(details, ", "))
+		}
+
+	case conflict != storage.ConflictNone:
+		resp.ReferencedByMilestone = &at
+		resp.LedgerInclusionState = "conflicting"
+		resp.Explanation = conflictExplanation(conflict, inputs)
+
+	default:
+		resp.ReferencedByMilestone = &at
+		resp.LedgerInclusionState = "included"
+		resp.Explanation = fmt.Sprintf("the transaction was included in the ledger by milestone %d", at)
+	}
+
+	return resp, nil
+}
+
+func requestState(data interface{}) string {
+	switch {
+	case deps.RequestQueue.IsQueued(data):
+		return requestStateQueued
+	case deps.RequestQueue.IsPending(data):
+		return requestStatePending
+	case deps.RequestQueue.IsProcessing(data):
+		return requestStateProcessing
+	default:
+		return requestStateNotRequested
+	}
+}
+
+// missingParents walks the past cone of the given parents until solid messages are reached and collects the missing messages.
+func missingParents(ctx context.Context, parents hornet.MessageIDs) ([]*missingParent, error) {
+
+	missing := []*missingParent{}
+	if err := dag.TraverseParents(
+		ctx,
+		deps.Storage,
+		parents,
+		// traversal stops if no more messages pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedMsgMeta *storage.CachedMetadata) (bool, error) { // meta +1
+			defer cachedMsgMeta.Release(true) // meta -1
+
+			// the past cone of solid messages is complete
+			return !cachedMsgMeta.Metadata().IsSolid(), nil
+		},
+		// consumer
+		nil,
+		// called on missing parents
+		func(parentMessageID hornet.MessageID) error {
+			children, err := deps.Storage.ChildrenMessageIDs(parentMessageID)
+			if err != nil {
+				return err
+			}
+
+			missing = append(missing, &missingParent{
+				MessageID:    parentMessageID.ToHex(),
+				RequestState: requestState(parentMessageID),
+				ReferencedBy: children.ToHex(),
+			})
+			return nil
+		},
+		// called on solid entry points
+		nil,
+		false); err != nil {
+		return nil, traversalError(err)
+	}
+
+	return missing, nil
+}
+
+func solidificationBlockers(_ echo.Context) (*solidificationBlockersResponse, error) {
+
+	cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+	lmi := deps.SyncManager.LatestMilestoneIndex()
+
+	blockers := []*solidificationBlocker{}
+	for msIndex := cmi + 1; msIndex <= lmi && msIndex <= cmi+solidificationBlockersMaxMilestones; msIndex++ {
+		cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+		if cachedMilestone == nil {
+			blockers = append(blockers, &solidificationBlocker{
+				MilestoneIndex:        msIndex,
+				MilestoneRequestState: requestState(msIndex),
+				MissingParents:        []*missingParent{},
+			})
+			continue
+		}
+
+		milestoneID := cachedMilestone.Milestone().MilestoneIDHex()
+		milestoneParents := cachedMilestone.Milestone().Parents()
+		cachedMilestone.Release(true) // milestone -1
+
+		missing, err := missingParents(Plugin.Daemon().ContextStopped(), milestoneParents)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		blockers = append(blockers, &solidificationBlocker{
+			MilestoneIndex: msIndex,
+			MilestoneID:    milestoneID,
+			MissingParents: missing,
+		})
+	}
+
+	return &solidificationBlockersResponse{
+		ConfirmedMilestoneIndex: cmi,
+		LatestMilestoneIndex:    lmi,
+		Blockers:                blockers,
+	}, nil
+}
+
+func solidificationBlockersByMessageID(c echo.Context) (*solidificationBlockersResponse, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID) // meta +1
+	if cachedMsgMeta == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+	}
+	isSolid := cachedMsgMeta.Metadata().IsSolid()
+	parents := cachedMsgMeta.Metadata().Parents()
+	cachedMsgMeta.Release(true) // meta -1
+
+	resp := &solidificationBlockersResponse{
+		ConfirmedMilestoneIndex: deps.SyncManager.ConfirmedMilestoneIndex(),
+		LatestMilestoneIndex:    deps.SyncManager.LatestMilestoneIndex(),
+		Blockers:                []*solidificationBlocker{},
+	}
+
+	if isSolid {
+		return resp, nil
+	}
+
+	missing, err := missingParents(Plugin.Daemon().ContextStopped(), parents)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Blockers = append(resp.Blockers, &solidificationBlocker{
+		MessageID:      messageID.ToHex(),
+		MissingParents: missing,
+	})
+
+	return resp, nil
+}
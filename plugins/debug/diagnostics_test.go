@@ -0,0 +1,138 @@
+package debug
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/testsuite"
+	"github.com/gohornet/hornet/pkg/testsuite/utils"
+)
+
+const (
+	showConfirmationGraphs = false
+	MinPoWScore            = 1.0
+	BelowMaxDepth          = uint16(15)
+)
+
+var (
+	seed1, _ = hex.DecodeString("96d9ff7a79e4b0a5f3e5848ae7867064402da92a62eabb4ebbe463f12d1f3b1aace1775488f51cb1e3a80732a03ef60b111d6833ab605aa9f8faebeb33bbe3d9")
+	seed2, _ = hex.DecodeString("b15209ddc93cbdb600137ea6a8f88cdd7c5d480d5815c9352a0fb5c4e4b86f7151dcb44c2ba635657a2df5a8fd48cb9bab674a9eceea527dbbb254ef8c9f9cd7")
+)
+
+// setupTestEnvironment creates a test environment and sets the dependencies of the debug plugin to it.
+func setupTestEnvironment(t *testing.T) (*testsuite.TestEnvironment, *utils.HDWallet, *utils.HDWallet) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, BelowMaxDepth, MinPoWScore, showConfirmationGraphs)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	deps = dependencies{
+		Storage:            te.Storage(),
+		SyncManager:        te.SyncManager(),
+		TipScoreCalculator: tangle.NewTipScoreCalculator(te.Storage(), 8, 13, int(BelowMaxDepth)),
+		UTXOManager:        te.UTXOManager(),
+	}
+
+	return te, seed1Wallet, seed2Wallet
+}
+
+func TestOrphanAnalysis(t *testing.T) {
+	te, _, _ := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		BuildTaggedData().
+		Store()
+
+	// the message is a tip
+	resp, err := orphanAnalysisByMessageID(context.Background(), messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.True(t, resp.IsSolid)
+	require.Nil(t, resp.ReferencedByMilestone)
+	require.Equal(t, te.SyncManager().ConfirmedMilestoneIndex(), resp.ConfirmedMilestoneIndex)
+	require.Equal(t, 0, resp.ChildrenCount)
+	require.Equal(t, tangle.TipScoreHealthy.String(), resp.TipScore)
+	require.False(t, resp.BelowMaxDepth)
+	require.Contains(t, resp.Reason, "no other message references the message")
+
+	// the parents were referenced by the milestone before the last one
+	require.Len(t, resp.Parents, len(te.LastMilestoneParents()))
+	for _, parent := range resp.Parents {
+		require.True(t, parent.Exists)
+		require.True(t, parent.IsSolid)
+		require.NotNil(t, parent.ReferencedByMilestone)
+	}
+
+	messageB := te.NewMessageBuilder("B").
+		Parents(hornet.MessageIDs{messageA.StoredMessageID()}).
+		BuildTaggedData().
+		Store()
+
+	resp, err = orphanAnalysisByMessageID(context.Background(), messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.ChildrenCount)
+	require.Contains(t, resp.Reason, "the message is referenced by 1 messages")
+
+	// the parent of message B was not referenced yet
+	resp, err = orphanAnalysisByMessageID(context.Background(), messageB.StoredMessageID())
+	require.NoError(t, err)
+	require.Len(t, resp.Parents, 1)
+	require.Equal(t, messageA.StoredMessageID().ToHex(), resp.Parents[0].MessageID)
+	require.Nil(t, resp.Parents[0].ReferencedByMilestone)
+
+	// the milestones don't reference the message until it is below max depth
+	for i := 0; i <= int(BelowMaxDepth); i++ {
+		te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{}, false)
+	}
+
+	resp, err = orphanAnalysisByMessageID(context.Background(), messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.True(t, resp.BelowMaxDepth)
+	require.Equal(t, tangle.TipScoreBelowMaxDepth.String(), resp.TipScore)
+	require.Equal(t, tangle.TipScoreHealthy.String(), resp.TipScoreAtAttachment)
+	require.Contains(t, resp.Reason, "the message is below max depth")
+
+	// messages with an incomplete past cone are not solid
+	messageC := te.NewMessageBuilder("C").
+		Parents(te.LastMilestoneParents()).
+		BuildTaggedData().
+		Store()
+
+	cachedMsgMeta := te.Storage().CachedMessageMetadataOrNil(messageC.StoredMessageID()) // meta +1
+	require.NotNil(t, cachedMsgMeta)
+	cachedMsgMeta.Metadata().SetSolid(false)
+	cachedMsgMeta.Release(true) // meta -1
+
+	resp, err = orphanAnalysisByMessageID(context.Background(), messageC.StoredMessageID())
+	require.NoError(t, err)
+	require.False(t, resp.IsSolid)
+	require.Contains(t, resp.Reason, "the message is not solid")
+
+	// referenced messages are not orphaned
+	conf, _ := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageB.StoredMessageID()}, false)
+
+	resp, err = orphanAnalysisByMessageID(context.Background(), messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, &conf.MilestoneIndex, resp.ReferencedByMilestone)
+	require.Equal(t, fmt.Sprintf("the message was referenced by milestone %d", conf.MilestoneIndex), resp.Reason)
+
+	_, err = orphanAnalysisByMessageID(context.Background(), hornet.MessageIDFromArray([32]byte{42}))
+	require.ErrorIs(t, err, echo.ErrNotFound)
+
+	// the genesis is a solid entry point
+	genesisParent, err := orphanParentInfo(hornet.NullMessageID())
+	require.NoError(t, err)
+	require.True(t, genesisParent.IsSolidEntryPoint)
+	require.Equal(t, milestone.Index(0), *genesisParent.ReferencedByMilestone)
+}
@@ -45,6 +45,27 @@ const (
 	// it traverses the parents of a message until they reference an older milestone than the start message.
 	// GET returns the path of this traversal and the "entry points".
 	RouteDebugMessageCone = "/message-cones/:" + restapipkg.ParameterMessageID
+
+	// RouteDebugOrphanAnalysis is the debug route for analyzing why a message was not referenced by a milestone.
+	// GET returns the state of the parents the message was attached to, the cone root indexes and the tip score of the message.
+	RouteDebugOrphanAnalysis = "/orphan-analysis/:" + restapipkg.ParameterMessageID
+
+	// RouteDebugConflictAnalysis is the debug route for analyzing the ledger inclusion state of a transaction.
+	// GET returns the state of the inputs of the transaction and the reason of a conflict.
+	RouteDebugConflictAnalysis = "/conflict-analysis/:" + restapipkg.ParameterMessageID
+
+	// RouteDebugMilestoneConfirmationGraph is the debug route for getting the confirmation graph of a milestone.
+	// GET returns the messages referenced by the milestone in white flag order.
+	// The graph is returned as graphviz DOT file if the query parameter "format" is set to "dot".
+	RouteDebugMilestoneConfirmationGraph = "/ms-confirmation-graph/:" + restapipkg.ParameterMilestoneIndex
+
+	// RouteDebugSolidificationBlockers is the debug route for getting the missing messages that block the solidification of the next milestones.
+	// GET returns the missing messages in the past cone of the next milestones.
+	RouteDebugSolidificationBlockers = "/solidification-blockers"
+
+	// RouteDebugSolidificationBlockersMessage is the debug route for getting the missing messages that block the solidification of a message.
+	// GET returns the missing messages in the past cone of the message.
+	RouteDebugSolidificationBlockersMessage = "/solidification-blockers/:" + restapipkg.ParameterMessageID
 )
 
 func init() {
@@ -65,12 +86,13 @@ var (
 
 type dependencies struct {
 	dig.In
-	Storage           *storage.Storage
-	SyncManager       *syncmanager.SyncManager
-	Tangle            *tangle.Tangle
-	RequestQueue      gossip.RequestQueue
-	UTXOManager       *utxo.Manager
-	RestPluginManager *restapi.RestPluginManager `optional:"true"`
+	Storage            *storage.Storage
+	SyncManager        *syncmanager.SyncManager
+	Tangle             *tangle.Tangle
+	TipScoreCalculator *tangle.TipScoreCalculator
+	RequestQueue       gossip.RequestQueue
+	UTXOManager        *utxo.Manager
+	RestPluginManager  *restapi.RestPluginManager `optional:"true"`
 }
 
 func configure() error {
@@ -141,5 +163,54 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteDebugOrphanAnalysis, func(c echo.Context) error {
+		resp, err := orphanAnalysis(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteDebugConflictAnalysis, func(c echo.Context) error {
+		resp, err := conflictAnalysis(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteDebugMilestoneConfirmationGraph, func(c echo.Context) error {
+		resp, err := milestoneConfirmationGraph(c)
+		if err != nil {
+			return err
+		}
+
+		if c.QueryParam(QueryParameterFormat) == formatDOT {
+			return c.Blob(http.StatusOK, MIMETextVndGraphviz, []byte(confirmationGraphDOT(resp)))
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteDebugSolidificationBlockers, func(c echo.Context) error {
+		resp, err := solidificationBlockers(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteDebugSolidificationBlockersMessage, func(c echo.Context) error {
+		resp, err := solidificationBlockersByMessageID(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	return nil
 }
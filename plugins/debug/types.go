@@ -2,7 +2,7 @@ package debug
 
 import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
-	restapiv2 "github.com/gohornet/hornet/plugins/restapi/v2"
+	"github.com/gohornet/hornet/pkg/model/storage"
 )
 
 // outputIDsResponse defines the response of a GET debug outputs REST API call.
@@ -27,16 +27,6 @@ type addressesResponse struct {
 	Addresses []*address `json:"addresses"`
 }
 
-// outputIDsResponse defines the response of a GET debug milestone diff REST API call.
-type milestoneDiffResponse struct {
-	// The index of the milestone.
-	MilestoneIndex milestone.Index `json:"index"`
-	// The newly created outputs by this milestone diff.
-	Outputs []*restapiv2.OutputResponse `json:"outputs"`
-	// The used outputs (spents) by this milestone diff.
-	Spents []*restapiv2.OutputResponse `json:"spents"`
-}
-
 // request defines an request response.
 type request struct {
 	// The hex encoded message ID of the message.
@@ -83,3 +73,155 @@ type messageConeResponse struct {
 	// The entry points of the cone of this message.
 	EntryPoints []*entryPoint `json:"entryPoints"`
 }
+
+// orphanParent defines a parent of a message with information about its state.
+type orphanParent struct {
+	// The hex encoded message ID of the parent.
+	MessageID string `json:"messageId"`
+	// Whether the parent exists in the storage layer.
+	Exists bool `json:"exists"`
+	// Whether the parent is solid.
+	IsSolid bool `json:"isSolid"`
+	// Whether the parent is a solid entry point.
+	IsSolidEntryPoint bool `json:"isSolidEntryPoint"`
+	// The index of the milestone that referenced the parent.
+	ReferencedByMilestone *milestone.Index `json:"referencedByMilestoneIndex,omitempty"`
+	// The youngest cone root index of the parent.
+	YoungestConeRootIndex milestone.Index `json:"youngestConeRootIndex"`
+	// The oldest cone root index of the parent.
+	OldestConeRootIndex milestone.Index `json:"oldestConeRootIndex"`
+}
+
+// orphanAnalysisResponse defines the response of a GET debug orphan analysis REST API call.
+type orphanAnalysisResponse struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// Whether the message is solid.
+	IsSolid bool `json:"isSolid"`
+	// The index of the milestone that referenced the message.
+	ReferencedByMilestone *milestone.Index `json:"referencedByMilestoneIndex,omitempty"`
+	// The confirmed milestone index of the node.
+	ConfirmedMilestoneIndex milestone.Index `json:"confirmedMilestoneIndex"`
+	// The youngest cone root index of the message.
+	YoungestConeRootIndex milestone.Index `json:"youngestConeRootIndex"`
+	// The oldest cone root index of the message.
+	OldestConeRootIndex milestone.Index `json:"oldestConeRootIndex"`
+	// The estimated confirmed milestone index at the time the message was attached.
+	// The message can't be older than the youngest milestone in its past cone.
+	AttachmentMilestoneIndex milestone.Index `json:"attachmentMilestoneIndex"`
+	// The tip score of the message at the time it was attached.
+	TipScoreAtAttachment string `json:"tipScoreAtAttachment,omitempty"`
+	// The tip score of the message at the confirmed milestone index.
+	TipScore string `json:"tipScore,omitempty"`
+	// Whether the message is below max depth.
+	BelowMaxDepth bool `json:"isBelowMaxDepth"`
+	// The parents (tips) the message was attached to.
+	Parents []*orphanParent `json:"parents"`
+	// The count of messages that reference the message.
+	ChildrenCount int `json:"childrenCount"`
+	// The explanation why the message was not referenced.
+	Reason string `json:"reason"`
+}
+
+// conflictInput defines an input of a transaction with information about its state.
+type conflictInput struct {
+	// The hex encoded output ID of the input.
+	OutputID string `json:"outputId"`
+	// Whether the output exists in the ledger.
+	Exists bool `json:"exists"`
+	// The hex encoded message ID of the message that created the output.
+	CreatedByMessageID string `json:"createdByMessageId,omitempty"`
+	// The index of the milestone that booked the output.
+	MilestoneIndexBooked milestone.Index `json:"milestoneIndexBooked,omitempty"`
+	// Whether the output is spent.
+	IsSpent bool `json:"isSpent"`
+	// The hex encoded ID of the transaction that spent the output.
+	SpentByTransactionID string `json:"spentByTransactionId,omitempty"`
+	// The hex encoded message ID of the message that contains the transaction that spent the output.
+	SpentByMessageID string `json:"spentByMessageId,omitempty"`
+	// The index of the milestone that spent the output.
+	MilestoneIndexSpent milestone.Index `json:"milestoneIndexSpent,omitempty"`
+	// Whether the output was spent by the transaction of the analyzed message.
+	SpentByThisTransaction bool `json:"isSpentByThisTransaction"`
+}
+
+// conflictAnalysisResponse defines the response of a GET debug conflict analysis REST API call.
+type conflictAnalysisResponse struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The hex encoded ID of the transaction.
+	TransactionID string `json:"transactionId"`
+	// The index of the milestone that referenced the message.
+	ReferencedByMilestone *milestone.Index `json:"referencedByMilestoneIndex,omitempty"`
+	// The ledger inclusion state of the transaction, if the message was referenced.
+	LedgerInclusionState string `json:"ledgerInclusionState,omitempty"`
+	// The reason why the transaction is conflicting.
+	ConflictReason storage.Conflict `json:"conflictReason"`
+	// The inputs of the transaction.
+	Inputs []*conflictInput `json:"inputs"`
+	// The explanation of the ledger inclusion state.
+	Explanation string `json:"explanation"`
+	// The ledger index at which the inputs were analyzed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// confirmationGraphNode defines a message in the confirmation graph of a milestone.
+type confirmationGraphNode struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The position of the message in the white flag ordering (-1 if it is not part of the cone).
+	Index int `json:"index"`
+	// The state of the message in the confirmation.
+	State string `json:"state"`
+	// The index of the milestone that referenced the message.
+	ReferencedByMilestone milestone.Index `json:"referencedByMilestoneIndex"`
+	// The reason why the transaction of the message is conflicting.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The hex encoded message IDs of the parents.
+	Parents []string `json:"parentMessageIds,omitempty"`
+}
+
+// confirmationGraphResponse defines the response of a GET debug milestone confirmation graph REST API call.
+type confirmationGraphResponse struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The hex encoded message IDs of the parents of the milestone.
+	MilestoneParents []string `json:"milestoneParents"`
+	// The messages referenced by the milestone in white flag order,
+	// followed by the messages of older milestones they reference.
+	Nodes []*confirmationGraphNode `json:"nodes"`
+}
+
+// missingParent defines a message that is missing in the storage layer.
+type missingParent struct {
+	// The hex encoded message ID of the missing message.
+	MessageID string `json:"messageId"`
+	// The state of the request for the missing message.
+	RequestState string `json:"requestState"`
+	// The hex encoded message IDs of the messages that reference the missing message.
+	ReferencedBy []string `json:"referencedBy"`
+}
+
+// solidificationBlocker defines a message or milestone that is not solid because of missing messages in its past cone.
+type solidificationBlocker struct {
+	// The hex encoded message ID of the message, if the blocker is a message.
+	MessageID string `json:"messageId,omitempty"`
+	// The index of the milestone, if the blocker is a milestone.
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	// The hex encoded ID of the milestone, if the blocker is a known milestone.
+	MilestoneID string `json:"milestoneId,omitempty"`
+	// The state of the request for the milestone, if the milestone itself is missing.
+	MilestoneRequestState string `json:"milestoneRequestState,omitempty"`
+	// The missing messages in the past cone.
+	MissingParents []*missingParent `json:"missingParents"`
+}
+
+// solidificationBlockersResponse defines the response of a GET debug solidification blockers REST API call.
+type solidificationBlockersResponse struct {
+	// The confirmed milestone index of the node.
+	ConfirmedMilestoneIndex milestone.Index `json:"confirmedMilestoneIndex"`
+	// The latest milestone index of the node.
+	LatestMilestoneIndex milestone.Index `json:"latestMilestoneIndex"`
+	// The messages that are blocked by missing parents.
+	Blockers []*solidificationBlocker `json:"blockers"`
+}
@@ -0,0 +1,23 @@
+package export
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersExport contains the definition of the parameters used by the export plugin.
+type ParametersExport struct {
+	// the directory the partitions are written to.
+	Path string `default:"export" usage:"the directory the partitions are written to"`
+	// the amount of milestones per partition.
+	PartitionSize uint32 `default:"10000" usage:"the amount of milestones per partition"`
+	// whether milestones are exported before they get pruned, even if their partition is not complete yet.
+	ExportBeforePruning bool `default:"true" usage:"whether milestones are exported before they get pruned, even if their partition is not complete yet"`
+}
+
+var ParamsExport = &ParametersExport{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"export": ParamsExport,
+	},
+}
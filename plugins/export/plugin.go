@@ -0,0 +1,141 @@
+package export
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/dig"
+
+	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/export"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/snapshot"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/events"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func init() {
+	Plugin = &app.Plugin{
+		Status: app.StatusDisabled,
+		Component: &app.Component{
+			Name:      "Export",
+			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
+			Configure: configure,
+			Run:       run,
+		},
+	}
+}
+
+var (
+	Plugin *app.Plugin
+	deps   dependencies
+
+	exporter *export.Exporter
+	source   *export.StorageSource
+	// exportLock ensures that the background worker and the pruning don't export at the same time.
+	exportLock sync.Mutex
+)
+
+type dependencies struct {
+	dig.In
+	Storage            *storage.Storage
+	SyncManager        *syncmanager.SyncManager
+	Tangle             *tangle.Tangle
+	SnapshotManager    *snapshot.SnapshotManager
+	ProtocolParameters *iotago.ProtocolParameters
+}
+
+func configure() error {
+
+	var err error
+	exporter, err = export.NewExporter(ParamsExport.Path, ParamsExport.PartitionSize, deps.ProtocolParameters.Bech32HRP)
+	if err != nil {
+		Plugin.LogPanicf("failed to create exporter: %s", err)
+	}
+	source = export.NewStorageSource(deps.Storage)
+
+	if ParamsExport.ExportBeforePruning {
+		// the pruning waits until the milestone was exported
+		deps.SnapshotManager.Events.PruningMilestone.Attach(events.NewClosure(func(msIndex milestone.Index) {
+			exportMilestones(context.Background(), msIndex)
+		}))
+	}
+
+	return nil
+}
+
+// exportMilestones exports the milestones up to the target index, if they were not exported yet.
+func exportMilestones(ctx context.Context, targetIndex milestone.Index) {
+	exportLock.Lock()
+	defer exportLock.Unlock()
+
+	state, err := exporter.State()
+	if err != nil {
+		Plugin.LogWarnf("reading export state failed: %s", err)
+		return
+	}
+
+	if state.LastExportedIndex >= targetIndex {
+		return
+	}
+
+	lastExportedIndex, err := exporter.Export(ctx, source, targetIndex)
+	if err != nil {
+		Plugin.LogWarnf("exporting milestones up to %d failed: %s", targetIndex, err)
+		return
+	}
+
+	if lastExportedIndex > state.LastExportedIndex {
+		Plugin.LogInfof("exported milestones %d-%d", state.LastExportedIndex+1, lastExportedIndex)
+	}
+}
+
+func run() error {
+
+	partitionSize := milestone.Index(ParamsExport.PartitionSize)
+
+	newConfirmedMilestoneSignal := make(chan milestone.Index)
+	onConfirmedMilestoneIndexChanged := events.NewClosure(func(msIndex milestone.Index) {
+		select {
+		case newConfirmedMilestoneSignal <- msIndex:
+		default:
+		}
+	})
+
+	if err := Plugin.Daemon().BackgroundWorker("Export", func(ctx context.Context) {
+		Plugin.LogInfo("Starting Export ... done")
+
+		deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Attach(onConfirmedMilestoneIndexChanged)
+		defer deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Detach(onConfirmedMilestoneIndexChanged)
+
+		for {
+			select {
+			case <-ctx.Done():
+				Plugin.LogInfo("Stopping Export ... done")
+				return
+
+			case confirmedMilestoneIndex := <-newConfirmedMilestoneSignal:
+				if !deps.SyncManager.IsNodeSynced() {
+					continue
+				}
+
+				// only complete partitions are exported
+				targetIndex := (confirmedMilestoneIndex / partitionSize) * partitionSize
+				if targetIndex == 0 {
+					continue
+				}
+
+				exportMilestones(ctx, targetIndex)
+			}
+		}
+	}, daemon.PriorityExport); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
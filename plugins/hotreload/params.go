@@ -0,0 +1,26 @@
+package hotreload
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersHotReload contains the definition of the parameters used by the HotReload plugin.
+type ParametersHotReload struct {
+	// Peering defines whether the peering config file is reloaded on changes
+	Peering bool `default:"true" usage:"whether to connect and disconnect peers if the peering config file changed"`
+	// Config defines whether the node config file is reloaded on changes
+	Config bool `default:"true" usage:"whether to apply changes of hot-reloadable parameters if the node config file changed"`
+	// DebounceTime defines the time to wait for further changes before a changed file is reloaded
+	DebounceTime time.Duration `default:"1s" usage:"the time to wait for further changes before a changed file is reloaded"`
+}
+
+var ParamsHotReload = &ParametersHotReload{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"hotReload": ParamsHotReload,
+	},
+	Masked: nil,
+}
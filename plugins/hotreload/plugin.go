@@ -0,0 +1,199 @@
+package hotreload
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"go.uber.org/dig"
+
+	p2pcore "github.com/gohornet/hornet/core/p2p"
+	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/hotreload"
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/events"
+)
+
+func init() {
+	Plugin = &app.Plugin{
+		Status: app.StatusDisabled,
+		Component: &app.Component{
+			Name:     "HotReload",
+			DepsFunc: func(cDeps dependencies) { deps = cDeps },
+			Params:   params,
+			Provide:  provide,
+			Run:      run,
+		},
+	}
+}
+
+var (
+	Plugin *app.Plugin
+	deps   dependencies
+)
+
+type dependencies struct {
+	dig.In
+	AppConfigFilePath     *string                      `name:"appConfigFilePath"`
+	PeeringConfig         *configuration.Configuration `name:"peeringConfig"`
+	PeeringConfigFilePath *string                      `name:"peeringConfigFilePath"`
+	PeeringConfigManager  *p2p.ConfigManager
+	PeeringManager        *p2p.Manager              `optional:"true"`
+	ConfigReloader        *hotreload.ConfigReloader `optional:"true"`
+}
+
+func provide(c *dig.Container) error {
+
+	if !ParamsHotReload.Config {
+		return nil
+	}
+
+	type configReloaderDeps struct {
+		dig.In
+		AppConfig         *configuration.Configuration `name:"appConfig"`
+		AppConfigFilePath *string                      `name:"appConfigFilePath"`
+	}
+
+	if err := c.Provide(func(deps configReloaderDeps) *hotreload.ConfigReloader {
+		configReloader, err := hotreload.NewConfigReloader(Plugin.Logger(), deps.AppConfig, *deps.AppConfigFilePath)
+		if err != nil {
+			Plugin.LogPanic(err)
+		}
+		return configReloader
+	}); err != nil {
+		Plugin.LogPanic(err)
+	}
+
+	return nil
+}
+
+func run() error {
+
+	if ParamsHotReload.Config {
+		runFileWatcher("HotReload[Config]", *deps.AppConfigFilePath, func() {
+			if _, err := deps.ConfigReloader.Reload(); err != nil {
+				Plugin.LogWarnf("reloading config failed: %s", err)
+			}
+		})
+	}
+
+	if ParamsHotReload.Peering {
+		if deps.PeeringManager == nil {
+			Plugin.LogWarn("peering manager not available, reloading of the peering config is disabled")
+			return nil
+		}
+
+		runFileWatcher("HotReload[Peering]", *deps.PeeringConfigFilePath, func() {
+			if err := reloadPeeringConfig(); err != nil {
+				Plugin.LogWarnf("reloading peering config failed: %s", err)
+			}
+		})
+	}
+
+	return nil
+}
+
+// runFileWatcher starts a background worker that calls onChange every time the content of the given file changed.
+func runFileWatcher(name string, filePath string, onChange func()) {
+
+	fileWatcher, err := hotreload.NewFileWatcher(filePath, ParamsHotReload.DebounceTime)
+	if err != nil {
+		Plugin.LogPanicf("failed to create file watcher for %s: %s", filePath, err)
+	}
+
+	onFileChanged := events.NewClosure(func(filePath string) {
+		Plugin.LogInfof("%s changed, reloading...", filePath)
+		onChange()
+	})
+
+	onFileWatcherError := events.NewClosure(func(err error) {
+		Plugin.LogWarnf("watching %s failed: %s", fileWatcher.FilePath(), err)
+	})
+
+	if err := Plugin.Daemon().BackgroundWorker(name, func(ctx context.Context) {
+		Plugin.LogInfof("watching %s for changes", fileWatcher.FilePath())
+
+		fileWatcher.Events.Changed.Attach(onFileChanged)
+		defer fileWatcher.Events.Changed.Detach(onFileChanged)
+		fileWatcher.Events.Error.Attach(onFileWatcherError)
+		defer fileWatcher.Events.Error.Detach(onFileWatcherError)
+
+		if err := fileWatcher.Run(ctx); err != nil {
+			Plugin.LogWarnf("watching %s stopped: %s", fileWatcher.FilePath(), err)
+		}
+	}, daemon.PriorityHotReload); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+// reloadPeeringConfig loads the peers from the peering config file and
+// connects to added peers and disconnects from removed peers.
+func reloadPeeringConfig() error {
+
+	peeringConfig := configuration.New()
+	if err := peeringConfig.LoadFile(*deps.PeeringConfigFilePath); err != nil {
+		return err
+	}
+
+	var peers []*p2p.PeerConfig
+	if err := peeringConfig.Unmarshal(p2pcore.CfgPeers, &peers); err != nil {
+		return errors.Wrap(err, "invalid peer config")
+	}
+
+	// validate all peers first, so that a broken file does not change the current peering
+	addrInfos := make(map[string]*peer.AddrInfo, len(peers))
+	for i, p := range peers {
+		multiAddr, err := multiaddr.NewMultiaddr(p.MultiAddress)
+		if err != nil {
+			return errors.Wrapf(err, "invalid config peer address at pos %d", i)
+		}
+
+		addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid config peer address info at pos %d", i)
+		}
+		addrInfos[p.MultiAddress] = addrInfo
+	}
+
+	added, removed := deps.PeeringConfigManager.ReplacePeers(peers)
+	if len(added) == 0 && len(removed) == 0 {
+		Plugin.LogDebug("reloaded peering config, no peers changed")
+		return nil
+	}
+
+	// keep the loaded peering config in sync with the config manager
+	if err := deps.PeeringConfig.Set(p2pcore.CfgPeers, peers); err != nil {
+		return err
+	}
+
+	for _, p := range removed {
+		multiAddr, err := multiaddr.NewMultiaddr(p.MultiAddress)
+		if err != nil {
+			// ignore wrong values in the previous config
+			continue
+		}
+
+		addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			// ignore wrong values in the previous config
+			continue
+		}
+
+		Plugin.LogInfof("peer removed from peering config: %s (%s)", p.MultiAddress, p.Alias)
+		if err := deps.PeeringManager.DisconnectPeer(addrInfo.ID, errors.New("peer was removed from the peering config")); err != nil {
+			Plugin.LogInfof("can't disconnect from peer (%s): %s", p.MultiAddress, err)
+		}
+	}
+
+	for _, p := range added {
+		Plugin.LogInfof("peer added to peering config: %s (%s)", p.MultiAddress, p.Alias)
+		if err := deps.PeeringManager.ConnectPeer(addrInfos[p.MultiAddress], p2p.PeerRelationKnown, p.Alias); err != nil {
+			Plugin.LogInfof("can't connect to peer (%s): %s", p.MultiAddress, err)
+		}
+	}
+
+	return nil
+}
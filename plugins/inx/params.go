@@ -1,9 +1,25 @@
 package inx
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
+// ConfigExtension defines an INX extension and the capabilities it is granted.
+type ConfigExtension struct {
+	// the name of the extension.
+	Name string `json:"name" koanf:"name"`
+	// the hex encoded sha256 hash of the bearer token of the extension.
+	TokenHash string `json:"tokenHash" koanf:"tokenHash"`
+	// the common name of the TLS client certificate of the extension.
+	CertificateCommonName string `json:"certificateCommonName" koanf:"certificateCommonName"`
+	// the capabilities that are granted to the extension.
+	Capabilities []string `json:"capabilities" koanf:"capabilities"`
+}
+
+type ConfigExtensions []*ConfigExtension
+
 // ParametersINX contains the definition of the parameters used by INX.
 type ParametersINX struct {
 	// the bind address on which the INX can be accessed from
@@ -13,6 +29,40 @@ type ParametersINX struct {
 		// the amount of workers used for calculating PoW when issuing messages via INX
 		WorkerCount int `default:"0" usage:"the amount of workers used for calculating PoW when issuing messages via INX. (use 0 to use the maximum possible)"`
 	} `name:"pow"`
+
+	TLS struct {
+		// whether the INX server uses TLS
+		Enabled bool `default:"false" usage:"whether the INX server uses TLS"`
+		// the path to the TLS certificate of the INX server
+		CertificatePath string `default:"inx.crt" usage:"the path to the TLS certificate of the INX server"`
+		// the path to the TLS private key of the INX server
+		PrivateKeyPath string `default:"inx.key" usage:"the path to the TLS private key of the INX server"`
+		// the path to the CA certificate that is used to verify the client certificates of the extensions (mTLS)
+		ClientCAPath string `default:"" usage:"the path to the CA certificate that is used to verify the client certificates of the extensions (mTLS)"`
+	} `name:"tls"`
+
+	KeepAlive struct {
+		// the interval in which the INX server pings the extensions to detect dead connections
+		Time time.Duration `default:"30s" usage:"the interval in which the INX server pings the extensions to detect dead connections"`
+		// the time the INX server waits for the response to a ping before the connection is closed
+		Timeout time.Duration `default:"10s" usage:"the time the INX server waits for the response to a ping before the connection is closed"`
+	}
+
+	Registry struct {
+		// the time after which an extension without open connections and calls is considered disconnected
+		Timeout time.Duration `default:"1m" usage:"the time after which an extension without open connections and calls is considered disconnected"`
+		// the interval in which the REST API proxy routes of the extensions are checked
+		HealthCheckInterval time.Duration `default:"10s" usage:"the interval in which the REST API proxy routes of the extensions are checked"`
+		// the amount of consecutive failed health checks after which a REST API proxy route is removed
+		MaxFailedHealthChecks int `default:"3" usage:"the amount of consecutive failed health checks after which a REST API proxy route is removed"`
+	}
+
+	Auth struct {
+		// whether the extensions need to authenticate with a token or client certificate
+		Enabled bool `default:"false" usage:"whether the extensions need to authenticate with a token or client certificate"`
+		// the extensions that are allowed to connect and their capabilities
+		Extensions ConfigExtensions `noflag:"true"`
+	}
 }
 
 var ParamsINX = &ParametersINX{}
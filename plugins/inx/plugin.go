@@ -9,8 +9,10 @@ import (
 
 	"github.com/gohornet/hornet/core/protocfg"
 	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/inxregistry"
 	"github.com/gohornet/hornet/pkg/keymanager"
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
@@ -20,6 +22,7 @@ import (
 	"github.com/gohornet/hornet/plugins/restapi"
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/timeutil"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
@@ -43,25 +46,29 @@ var (
 	attacher *tangle.MessageAttacher
 
 	messageProcessedTimeout = 1 * time.Second
+
+	onRouteRemoved *events.Closure
 )
 
 type dependencies struct {
 	dig.In
-	SyncManager             *syncmanager.SyncManager
-	UTXOManager             *utxo.Manager
-	Tangle                  *tangle.Tangle
-	TipScoreCalculator      *tangle.TipScoreCalculator
-	Storage                 *storage.Storage
-	KeyManager              *keymanager.KeyManager
-	TipSelector             *tipselect.TipSelector `optional:"true"`
-	MilestonePublicKeyCount int                    `name:"milestonePublicKeyCount"`
-	ProtocolParameters      *iotago.ProtocolParameters
-	BaseToken               *protocfg.BaseToken
-	PoWHandler              *pow.Handler
-	INXServer               *INXServer
-	INXMetrics              *metrics.INXMetrics
-	Echo                    *echo.Echo                 `optional:"true"`
-	RestPluginManager       *restapi.RestPluginManager `optional:"true"`
+	SyncManager                     *syncmanager.SyncManager
+	UTXOManager                     *utxo.Manager
+	Tangle                          *tangle.Tangle
+	TipScoreCalculator              *tangle.TipScoreCalculator
+	Storage                         *storage.Storage
+	KeyManager                      *keymanager.KeyManager
+	TipSelector                     *tipselect.TipSelector `optional:"true"`
+	MilestonePublicKeyCount         int                    `name:"milestonePublicKeyCount"`
+	KeyRangesExpiryWarningThreshold milestone.Index        `name:"keyRangesExpiryWarningThreshold"`
+	ProtocolParameters              *iotago.ProtocolParameters
+	BaseToken                       *protocfg.BaseToken
+	PoWHandler                      *pow.Handler
+	INXServer                       *INXServer
+	INXRegistry                     *inxregistry.Registry
+	INXMetrics                      *metrics.INXMetrics
+	Echo                            *echo.Echo                 `optional:"true"`
+	RestPluginManager               *restapi.RestPluginManager `optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -76,8 +83,22 @@ func provide(c *dig.Container) error {
 		Plugin.LogPanic(err)
 	}
 
-	if err := c.Provide(func() *INXServer {
-		return newINXServer()
+	if err := c.Provide(func() *inxregistry.Registry {
+		return inxregistry.New(
+			inxregistry.WithLogger(Plugin.Logger()),
+			inxregistry.WithTimeout(ParamsINX.Registry.Timeout),
+			inxregistry.WithMaxFailedProbes(ParamsINX.Registry.MaxFailedHealthChecks),
+		)
+	}); err != nil {
+		Plugin.LogPanic(err)
+	}
+
+	if err := c.Provide(func(registry *inxregistry.Registry) *INXServer {
+		server, err := newINXServer(registry)
+		if err != nil {
+			Plugin.LogPanicf("failed to create INX server: %s", err)
+		}
+		return server
 	}); err != nil {
 		Plugin.LogPanic(err)
 	}
@@ -98,6 +119,12 @@ func configure() error {
 
 	attacher = deps.Tangle.MessageAttacher(attacherOpts...)
 
+	onRouteRemoved = events.NewClosure(func(_ *inxregistry.Extension, route *inxregistry.Route) {
+		if deps.RestPluginManager != nil {
+			deps.RestPluginManager.RemovePlugin(route.Route)
+		}
+	})
+
 	return nil
 }
 
@@ -113,5 +140,17 @@ func run() error {
 		Plugin.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if err := Plugin.Daemon().BackgroundWorker("INX[Registry]", func(ctx context.Context) {
+		deps.INXRegistry.Events.RouteRemoved.Attach(onRouteRemoved)
+		defer deps.INXRegistry.Events.RouteRemoved.Detach(onRouteRemoved)
+
+		ticker := timeutil.NewTicker(func() {
+			deps.INXRegistry.CheckLiveness(ctx)
+		}, ParamsINX.Registry.HealthCheckInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, daemon.PriorityIndexer); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
 	return nil
 }
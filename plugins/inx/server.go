@@ -2,11 +2,21 @@ package inx
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"net"
+	"os"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 
+	"github.com/gohornet/hornet/pkg/inxauth"
+	"github.com/gohornet/hornet/pkg/inxregistry"
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/tracing"
 	inx "github.com/iotaledger/inx/go"
 )
 
@@ -15,14 +25,108 @@ const (
 	workerQueueSize = 10000
 )
 
-func newINXServer() *INXServer {
-	grpcServer := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-	)
+func newINXServer(registry *inxregistry.Registry) (*INXServer, error) {
+
+	streamInterceptors := []grpc.StreamServerInterceptor{grpc_prometheus.StreamServerInterceptor, tracing.StreamServerInterceptor()}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor, tracing.UnaryServerInterceptor()}
+
+	if ParamsINX.Auth.Enabled {
+		authorizer, err := newAuthorizer()
+		if err != nil {
+			return nil, err
+		}
+		streamInterceptors = append(streamInterceptors, authorizer.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, authorizer.UnaryServerInterceptor())
+	}
+
+	// the registry is called after the authorization to know the name of authenticated extensions
+	streamInterceptors = append(streamInterceptors, func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		extensionID := registry.StreamStarted(stream.Context())
+		defer registry.StreamEnded(extensionID)
+		return handler(srv, stream)
+	})
+	unaryInterceptors = append(unaryInterceptors, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		registry.Seen(ctx)
+		return handler(ctx, req)
+	})
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		// track the connections of the extensions to know if they are alive
+		grpc.StatsHandler(registry.StatsHandler()),
+		// ping the extensions to detect dead connections and to close their streams
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    ParamsINX.KeepAlive.Time,
+			Timeout: ParamsINX.KeepAlive.Timeout,
+		}),
+	}
+
+	if ParamsINX.TLS.Enabled {
+		tlsConfig, err := loadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if ParamsINX.Auth.Enabled {
+		Plugin.LogWarn("INX authentication is enabled without TLS, the tokens of the extensions are transmitted in plaintext")
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	s := &INXServer{grpcServer: grpcServer}
 	inx.RegisterINXServer(grpcServer, s)
-	return s
+	inxtangle.RegisterINXTangleServer(grpcServer, &INXTangleServer{})
+	return s, nil
+}
+
+// newAuthorizer creates the authorizer for the configured extensions.
+func newAuthorizer() (*inxauth.Authorizer, error) {
+	extensions := make([]*inxauth.Extension, 0, len(ParamsINX.Auth.Extensions))
+	for _, configExtension := range ParamsINX.Auth.Extensions {
+		capabilities := make([]inxauth.Capability, 0, len(configExtension.Capabilities))
+		for _, capability := range configExtension.Capabilities {
+			capabilities = append(capabilities, inxauth.Capability(capability))
+		}
+
+		extensions = append(extensions, &inxauth.Extension{
+			Name:                  configExtension.Name,
+			TokenHash:             configExtension.TokenHash,
+			CertificateCommonName: configExtension.CertificateCommonName,
+			Capabilities:          capabilities,
+		})
+	}
+
+	return inxauth.NewAuthorizer(Plugin.Logger(), extensions)
+}
+
+// loadTLSConfig loads the TLS certificate of the INX server and the CA to verify client certificates.
+func loadTLSConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(ParamsINX.TLS.CertificatePath, ParamsINX.TLS.PrivateKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load INX TLS certificate")
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if ParamsINX.TLS.ClientCAPath != "" {
+		caCertificate, err := os.ReadFile(ParamsINX.TLS.ClientCAPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load INX client CA certificate")
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, errors.New("unable to parse INX client CA certificate")
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
 }
 
 type INXServer struct {
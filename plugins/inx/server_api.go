@@ -12,7 +12,7 @@ import (
 	inx "github.com/iotaledger/inx/go"
 )
 
-func (s *INXServer) RegisterAPIRoute(_ context.Context, req *inx.APIRouteRequest) (*inx.NoParams, error) {
+func (s *INXServer) RegisterAPIRoute(ctx context.Context, req *inx.APIRouteRequest) (*inx.NoParams, error) {
 	if Plugin.App.IsPluginSkipped(restapi.Plugin) {
 		return nil, status.Error(codes.Unavailable, "RestAPI plugin is not enabled")
 	}
@@ -27,6 +27,7 @@ func (s *INXServer) RegisterAPIRoute(_ context.Context, req *inx.APIRouteRequest
 		return nil, status.Error(codes.InvalidArgument, "port can not be zero")
 	}
 	deps.RestPluginManager.AddPluginProxy(req.GetRoute(), req.GetHost(), req.GetPort())
+	deps.INXRegistry.AddRoute(ctx, req.GetRoute(), req.GetHost(), req.GetPort())
 	Plugin.LogInfof("Registered proxy %s => %s:%d", req.GetRoute(), req.GetHost(), req.GetPort())
 	return &inx.NoParams{}, nil
 }
@@ -40,6 +41,7 @@ func (s *INXServer) UnregisterAPIRoute(_ context.Context, req *inx.APIRouteReque
 		return nil, status.Error(codes.InvalidArgument, "route can not be empty")
 	}
 	deps.RestPluginManager.RemovePlugin(req.GetRoute())
+	deps.INXRegistry.RemoveRoute(req.GetRoute())
 	Plugin.LogInfof("Removed proxy %s", req.GetRoute())
 	return &inx.NoParams{}, nil
 }
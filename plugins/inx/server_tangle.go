@@ -0,0 +1,537 @@
+package inx
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hive.go/workerpool"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// the maximum amount of solid but unreferenced messages that are tracked per metadata update stream
+	// to detect if they fell below max depth.
+	metadataUpdatesMaxTrackedMessages = 50000
+)
+
+// INXTangleServer serves the calls of INX extensions to walk the tangle structure.
+type INXTangleServer struct {
+	inxtangle.UnimplementedINXTangleServer
+}
+
+func (s *INXTangleServer) ReadMessageChildren(messageID *inx.MessageId, srv inxtangle.INXTangle_ReadMessageChildrenServer) error {
+	msgID := hornet.MessageIDFromArray(messageID.Unwrap())
+
+	if !deps.Storage.MessageExistsInStore(msgID) {
+		return status.Errorf(codes.NotFound, "message %s not found", msgID.ToHex())
+	}
+
+	childrenMessageIDs, err := deps.Storage.ChildrenMessageIDs(msgID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read children of message %s: %s", msgID.ToHex(), err)
+	}
+
+	for _, childMessageID := range childrenMessageIDs {
+		if err := srv.Send(inx.NewMessageId(childMessageID.ToArray())); err != nil {
+			return errors.Wrap(err, "send error")
+		}
+	}
+
+	return nil
+}
+
+func milestoneIndexAndParentsForRequest(req *inx.MilestoneRequest) (milestone.Index, hornet.MessageIDs, error) {
+	var cachedMilestone *storage.CachedMilestone
+	if msIndex := milestone.Index(req.GetMilestoneIndex()); msIndex != 0 {
+		cachedMilestone = deps.Storage.CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+		if cachedMilestone == nil {
+			return 0, nil, status.Errorf(codes.NotFound, "milestone index %d not found", msIndex)
+		}
+	} else {
+		milestoneID := req.GetMilestoneId().Unwrap()
+		cachedMilestone = deps.Storage.CachedMilestoneOrNil(milestoneID) // milestone +1
+		if cachedMilestone == nil {
+			return 0, nil, status.Errorf(codes.NotFound, "milestone %s not found", iotago.EncodeHex(milestoneID[:]))
+		}
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	return cachedMilestone.Milestone().Index(), cachedMilestone.Milestone().Parents(), nil
+}
+
+func (s *INXTangleServer) ReadMilestoneCone(req *inx.MilestoneRequest, srv inxtangle.INXTangle_ReadMilestoneConeServer) error {
+	msIndex, parents, err := milestoneIndexAndParentsForRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex(); msIndex > confirmedMilestoneIndex {
+		return status.Errorf(codes.FailedPrecondition, "milestone %d is not confirmed yet, confirmed milestone index: %d", msIndex, confirmedMilestoneIndex)
+	}
+
+	// the consumer of the parents traverser is called in DFS order,
+	// which results in the same order as the white-flag confirmation of the milestone.
+	if err := dag.TraverseParents(
+		srv.Context(),
+		deps.Storage,
+		parents,
+		// traversal stops if no more messages pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedMsgMeta *storage.CachedMetadata) (bool, error) { // meta +1
+			defer cachedMsgMeta.Release(true) // meta -1
+
+			referenced, at := cachedMsgMeta.Metadata().ReferencedWithIndex()
+			return referenced && at == msIndex, nil
+		},
+		// consumer
+		func(cachedMsgMeta *storage.CachedMetadata) error { // meta +1
+			defer cachedMsgMeta.Release(true) // meta -1
+
+			payload, err := INXNewMessageMetadata(cachedMsgMeta.Metadata().MessageID(), cachedMsgMeta.Metadata())
+			if err != nil {
+				return err
+			}
+			if err := srv.Send(payload); err != nil {
+				return errors.Wrap(err, "send error")
+			}
+			return nil
+		},
+		// called on missing parents
+		// return error on missing parents
+		nil,
+		// called on solid entry points
+		// Ignore solid entry points (snapshot milestone included)
+		nil,
+		false); err != nil {
+		if errors.Is(err, common.ErrMessageNotFound) {
+			return status.Errorf(codes.NotFound, "cone of milestone %d is not available: %s", msIndex, err)
+		}
+		if errors.Is(err, common.ErrOperationAborted) {
+			return status.Error(codes.Canceled, err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// trackedMessage is the last sent tip state of a solid but unreferenced message.
+type trackedMessage struct {
+	shouldPromote  bool
+	shouldReattach bool
+	// the confirmed milestone index at which the tip state of the message needs to be evaluated again.
+	nextEvaluation milestone.Index
+}
+
+// metadataUpdateTracker keeps track of the tip state of the solid but unreferenced messages that pass the filter,
+// to be able to signal when they need to be promoted or fell below max depth.
+// Messages are only evaluated again at the confirmed milestone index at which their tip state can change.
+type metadataUpdateTracker struct {
+	matcher *inxtangle.MessageMetadataMatcher
+	// the tracked messages by their map key.
+	tracked map[string]*trackedMessage
+	// the map keys of the tracked messages by the confirmed milestone index of their next evaluation.
+	pending map[milestone.Index][]string
+}
+
+func newMetadataUpdateTracker(matcher *inxtangle.MessageMetadataMatcher) *metadataUpdateTracker {
+	return &metadataUpdateTracker{
+		matcher: matcher,
+		tracked: make(map[string]*trackedMessage),
+		pending: make(map[milestone.Index][]string),
+	}
+}
+
+// evaluate returns the metadata of the given message and the confirmed milestone index at which its tip state needs to be evaluated again.
+// The returned index is 0 if the state of the message doesn't change anymore.
+func (t *metadataUpdateTracker) evaluate(ctx context.Context, cachedMsgMeta *storage.CachedMetadata) (*inx.MessageMetadata, milestone.Index, error) {
+	defer cachedMsgMeta.Release(true) // meta -1
+
+	cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+
+	metadata, err := INXNewMessageMetadata(cachedMsgMeta.Metadata().MessageID(), cachedMsgMeta.Metadata())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !metadata.GetSolid() || metadata.GetReferencedByMilestoneIndex() != 0 || metadata.GetShouldReattach() {
+		// the state of referenced messages and messages below max depth doesn't change anymore
+		return metadata, 0, nil
+	}
+
+	if metadata.GetShouldPromote() {
+		// the cone root indexes of a message get younger if its parents get referenced,
+		// so messages that need to be promoted are evaluated with every milestone.
+		return metadata, cmi + 1, nil
+	}
+
+	ycri, ocri, err := dag.ConeRootIndexes(ctx, deps.Storage, cachedMsgMeta.Retain(), cmi) // meta pass +1
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the cone root indexes don't get older, so the tip state doesn't change before it would change with the current ones.
+	// the loop ends at the latest if the message falls below max depth.
+	nextEvaluation := cmi + 1
+	for deps.TipScoreCalculator.TipScoreForConeRootIndexes(ycri, ocri, nextEvaluation) == tangle.TipScoreHealthy {
+		nextEvaluation++
+	}
+
+	return metadata, nextEvaluation, nil
+}
+
+// track updates the tracked tip state of a message after its metadata was sent.
+func (t *metadataUpdateTracker) track(metadata *inx.MessageMetadata, nextEvaluation milestone.Index) {
+	key := hornet.MessageIDFromArray(metadata.GetMessageId().Unwrap()).ToMapKey()
+
+	if nextEvaluation == 0 {
+		delete(t.tracked, key)
+		return
+	}
+
+	tracked, exists := t.tracked[key]
+	if !exists {
+		if len(t.tracked) >= metadataUpdatesMaxTrackedMessages {
+			return
+		}
+		tracked = &trackedMessage{}
+		t.tracked[key] = tracked
+	}
+
+	tracked.shouldPromote = metadata.GetShouldPromote()
+	tracked.shouldReattach = metadata.GetShouldReattach()
+	if tracked.nextEvaluation != nextEvaluation {
+		// entries of the former evaluation index are skipped in update
+		tracked.nextEvaluation = nextEvaluation
+		t.pending[nextEvaluation] = append(t.pending[nextEvaluation], key)
+	}
+}
+
+// update evaluates the tracked messages whose tip state can change at the given confirmed milestone index
+// and returns the metadata of the ones whose state changed.
+func (t *metadataUpdateTracker) update(ctx context.Context, cmi milestone.Index) ([]*inx.MessageMetadata, error) {
+	var updates []*inx.MessageMetadata
+
+	for index, keys := range t.pending {
+		if index > cmi {
+			continue
+		}
+		delete(t.pending, index)
+
+		for _, key := range keys {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			tracked, exists := t.tracked[key]
+			if !exists || tracked.nextEvaluation != index {
+				// the message is not tracked anymore or was scheduled again
+				continue
+			}
+
+			cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(hornet.MessageIDFromMapKey(key)) // meta +1
+			if cachedMsgMeta == nil {
+				// message was pruned
+				delete(t.tracked, key)
+				continue
+			}
+
+			if cachedMsgMeta.Metadata().IsReferenced() {
+				// the referenced update is sent by the event
+				cachedMsgMeta.Release(true) // meta -1
+				delete(t.tracked, key)
+				continue
+			}
+
+			metadata, nextEvaluation, err := t.evaluate(ctx, cachedMsgMeta)
+			if err != nil {
+				return nil, err
+			}
+
+			if tracked.shouldPromote != metadata.GetShouldPromote() || tracked.shouldReattach != metadata.GetShouldReattach() {
+				updates = append(updates, metadata)
+			}
+			t.track(metadata, nextEvaluation)
+		}
+	}
+
+	return updates, nil
+}
+
+func (s *INXTangleServer) ListenToMessageMetadataUpdates(req *structpb.Struct, srv inxtangle.INXTangle_ListenToMessageMetadataUpdatesServer) error {
+	filter := &inxtangle.MessageMetadataFilter{}
+	if err := inxtangle.FromStruct(req, filter); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid filter: %s", err)
+	}
+
+	matcher, err := filter.Decode()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid filter: %s", err)
+	}
+
+	tracker := newMetadataUpdateTracker(matcher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	send := func(metadata *inx.MessageMetadata) bool {
+		if !matcher.Matches(metadata) {
+			return true
+		}
+		if err := srv.Send(metadata); err != nil {
+			Plugin.LogInfof("send error: %v", err)
+			cancel()
+			return false
+		}
+		return true
+	}
+
+	// a single worker is used, so that the updates of a message are sent in the correct order.
+	wp := workerpool.New(func(task workerpool.Task) {
+		defer task.Return(nil)
+
+		switch param := task.Param(0).(type) {
+		case *storage.CachedMetadata:
+			metadata, nextEvaluation, err := tracker.evaluate(ctx, param) // meta pass +1
+			if err != nil {
+				Plugin.LogInfof("error creating message metadata: %v", err)
+				cancel()
+				return
+			}
+			if !send(metadata) {
+				return
+			}
+			if matcher.TracksTipStates() {
+				tracker.track(metadata, nextEvaluation)
+			}
+
+		case milestone.Index:
+			updates, err := tracker.update(ctx, param)
+			if err != nil {
+				Plugin.LogInfof("error updating message metadata: %v", err)
+				cancel()
+				return
+			}
+			for _, metadata := range updates {
+				if !send(metadata) {
+					return
+				}
+			}
+		}
+	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(workerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	submitMetadata := func(cachedMsgMeta *storage.CachedMetadata) {
+		if !matcher.MatchesMessageID(cachedMsgMeta.Metadata().MessageID()) {
+			cachedMsgMeta.Release(true) // meta -1
+			return
+		}
+		if _, added := wp.Submit(cachedMsgMeta); !added {
+			cachedMsgMeta.Release(true) // meta -1
+		}
+	}
+
+	onMessageSolid := events.NewClosure(func(cachedMsgMeta *storage.CachedMetadata) {
+		submitMetadata(cachedMsgMeta)
+	})
+	onMessageReferenced := events.NewClosure(func(cachedMsgMeta *storage.CachedMetadata, _ milestone.Index, _ uint32) {
+		submitMetadata(cachedMsgMeta)
+	})
+	onConfirmedMilestoneIndexChanged := events.NewClosure(func(msIndex milestone.Index) {
+		// the tip score of unreferenced messages changes with the confirmed milestone index
+		wp.Submit(msIndex)
+	})
+
+	wp.Start()
+	deps.Tangle.Events.MessageSolid.Attach(onMessageSolid)
+	deps.Tangle.Events.MessageReferenced.Attach(onMessageReferenced)
+	if matcher.TracksTipStates() {
+		deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Attach(onConfirmedMilestoneIndexChanged)
+	}
+	<-ctx.Done()
+	deps.Tangle.Events.MessageSolid.Detach(onMessageSolid)
+	deps.Tangle.Events.MessageReferenced.Detach(onMessageReferenced)
+	deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Detach(onConfirmedMilestoneIndexChanged)
+	wp.Stop()
+	return ctx.Err()
+}
+
+func (s *INXTangleServer) ReadMilestoneStats(_ context.Context, req *inx.MilestoneRequest) (*structpb.Struct, error) {
+	msIndex, _, err := milestoneIndexAndParentsForRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := deps.Storage.MilestoneStats(msIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read stats of milestone %d: %s", msIndex, err)
+	}
+	if stats == nil {
+		return nil, status.Errorf(codes.NotFound, "stats of milestone %d not found", msIndex)
+	}
+
+	return inxtangle.ToStruct(stats)
+}
+
+func (s *INXTangleServer) ListenToMilestoneStats(_ *inx.NoParams, srv inxtangle.INXTangle_ListenToMilestoneStatsServer) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	wp := workerpool.New(func(task workerpool.Task) {
+		defer task.Return(nil)
+
+		payload, err := inxtangle.ToStruct(task.Param(0).(*storage.MilestoneStats))
+		if err != nil {
+			Plugin.LogInfof("error creating milestone stats: %v", err)
+			cancel()
+			return
+		}
+		if err := srv.Send(payload); err != nil {
+			Plugin.LogInfof("send error: %v", err)
+			cancel()
+		}
+	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(workerQueueSize), workerpool.FlushTasksAtShutdown(true))
+	closure := events.NewClosure(func(stats *storage.MilestoneStats) {
+		wp.Submit(stats)
+	})
+	wp.Start()
+	deps.Tangle.Events.MilestoneStatsStored.Attach(closure)
+	<-ctx.Done()
+	deps.Tangle.Events.MilestoneStatsStored.Detach(closure)
+	wp.Stop()
+	return ctx.Err()
+}
+
+func (s *INXTangleServer) ReadMessageInclusionProof(_ context.Context, messageID *inx.MessageId) (*structpb.Struct, error) {
+	msgID := hornet.MessageIDFromArray(messageID.Unwrap())
+
+	proof, err := whiteflag.InclusionProofForMessage(deps.Storage, msgID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrMessageNotFound):
+			return nil, status.Errorf(codes.NotFound, "message %s not found", msgID.ToHex())
+		case errors.Is(err, whiteflag.ErrMessageNotReferenced):
+			return nil, status.Errorf(codes.FailedPrecondition, "message %s not referenced by a milestone yet", msgID.ToHex())
+		case errors.Is(err, whiteflag.ErrInclusionProofUnavailable):
+			return nil, status.Errorf(codes.NotFound, "failed to create inclusion proof: %s", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to create inclusion proof: %s", err)
+		}
+	}
+
+	return inxtangle.ToStruct(proof)
+}
+
+func (s *INXTangleServer) ValidateTransaction(_ context.Context, transactionBytes *wrapperspb.BytesValue) (*structpb.Struct, error) {
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, status.Error(codes.Unavailable, "node is not synced")
+	}
+
+	// Do not validate here, syntactic errors are part of the validation result
+	transaction := &iotago.Transaction{}
+	if _, err := transaction.Deserialize(transactionBytes.GetValue(), serializer.DeSeriModeNoValidation, deps.ProtocolParameters); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %s", err)
+	}
+
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	validation, err := whiteflag.ValidateTransaction(deps.UTXOManager, transaction, deps.ProtocolParameters, uint32(time.Now().Unix()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to validate transaction: %s", err)
+	}
+
+	return inxtangle.ToStruct(validation)
+}
+
+func (s *INXTangleServer) ComputeStorageDeposit(_ context.Context, outputBytes *wrapperspb.BytesValue) (*structpb.Struct, error) {
+	output, err := utxo.OutputFromBytes(outputBytes.GetValue(), deps.ProtocolParameters)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid output: %s", err)
+	}
+
+	deposit, err := utxo.ComputeStorageDeposit(output, &deps.ProtocolParameters.RentStructure)
+	if err != nil {
+		if errors.Is(err, utxo.ErrStorageDepositNotApplicable) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid output: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to compute storage deposit: %s", err)
+	}
+
+	return inxtangle.ToStruct(deposit)
+}
+
+func keyRangeRequestFromStruct(req *structpb.Struct) (*keymanager.KeyRange, []*iotago.Ed25519Signature, error) {
+	request := &keymanager.KeyRangeAuthorization{}
+	if err := inxtangle.FromStruct(req, request); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid request: %s", err)
+	}
+
+	keyRange, signatures, err := request.Decode()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid request: %s", err)
+	}
+
+	return keyRange, signatures, nil
+}
+
+func keyRanges() (*structpb.Struct, error) {
+	confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex()
+	statuses := deps.KeyManager.KeyRangeStatuses(confirmedMilestoneIndex, deps.KeyRangesExpiryWarningThreshold, deps.MilestonePublicKeyCount)
+
+	return inxtangle.ToStruct(inxtangle.NewKeyRanges(confirmedMilestoneIndex, statuses))
+}
+
+func (s *INXTangleServer) ListKeyRanges(_ context.Context, _ *inx.NoParams) (*structpb.Struct, error) {
+	return keyRanges()
+}
+
+func (s *INXTangleServer) AddKeyRange(_ context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	keyRange, signatures, err := keyRangeRequestFromStruct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deps.KeyManager.AddAuthorizedKeyRange(deps.ProtocolParameters.NetworkID(), keyRange, signatures, deps.SyncManager.ConfirmedMilestoneIndex(), deps.MilestonePublicKeyCount); err != nil {
+		if keymanager.IsKeyRangeRejected(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid key range: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "adding key range failed: %s", err)
+	}
+
+	return keyRanges()
+}
+
+func (s *INXTangleServer) ValidateKeyRange(_ context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	keyRange, signatures, err := keyRangeRequestFromStruct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &inxtangle.KeyRangeValidation{
+		Essence: iotago.EncodeHex(keymanager.KeyRangeEssence(deps.ProtocolParameters.NetworkID(), keyRange)),
+		Valid:   true,
+	}
+
+	if err := deps.KeyManager.ValidateKeyRangeAuthorization(deps.ProtocolParameters.NetworkID(), keyRange, signatures, deps.SyncManager.ConfirmedMilestoneIndex(), deps.MilestonePublicKeyCount); err != nil {
+		result.Valid = false
+		result.Error = err.Error()
+	}
+
+	return inxtangle.ToStruct(result)
+}
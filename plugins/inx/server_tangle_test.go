@@ -0,0 +1,312 @@
+package inx
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/testsuite"
+	"github.com/gohornet/hornet/pkg/testsuite/utils"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	showConfirmationGraphs = false
+	MinPoWScore            = 1.0
+	BelowMaxDepth          = uint16(15)
+)
+
+var (
+	seed1, _ = hex.DecodeString("96d9ff7a79e4b0a5f3e5848ae7867064402da92a62eabb4ebbe463f12d1f3b1aace1775488f51cb1e3a80732a03ef60b111d6833ab605aa9f8faebeb33bbe3d9")
+	seed2, _ = hex.DecodeString("b15209ddc93cbdb600137ea6a8f88cdd7c5d480d5815c9352a0fb5c4e4b86f7151dcb44c2ba635657a2df5a8fd48cb9bab674a9eceea527dbbb254ef8c9f9cd7")
+)
+
+// setupTestEnvironment creates a test environment and sets the dependencies of the INX server to it.
+func setupTestEnvironment(t *testing.T) (*testsuite.TestEnvironment, *utils.HDWallet, *utils.HDWallet) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, BelowMaxDepth, MinPoWScore, showConfirmationGraphs)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	deps = dependencies{
+		SyncManager:                     te.SyncManager(),
+		UTXOManager:                     te.UTXOManager(),
+		Storage:                         te.Storage(),
+		KeyManager:                      keymanager.New(),
+		MilestonePublicKeyCount:         2,
+		KeyRangesExpiryWarningThreshold: 10,
+		ProtocolParameters:              te.ProtocolParameters(),
+	}
+
+	return te, seed1Wallet, seed2Wallet
+}
+
+// issueAndConfirmMilestone confirms a milestone on the given tips and stores
+// the milestone stats and white-flag messages like the solidifier of the node.
+func issueAndConfirmMilestone(te *testsuite.TestEnvironment, tips hornet.MessageIDs) *whiteflag.Confirmation {
+	conf, confStats := te.IssueAndConfirmMilestoneOnTips(tips, false)
+
+	stats := &storage.MilestoneStats{
+		Index:                            confStats.Index,
+		MessagesReferenced:               uint32(confStats.MessagesReferenced),
+		MessagesIncludedWithTransactions: uint32(confStats.MessagesIncludedWithTransactions),
+		ConflictsByReason:                make(map[storage.Conflict]uint32),
+	}
+	require.NoError(te.TestInterface, te.Storage().StoreMilestoneStats(stats))
+
+	whiteFlagMessages, err := storage.NewWhiteFlagMessages(conf.MilestoneIndex, conf.Mutations.MessagesReferenced, conf.Mutations.MessagesIncludedWithTransactions)
+	require.NoError(te.TestInterface, err)
+	require.NoError(te.TestInterface, te.Storage().StoreWhiteFlagMessages(whiteFlagMessages))
+
+	return conf
+}
+
+func TestReadMilestoneStats(t *testing.T) {
+	te, _, _ := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXTangleServer{}
+
+	// the milestones of the setup were confirmed without stats
+	_, err := server.ReadMilestoneStats(context.Background(), &inx.MilestoneRequest{MilestoneIndex: uint32(te.LastMilestoneIndex())})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	conf := issueAndConfirmMilestone(te, te.LastMilestoneParents())
+
+	statsStruct, err := server.ReadMilestoneStats(context.Background(), &inx.MilestoneRequest{MilestoneIndex: uint32(conf.MilestoneIndex)})
+	require.NoError(t, err)
+	stats := &storage.MilestoneStats{}
+	require.NoError(t, inxtangle.FromStruct(statsStruct, stats))
+	require.Equal(t, conf.MilestoneIndex, stats.Index)
+	require.Equal(t, uint32(len(conf.Mutations.MessagesReferenced)), stats.MessagesReferenced)
+
+	// the milestone can also be requested by its ID
+	milestoneID := te.LastMilestoneID()
+	statsStruct, err = server.ReadMilestoneStats(context.Background(), &inx.MilestoneRequest{MilestoneId: inx.NewMilestoneId(milestoneID)})
+	require.NoError(t, err)
+	require.NoError(t, inxtangle.FromStruct(statsStruct, stats))
+	require.Equal(t, conf.MilestoneIndex, stats.Index)
+
+	_, err = server.ReadMilestoneStats(context.Background(), &inx.MilestoneRequest{MilestoneIndex: uint32(conf.MilestoneIndex + 1)})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestReadMessageInclusionProof(t *testing.T) {
+	te, seed1Wallet, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXTangleServer{}
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		Build().
+		Store().
+		BookOnWallets()
+
+	messageB := te.NewMessageBuilder("B").
+		Parents(hornet.MessageIDs{messageA.StoredMessageID()}).
+		BuildTaggedData().
+		Store()
+
+	// the message is not referenced yet
+	_, err := server.ReadMessageInclusionProof(context.Background(), inx.NewMessageId(messageA.StoredMessageID().ToArray()))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	issueAndConfirmMilestone(te, hornet.MessageIDs{messageB.StoredMessageID()})
+
+	proofStruct, err := server.ReadMessageInclusionProof(context.Background(), inx.NewMessageId(messageA.StoredMessageID().ToArray()))
+	require.NoError(t, err)
+	proof := &whiteflag.InclusionProof{}
+	require.NoError(t, inxtangle.FromStruct(proofStruct, proof))
+	require.Equal(t, messageA.StoredMessageID(), proof.MessageID)
+
+	// the transaction of message A mutated the ledger
+	applied, err := whiteflag.VerifyInclusionProof(proof)
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	// message B was referenced without a transaction
+	proofStruct, err = server.ReadMessageInclusionProof(context.Background(), inx.NewMessageId(messageB.StoredMessageID().ToArray()))
+	require.NoError(t, err)
+	require.NoError(t, inxtangle.FromStruct(proofStruct, proof))
+	applied, err = whiteflag.VerifyInclusionProof(proof)
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	// the white-flag messages of the milestone were not stored
+	messageC := te.NewMessageBuilder("C").
+		Parents(te.LastMilestoneParents()).
+		BuildTaggedData().
+		Store()
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageC.StoredMessageID()}, false)
+
+	_, err = server.ReadMessageInclusionProof(context.Background(), inx.NewMessageId(messageC.StoredMessageID().ToArray()))
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.ReadMessageInclusionProof(context.Background(), inx.NewMessageId([32]byte{42}))
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestValidateTransaction(t *testing.T) {
+	te, seed1Wallet, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXTangleServer{}
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		Build()
+
+	transactionBytes, err := messageA.IotaMessage().Payload.(*iotago.Transaction).Serialize(serializer.DeSeriModeNoValidation, te.ProtocolParameters())
+	require.NoError(t, err)
+
+	validationStruct, err := server.ValidateTransaction(context.Background(), wrapperspb.Bytes(transactionBytes))
+	require.NoError(t, err)
+	validation := &whiteflag.TransactionValidation{}
+	require.NoError(t, inxtangle.FromStruct(validationStruct, validation))
+	require.True(t, validation.Valid(), "%v", validation.Errors)
+	require.Equal(t, te.SyncManager().ConfirmedMilestoneIndex(), validation.LedgerIndex)
+
+	messageA.Store().BookOnWallets()
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageA.StoredMessageID()}, false)
+
+	// the input was spent by the milestone
+	validationStruct, err = server.ValidateTransaction(context.Background(), wrapperspb.Bytes(transactionBytes))
+	require.NoError(t, err)
+	validation = &whiteflag.TransactionValidation{}
+	require.NoError(t, inxtangle.FromStruct(validationStruct, validation))
+	require.False(t, validation.Valid())
+	require.EqualValues(t, storage.ConflictInputUTXOAlreadySpent, validation.Conflict)
+
+	_, err = server.ValidateTransaction(context.Background(), wrapperspb.Bytes([]byte{42}))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestComputeStorageDeposit(t *testing.T) {
+	te, _, _ := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXTangleServer{}
+
+	output := te.GenesisOutput.Output()
+	outputBytes, err := output.Serialize(serializer.DeSeriModeNoValidation, te.ProtocolParameters())
+	require.NoError(t, err)
+
+	depositStruct, err := server.ComputeStorageDeposit(context.Background(), wrapperspb.Bytes(outputBytes))
+	require.NoError(t, err)
+	deposit := &utxo.StorageDeposit{}
+	require.NoError(t, inxtangle.FromStruct(depositStruct, deposit))
+	require.Equal(t, output.VBytes(&te.ProtocolParameters().RentStructure, nil), deposit.VBytes)
+	require.Equal(t, utxo.MinStorageDeposit(output, &te.ProtocolParameters().RentStructure), deposit.MinDeposit)
+	require.True(t, deposit.Covered)
+
+	// treasury outputs don't need a storage deposit
+	treasuryBytes, err := (&iotago.TreasuryOutput{Amount: 42}).Serialize(serializer.DeSeriModeNoValidation, te.ProtocolParameters())
+	require.NoError(t, err)
+	_, err = server.ComputeStorageDeposit(context.Background(), wrapperspb.Bytes(treasuryBytes))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.ComputeStorageDeposit(context.Background(), &wrapperspb.BytesValue{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestKeyRanges(t *testing.T) {
+	te, _, _ := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXTangleServer{}
+
+	pubKey1, privKey1, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	pubKey2, privKey2, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	pubKey3, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	deps.KeyManager.AddKeyRange(pubKey1, 0, 100)
+	deps.KeyManager.AddKeyRange(pubKey2, 0, 100)
+
+	keyRangesStruct, err := server.ListKeyRanges(context.Background(), &inx.NoParams{})
+	require.NoError(t, err)
+	keyRanges := &inxtangle.KeyRanges{}
+	require.NoError(t, inxtangle.FromStruct(keyRangesStruct, keyRanges))
+	require.Equal(t, te.SyncManager().ConfirmedMilestoneIndex(), keyRanges.ConfirmedMilestoneIndex)
+	require.Len(t, keyRanges.KeyRanges, 2)
+
+	var msPubKey3 iotago.MilestonePublicKey
+	copy(msPubKey3[:], pubKey3)
+
+	keyRange := &keymanager.KeyRange{PublicKey: msPubKey3, StartIndex: 80, EndIndex: 0}
+
+	authorizationStruct := func(privateKeys ...ed25519.PrivateKey) *structpb.Struct {
+		authorization, err := inxtangle.ToStruct(keymanager.NewKeyRangeAuthorization(keyRange, keymanager.SignKeyRange(te.ProtocolParameters().NetworkID(), keyRange, privateKeys)))
+		require.NoError(t, err)
+		return authorization
+	}
+
+	// not enough signatures
+	validationStruct, err := server.ValidateKeyRange(context.Background(), authorizationStruct(privKey1))
+	require.NoError(t, err)
+	validation := &inxtangle.KeyRangeValidation{}
+	require.NoError(t, inxtangle.FromStruct(validationStruct, validation))
+	require.False(t, validation.Valid)
+	require.NotEmpty(t, validation.Error)
+	require.Equal(t, iotago.EncodeHex(keymanager.KeyRangeEssence(te.ProtocolParameters().NetworkID(), keyRange)), validation.Essence)
+
+	_, err = server.AddKeyRange(context.Background(), authorizationStruct(privKey1))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	validationStruct, err = server.ValidateKeyRange(context.Background(), authorizationStruct(privKey1, privKey2))
+	require.NoError(t, err)
+	validation = &inxtangle.KeyRangeValidation{}
+	require.NoError(t, inxtangle.FromStruct(validationStruct, validation))
+	require.True(t, validation.Valid, validation.Error)
+
+	// validating doesn't add the key range
+	require.Len(t, deps.KeyManager.KeyRanges(), 2)
+
+	keyRangesStruct, err = server.AddKeyRange(context.Background(), authorizationStruct(privKey1, privKey2))
+	require.NoError(t, err)
+	keyRanges = &inxtangle.KeyRanges{}
+	require.NoError(t, inxtangle.FromStruct(keyRangesStruct, keyRanges))
+	require.Len(t, keyRanges.KeyRanges, 3)
+
+	var added *inxtangle.KeyRange
+	for _, kr := range keyRanges.KeyRanges {
+		if kr.PublicKey == iotago.EncodeHex(pubKey3) {
+			added = kr
+		}
+	}
+	require.NotNil(t, added)
+	require.True(t, added.Authorized)
+	require.Equal(t, milestone.Index(80), added.StartIndex)
+
+	_, err = server.AddKeyRange(context.Background(), &structpb.Struct{Fields: map[string]*structpb.Value{"publicKey": structpb.NewStringValue("0x1234")}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
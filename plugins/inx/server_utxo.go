@@ -1,12 +1,17 @@
 package inx
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/gohornet/hornet/pkg/inxtangle"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/iotaledger/hive.go/events"
@@ -88,6 +93,122 @@ func NewTreasuryUpdate(index milestone.Index, created *utxo.TreasuryOutput, cons
 	return u, nil
 }
 
+const (
+	// MetadataKeyLedgerIndex is the header key that contains the ledger index at the start of a ledger stream.
+	MetadataKeyLedgerIndex = "inx-ledger-index"
+	// MetadataKeyLedgerMilestoneID is the header key that contains the milestone ID of the ledger index at the start of a ledger stream.
+	// The commitments of the milestones of the ledger updates are appended to each update (see inxtangle.LedgerUpdateCommitment).
+	MetadataKeyLedgerMilestoneID = "inx-ledger-milestone-id"
+	// MetadataKeyPruningIndex is the header key that contains the pruning index at the start of a ledger stream.
+	MetadataKeyPruningIndex = "inx-pruning-index"
+	// MetadataKeyLedgerCursorMilestoneID is the metadata key an extension can use to pass the milestone ID of the
+	// last ledger update it applied ("startMilestoneIndex" - 1), so the node can verify that both are on the same ledger.
+	MetadataKeyLedgerCursorMilestoneID = "inx-ledger-cursor-milestone-id"
+
+	// ErrorInfoDomain is the domain of the error details returned by the INX server.
+	ErrorInfoDomain = "inx.hornet"
+	// ErrorReasonLedgerGap signals that the requested ledger updates are not available anymore.
+	// The extension needs to resync with the current unspent outputs (ReadUnspentOutputs).
+	ErrorReasonLedgerGap = "LEDGER_GAP"
+	// ErrorReasonLedgerCursorAhead signals that the requested ledger updates are newer than the ledger of the node.
+	ErrorReasonLedgerCursorAhead = "LEDGER_CURSOR_AHEAD"
+	// ErrorReasonLedgerCursorMismatch signals that the milestone ID of the cursor does not match the ledger of the node.
+	// The extension needs to resync with the current unspent outputs (ReadUnspentOutputs).
+	ErrorReasonLedgerCursorMismatch = "LEDGER_CURSOR_MISMATCH"
+)
+
+// ledgerStatusError returns a status error with the given reason and the indexes in the error details,
+// so that extensions can distinguish a gap in the ledger updates from other errors.
+func ledgerStatusError(code codes.Code, reason string, startIndex milestone.Index, ledgerIndex milestone.Index, format string, args ...interface{}) error {
+	st := status.Newf(code, format, args...)
+
+	stWithDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorInfoDomain,
+		Metadata: map[string]string{
+			"startMilestoneIndex": strconv.FormatUint(uint64(startIndex), 10),
+			"ledgerIndex":         strconv.FormatUint(uint64(ledgerIndex), 10),
+			"pruningIndex":        strconv.FormatUint(uint64(deps.Storage.SnapshotInfo().PruningIndex), 10),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+func ledgerGapError(startIndex milestone.Index, ledgerIndex milestone.Index, format string, args ...interface{}) error {
+	return ledgerStatusError(codes.OutOfRange, ErrorReasonLedgerGap, startIndex, ledgerIndex, format, args...)
+}
+
+// verifyLedgerCursor checks if the ledger updates starting at the given index can be streamed to the extension.
+// the ledger must be locked outside.
+func verifyLedgerCursor(ctx context.Context, startIndex milestone.Index, ledgerIndex milestone.Index) error {
+	if startIndex > ledgerIndex+1 {
+		return ledgerStatusError(codes.FailedPrecondition, ErrorReasonLedgerCursorAhead, startIndex, ledgerIndex, "given startMilestoneIndex %d is newer than the current ledgerIndex %d", startIndex, ledgerIndex)
+	}
+
+	pruningIndex := deps.Storage.SnapshotInfo().PruningIndex
+	if startIndex <= pruningIndex {
+		return ledgerGapError(startIndex, ledgerIndex, "given startMilestoneIndex %d is older than the current pruningIndex %d", startIndex, pruningIndex)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(MetadataKeyLedgerCursorMilestoneID)) == 0 || startIndex <= 1 {
+		return nil
+	}
+
+	cursorMilestoneIDBytes, err := iotago.DecodeHex(md.Get(MetadataKeyLedgerCursorMilestoneID)[0])
+	if err != nil || len(cursorMilestoneIDBytes) != iotago.MilestoneIDLength {
+		return status.Errorf(codes.InvalidArgument, "invalid %s", MetadataKeyLedgerCursorMilestoneID)
+	}
+
+	cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(startIndex - 1) // milestone +1
+	if cachedMilestone == nil {
+		// the milestone was already pruned, the milestone diffs are checked while streaming
+		return nil
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	milestoneID := cachedMilestone.Milestone().MilestoneID()
+	if !bytes.Equal(milestoneID[:], cursorMilestoneIDBytes) {
+		return ledgerStatusError(codes.FailedPrecondition, ErrorReasonLedgerCursorMismatch, startIndex, ledgerIndex, "milestone ID of milestone index %d does not match, expected: %s", startIndex-1, iotago.EncodeHex(milestoneID[:]))
+	}
+
+	return nil
+}
+
+// ledgerCommitment returns the commitment of the milestone with the given index, or nil if the milestone was not found.
+func ledgerCommitment(index milestone.Index) *inxtangle.LedgerCommitment {
+	cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(index) // milestone +1
+	if cachedMilestone == nil {
+		return nil
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	return &inxtangle.LedgerCommitment{
+		MilestoneID:       cachedMilestone.Milestone().MilestoneID(),
+		AppliedMerkleRoot: cachedMilestone.Milestone().Milestone().AppliedMerkleRoot,
+	}
+}
+
+// ledgerStateMetadata returns the header of a ledger stream.
+// the ledger must be locked outside.
+func ledgerStateMetadata(ledgerIndex milestone.Index) metadata.MD {
+	md := metadata.Pairs(
+		MetadataKeyLedgerIndex, strconv.FormatUint(uint64(ledgerIndex), 10),
+		MetadataKeyPruningIndex, strconv.FormatUint(uint64(deps.Storage.SnapshotInfo().PruningIndex), 10),
+	)
+
+	cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(ledgerIndex) // milestone +1
+	if cachedMilestone != nil {
+		defer cachedMilestone.Release(true) // milestone -1
+		md.Set(MetadataKeyLedgerMilestoneID, cachedMilestone.Milestone().MilestoneIDHex())
+	}
+
+	return md
+}
+
 func (s *INXServer) ReadOutput(_ context.Context, id *inx.OutputId) (*inx.OutputResponse, error) {
 	deps.UTXOManager.ReadLockLedger()
 	defer deps.UTXOManager.ReadUnlockLedger()
@@ -146,6 +267,11 @@ func (s *INXServer) ReadUnspentOutputs(_ *inx.NoParams, srv inx.INX_ReadUnspentO
 		return err
 	}
 
+	// the ledger index is also sent in the header, so that extensions are able to resync with an empty ledger
+	if err := srv.SendHeader(ledgerStateMetadata(ledgerIndex)); err != nil {
+		return fmt.Errorf("send error: %w", err)
+	}
+
 	var innerErr error
 	err = deps.UTXOManager.ForEachUnspentOutput(func(output *utxo.Output) bool {
 		ledgerOutput, err := NewLedgerOutput(output)
@@ -171,66 +297,124 @@ func (s *INXServer) ReadUnspentOutputs(_ *inx.NoParams, srv inx.INX_ReadUnspentO
 
 func (s *INXServer) ListenToLedgerUpdates(req *inx.LedgerRequest, srv inx.INX_ListenToLedgerUpdatesServer) error {
 
-	sendPreviousMilestoneDiffs := func(startIndex milestone.Index) error {
-		if startIndex > 0 {
-			deps.UTXOManager.ReadLockLedger()
-			defer deps.UTXOManager.ReadUnlockLedger()
-
-			// Stream all available milestone diffs first
-			pruningIndex := deps.Storage.SnapshotInfo().PruningIndex
-			if startIndex <= pruningIndex {
-				return status.Errorf(codes.InvalidArgument, "given startMilestoneIndex %d is older than the current pruningIndex %d", startIndex, pruningIndex)
-			}
-
-			ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
-			if err != nil {
-				return status.Error(codes.Unavailable, "error accessing the UTXO ledger")
-			}
-			for currentIndex := startIndex; currentIndex <= ledgerIndex; currentIndex++ {
-				msDiff, err := deps.UTXOManager.MilestoneDiffWithoutLocking(currentIndex)
-				if err != nil {
-					return status.Errorf(codes.NotFound, "ledger update for milestoneIndex %d not found", currentIndex)
-				}
-				payload, err := NewLedgerUpdate(msDiff.Index, msDiff.Outputs, msDiff.Spents)
-				if err != nil {
-					return err
-				}
-				if err := srv.Send(payload); err != nil {
-					return fmt.Errorf("send error: %w", err)
-				}
-			}
-		}
-		return nil
-	}
-
-	if err := sendPreviousMilestoneDiffs(milestone.Index(req.GetStartMilestoneIndex())); err != nil {
-		return err
-	}
+	// nextIndex is the cursor of the stream, the milestone index of the next ledger update that is sent.
+	var nextIndex milestone.Index
+	var streamErr error
 
 	ctx, cancel := context.WithCancel(context.Background())
+
+	// a single worker is used to keep the order of the ledger updates.
 	wp := workerpool.New(func(task workerpool.Task) {
+		defer task.Return(nil)
+
 		index := task.Param(0).(milestone.Index)
 		newOutputs := task.Param(1).(utxo.Outputs)
 		newSpents := task.Param(2).(utxo.Spents)
+
+		if index < nextIndex {
+			// already sent
+			return
+		}
+
+		if index > nextIndex {
+			// this should never happen, but we don't want the extension to silently miss ledger updates.
+			streamErr = ledgerGapError(nextIndex, index, "ledger update for milestone index %d was skipped", nextIndex)
+			Plugin.LogWarnf("stopping ledger update stream: %s", streamErr)
+			cancel()
+			return
+		}
+
 		payload, err := NewLedgerUpdate(index, newOutputs, newSpents)
 		if err != nil {
 			Plugin.LogInfof("send error: %v", err)
 			cancel()
+			return
+		}
+
+		commitment := ledgerCommitment(index)
+		if commitment == nil {
+			// this should never happen, the milestone is stored before it is applied to the ledger.
+			streamErr = status.Errorf(codes.Internal, "milestone for ledger update %d not found", index)
+			Plugin.LogWarnf("stopping ledger update stream: %s", streamErr)
+			cancel()
+			return
 		}
+		inxtangle.SetLedgerUpdateCommitment(payload, commitment)
+
 		if err := srv.Send(payload); err != nil {
 			Plugin.LogInfof("send error: %v", err)
 			cancel()
+			return
 		}
-		task.Return(nil)
-	})
+		nextIndex = index + 1
+	}, workerpool.WorkerCount(1))
+
 	closure := events.NewClosure(func(index milestone.Index, newOutputs utxo.Outputs, newSpents utxo.Spents) {
 		wp.Submit(index, newOutputs, newSpents)
 	})
+
+	sendPreviousMilestoneDiffs := func(startIndex milestone.Index) error {
+		// the ledger updates are triggered while the ledger is locked, so holding the lock while
+		// attaching to the event guarantees that no ledger update is missed or sent twice.
+		deps.UTXOManager.ReadLockLedger()
+		defer deps.UTXOManager.ReadUnlockLedger()
+
+		ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+		if err != nil {
+			return status.Error(codes.Unavailable, "error accessing the UTXO ledger")
+		}
+
+		if startIndex == 0 {
+			// only stream new ledger updates
+			startIndex = ledgerIndex + 1
+		}
+
+		if err := verifyLedgerCursor(srv.Context(), startIndex, ledgerIndex); err != nil {
+			return err
+		}
+
+		if err := srv.SendHeader(ledgerStateMetadata(ledgerIndex)); err != nil {
+			return fmt.Errorf("send error: %w", err)
+		}
+
+		// Stream all available milestone diffs first
+		for currentIndex := startIndex; currentIndex <= ledgerIndex; currentIndex++ {
+			msDiff, err := deps.UTXOManager.MilestoneDiffWithoutLocking(currentIndex)
+			if err != nil {
+				// the milestone diff was pruned in the meantime
+				return ledgerGapError(currentIndex, ledgerIndex, "ledger update for milestone index %d not found", currentIndex)
+			}
+			payload, err := NewLedgerUpdate(msDiff.Index, msDiff.Outputs, msDiff.Spents)
+			if err != nil {
+				return err
+			}
+			commitment := ledgerCommitment(currentIndex)
+			if commitment == nil {
+				// the milestone was pruned in the meantime
+				return ledgerGapError(currentIndex, ledgerIndex, "milestone for ledger update %d not found", currentIndex)
+			}
+			inxtangle.SetLedgerUpdateCommitment(payload, commitment)
+			if err := srv.Send(payload); err != nil {
+				return fmt.Errorf("send error: %w", err)
+			}
+		}
+
+		nextIndex = ledgerIndex + 1
+		deps.Tangle.Events.LedgerUpdated.Attach(closure)
+		return nil
+	}
+
+	if err := sendPreviousMilestoneDiffs(milestone.Index(req.GetStartMilestoneIndex())); err != nil {
+		return err
+	}
+
 	wp.Start()
-	deps.Tangle.Events.LedgerUpdated.Attach(closure)
 	<-ctx.Done()
 	deps.Tangle.Events.LedgerUpdated.Detach(closure)
 	wp.Stop()
+	if streamErr != nil {
+		return streamErr
+	}
 	return ctx.Err()
 }
 
@@ -249,7 +433,7 @@ func (s *INXServer) ListenToTreasuryUpdates(req *inx.LedgerRequest, srv inx.INX_
 			// Stream all available milestone diffs first
 			pruningIndex := deps.Storage.SnapshotInfo().PruningIndex
 			if startIndex <= pruningIndex {
-				return status.Errorf(codes.InvalidArgument, "given startMilestoneIndex %d is older than the current pruningIndex %d", startIndex, pruningIndex)
+				return ledgerGapError(startIndex, ledgerIndex, "given startMilestoneIndex %d is older than the current pruningIndex %d", startIndex, pruningIndex)
 			}
 
 			for currentIndex := startIndex; currentIndex <= ledgerIndex; currentIndex++ {
@@ -0,0 +1,254 @@
+package inx
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gohornet/hornet/pkg/inxtangle"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/testsuite"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// ledgerUpdatesServer collects the header and the ledger updates of a ListenToLedgerUpdates stream.
+type ledgerUpdatesServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	header  metadata.MD
+	updates chan *inx.LedgerUpdate
+}
+
+func newLedgerUpdatesServer(ctx context.Context) *ledgerUpdatesServer {
+	return &ledgerUpdatesServer{
+		ctx:     ctx,
+		updates: make(chan *inx.LedgerUpdate, 100),
+	}
+}
+
+func (s *ledgerUpdatesServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ledgerUpdatesServer) SendHeader(md metadata.MD) error {
+	s.header = md
+	return nil
+}
+
+func (s *ledgerUpdatesServer) Send(update *inx.LedgerUpdate) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	s.updates <- update
+	return nil
+}
+
+func (s *ledgerUpdatesServer) receive(t *testing.T) *inx.LedgerUpdate {
+	select {
+	case update := <-s.updates:
+		return update
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no ledger update received")
+		return nil
+	}
+}
+
+// setupLedgerTestEnvironment creates a test environment that triggers the ledger updates of the confirmed milestones.
+func setupLedgerTestEnvironment(t *testing.T) (*testsuite.TestEnvironment, milestone.Index) {
+
+	cfg := configuration.New()
+	require.NoError(t, cfg.Set("logger.disableStacktrace", true))
+
+	// no need to check the error, since the global logger could already be initialized
+	_ = logger.InitGlobalLogger(cfg)
+
+	te, _, _ := setupTestEnvironment(t)
+
+	deps.Tangle = &tangle.Tangle{
+		Events: &tangle.Events{
+			LedgerUpdated: events.NewEvent(tangle.LedgerUpdatedCaller),
+		},
+	}
+	te.ConfigureUTXOCallbacks(func(index milestone.Index, newOutputs utxo.Outputs, newSpents utxo.Spents) {
+		deps.Tangle.Events.LedgerUpdated.Trigger(index, newOutputs, newSpents)
+	})
+
+	ledgerIndex, err := te.UTXOManager().ReadLedgerIndex()
+	require.NoError(t, err)
+
+	return te, ledgerIndex
+}
+
+// setPruningIndex sets the pruning index of the snapshot info.
+func setPruningIndex(t *testing.T, te *testsuite.TestEnvironment, pruningIndex milestone.Index) {
+	info := te.Storage().SnapshotInfo()
+	require.NoError(t, te.Storage().SetSnapshotMilestone(info.NetworkID, info.SnapshotIndex, info.EntryPointIndex, pruningIndex, info.Timestamp))
+}
+
+func milestoneIDForIndex(t *testing.T, te *testsuite.TestEnvironment, msIndex milestone.Index) iotago.MilestoneID {
+	cachedMilestone := te.Storage().CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+	require.NotNil(t, cachedMilestone)
+	defer cachedMilestone.Release(true) // milestone -1
+
+	return cachedMilestone.Milestone().MilestoneID()
+}
+
+// requireLedgerUpdate checks the milestone index and the commitment of a ledger update.
+func requireLedgerUpdate(t *testing.T, te *testsuite.TestEnvironment, update *inx.LedgerUpdate, msIndex milestone.Index) {
+	require.Equal(t, uint32(msIndex), update.GetMilestoneIndex())
+
+	cachedMilestone := te.Storage().CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+	require.NotNil(t, cachedMilestone)
+	defer cachedMilestone.Release(true) // milestone -1
+
+	commitment, err := inxtangle.LedgerUpdateCommitment(update)
+	require.NoError(t, err)
+	require.Equal(t, cachedMilestone.Milestone().MilestoneID(), commitment.MilestoneID)
+	require.Equal(t, cachedMilestone.Milestone().Milestone().AppliedMerkleRoot, commitment.AppliedMerkleRoot)
+}
+
+// requireLedgerError checks the status code and the error details of a ledger stream error.
+func requireLedgerError(t *testing.T, err error, code codes.Code, reason string, startIndex milestone.Index) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, ErrorInfoDomain, errorInfo.GetDomain())
+	require.Equal(t, reason, errorInfo.GetReason())
+	require.Equal(t, strconv.FormatUint(uint64(startIndex), 10), errorInfo.GetMetadata()["startMilestoneIndex"])
+}
+
+func cursorContext(milestoneID string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKeyLedgerCursorMilestoneID, milestoneID))
+}
+
+func TestVerifyLedgerCursor(t *testing.T) {
+	te, ledgerIndex := setupLedgerTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	// the next ledger update is the newest one that can be requested
+	require.NoError(t, verifyLedgerCursor(context.Background(), ledgerIndex+1, ledgerIndex))
+
+	err := verifyLedgerCursor(context.Background(), ledgerIndex+2, ledgerIndex)
+	requireLedgerError(t, err, codes.FailedPrecondition, ErrorReasonLedgerCursorAhead, ledgerIndex+2)
+
+	// the milestone ID of the cursor matches the last applied milestone
+	lastAppliedMilestoneID := milestoneIDForIndex(t, te, ledgerIndex-1)
+	require.NoError(t, verifyLedgerCursor(cursorContext(iotago.EncodeHex(lastAppliedMilestoneID[:])), ledgerIndex, ledgerIndex))
+
+	otherMilestoneID := milestoneIDForIndex(t, te, ledgerIndex)
+	err = verifyLedgerCursor(cursorContext(iotago.EncodeHex(otherMilestoneID[:])), ledgerIndex, ledgerIndex)
+	requireLedgerError(t, err, codes.FailedPrecondition, ErrorReasonLedgerCursorMismatch, ledgerIndex)
+
+	err = verifyLedgerCursor(cursorContext("0x1234"), ledgerIndex, ledgerIndex)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the ledger updates up to the pruning index are not available anymore
+	setPruningIndex(t, te, 1)
+
+	err = verifyLedgerCursor(context.Background(), 1, ledgerIndex)
+	requireLedgerError(t, err, codes.OutOfRange, ErrorReasonLedgerGap, 1)
+	require.NoError(t, verifyLedgerCursor(context.Background(), 2, ledgerIndex))
+}
+
+func TestLedgerStateMetadata(t *testing.T) {
+	te, ledgerIndex := setupLedgerTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	setPruningIndex(t, te, 1)
+
+	md := ledgerStateMetadata(ledgerIndex)
+	require.Equal(t, []string{strconv.FormatUint(uint64(ledgerIndex), 10)}, md.Get(MetadataKeyLedgerIndex))
+	require.Equal(t, []string{"1"}, md.Get(MetadataKeyPruningIndex))
+
+	milestoneID := milestoneIDForIndex(t, te, ledgerIndex)
+	require.Equal(t, []string{iotago.EncodeHex(milestoneID[:])}, md.Get(MetadataKeyLedgerMilestoneID))
+
+	// the milestone ID is omitted if the milestone is not available
+	md = ledgerStateMetadata(ledgerIndex + 1)
+	require.Empty(t, md.Get(MetadataKeyLedgerMilestoneID))
+}
+
+func TestListenToLedgerUpdates(t *testing.T) {
+	te, ledgerIndex := setupLedgerTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXServer{}
+
+	srv := newLedgerUpdatesServer(context.Background())
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- server.ListenToLedgerUpdates(&inx.LedgerRequest{StartMilestoneIndex: 2}, srv)
+	}()
+
+	// the missed ledger updates are replayed from the milestone diffs
+	for msIndex := milestone.Index(2); msIndex <= ledgerIndex; msIndex++ {
+		requireLedgerUpdate(t, te, srv.receive(t), msIndex)
+	}
+	require.Equal(t, []string{strconv.FormatUint(uint64(ledgerIndex), 10)}, srv.header.Get(MetadataKeyLedgerIndex))
+
+	// new ledger updates follow the replayed ones
+	te.IssueAndConfirmMilestoneOnTips(nil, false)
+	requireLedgerUpdate(t, te, srv.receive(t), ledgerIndex+1)
+
+	// ledger updates that were already sent are skipped
+	deps.Tangle.Events.LedgerUpdated.Trigger(ledgerIndex, utxo.Outputs{}, utxo.Spents{})
+
+	// the stream fails instead of silently skipping a ledger update
+	deps.Tangle.Events.LedgerUpdated.Trigger(ledgerIndex+3, utxo.Outputs{}, utxo.Spents{})
+
+	select {
+	case err := <-streamErr:
+		requireLedgerError(t, err, codes.OutOfRange, ErrorReasonLedgerGap, ledgerIndex+2)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the stream was not stopped")
+	}
+	require.Empty(t, srv.updates)
+}
+
+func TestListenToLedgerUpdatesCursor(t *testing.T) {
+	te, ledgerIndex := setupLedgerTestEnvironment(t)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	server := &INXServer{}
+
+	err := server.ListenToLedgerUpdates(&inx.LedgerRequest{StartMilestoneIndex: uint32(ledgerIndex + 2)}, newLedgerUpdatesServer(context.Background()))
+	requireLedgerError(t, err, codes.FailedPrecondition, ErrorReasonLedgerCursorAhead, ledgerIndex+2)
+
+	otherMilestoneID := milestoneIDForIndex(t, te, ledgerIndex)
+	err = server.ListenToLedgerUpdates(&inx.LedgerRequest{StartMilestoneIndex: uint32(ledgerIndex)}, newLedgerUpdatesServer(cursorContext(iotago.EncodeHex(otherMilestoneID[:]))))
+	requireLedgerError(t, err, codes.FailedPrecondition, ErrorReasonLedgerCursorMismatch, ledgerIndex)
+
+	// the extension needs to resync after the requested ledger updates were pruned
+	setPruningIndex(t, te, 2)
+
+	err = server.ListenToLedgerUpdates(&inx.LedgerRequest{StartMilestoneIndex: 2}, newLedgerUpdatesServer(context.Background()))
+	requireLedgerError(t, err, codes.OutOfRange, ErrorReasonLedgerGap, 2)
+
+	// a milestone diff that was pruned before the pruning index was updated is a gap as well
+	te.UTXOManager().WriteLockLedger()
+	require.NoError(t, te.UTXOManager().PruneMilestoneIndexWithoutLocking(ledgerIndex, false))
+	te.UTXOManager().WriteUnlockLedger()
+
+	srv := newLedgerUpdatesServer(context.Background())
+	err = server.ListenToLedgerUpdates(&inx.LedgerRequest{StartMilestoneIndex: uint32(ledgerIndex)}, srv)
+	requireLedgerError(t, err, codes.OutOfRange, ErrorReasonLedgerGap, ledgerIndex)
+	require.Empty(t, srv.updates)
+}
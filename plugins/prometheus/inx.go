@@ -12,6 +12,10 @@ var (
 	inxPoWCompletedCount prometheus.Gauge
 	inxPoWMessageSizes   prometheus.Histogram
 	inxPoWDurations      prometheus.Histogram
+
+	inxExtensionsCount        prometheus.Gauge
+	inxExtensionActiveStreams *prometheus.GaugeVec
+	inxExtensionRoutes        *prometheus.GaugeVec
 )
 
 func configureINX() {
@@ -55,6 +59,62 @@ func configureINX() {
 	addCollect(collectINX)
 }
 
+func configureINXExtensions() {
+
+	inxExtensionsCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "inx",
+			Name:      "extensions_count",
+			Help:      "The amount of connected INX extensions.",
+		},
+	)
+
+	inxExtensionActiveStreams = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "inx",
+			Name:      "extension_active_streams",
+			Help:      "The amount of open streams by INX extension.",
+		},
+		[]string{"id", "name", "version"},
+	)
+
+	inxExtensionRoutes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "inx",
+			Name:      "extension_routes",
+			Help:      "The amount of registered REST API proxy routes by INX extension.",
+		},
+		[]string{"id", "name", "version"},
+	)
+
+	registry.MustRegister(inxExtensionsCount)
+	registry.MustRegister(inxExtensionActiveStreams)
+	registry.MustRegister(inxExtensionRoutes)
+
+	addCollect(collectINXExtensions)
+}
+
 func collectINX() {
 	inxPoWCompletedCount.Set(float64(deps.INXMetrics.PoWCompletedCounter.Load()))
 }
+
+func collectINXExtensions() {
+	inxExtensionActiveStreams.Reset()
+	inxExtensionRoutes.Reset()
+
+	extensions := deps.INXRegistry.Extensions()
+	inxExtensionsCount.Set(float64(len(extensions)))
+
+	for _, extension := range extensions {
+		labels := prometheus.Labels{
+			"id":      extension.ID,
+			"name":    extension.Name,
+			"version": extension.Version,
+		}
+		inxExtensionActiveStreams.With(labels).Set(float64(extension.ActiveStreams))
+		inxExtensionRoutes.With(labels).Set(float64(len(extension.Routes)))
+	}
+}
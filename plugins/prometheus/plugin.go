@@ -19,6 +19,7 @@ import (
 	coreDatabase "github.com/gohornet/hornet/core/database"
 	"github.com/gohornet/hornet/pkg/daemon"
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/inxregistry"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/migrator"
 	"github.com/gohornet/hornet/pkg/model/storage"
@@ -80,8 +81,9 @@ type dependencies struct {
 	MessageProcessor *gossip.MessageProcessor
 	TipSelector      *tipselect.TipSelector `optional:"true"`
 	SnapshotManager  *snapshot.SnapshotManager
-	PrometheusEcho   *echo.Echo     `name:"prometheusEcho"`
-	INXServer        *inx.INXServer `optional:"true"`
+	PrometheusEcho   *echo.Echo            `name:"prometheusEcho"`
+	INXServer        *inx.INXServer        `optional:"true"`
+	INXRegistry      *inxregistry.Registry `optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -127,6 +129,9 @@ func configure() error {
 	if ParamsPrometheus.INXMetrics && deps.INXMetrics != nil {
 		configureINX()
 	}
+	if ParamsPrometheus.INXMetrics && deps.INXRegistry != nil {
+		configureINXExtensions()
+	}
 	if ParamsPrometheus.INXMetrics && deps.INXServer != nil {
 		deps.INXServer.ConfigurePrometheus()
 		registry.MustRegister(grpc_prometheus.DefaultServerMetrics)
@@ -28,6 +28,18 @@ type ParametersReceipts struct {
 			Timeout time.Duration `default:"5s" usage:"timeout of API calls"`
 		} `name:"api"`
 
+		Archive struct {
+			// CfgReceiptsValidatorArchivePath configures the path to a legacy archive file to validate receipts offline.
+			Path string `default:"" usage:"path to a legacy archive file containing the white-flag confirmation data (replaces the legacy node API if set)"`
+		}
+
+		Cache struct {
+			// CfgReceiptsValidatorCacheEnabled configures whether white-flag confirmation data fetched from the legacy node API is cached locally.
+			Enabled bool `default:"false" usage:"whether to cache the white-flag confirmation data fetched from the legacy node API"`
+			// CfgReceiptsValidatorCachePath configures the path to the cache folder.
+			Path string `default:"legacycache" usage:"path to the white-flag confirmation cache folder"`
+		}
+
 		Coordinator struct {
 			// Address configures the address of the legacy coordinator.
 			Address string `default:"UDYXTZBE9GZGPM9SSQV9LTZNDLJIZMPUVVXYXFYVBLIEUHLSEWFTKZZLXYRHHWVQV9MNNX9KZC9D9UZWZ" usage:"address of the legacy coordinator"`
@@ -43,15 +43,8 @@ type dependencies struct {
 func provide(c *dig.Container) error {
 
 	if err := c.Provide(func() *migrator.Validator {
-		iotaAPI, err := api.ComposeAPI(api.HTTPClientSettings{
-			URI:    ParamsReceipts.Validator.API.Address,
-			Client: &http.Client{Timeout: ParamsReceipts.Validator.API.Timeout},
-		})
-		if err != nil {
-			Plugin.LogPanicf("failed to initialize API: %s", err)
-		}
 		return migrator.NewValidator(
-			iotaAPI,
+			legacyAPI(),
 			ParamsReceipts.Validator.Coordinator.Address,
 			ParamsReceipts.Validator.Coordinator.MerkleTreeDepth,
 		)
@@ -81,11 +74,49 @@ func provide(c *dig.Container) error {
 	return nil
 }
 
+// legacyAPI returns the source of the white-flag confirmation data used to validate receipts.
+func legacyAPI() migrator.LegacyAPI {
+
+	if ParamsReceipts.Validator.Archive.Path != "" {
+		archive, err := migrator.ReadLegacyArchive(ParamsReceipts.Validator.Archive.Path)
+		if err != nil {
+			Plugin.LogPanicf("failed to load legacy archive: %s", err)
+		}
+		return migrator.NewArchiveAPI(archive)
+	}
+
+	iotaAPI, err := api.ComposeAPI(api.HTTPClientSettings{
+		URI:    ParamsReceipts.Validator.API.Address,
+		Client: &http.Client{Timeout: ParamsReceipts.Validator.API.Timeout},
+	})
+	if err != nil {
+		Plugin.LogPanicf("failed to initialize API: %s", err)
+	}
+
+	if !ParamsReceipts.Validator.Cache.Enabled {
+		return iotaAPI
+	}
+
+	cachingAPI, err := migrator.NewCachingAPI(iotaAPI, ParamsReceipts.Validator.Cache.Path)
+	if err != nil {
+		Plugin.LogPanicf("failed to initialize API cache: %s", err)
+	}
+	return cachingAPI
+}
+
+// validationSource returns a description of the source used to validate receipts.
+func validationSource() string {
+	if ParamsReceipts.Validator.Archive.Path != "" {
+		return ParamsReceipts.Validator.Archive.Path
+	}
+	return ParamsReceipts.Validator.API.Address
+}
+
 func configure() error {
 
 	deps.Tangle.Events.NewReceipt.Attach(events.NewClosure(func(r *iotago.ReceiptMilestoneOpt) {
 		if deps.ReceiptService.ValidationEnabled {
-			Plugin.LogInfof("receipt passed validation against %s", ParamsReceipts.Validator.API.Address)
+			Plugin.LogInfof("receipt passed validation against %s", validationSource())
 		}
 		Plugin.LogInfof("new receipt processed (migrated_at %d, final %v, entries %d),", r.MigratedAt, r.Final, len(r.Funds))
 	}))
@@ -1,6 +1,7 @@
 package restapi
 
 import (
+	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
@@ -8,6 +9,14 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/gohornet/hornet/pkg/jwt"
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+var (
+	// the compiled routes that are exposed by the API.
+	routesLock      syncutils.RWMutex
+	publicRoutes    []*regexp.Regexp
+	protectedRoutes []*regexp.Regexp
 )
 
 func compileRouteAsRegex(route string) *regexp.Regexp {
@@ -23,25 +32,49 @@ func compileRouteAsRegex(route string) *regexp.Regexp {
 	return reg
 }
 
-func compileRoutesAsRegexes(routes []string) []*regexp.Regexp {
+func compileRoutesAsRegexes(routes []string) ([]*regexp.Regexp, error) {
 	var regexes []*regexp.Regexp
 	for _, route := range routes {
 		reg := compileRouteAsRegex(route)
 		if reg == nil {
-			Plugin.LogFatalf("Invalid route in config: %s", route)
-			continue
+			return nil, fmt.Errorf("invalid route in config: %s", route)
 		}
 		regexes = append(regexes, reg)
 	}
-	return regexes
+	return regexes, nil
+}
+
+// loadRoutes compiles the public and protected routes from the config.
+func loadRoutes() error {
+	public, err := compileRoutesAsRegexes(ParamsRestAPI.PublicRoutes)
+	if err != nil {
+		return err
+	}
+
+	protected, err := compileRoutesAsRegexes(ParamsRestAPI.ProtectedRoutes)
+	if err != nil {
+		return err
+	}
+
+	routesLock.Lock()
+	defer routesLock.Unlock()
+
+	publicRoutes = public
+	protectedRoutes = protected
+
+	return nil
 }
 
 func apiMiddleware() echo.MiddlewareFunc {
 
-	publicRoutes := compileRoutesAsRegexes(ParamsRestAPI.PublicRoutes)
-	protectedRoutes := compileRoutesAsRegexes(ParamsRestAPI.ProtectedRoutes)
+	if err := loadRoutes(); err != nil {
+		Plugin.LogFatal(err)
+	}
 
 	matchPublic := func(c echo.Context) bool {
+		routesLock.RLock()
+		defer routesLock.RUnlock()
+
 		for _, reg := range publicRoutes {
 			if reg.MatchString(strings.ToLower(c.Path())) {
 				return true
@@ -51,7 +84,15 @@ func apiMiddleware() echo.MiddlewareFunc {
 	}
 
 	matchExposed := func(c echo.Context) bool {
-		for _, reg := range append(publicRoutes, protectedRoutes...) {
+		routesLock.RLock()
+		defer routesLock.RUnlock()
+
+		for _, reg := range publicRoutes {
+			if reg.MatchString(strings.ToLower(c.Path())) {
+				return true
+			}
+		}
+		for _, reg := range protectedRoutes {
 			if reg.MatchString(strings.ToLower(c.Path())) {
 				return true
 			}
@@ -117,13 +158,14 @@ func apiMiddleware() echo.MiddlewareFunc {
 
 var dashboardAllowedRoutes = map[string][]string{
 	http.MethodGet: {
-		"/api/v2/addresses",
 		"/api/v2/info",
 		"/api/v2/messages",
 		"/api/v2/milestones",
 		"/api/v2/outputs",
 		"/api/v2/peers",
+		"/api/v2/receipts",
 		"/api/v2/transactions",
+		"/api/v2/treasury",
 		"/api/plugins/indexer/v1",
 		"/api/plugins/spammer/v1",
 		"/api/plugins/participation/v1/events",
@@ -1,11 +1,60 @@
 package restapi
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
+	"github.com/gohornet/hornet/pkg/restapi"
 )
 
+const (
+	// nodeAPIHealthLiveRoute is the route for the liveness probe.
+	// GET returns 200 as long as the node is running and not shutting down.
+	nodeAPIHealthLiveRoute = "/health/live"
+
+	// nodeAPIHealthReadyRoute is the route for the readiness probe.
+	// GET returns 200 if all components that are required for readiness are healthy, 503 otherwise.
+	// The response contains the health report of all components.
+	nodeAPIHealthReadyRoute = "/health/ready"
+)
+
+const (
+	healthComponentDatabase   = "database"
+	healthComponentSnapshots  = "snapshots"
+	healthComponentSync       = "sync"
+	healthComponentMilestones = "milestones"
+	healthComponentGossip     = "gossip"
+	healthComponentINX        = "inx"
+)
+
+// healthComponent defines the health of a single component of the node.
+type healthComponent struct {
+	// The name of the component.
+	Name string `json:"name"`
+	// Whether the component is healthy.
+	Healthy bool `json:"isHealthy"`
+	// Whether the component needs to be healthy for the node to be ready.
+	RequiredForReadiness bool `json:"isRequiredForReadiness"`
+	// The reason why the component is not healthy.
+	Message string `json:"message,omitempty"`
+	// Additional information about the state of the component.
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// healthResponse defines the response of the GET health probes.
+type healthResponse struct {
+	// Whether the node is live.
+	IsLive bool `json:"isLive"`
+	// Whether the node is ready to serve requests.
+	IsReady bool `json:"isReady"`
+	// The health of the components of the node.
+	Components []*healthComponent `json:"components,omitempty"`
+}
+
 func setupHealthRoute() {
 	deps.Echo.GET(nodeAPIHealthRoute, func(c echo.Context) error {
 
@@ -16,4 +65,230 @@ func setupHealthRoute() {
 
 		return c.NoContent(http.StatusOK)
 	})
+
+	deps.Echo.GET(nodeAPIHealthLiveRoute, func(c echo.Context) error {
+		// the liveness does not depend on the sync state or the peers,
+		// so that a node that is syncing (e.g. after a restart) is not restarted by the orchestrator.
+		if Plugin.Daemon().IsStopped() {
+			return restapi.JSONResponse(c, http.StatusServiceUnavailable, &healthResponse{IsLive: false})
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, &healthResponse{IsLive: true})
+	})
+
+	deps.Echo.GET(nodeAPIHealthReadyRoute, func(c echo.Context) error {
+		resp := healthReport()
+		if !resp.IsReady {
+			return restapi.JSONResponse(c, http.StatusServiceUnavailable, resp)
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+}
+
+// healthReport collects the health of all components of the node.
+func healthReport() *healthResponse {
+
+	var components []*healthComponent
+	for _, componentFunc := range []func() *healthComponent{
+		databaseHealth,
+		snapshotsHealth,
+		syncHealth,
+		milestonesHealth,
+		gossipHealth,
+		inxHealth,
+	} {
+		if component := componentFunc(); component != nil {
+			components = append(components, component)
+		}
+	}
+
+	resp := &healthResponse{
+		IsLive:     !Plugin.Daemon().IsStopped(),
+		Components: components,
+	}
+
+	resp.IsReady = resp.IsLive
+	for _, component := range components {
+		if component.RequiredForReadiness && !component.Healthy {
+			resp.IsReady = false
+		}
+	}
+
+	return resp
+}
+
+func databaseHealth() *healthComponent {
+	if deps.Storage == nil {
+		return nil
+	}
+
+	// the databases are marked as corrupted while the node is running (they are marked healthy at a clean shutdown),
+	// therefore only the tainted flag is meaningful at runtime.
+	component := &healthComponent{
+		Name:                 healthComponentDatabase,
+		Healthy:              true,
+		RequiredForReadiness: true,
+	}
+
+	tainted, err := deps.Storage.AreDatabasesTainted()
+	if err != nil {
+		component.Healthy = false
+		component.Message = fmt.Sprintf("checking the database health failed: %s", err)
+		return component
+	}
+
+	if tainted {
+		component.Healthy = false
+		component.Message = "the databases are tainted"
+	}
+
+	return component
+}
+
+func snapshotsHealth() *healthComponent {
+	if deps.SnapshotManager == nil {
+		return nil
+	}
+
+	component := &healthComponent{
+		Name:                 healthComponentSnapshots,
+		Healthy:              true,
+		RequiredForReadiness: !ParamsRestAPI.Health.ReadyWhileSnapshottingOrPruning,
+	}
+
+	if deps.SnapshotManager.IsSnapshottingOrPruning() {
+		component.Healthy = false
+		component.Message = "a snapshot is created or the database is pruned"
+	}
+
+	return component
+}
+
+func syncHealth() *healthComponent {
+	if deps.SyncManager == nil {
+		return nil
+	}
+
+	cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+	lmi := deps.SyncManager.LatestMilestoneIndex()
+
+	var distance uint32
+	if lmi > cmi {
+		distance = uint32(lmi - cmi)
+	}
+
+	component := &healthComponent{
+		Name:                 healthComponentSync,
+		Healthy:              true,
+		RequiredForReadiness: true,
+		Details: map[string]any{
+			"confirmedMilestoneIndex": cmi,
+			"latestMilestoneIndex":    lmi,
+			"syncDistance":            distance,
+		},
+	}
+
+	if distance > ParamsRestAPI.Health.MaxSyncDistance {
+		component.Healthy = false
+		component.Message = fmt.Sprintf("the node is syncing (%d milestones behind)", distance)
+	}
+
+	return component
+}
+
+func milestonesHealth() *healthComponent {
+	if deps.SyncManager == nil || deps.Storage == nil {
+		return nil
+	}
+
+	component := &healthComponent{
+		Name:                 healthComponentMilestones,
+		Healthy:              true,
+		RequiredForReadiness: true,
+	}
+
+	milestoneTimestamp, err := deps.Storage.MilestoneTimestampByIndex(deps.SyncManager.LatestMilestoneIndex())
+	if err != nil {
+		component.Healthy = false
+		component.Message = "no milestone known yet"
+		return component
+	}
+
+	age := time.Since(milestoneTimestamp).Truncate(time.Second)
+	component.Details = map[string]any{
+		"latestMilestoneAge": age.String(),
+	}
+
+	if age > ParamsRestAPI.Health.MaxMilestoneAge {
+		component.Healthy = false
+		component.Message = fmt.Sprintf("the latest milestone is too old (%s)", age)
+	}
+
+	return component
+}
+
+func gossipHealth() *healthComponent {
+	if deps.GossipService == nil {
+		return nil
+	}
+
+	var gossipStreams int
+	deps.GossipService.ForEach(func(_ *gossip.Protocol) bool {
+		gossipStreams++
+		return true
+	})
+
+	component := &healthComponent{
+		Name:                 healthComponentGossip,
+		Healthy:              true,
+		RequiredForReadiness: true,
+		Details: map[string]any{
+			"gossipStreams": gossipStreams,
+		},
+	}
+
+	if gossipStreams < ParamsRestAPI.Health.MinGossipStreams {
+		component.Healthy = false
+		component.Message = fmt.Sprintf("not enough gossip streams (%d/%d)", gossipStreams, ParamsRestAPI.Health.MinGossipStreams)
+	}
+
+	return component
+}
+
+func inxHealth() *healthComponent {
+	if deps.INXRegistry == nil {
+		return nil
+	}
+
+	extensions := deps.INXRegistry.Extensions()
+
+	connected := make(map[string]struct{}, len(extensions))
+	for _, extension := range extensions {
+		connected[extension.ID] = struct{}{}
+	}
+
+	// the IDs and the routes of the extensions are not part of the report, since the health routes are public
+	component := &healthComponent{
+		Name:                 healthComponentINX,
+		Healthy:              true,
+		RequiredForReadiness: len(ParamsRestAPI.Health.RequiredINXExtensions) > 0,
+		Details: map[string]any{
+			"connectedExtensions": len(extensions),
+		},
+	}
+
+	var missing int
+	for _, required := range ParamsRestAPI.Health.RequiredINXExtensions {
+		if _, exists := connected[required]; !exists {
+			missing++
+		}
+	}
+
+	if missing > 0 {
+		component.Healthy = false
+		component.Message = fmt.Sprintf("required INX extensions are not connected (%d/%d)", missing, len(ParamsRestAPI.Health.RequiredINXExtensions))
+	}
+
+	return component
 }
@@ -1,6 +1,8 @@
 package restapi
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
@@ -31,20 +33,34 @@ type ParametersRestAPI struct {
 		// the maximum number of results that may be returned by an endpoint
 		MaxResults int `default:"1000" usage:"the maximum number of results that may be returned by an endpoint"`
 	}
+
+	Health struct {
+		// the maximum amount of milestones the node may be behind the latest milestone to be ready
+		MaxSyncDistance uint32 `default:"2" usage:"the maximum amount of milestones the node may be behind the latest milestone to be ready"`
+		// the maximum age of the latest milestone for the node to be ready
+		MaxMilestoneAge time.Duration `default:"5m" usage:"the maximum age of the latest milestone for the node to be ready"`
+		// the minimum amount of gossip streams for the node to be ready
+		MinGossipStreams int `default:"1" usage:"the minimum amount of gossip streams for the node to be ready"`
+		// whether the node is ready while a snapshot is created or the database is pruned
+		ReadyWhileSnapshottingOrPruning bool `default:"true" usage:"whether the node is ready while a snapshot is created or the database is pruned"`
+		// the IDs of the INX extensions that need to be connected for the node to be ready
+		RequiredINXExtensions []string `name:"requiredINXExtensions" usage:"the IDs of the INX extensions that need to be connected for the node to be ready"`
+	}
 }
 
 var ParamsRestAPI = &ParametersRestAPI{
 	PublicRoutes: []string{
-		"/health",
+		"/health*",
 		"/api/v2/info",
 		"/api/v2/tips",
 		"/api/v2/messages*",
-		"/api/v2/transactions*",
+		"/api/v2/transactions/:*",
 		"/api/v2/milestones*",
 		"/api/v2/outputs*",
 		"/api/v2/addresses*",
-		"/api/v2/treasury",
-		"/api/v2/receipts*",
+		"/api/v2/treasury*",
+		"/api/v2/receipts",
+		"/api/v2/receipts/:*",
 		"/api/plugins/debug/v1/*",
 		"/api/plugins/indexer/v1/*",
 		"/api/plugins/mqtt/v1",
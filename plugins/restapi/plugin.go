@@ -13,9 +13,15 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/hotreload"
+	"github.com/gohornet/hornet/pkg/inxregistry"
 	"github.com/gohornet/hornet/pkg/jwt"
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
 	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/snapshot"
 	"github.com/gohornet/hornet/pkg/tangle"
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/events"
@@ -46,13 +52,19 @@ var (
 
 type dependencies struct {
 	dig.In
-	Tangle                *tangle.Tangle `optional:"true"`
+	Tangle                *tangle.Tangle            `optional:"true"`
+	Storage               *storage.Storage          `optional:"true"`
+	SyncManager           *syncmanager.SyncManager  `optional:"true"`
+	SnapshotManager       *snapshot.SnapshotManager `optional:"true"`
+	GossipService         *gossip.Service           `optional:"true"`
+	INXRegistry           *inxregistry.Registry     `optional:"true"`
 	Echo                  *echo.Echo
 	RestAPIMetrics        *metrics.RestAPIMetrics
 	Host                  host.Host
-	RestAPIBindAddress    string         `name:"restAPIBindAddress"`
-	NodePrivateKey        crypto.PrivKey `name:"nodePrivateKey"`
-	DashboardAuthUsername string         `name:"dashboardAuthUsername" optional:"true"`
+	RestAPIBindAddress    string                    `name:"restAPIBindAddress"`
+	NodePrivateKey        crypto.PrivKey            `name:"nodePrivateKey"`
+	DashboardAuthUsername string                    `name:"dashboardAuthUsername" optional:"true"`
+	ConfigReloader        *hotreload.ConfigReloader `optional:"true"`
 }
 
 func initConfigPars(c *dig.Container) error {
@@ -100,6 +112,7 @@ func provide(c *dig.Container) error {
 		e.Use(middleware.CORS())
 		e.Use(middleware.Gzip())
 		e.Use(middleware.BodyLimit(ParamsRestAPI.Limits.MaxBodyLength))
+		e.Use(restapi.TracingMiddleware())
 
 		return echoResult{
 			Echo:                     e,
@@ -127,6 +140,13 @@ func configure() error {
 	deps.Echo.Use(apiMiddleware())
 	setupRoutes()
 
+	if deps.ConfigReloader != nil {
+		deps.ConfigReloader.RegisterParameters(Plugin.Name, loadRoutes,
+			Plugin.App.Config().GetParameterPath(&(ParamsRestAPI.PublicRoutes)),
+			Plugin.App.Config().GetParameterPath(&(ParamsRestAPI.ProtectedRoutes)),
+		)
+	}
+
 	return nil
 }
 
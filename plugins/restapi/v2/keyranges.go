@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/keymanager"
+	"github.com/gohornet/hornet/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func parseKeyRangeRequest(c echo.Context) (*keymanager.KeyRange, []*iotago.Ed25519Signature, error) {
+
+	request := &keymanager.KeyRangeAuthorization{}
+	if err := c.Bind(request); err != nil {
+		return nil, nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	keyRange, signatures, err := request.Decode()
+	if err != nil {
+		return nil, nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	return keyRange, signatures, nil
+}
+
+func keyRanges(_ echo.Context) (*KeyRangesResponse, error) {
+
+	confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex()
+
+	result := &KeyRangesResponse{
+		ConfirmedMilestoneIndex: confirmedMilestoneIndex,
+		KeyRanges:               []*KeyRangeResponse{},
+	}
+
+	for _, status := range deps.KeyManager.KeyRangeStatuses(confirmedMilestoneIndex, deps.KeyRangesExpiryWarningThreshold, deps.MilestonePublicKeyCount) {
+		result.KeyRanges = append(result.KeyRanges, &KeyRangeResponse{
+			PublicKey:               iotago.EncodeHex(status.PublicKey[:]),
+			StartIndex:              status.StartIndex,
+			EndIndex:                status.EndIndex,
+			Authorized:              status.Authorized,
+			ExpiresWithoutSuccessor: status.ExpiresWithoutSuccessor,
+		})
+	}
+
+	return result, nil
+}
+
+func addKeyRange(c echo.Context) (*KeyRangesResponse, error) {
+
+	keyRange, signatures, err := parseKeyRangeRequest(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deps.KeyManager.AddAuthorizedKeyRange(deps.ProtocolParameters.NetworkID(), keyRange, signatures, deps.SyncManager.ConfirmedMilestoneIndex(), deps.MilestonePublicKeyCount); err != nil {
+		if keymanager.IsKeyRangeRejected(err) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid key range, error: %s", err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "adding key range failed, error: %s", err)
+	}
+
+	Plugin.LogInfof("added public key range %s (%d-%d)", iotago.EncodeHex(keyRange.PublicKey[:]), keyRange.StartIndex, keyRange.EndIndex)
+
+	return keyRanges(c)
+}
+
+func validateKeyRange(c echo.Context) (*ValidateKeyRangeResponse, error) {
+
+	keyRange, signatures, err := parseKeyRangeRequest(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ValidateKeyRangeResponse{
+		Essence: iotago.EncodeHex(keymanager.KeyRangeEssence(deps.ProtocolParameters.NetworkID(), keyRange)),
+		Valid:   true,
+	}
+
+	if err := deps.KeyManager.ValidateKeyRangeAuthorization(deps.ProtocolParameters.NetworkID(), keyRange, signatures, deps.SyncManager.ConfirmedMilestoneIndex(), deps.MilestonePublicKeyCount); err != nil {
+		result.Valid = false
+		result.Error = err.Error()
+	}
+
+	return result, nil
+}
@@ -8,10 +8,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/proofbundle"
 	"github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/whiteflag"
 	"github.com/iotaledger/hive.go/contextutils"
 	"github.com/iotaledger/hive.go/serializer/v2"
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -158,6 +161,52 @@ func childrenIDsByID(c echo.Context) (*childrenResponse, error) {
 	}, nil
 }
 
+// inclusionProofError converts the errors of the inclusion proof creation to HTTP errors.
+func inclusionProofError(err error, messageID hornet.MessageID) error {
+	switch {
+	case errors.Is(err, common.ErrMessageNotFound):
+		return errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+	case errors.Is(err, whiteflag.ErrMessageNotReferenced):
+		return errors.WithMessagef(echo.ErrNotFound, "message not referenced by a milestone yet: %s", messageID.ToHex())
+	case errors.Is(err, proofbundle.ErrMessageNotApplied):
+		return errors.WithMessagef(echo.ErrNotFound, "message not applied to the ledger: %s", messageID.ToHex())
+	case errors.Is(err, whiteflag.ErrInclusionProofUnavailable):
+		return errors.WithMessagef(echo.ErrNotFound, "failed to create inclusion proof: %s", err)
+	default:
+		return errors.WithMessagef(echo.ErrInternalServerError, "failed to create inclusion proof: %s", err)
+	}
+}
+
+func messageInclusionProofByID(c echo.Context) (*whiteflag.InclusionProof, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := whiteflag.InclusionProofForMessage(deps.Storage, messageID)
+	if err != nil {
+		return nil, inclusionProofError(err, messageID)
+	}
+
+	return proof, nil
+}
+
+func messageProofBundleByID(c echo.Context) (*proofbundle.Bundle, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := proofbundle.New(deps.Storage, messageID)
+	if err != nil {
+		return nil, inclusionProofError(err, messageID)
+	}
+
+	return bundle, nil
+}
+
 func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
 
 	if !deps.SyncManager.IsNodeAlmostSynced() {
@@ -120,3 +120,87 @@ func milestoneUTXOChangesByID(c echo.Context) (*milestoneUTXOChangesResponse, er
 
 	return milestoneUTXOChanges(milestone.Index())
 }
+
+func milestoneStats(msIndex milestone.Index) (*storage.MilestoneStats, error) {
+	stats, err := deps.Storage.MilestoneStats(msIndex)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone stats for index: %d, error: %s", msIndex, err)
+	}
+	if stats == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "milestone stats not found for index: %d", msIndex)
+	}
+
+	return stats, nil
+}
+
+func milestoneStatsByIndex(c echo.Context) (*storage.MilestoneStats, error) {
+	msIndex, err := restapi.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return milestoneStats(msIndex)
+}
+
+func milestoneStatsByID(c echo.Context) (*storage.MilestoneStats, error) {
+	milestone, err := storageMilestoneByID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return milestoneStats(milestone.Index())
+}
+
+// MilestoneDiff returns the outputs that were created and consumed by the milestone with the given index.
+func MilestoneDiff(msIndex milestone.Index) (*MilestoneDiffResponse, error) {
+	diff, err := deps.UTXOManager.MilestoneDiffWithoutLocking(msIndex)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+	}
+
+	outputs := make([]*OutputResponse, len(diff.Outputs))
+	spents := make([]*OutputResponse, len(diff.Spents))
+
+	for i, output := range diff.Outputs {
+		o, err := NewOutputResponse(output, diff.Index)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = o
+	}
+
+	for i, spent := range diff.Spents {
+		o, err := NewSpentResponse(spent, diff.Index)
+		if err != nil {
+			return nil, err
+		}
+		spents[i] = o
+	}
+
+	return &MilestoneDiffResponse{
+		MilestoneIndex: msIndex,
+		Outputs:        outputs,
+		Spents:         spents,
+	}, nil
+}
+
+func milestoneDiffByIndex(c echo.Context) (*MilestoneDiffResponse, error) {
+	msIndex, err := restapi.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return MilestoneDiff(msIndex)
+}
+
+func milestoneDiffByID(c echo.Context) (*MilestoneDiffResponse, error) {
+	milestone, err := storageMilestoneByID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return MilestoneDiff(milestone.Index())
+}
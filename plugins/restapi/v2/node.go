@@ -52,6 +52,27 @@ func info() (*infoResponse, error) {
 		pruningIndex = snapshotInfo.PruningIndex
 	}
 
+	// only named extensions are listed, unnamed extensions are identified by their remote address
+	var extensions []*extensionResponse
+	if deps.INXRegistry != nil {
+		for _, extension := range deps.INXRegistry.Extensions() {
+			if extension.Name == "" {
+				continue
+			}
+
+			routes := make([]string, 0, len(extension.Routes))
+			for _, route := range extension.Routes {
+				routes = append(routes, route.Route)
+			}
+
+			extensions = append(extensions, &extensionResponse{
+				Name:    extension.Name,
+				Version: extension.Version,
+				Routes:  routes,
+			})
+		}
+	}
+
 	return &infoResponse{
 		Name:    deps.AppInfo.Name,
 		Version: deps.AppInfo.Version,
@@ -76,8 +97,9 @@ func info() (*infoResponse, error) {
 			ReferencedMessagesPerSecond: referencedMessagesPerSecond,
 			ReferencedRate:              referencedRate,
 		},
-		Features: features,
-		Plugins:  deps.RestPluginManager.Plugins(),
+		Features:   features,
+		Plugins:    deps.RestPluginManager.Plugins(),
+		Extensions: extensions,
 	}, nil
 }
 
@@ -112,3 +112,45 @@ func addPeer(c echo.Context) (*PeerResponse, error) {
 
 	return WrapInfoSnapshot(info), nil
 }
+
+//nolint:unparam // even if the error is never used, the structure of all routes should be the same
+func getPeeringPolicies(_ echo.Context) (*p2p.PeeringPolicies, error) {
+	return deps.PeeringPolicyManager.Policies(), nil
+}
+
+func setPeeringPolicies(c echo.Context) (*p2p.PeeringPolicies, error) {
+
+	request := p2p.NewPeeringPolicies()
+
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid peering policies, error: %s", err)
+	}
+
+	if err := deps.PeeringPolicyManager.SetPolicies(request); err != nil {
+		if errors.Is(err, p2p.ErrInvalidPeeringPolicies) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid peering policies, error: %s", err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "setting peering policies failed, error: %s", err)
+	}
+
+	// disconnect all peers that are denied by the new policies
+	for _, info := range deps.PeeringManager.PeerInfoSnapshots() {
+		violation := deps.PeeringPolicyManager.IsDenied(info.Peer.ID, nil)
+		for _, multiAddr := range info.Addresses {
+			if violation != p2p.PolicyViolationNone {
+				break
+			}
+			violation = deps.PeeringPolicyManager.IsDenied(info.Peer.ID, multiAddr)
+		}
+
+		if violation == p2p.PolicyViolationNone {
+			continue
+		}
+
+		if err := deps.PeeringManager.DisconnectPeer(info.Peer.ID, errors.Errorf("peer was denied by the peering policies: %s", violation)); err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "disconnecting denied peer failed: %s", err)
+		}
+	}
+
+	return deps.PeeringPolicyManager.Policies(), nil
+}
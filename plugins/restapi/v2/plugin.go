@@ -8,7 +8,11 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/gohornet/hornet/core/protocfg"
+	"github.com/gohornet/hornet/pkg/inxregistry"
+	"github.com/gohornet/hornet/pkg/keymanager"
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
@@ -47,6 +51,17 @@ const (
 	// GET returns the message IDs of all children.
 	RouteMessageChildren = "/messages/:" + restapipkg.ParameterMessageID + "/children"
 
+	// RouteMessageProof is the route for getting the proof of inclusion of a message, identified by its messageID.
+	// GET returns the milestone that referenced the message and the merkle audit paths
+	// of the message to the confirmed and applied merkle roots of the milestone.
+	RouteMessageProof = "/messages/:" + restapipkg.ParameterMessageID + "/proof"
+
+	// RouteMessageProofBundle is the route for exporting a self-contained proof bundle of a message, identified by its messageID.
+	// GET returns the serialized message, the signed milestone that applied the message
+	// and the merkle audit path of the message to the applied merkle root of the milestone.
+	// The bundle can be verified offline, even after the message was pruned.
+	RouteMessageProofBundle = "/messages/:" + restapipkg.ParameterMessageID + "/proof-bundle"
+
 	// RouteMessages is the route for creating new messages.
 	// POST creates a single new message and returns the new message ID.
 	// The message is parsed based on the given type in the request "Content-Type" header.
@@ -60,6 +75,22 @@ const (
 	// MIMEVendorIOTASerializer => bytes
 	RouteTransactionsIncludedMessage = "/transactions/:" + restapipkg.ParameterTransactionID + "/included-message"
 
+	// RouteTransactionsStatus is the route for getting the status of a transaction by its transaction ID.
+	// GET returns all referenced messages that carried the transaction, the milestone that referenced them
+	// and why the transaction was excluded from the ledger, including the winner of a double spend.
+	RouteTransactionsStatus = "/transactions/:" + restapipkg.ParameterTransactionID + "/status"
+
+	// RouteTransactionsValidate is the route for validating a transaction against the current ledger state without attaching it.
+	// POST validates the transaction payload the same way a milestone would and returns the errors per input and check.
+	// The transaction is parsed based on the given type in the request "Content-Type" header.
+	// MIMEApplicationJSON => json
+	// MIMEVendorIOTASerializer => bytes
+	RouteTransactionsValidate = "/transactions/validate"
+
+	// RouteTransaction is the route for getting a transaction by its transaction ID.
+	// GET returns the included message, the booking milestone and all inputs and outputs of the transaction including their metadata.
+	RouteTransaction = "/transactions/:" + restapipkg.ParameterTransactionID
+
 	// RouteMilestoneByID is the route for getting a milestone by its ID.
 	// GET returns the milestone.
 	// MIMEApplicationJSON => json
@@ -70,6 +101,14 @@ const (
 	// GET returns the output IDs of all UTXO changes.
 	RouteMilestoneByIDUTXOChanges = "/milestones/:" + restapipkg.ParameterMilestoneID + "/utxo-changes"
 
+	// RouteMilestoneByIDStats is the route for getting the confirmation statistics of a milestone by its ID.
+	// GET returns the statistics of the confirmation of the milestone by the node.
+	RouteMilestoneByIDStats = "/milestones/:" + restapipkg.ParameterMilestoneID + "/stats"
+
+	// RouteMilestoneByIDDiff is the route for getting the ledger diff of a milestone by its ID.
+	// GET returns all outputs that were created and consumed by the milestone.
+	RouteMilestoneByIDDiff = "/milestones/:" + restapipkg.ParameterMilestoneID + "/diff"
+
 	// RouteMilestoneByIndex is the route for getting a milestone by its milestoneIndex.
 	// GET returns the milestone.
 	// MIMEApplicationJSON => json
@@ -80,6 +119,14 @@ const (
 	// GET returns the output IDs of all UTXO changes.
 	RouteMilestoneByIndexUTXOChanges = "/milestones/by-index/:" + restapipkg.ParameterMilestoneIndex + "/utxo-changes"
 
+	// RouteMilestoneByIndexStats is the route for getting the confirmation statistics of a milestone by its milestoneIndex.
+	// GET returns the statistics of the confirmation of the milestone by the node.
+	RouteMilestoneByIndexStats = "/milestones/by-index/:" + restapipkg.ParameterMilestoneIndex + "/stats"
+
+	// RouteMilestoneByIndexDiff is the route for getting the ledger diff of a milestone by its milestoneIndex.
+	// GET returns all outputs that were created and consumed by the milestone.
+	RouteMilestoneByIndexDiff = "/milestones/by-index/:" + restapipkg.ParameterMilestoneIndex + "/diff"
+
 	// RouteOutput is the route for getting an output by its outputID (transactionHash + outputIndex).
 	// GET returns the output based on the given type in the request "Accept" header.
 	// MIMEApplicationJSON => json
@@ -90,14 +137,29 @@ const (
 	// GET returns the output metadata.
 	RouteOutputMetadata = "/outputs/:" + restapipkg.ParameterOutputID + "/metadata"
 
+	// RouteOutputsStorageDeposit is the route for computing the minimum storage deposit of an output.
+	// POST returns the minimum storage deposit of the given output or output draft with a breakdown of its virtual bytes.
+	// The output is parsed based on the given type in the request "Content-Type" header.
+	// MIMEApplicationJSON => json
+	// MIMEVendorIOTASerializer => bytes
+	RouteOutputsStorageDeposit = "/outputs/storage-deposit"
+
 	// RouteTreasury is the route for getting the current treasury output.
 	// GET returns the treasury.
 	RouteTreasury = "/treasury"
 
+	// RouteTreasuryHistory is the route for getting all stored treasury outputs.
+	// GET returns the spent and unspent treasury outputs.
+	RouteTreasuryHistory = "/treasury/history"
+
 	// RouteReceipts is the route for getting all persisted receipts on a node.
 	// GET returns the receipts.
 	RouteReceipts = "/receipts"
 
+	// RouteReceiptsAudit is the route for auditing the persisted receipts and treasury outputs on a node.
+	// GET returns the audit result.
+	RouteReceiptsAudit = "/receipts/audit"
+
 	// RouteReceiptsMigratedAtIndex is the route for getting all persisted receipts for a given migrated at index on a node.
 	// GET returns the receipts for the given migrated at index.
 	RouteReceiptsMigratedAtIndex = "/receipts/:" + restapipkg.ParameterMilestoneIndex
@@ -116,6 +178,11 @@ const (
 	// POST adds a new peer.
 	RoutePeers = "/peers"
 
+	// RoutePeersPolicies is the route for the peering policies of the node.
+	// GET returns the peering policies.
+	// PUT replaces the peering policies.
+	RoutePeersPolicies = "/peers/policies"
+
 	// RouteControlDatabasePrune is the control route to manually prune the database.
 	// POST prunes the database.
 	RouteControlDatabasePrune = "/control/database/prune"
@@ -123,6 +190,15 @@ const (
 	// RouteControlSnapshotsCreate is the control route to manually create a snapshot files.
 	// POST creates a snapshot (full, delta or both).
 	RouteControlSnapshotsCreate = "/control/snapshots/create"
+
+	// RouteControlKeyRanges is the control route for the public key ranges of the milestone signers.
+	// GET returns all known public key ranges.
+	// POST adds a new upcoming public key range that is authorized by the currently valid public keys.
+	RouteControlKeyRanges = "/control/key-ranges"
+
+	// RouteControlKeyRangesValidate is the control route to validate an upcoming public key range.
+	// POST validates the public key range and its authorization without adding it.
+	RouteControlKeyRangesValidate = "/control/key-ranges/validate"
 )
 
 func init() {
@@ -141,6 +217,9 @@ var (
 	features = []string{}
 	attacher *tangle.MessageAttacher
 
+	// receiptsAuditor audits the receipts if the receipt service is not available.
+	receiptsAuditor *migrator.Auditor
+
 	// ErrNodeNotSync is returned when the node was not synced.
 	ErrNodeNotSync = errors.New("node not synced")
 
@@ -149,26 +228,32 @@ var (
 
 type dependencies struct {
 	dig.In
-	Storage                 *storage.Storage
-	SyncManager             *syncmanager.SyncManager
-	Tangle                  *tangle.Tangle
-	TipScoreCalculator      *tangle.TipScoreCalculator
-	PeeringManager          *p2p.Manager
-	GossipService           *gossip.Service
-	UTXOManager             *utxo.Manager
-	PoWHandler              *pow.Handler
-	SnapshotManager         *snapshot.SnapshotManager
-	AppInfo                 *app.AppInfo
-	PeeringConfigManager    *p2p.ConfigManager
-	ProtocolParameters      *iotago.ProtocolParameters
-	BaseToken               *protocfg.BaseToken
-	RestAPILimitsMaxResults int                        `name:"restAPILimitsMaxResults"`
-	SnapshotsFullPath       string                     `name:"snapshotsFullPath"`
-	SnapshotsDeltaPath      string                     `name:"snapshotsDeltaPath"`
-	TipSelector             *tipselect.TipSelector     `optional:"true"`
-	Echo                    *echo.Echo                 `optional:"true"`
-	RestPluginManager       *restapi.RestPluginManager `optional:"true"`
-	RestAPIMetrics          *metrics.RestAPIMetrics
+	Storage                         *storage.Storage
+	SyncManager                     *syncmanager.SyncManager
+	Tangle                          *tangle.Tangle
+	TipScoreCalculator              *tangle.TipScoreCalculator
+	PeeringManager                  *p2p.Manager
+	GossipService                   *gossip.Service
+	UTXOManager                     *utxo.Manager
+	PoWHandler                      *pow.Handler
+	SnapshotManager                 *snapshot.SnapshotManager
+	AppInfo                         *app.AppInfo
+	PeeringConfigManager            *p2p.ConfigManager
+	PeeringPolicyManager            *p2p.PolicyManager
+	ProtocolParameters              *iotago.ProtocolParameters
+	BaseToken                       *protocfg.BaseToken
+	KeyManager                      *keymanager.KeyManager
+	MilestonePublicKeyCount         int                        `name:"milestonePublicKeyCount"`
+	KeyRangesExpiryWarningThreshold milestone.Index            `name:"keyRangesExpiryWarningThreshold"`
+	RestAPILimitsMaxResults         int                        `name:"restAPILimitsMaxResults"`
+	SnapshotsFullPath               string                     `name:"snapshotsFullPath"`
+	SnapshotsDeltaPath              string                     `name:"snapshotsDeltaPath"`
+	TipSelector                     *tipselect.TipSelector     `optional:"true"`
+	Echo                            *echo.Echo                 `optional:"true"`
+	RestPluginManager               *restapi.RestPluginManager `optional:"true"`
+	INXRegistry                     *inxregistry.Registry      `optional:"true"`
+	ReceiptService                  *migrator.ReceiptService   `optional:"true"`
+	RestAPIMetrics                  *metrics.RestAPIMetrics
 }
 
 func configure() error {
@@ -194,6 +279,7 @@ func configure() error {
 	}
 
 	attacher = deps.Tangle.MessageAttacher(attacherOpts...)
+	receiptsAuditor = migrator.NewAuditor(deps.UTXOManager, "")
 
 	routeGroup.GET(RouteInfo, func(c echo.Context) error {
 		resp, err := info()
@@ -255,6 +341,24 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteMessageProof, func(c echo.Context) error {
+		resp, err := messageInclusionProofByID(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMessageProofBundle, func(c echo.Context) error {
+		resp, err := messageProofBundleByID(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.POST(RouteMessages, func(c echo.Context) error {
 		resp, err := sendMessage(c)
 		if err != nil {
@@ -288,6 +392,30 @@ func configure() error {
 		}
 	})
 
+	routeGroup.GET(RouteTransactionsStatus, func(c echo.Context) error {
+		resp, err := transactionStatus(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteTransactionsValidate, func(c echo.Context) error {
+		resp, err := validateTransaction(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteTransaction, func(c echo.Context) error {
+		resp, err := transactionByID(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteMilestoneByID, func(c echo.Context) error {
 		mimeType, err := restapipkg.GetAcceptHeaderContentType(c, restapipkg.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
 		if err != nil && err != restapipkg.ErrNotAcceptable {
@@ -320,6 +448,22 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteMilestoneByIDStats, func(c echo.Context) error {
+		resp, err := milestoneStatsByID(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMilestoneByIDDiff, func(c echo.Context) error {
+		resp, err := milestoneDiffByID(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteMilestoneByIndex, func(c echo.Context) error {
 		mimeType, err := restapipkg.GetAcceptHeaderContentType(c, restapipkg.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
 		if err != nil && err != restapipkg.ErrNotAcceptable {
@@ -352,6 +496,22 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteMilestoneByIndexStats, func(c echo.Context) error {
+		resp, err := milestoneStatsByIndex(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMilestoneByIndexDiff, func(c echo.Context) error {
+		resp, err := milestoneDiffByIndex(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteOutput, func(c echo.Context) error {
 		mimeType, err := restapipkg.GetAcceptHeaderContentType(c, restapipkg.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
 		if err != nil && err != restapipkg.ErrNotAcceptable {
@@ -384,6 +544,14 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.POST(RouteOutputsStorageDeposit, func(c echo.Context) error {
+		resp, err := outputStorageDeposit(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteTreasury, func(c echo.Context) error {
 		resp, err := treasury(c)
 		if err != nil {
@@ -392,6 +560,14 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteTreasuryHistory, func(c echo.Context) error {
+		resp, err := treasuryHistory(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteReceipts, func(c echo.Context) error {
 		resp, err := receipts(c)
 		if err != nil {
@@ -400,6 +576,14 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteReceiptsAudit, func(c echo.Context) error {
+		resp, err := receiptsAudit(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteReceiptsMigratedAtIndex, func(c echo.Context) error {
 		resp, err := receiptsByMigratedAtIndex(c)
 		if err != nil {
@@ -431,6 +615,22 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RoutePeersPolicies, func(c echo.Context) error {
+		resp, err := getPeeringPolicies(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.PUT(RoutePeersPolicies, func(c echo.Context) error {
+		resp, err := setPeeringPolicies(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.POST(RouteComputeWhiteFlagMutations, func(c echo.Context) error {
 		resp, err := computeWhiteFlagMutations(c)
 		if err != nil {
@@ -464,6 +664,30 @@ func configure() error {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteControlKeyRanges, func(c echo.Context) error {
+		resp, err := keyRanges(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteControlKeyRanges, func(c echo.Context) error {
+		resp, err := addKeyRange(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteControlKeyRangesValidate, func(c echo.Context) error {
+		resp, err := validateKeyRange(c)
+		if err != nil {
+			return err
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	return nil
 }
 
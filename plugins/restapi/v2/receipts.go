@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
+	"github.com/gohornet/hornet/pkg/model/migrator"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/restapi"
 )
@@ -36,3 +37,19 @@ func receiptsByMigratedAtIndex(c echo.Context) (*receiptsResponse, error) {
 
 	return &receiptsResponse{Receipts: receipts}, nil
 }
+
+func receiptsAudit(_ echo.Context) (*migrator.AuditResult, error) {
+	var result *migrator.AuditResult
+	var err error
+
+	if deps.ReceiptService != nil {
+		result, err = deps.ReceiptService.Audit()
+	} else {
+		result, err = receiptsAuditor.Audit()
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "unable to audit receipts: %s", err)
+	}
+
+	return result, nil
+}
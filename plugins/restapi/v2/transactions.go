@@ -1,12 +1,17 @@
 package v2
 
 import (
+	"io/ioutil"
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/whiteflag"
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
@@ -53,3 +58,184 @@ func messageBytesByTransactionID(c echo.Context) ([]byte, error) {
 	}
 	return message.Data(), nil
 }
+
+func transactionByID(c echo.Context) (*transactionResponse, error) {
+
+	transactionID, err := restapi.ParseTransactionIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := storageMessageByTransactionID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := message.Transaction()
+	if transaction == nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "message does not contain a transaction: %s", message.MessageID().ToHex())
+	}
+
+	// we need to lock the ledger here to have the correct index for unspent info of the outputs.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger index failed, error: %s", err)
+	}
+
+	inputIDs := message.TransactionEssenceUTXOInputs()
+	inputs := make([]*OutputResponse, len(inputIDs))
+	for i, inputID := range inputIDs {
+		input, err := outputResponseWithoutLocking(inputID, ledgerIndex)
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = input
+	}
+
+	outputs := make([]*OutputResponse, len(message.TransactionEssence().Outputs))
+	for i := range outputs {
+		outputID := iotago.OutputIDFromTransactionIDAndIndex(*transactionID, uint16(i))
+
+		output, err := outputResponseWithoutLocking(&outputID, ledgerIndex)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = output
+	}
+
+	// all outputs of a transaction are booked by the same milestone.
+	return &transactionResponse{
+		TransactionID:            transactionID.ToHex(),
+		MessageID:                message.MessageID().ToHex(),
+		MilestoneIndexBooked:     outputs[0].Metadata.MilestoneIndexBooked,
+		MilestoneTimestampBooked: outputs[0].Metadata.MilestoneTimestampBooked,
+		Inputs:                   inputs,
+		Outputs:                  outputs,
+		LedgerIndex:              ledgerIndex,
+	}, nil
+}
+
+func transactionStatus(c echo.Context) (*transactionStatusResponse, error) {
+
+	transactionID, err := restapi.ParseTransactionIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionMessages, err := deps.Storage.TransactionMessages(transactionID)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to load messages of transaction: %s, error: %s", transactionID.ToHex(), err)
+	}
+
+	response := &transactionStatusResponse{
+		TransactionID:        transactionID.ToHex(),
+		LedgerInclusionState: "conflicting",
+		Messages:             make([]*transactionMessageResponse, 0, len(transactionMessages)),
+	}
+
+	for _, transactionMessage := range transactionMessages {
+		messageResponse := &transactionMessageResponse{
+			MessageID:                  transactionMessage.MessageID.ToHex(),
+			ReferencedByMilestoneIndex: transactionMessage.ReferencedByMilestoneIndex,
+			LedgerInclusionState:       "included",
+		}
+
+		if transactionMessage.IsIncluded() {
+			response.LedgerInclusionState = "included"
+			response.IncludedMessageID = messageResponse.MessageID
+		} else {
+			conflict := transactionMessage.Conflict
+			messageResponse.LedgerInclusionState = "conflicting"
+			messageResponse.ConflictReason = &conflict
+
+			if doubleSpend := transactionMessage.DoubleSpend; doubleSpend != nil {
+				messageResponse.DoubleSpentInputID = doubleSpend.InputID.ToHex()
+				messageResponse.WinningTransactionID = doubleSpend.WinningTransactionID.ToHex()
+				if doubleSpend.WinningMessageID != nil {
+					messageResponse.WinningMessageID = doubleSpend.WinningMessageID.ToHex()
+				}
+			}
+		}
+
+		response.Messages = append(response.Messages, messageResponse)
+	}
+
+	if response.IncludedMessageID == "" {
+		// the transaction could have been included before the messages of the transactions were tracked,
+		// so the first output of the transaction is checked as well.
+		outputID := iotago.OutputIDFromTransactionIDAndIndex(*transactionID, 0)
+
+		output, err := deps.UTXOManager.ReadOutputByOutputIDWithoutLocking(&outputID)
+		if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to load output for transaction: %s", transactionID.ToHex())
+		}
+
+		if output != nil {
+			response.LedgerInclusionState = "included"
+			response.IncludedMessageID = output.MessageID().ToHex()
+			response.Messages = append(response.Messages, &transactionMessageResponse{
+				MessageID:                  response.IncludedMessageID,
+				ReferencedByMilestoneIndex: output.MilestoneIndex(),
+				LedgerInclusionState:       "included",
+			})
+		}
+	}
+
+	if len(response.Messages) == 0 {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "transaction not found: %s", transactionID.ToHex())
+	}
+
+	return response, nil
+}
+
+func validateTransaction(c echo.Context) (*whiteflag.TransactionValidation, error) {
+
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	mimeType, err := restapi.GetRequestContentType(c, restapi.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &iotago.Transaction{}
+
+	switch mimeType {
+	case echo.MIMEApplicationJSON:
+		if err := c.Bind(transaction); err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid transaction, error: %s", err)
+		}
+
+	case restapi.MIMEApplicationVendorIOTASerializerV1:
+		if c.Request().Body == nil {
+			return nil, errors.WithMessage(restapi.ErrInvalidParameter, "invalid transaction, error: request body missing")
+		}
+
+		bytes, err := ioutil.ReadAll(c.Request().Body)
+		if err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid transaction, error: %s", err)
+		}
+
+		// Do not validate here, syntactic errors are part of the validation result
+		if _, err := transaction.Deserialize(bytes, serializer.DeSeriModeNoValidation, deps.ProtocolParameters); err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid transaction, error: %s", err)
+		}
+
+	default:
+		return nil, echo.ErrUnsupportedMediaType
+	}
+
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	validation, err := whiteflag.ValidateTransaction(deps.UTXOManager, transaction, deps.ProtocolParameters, uint32(time.Now().Unix()))
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to validate transaction, error: %s", err)
+	}
+
+	return validation, nil
+}
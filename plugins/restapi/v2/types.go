@@ -59,6 +59,18 @@ type infoResponse struct {
 	Features []string `json:"features"`
 	// The plugins this node exposes.
 	Plugins []string `json:"plugins"`
+	// The INX extensions that are connected to this node.
+	Extensions []*extensionResponse `json:"extensions,omitempty"`
+}
+
+// extensionResponse defines an INX extension that is connected to the node.
+type extensionResponse struct {
+	// The name of the extension.
+	Name string `json:"name"`
+	// The version of the extension.
+	Version string `json:"version,omitempty"`
+	// The REST API routes the extension exposes.
+	Routes []string `json:"routes"`
 }
 
 // tipsResponse defines the response of a GET tips REST API call.
@@ -159,6 +171,82 @@ type treasuryResponse struct {
 	Amount      string `json:"amount"`
 }
 
+// treasuryOutputResponse defines a treasury output in the response of a GET treasury history REST API call.
+type treasuryOutputResponse struct {
+	// The hex encoded ID of the milestone which generated the output.
+	MilestoneID string `json:"milestoneId"`
+	// The index of the milestone which generated the output (0 if the milestone was pruned).
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	// The amount residing on the output.
+	Amount string `json:"amount"`
+	// Whether the output was already spent by a later milestone.
+	Spent bool `json:"isSpent"`
+}
+
+// treasuryHistoryResponse defines the response of a GET treasury history REST API call.
+type treasuryHistoryResponse struct {
+	// All stored treasury outputs, ordered by the index of the milestone which generated them.
+	TreasuryOutputs []*treasuryOutputResponse `json:"treasuryOutputs"`
+}
+
+// transactionResponse defines the response of a GET transaction REST API call.
+type transactionResponse struct {
+	// The hex encoded ID of the transaction.
+	TransactionID string `json:"transactionId"`
+	// The hex encoded message ID of the message that was included in the ledger for the transaction.
+	MessageID string `json:"messageId"`
+	// The milestone index at which the transaction was booked into the ledger.
+	MilestoneIndexBooked milestone.Index `json:"milestoneIndexBooked"`
+	// The milestone timestamp at which the transaction was booked into the ledger.
+	MilestoneTimestampBooked uint32 `json:"milestoneTimestampBooked"`
+	// The outputs consumed by the transaction.
+	Inputs []*OutputResponse `json:"inputs"`
+	// The outputs created by the transaction.
+	Outputs []*OutputResponse `json:"outputs"`
+	// The ledger index at which the spent status of the outputs was read.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// transactionMessageResponse defines a referenced message carrying a transaction in a transaction status response.
+type transactionMessageResponse struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The milestone index that referenced the message.
+	ReferencedByMilestoneIndex milestone.Index `json:"referencedByMilestoneIndex"`
+	// The ledger inclusion state of the transaction in this message.
+	LedgerInclusionState string `json:"ledgerInclusionState"`
+	// The reason why the transaction in this message was excluded from the ledger.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The hex encoded output ID of the input that was already spent, if the conflict was a double spend.
+	DoubleSpentInputID string `json:"doubleSpentInputId,omitempty"`
+	// The hex encoded ID of the transaction that spent the input, if the conflict was a double spend.
+	WinningTransactionID string `json:"winningTransactionId,omitempty"`
+	// The hex encoded message ID of the message that contained the winning transaction, if the conflict was a double spend.
+	WinningMessageID string `json:"winningMessageId,omitempty"`
+}
+
+// transactionStatusResponse defines the response of a GET transaction status REST API call.
+type transactionStatusResponse struct {
+	// The hex encoded ID of the transaction.
+	TransactionID string `json:"transactionId"`
+	// The ledger inclusion state of the transaction.
+	LedgerInclusionState string `json:"ledgerInclusionState"`
+	// The hex encoded message ID of the message that was included in the ledger for the transaction.
+	IncludedMessageID string `json:"includedMessageId,omitempty"`
+	// All referenced messages that carried the transaction.
+	Messages []*transactionMessageResponse `json:"messages"`
+}
+
+// MilestoneDiffResponse defines the response of a GET milestone diff REST API call.
+type MilestoneDiffResponse struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index `json:"index"`
+	// The newly created outputs by this milestone diff.
+	Outputs []*OutputResponse `json:"outputs"`
+	// The used outputs (spents) by this milestone diff.
+	Spents []*OutputResponse `json:"spents"`
+}
+
 // addPeerRequest defines the request for a POST peer REST API call.
 type addPeerRequest struct {
 	// The libp2p multi address of the peer.
@@ -238,3 +326,35 @@ type ComputeWhiteFlagMutationsResponse struct {
 	// The hex encoded applied merkle tree root as a result of the white flag computation.
 	AppliedMerkleRoot string `json:"appliedMerkleRoot"`
 }
+
+// KeyRangeResponse defines a public key range of the milestone signers.
+type KeyRangeResponse struct {
+	// The hex encoded ed25519 public key.
+	PublicKey string `json:"publicKey"`
+	// The milestone index the public key is valid from.
+	StartIndex milestone.Index `json:"start"`
+	// The milestone index the public key is valid until (0 = forever).
+	EndIndex milestone.Index `json:"end"`
+	// Whether the key range was added at runtime with an authorization of the valid keys.
+	Authorized bool `json:"authorized"`
+	// Whether the key range expires soon without enough valid public keys afterwards.
+	ExpiresWithoutSuccessor bool `json:"expiresWithoutSuccessor"`
+}
+
+// KeyRangesResponse defines the response of a GET key ranges REST API call.
+type KeyRangesResponse struct {
+	// The confirmed milestone index of the node.
+	ConfirmedMilestoneIndex milestone.Index `json:"confirmedMilestoneIndex"`
+	// The public key ranges of the milestone signers.
+	KeyRanges []*KeyRangeResponse `json:"keyRanges"`
+}
+
+// ValidateKeyRangeResponse defines the response of a POST validate key range REST API call.
+type ValidateKeyRangeResponse struct {
+	// The hex encoded essence of the key range that needs to be signed by the valid public keys.
+	Essence string `json:"essence"`
+	// Whether the key range and its authorization are valid.
+	Valid bool `json:"valid"`
+	// The reason why the key range or its authorization is invalid.
+	Error string `json:"error,omitempty"`
+}
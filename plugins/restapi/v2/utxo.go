@@ -2,6 +2,8 @@ package v2
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"sort"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
@@ -66,21 +68,7 @@ func NewSpentResponse(spent *utxo.Spent, ledgerIndex milestone.Index) (*OutputRe
 	}, nil
 }
 
-func outputByID(c echo.Context) (*OutputResponse, error) {
-	outputID, err := restapi.ParseOutputIDParam(c)
-	if err != nil {
-		return nil, err
-	}
-
-	// we need to lock the ledger here to have the correct index for unspent info of the output.
-	deps.UTXOManager.ReadLockLedger()
-	defer deps.UTXOManager.ReadUnlockLedger()
-
-	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
-	if err != nil {
-		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", outputID.ToHex(), err)
-	}
-
+func outputResponseWithoutLocking(outputID *iotago.OutputID, ledgerIndex milestone.Index) (*OutputResponse, error) {
 	isUnspent, err := deps.UTXOManager.IsOutputIDUnspentWithoutLocking(outputID)
 	if err != nil {
 		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output spent status failed: %s, error: %s", outputID.ToHex(), err)
@@ -107,6 +95,24 @@ func outputByID(c echo.Context) (*OutputResponse, error) {
 	return NewSpentResponse(spent, ledgerIndex)
 }
 
+func outputByID(c echo.Context) (*OutputResponse, error) {
+	outputID, err := restapi.ParseOutputIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// we need to lock the ledger here to have the correct index for unspent info of the output.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", outputID.ToHex(), err)
+	}
+
+	return outputResponseWithoutLocking(outputID, ledgerIndex)
+}
+
 func outputMetadataByID(c echo.Context) (*OutputMetadataResponse, error) {
 	outputID, err := restapi.ParseOutputIDParam(c)
 	if err != nil {
@@ -176,3 +182,75 @@ func treasury(_ echo.Context) (*treasuryResponse, error) {
 		Amount:      iotago.EncodeUint64(treasuryOutput.Amount),
 	}, nil
 }
+
+func treasuryHistory(_ echo.Context) (*treasuryHistoryResponse, error) {
+
+	treasuryOutputs := make([]*treasuryOutputResponse, 0)
+	if err := deps.UTXOManager.ForEachTreasuryOutput(func(output *utxo.TreasuryOutput) bool {
+		treasuryOutput := &treasuryOutputResponse{
+			MilestoneID: iotago.EncodeHex(output.MilestoneID[:]),
+			Amount:      iotago.EncodeUint64(output.Amount),
+			Spent:       output.Spent,
+		}
+
+		// the milestone that generated the output may already be pruned.
+		if cachedMilestone := deps.Storage.CachedMilestoneOrNil(output.MilestoneID); cachedMilestone != nil { // milestone +1
+			treasuryOutput.MilestoneIndex = cachedMilestone.Milestone().Index()
+			cachedMilestone.Release(true) // milestone -1
+		}
+
+		treasuryOutputs = append(treasuryOutputs, treasuryOutput)
+		return true
+	}, utxo.ReadLockLedger(false)); err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "unable to retrieve treasury outputs: %s", err)
+	}
+
+	// outputs of pruned milestones are listed first, followed by the outputs in the order of their milestones.
+	sort.SliceStable(treasuryOutputs, func(i, j int) bool {
+		return treasuryOutputs[i].MilestoneIndex < treasuryOutputs[j].MilestoneIndex
+	})
+
+	return &treasuryHistoryResponse{TreasuryOutputs: treasuryOutputs}, nil
+}
+
+func outputStorageDeposit(c echo.Context) (*utxo.StorageDeposit, error) {
+
+	mimeType, err := restapi.GetRequestContentType(c, restapi.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Request().Body == nil {
+		return nil, errors.WithMessage(restapi.ErrInvalidParameter, "invalid output, error: request body missing")
+	}
+
+	bytes, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid output, error: %s", err)
+	}
+
+	var output iotago.Output
+	switch mimeType {
+	case echo.MIMEApplicationJSON:
+		output, err = utxo.OutputFromJSON(bytes)
+
+	case restapi.MIMEApplicationVendorIOTASerializerV1:
+		output, err = utxo.OutputFromBytes(bytes, deps.ProtocolParameters)
+
+	default:
+		return nil, echo.ErrUnsupportedMediaType
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid output, error: %s", err)
+	}
+
+	deposit, err := utxo.ComputeStorageDeposit(output, &deps.ProtocolParameters.RentStructure)
+	if err != nil {
+		if errors.Is(err, utxo.ErrStorageDepositNotApplicable) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid output, error: %s", err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to compute storage deposit, error: %s", err)
+	}
+
+	return deposit, nil
+}
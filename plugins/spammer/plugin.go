@@ -13,6 +13,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/common"
 	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/hotreload"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
@@ -88,6 +89,7 @@ type dependencies struct {
 	TipSelector        *tipselect.TipSelector `optional:"true"`
 	ProtocolParameters *iotago.ProtocolParameters
 	RestPluginManager  *restapi.RestPluginManager `optional:"true"`
+	ConfigReloader     *hotreload.ConfigReloader  `optional:"true"`
 }
 
 func configure() error {
@@ -137,6 +139,15 @@ func configure() error {
 		sendMessage,
 		deps.ServerMetrics,
 	)
+
+	if deps.ConfigReloader != nil {
+		deps.ConfigReloader.RegisterParameters(Plugin.Name, restartIfRunning,
+			Plugin.App.Config().GetParameterPath(&(ParamsSpammer.CPUMaxUsage)),
+			Plugin.App.Config().GetParameterPath(&(ParamsSpammer.MPSRateLimit)),
+			Plugin.App.Config().GetParameterPath(&(ParamsSpammer.Workers)),
+		)
+	}
+
 	return nil
 }
 
@@ -351,6 +362,19 @@ func startSpammerWorkers(mpsRateLimit float64, cpuMaxUsage float64, spammerWorke
 	}
 }
 
+// restartIfRunning restarts the spammer with the settings from the config if it is running.
+func restartIfRunning() error {
+	spammerLock.RLock()
+	running := isRunning
+	spammerLock.RUnlock()
+
+	if !running {
+		return nil
+	}
+
+	return start(nil, nil, nil)
+}
+
 // stop stops the spammer.
 func stop() error {
 	if spammerInstance == nil {
@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersTracing contains the definition of the parameters used by the tracing plugin.
+type ParametersTracing struct {
+	// the name of the service that is reported to the collector.
+	ServiceName string `default:"hornet" usage:"the name of the service that is reported to the collector"`
+	// the OTLP/HTTP endpoint of the collector the spans are exported to.
+	Endpoint string `default:"http://localhost:4318/v1/traces" usage:"the OTLP/HTTP endpoint of the collector the spans are exported to"`
+	// additional HTTP headers that are sent with every export request.
+	Headers map[string]string `noflag:"true" usage:"additional HTTP headers that are sent with every export request"`
+	// the timeout of a single export request.
+	Timeout time.Duration `default:"10s" usage:"the timeout of a single export request"`
+	// the fraction of the traces that are recorded (0.0 - 1.0).
+	SamplingRatio float64 `default:"1.0" usage:"the fraction of the traces that are recorded (0.0 - 1.0)"`
+	// the maximum amount of ended spans that are queued for export.
+	MaxQueueSize int `default:"2048" usage:"the maximum amount of ended spans that are queued for export"`
+	// the maximum amount of spans that are exported in a single request.
+	MaxExportBatchSize int `default:"512" usage:"the maximum amount of spans that are exported in a single request"`
+	// the maximum time until queued spans are exported.
+	BatchTimeout time.Duration `default:"5s" usage:"the maximum time until queued spans are exported"`
+}
+
+var ParamsTracing = &ParametersTracing{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"tracing": ParamsTracing,
+	},
+	Masked: []string{"tracing.headers"},
+}
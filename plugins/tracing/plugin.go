@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/tracing"
+	"github.com/iotaledger/hive.go/app"
+)
+
+func init() {
+	Plugin = &app.Plugin{
+		Status: app.StatusDisabled,
+		Component: &app.Component{
+			Name:      "Tracing",
+			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
+			Configure: configure,
+			Run:       run,
+		},
+	}
+}
+
+var (
+	Plugin *app.Plugin
+	deps   dependencies
+
+	tracerProvider *tracing.TracerProvider
+)
+
+type dependencies struct {
+	dig.In
+	AppInfo *app.AppInfo
+}
+
+func configure() error {
+
+	exporter := tracing.NewOTLPExporter(ParamsTracing.Endpoint,
+		tracing.WithOTLPHeaders(ParamsTracing.Headers),
+		tracing.WithOTLPTimeout(ParamsTracing.Timeout),
+		tracing.WithOTLPResourceAttributes(
+			tracing.String("service.name", ParamsTracing.ServiceName),
+			tracing.String("service.version", deps.AppInfo.Version),
+		),
+	)
+
+	tracerProvider = tracing.NewTracerProvider(
+		tracing.WithLogger(Plugin.Logger()),
+		tracing.WithSampler(tracing.ParentBased(tracing.TraceIDRatioBased(ParamsTracing.SamplingRatio))),
+		tracing.WithExporter(exporter),
+		tracing.WithMaxQueueSize(ParamsTracing.MaxQueueSize),
+		tracing.WithMaxExportBatchSize(ParamsTracing.MaxExportBatchSize),
+		tracing.WithBatchTimeout(ParamsTracing.BatchTimeout),
+	)
+
+	// the provider is set during configure, so that the spans of all components are recorded once they run
+	tracing.SetTracerProvider(tracerProvider)
+
+	return nil
+}
+
+func run() error {
+
+	if err := Plugin.Daemon().BackgroundWorker("Tracing[Exporter]", func(ctx context.Context) {
+		Plugin.LogInfof("Starting Tracing[Exporter] (%s) ... done", ParamsTracing.Endpoint)
+		// blocks until the context is canceled and flushes the remaining spans afterwards
+		tracerProvider.Run(ctx, ParamsTracing.Timeout)
+		Plugin.LogInfo("Stopping Tracing[Exporter] ... done")
+
+		if dropped := tracerProvider.DroppedSpans(); dropped > 0 {
+			Plugin.LogWarnf("%d spans were dropped because the export queue was full", dropped)
+		}
+	}, daemon.PriorityTracing); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/common"
 	"github.com/gohornet/hornet/pkg/daemon"
+	"github.com/gohornet/hornet/pkg/hotreload"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
@@ -49,6 +50,7 @@ type dependencies struct {
 	SyncManager     *syncmanager.SyncManager
 	Tangle          *tangle.Tangle
 	ShutdownHandler *shutdown.ShutdownHandler
+	ConfigReloader  *hotreload.ConfigReloader `optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -85,6 +87,27 @@ func provide(c *dig.Container) error {
 
 func configure() error {
 	configureEvents()
+
+	if deps.ConfigReloader != nil {
+		deps.ConfigReloader.RegisterParameters(Plugin.Name, applyRetentionRules, "tipsel")
+	}
+
+	return nil
+}
+
+// applyRetentionRules applies the retention rules from the config to the tip-selector.
+func applyRetentionRules() error {
+	deps.TipSelector.SetRetentionRules(
+		ParamsTipsel.NonLazy.RetentionRulesTipsLimit,
+		ParamsTipsel.NonLazy.MaxReferencedTipAge,
+		ParamsTipsel.NonLazy.MaxChildren,
+		ParamsTipsel.NonLazy.SpammerTipsThreshold,
+
+		ParamsTipsel.SemiLazy.RetentionRulesTipsLimit,
+		ParamsTipsel.SemiLazy.MaxReferencedTipAge,
+		ParamsTipsel.SemiLazy.MaxChildren,
+		ParamsTipsel.SemiLazy.SpammerTipsThreshold,
+	)
 	return nil
 }
 